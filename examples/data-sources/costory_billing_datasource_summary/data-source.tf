@@ -0,0 +1,19 @@
+variable "costory_api_token" {
+  type        = string
+  description = "Costory API token."
+  sensitive   = true
+}
+
+provider "costory" {
+  token = var.costory_api_token
+}
+
+data "costory_billing_datasource_summary" "january" {
+  id    = costory_billing_datasource_aws.main.id
+  start = "2025-01-01"
+  end   = "2025-02-01"
+}
+
+output "january_cost" {
+  value = "${data.costory_billing_datasource_summary.january.total_cost} ${data.costory_billing_datasource_summary.january.currency}"
+}
@@ -0,0 +1,26 @@
+variable "costory_api_token" {
+  type        = string
+  description = "Costory API token."
+  sensitive   = true
+}
+
+variable "billing_datasource_ids" {
+  type        = list(string)
+  description = "Billing datasource IDs created by another module."
+}
+
+provider "costory" {
+  token = var.costory_api_token
+}
+
+data "costory_billing_datasources_by_ids" "shared" {
+  ids = var.billing_datasource_ids
+}
+
+output "shared_datasource_names" {
+  value = { for id, datasource in data.costory_billing_datasources_by_ids.shared.datasources : id => datasource.name }
+}
+
+output "missing_datasource_ids" {
+  value = data.costory_billing_datasources_by_ids.shared.not_found_ids
+}
@@ -0,0 +1,15 @@
+variable "costory_api_token" {
+  type        = string
+  description = "Costory API token."
+  sensitive   = true
+}
+
+provider "costory" {
+  token = var.costory_api_token
+}
+
+data "costory_capabilities" "current" {}
+
+output "supports_azure" {
+  value = data.costory_capabilities.current.all_supported || contains(data.costory_capabilities.current.datasource_types, "Azure")
+}
@@ -0,0 +1,18 @@
+variable "costory_api_token" {
+  type        = string
+  description = "Costory API token."
+  sensitive   = true
+}
+
+provider "costory" {
+  token = var.costory_api_token
+}
+
+data "costory_diagnostics" "current" {}
+
+check "no_provider_warnings" {
+  assert {
+    condition     = data.costory_diagnostics.current.warning_count == 0
+    error_message = "The Costory provider raised warnings: ${join("; ", data.costory_diagnostics.current.warnings[*].summary)}"
+  }
+}
@@ -0,0 +1,15 @@
+variable "costory_api_token" {
+  type        = string
+  description = "Costory API token."
+  sensitive   = true
+}
+
+provider "costory" {
+  token = var.costory_api_token
+}
+
+data "costory_provider_config" "current" {}
+
+output "costory_base_url" {
+  value = data.costory_provider_config.current.base_url
+}
@@ -0,0 +1 @@
+terraform import costory_allocation_rule.platform <allocation-rule-id>
@@ -0,0 +1,25 @@
+terraform {
+  required_providers {
+    costory = {
+      source  = "costory-io/costory"
+      version = ">= 0.1.0"
+    }
+  }
+}
+
+variable "costory_api_token" {
+  type        = string
+  description = "Costory API token."
+  sensitive   = true
+}
+
+provider "costory" {
+  token = var.costory_api_token
+}
+
+# Label platform team costs with their cost center
+resource "costory_allocation_rule" "platform" {
+  name         = "Platform cost center"
+  match        = "labels.team = 'platform'"
+  target_label = "cost_center"
+}
@@ -0,0 +1,29 @@
+terraform {
+  required_providers {
+    costory = {
+      source  = "costory-io/costory"
+      version = ">= 0.1.0"
+    }
+  }
+}
+
+variable "costory_api_token" {
+  type        = string
+  description = "Costory API token."
+  sensitive   = true
+}
+
+provider "costory" {
+  token = var.costory_api_token
+}
+
+# Read-only token for CI plans
+resource "costory_api_token" "ci" {
+  name   = "ci-plan"
+  scopes = ["read"]
+}
+
+output "ci_token" {
+  value     = costory_api_token.ci.token
+  sensitive = true
+}
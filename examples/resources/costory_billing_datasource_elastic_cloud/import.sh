@@ -0,0 +1,3 @@
+# Import by datasource ID, optionally prefixed with the datasource type to guard
+# against importing into the wrong resource type.
+terraform import costory_billing_datasource_elastic_cloud.main elastic_cloud:<datasource-id>
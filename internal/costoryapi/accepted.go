@@ -0,0 +1,132 @@
+package costoryapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	defaultAcceptedPollInterval = 2 * time.Second
+	// acceptedCreateTimeout bounds polling when the caller's context has no deadline.
+	acceptedCreateTimeout = 20 * time.Minute
+)
+
+type acceptedCreateAPIResponse struct {
+	StatusURL string `json:"statusUrl"`
+	Location  string `json:"location"`
+}
+
+type acceptedStatusAPIResponse struct {
+	ID string `json:"id"`
+}
+
+// doCreateBillingDatasourceEndpoint issues a billing datasource create. When the API answers
+// 202 Accepted, it polls the returned status URL until the datasource ID is known and then
+// returns the datasource as if the create had completed synchronously.
+func doCreateBillingDatasourceEndpoint[TReq any, TResp any](
+	ctx context.Context,
+	c *Client,
+	endpoint endpointContract[TReq, TResp],
+	request TReq,
+) ([]byte, int, error) {
+	if endpoint.RequestTransport != requestTransportJSONBody {
+		return doEndpoint(ctx, c, endpoint, request)
+	}
+
+	body, statusCode, header, err := c.doJSONWithHeader(ctx, endpoint.Method, endpoint.Path, request)
+	if err != nil || statusCode != http.StatusAccepted {
+		return body, statusCode, err
+	}
+
+	return c.awaitAcceptedBillingDatasourceCreate(ctx, header, body)
+}
+
+func (c *Client) awaitAcceptedBillingDatasourceCreate(ctx context.Context, header http.Header, body []byte) ([]byte, int, error) {
+	statusPath, err := c.acceptedStatusPath(header, body)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, acceptedCreateTimeout)
+		defer cancel()
+	}
+
+	interval := c.acceptedPollInterval
+	if interval <= 0 {
+		interval = defaultAcceptedPollInterval
+	}
+
+	for {
+		statusBody, statusCode, err := c.doJSON(withEndpointName(ctx, "poll accepted create"), http.MethodGet, statusPath, nil)
+		if err != nil {
+			return nil, 0, fmt.Errorf("poll accepted create: %w", err)
+		}
+
+		switch {
+		case statusCode == http.StatusAccepted:
+		case statusCode >= http.StatusOK && statusCode < http.StatusMultipleChoices:
+			var out acceptedStatusAPIResponse
+			if err := json.Unmarshal(statusBody, &out); err != nil {
+				return nil, 0, fmt.Errorf("decode accepted create status: %w", err)
+			}
+			if out.ID != "" {
+				return c.doJSON(withEndpointName(ctx, endpointGetBillingDatasourceByID.Name), http.MethodGet, routeBillingDatasourceByID(out.ID), nil)
+			}
+		default:
+			return nil, 0, unexpectedStatusError("poll accepted create", statusCode, statusBody)
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, 0, fmt.Errorf("wait for accepted create: %w", ctx.Err())
+		case <-c.closed:
+			timer.Stop()
+			return nil, 0, fmt.Errorf("wait for accepted create: %w", ErrClientClosed)
+		case <-timer.C:
+		}
+	}
+}
+
+// acceptedStatusPath extracts the status URL of a 202 response from the Location header or
+// the statusUrl/location body fields. Absolute URLs must point at the configured API host so
+// the token is never sent elsewhere.
+func (c *Client) acceptedStatusPath(header http.Header, body []byte) (string, error) {
+	location := strings.TrimSpace(header.Get("Location"))
+	if location == "" {
+		var out acceptedCreateAPIResponse
+		if err := json.Unmarshal(body, &out); err == nil {
+			location = strings.TrimSpace(firstNonEmptyString(out.StatusURL, out.Location))
+		}
+	}
+	if location == "" {
+		return "", errors.New("create accepted without a status URL")
+	}
+
+	statusURL, err := url.Parse(location)
+	if err != nil {
+		return "", fmt.Errorf("parse accepted create status URL: %w", err)
+	}
+
+	baseURL, err := url.Parse(c.baseURL)
+	if err != nil {
+		return "", fmt.Errorf("parse base URL: %w", err)
+	}
+
+	if statusURL.IsAbs() && !strings.EqualFold(baseURL.Host, statusURL.Host) {
+		return "", fmt.Errorf("accepted create status URL %q is not on the Costory API host", location)
+	}
+
+	// Requests are issued relative to the base URL, so drop its path prefix if the server
+	// returned a host-absolute path.
+	return strings.TrimPrefix(statusURL.RequestURI(), strings.TrimRight(baseURL.Path, "/")), nil
+}
@@ -0,0 +1,128 @@
+package costoryapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClientCreateAWSBillingDatasourceAccepted(t *testing.T) {
+	t.Parallel()
+
+	var polls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == routeBillingDatasourceBase:
+			w.Header().Set("Location", "/terraform/billingDatasources/operations/op-1")
+			w.WriteHeader(http.StatusAccepted)
+		case r.Method == http.MethodGet && r.URL.Path == "/terraform/billingDatasources/operations/op-1":
+			if got := r.Header.Get("Authorization"); got != "Bearer test-token" {
+				t.Fatalf("unexpected auth header on status poll: %q", got)
+			}
+			if polls.Add(1) < 3 {
+				w.WriteHeader(http.StatusAccepted)
+				_, _ = w.Write([]byte(`{"status":"IN_PROGRESS"}`))
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"status":"DONE","id":"aws-ds-1"}`))
+		case r.Method == http.MethodGet && r.URL.Path == routeBillingDatasourceByID("aws-ds-1"):
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"id":"aws-ds-1","type":"AWS","status":"PENDING","name":"AWS Billing","bucketName":"billing-bucket"}`))
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", server.Client())
+	client.acceptedPollInterval = 10 * time.Millisecond
+
+	created, err := client.CreateAWSBillingDatasource(context.Background(), AWSBillingDatasourceRequest{Name: "AWS Billing"})
+	if err != nil {
+		t.Fatalf("unexpected create error: %v", err)
+	}
+
+	if created.ID != "aws-ds-1" || created.BucketName != "billing-bucket" {
+		t.Fatalf("unexpected created datasource: %#v", created)
+	}
+	if got := polls.Load(); got != 3 {
+		t.Fatalf("unexpected status polls: got %d, want 3", got)
+	}
+}
+
+func TestClientCreateBillingDatasourceAcceptedBodyStatusURL(t *testing.T) {
+	t.Parallel()
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost:
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = w.Write([]byte(`{"statusUrl":"` + server.URL + `/terraform/billingDatasources/operations/op-2"}`))
+		case r.URL.Path == "/terraform/billingDatasources/operations/op-2":
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"id":"gcp-ds-1"}`))
+		case r.URL.Path == routeBillingDatasourceByID("gcp-ds-1"):
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"id":"gcp-ds-1","type":"GCP","bqUri":"project.dataset.table"}`))
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", server.Client())
+
+	created, err := client.CreateGCPBillingDatasource(context.Background(), GCPBillingDatasourceRequest{Name: "GCP Billing"})
+	if err != nil {
+		t.Fatalf("unexpected create error: %v", err)
+	}
+	if created.ID != "gcp-ds-1" || created.BQURI != "project.dataset.table" {
+		t.Fatalf("unexpected created datasource: %#v", created)
+	}
+}
+
+func TestClientCreateBillingDatasourceAcceptedRejectsForeignHost(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Location", "https://elsewhere.example.com/status/op-3")
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", server.Client())
+
+	if _, err := client.CreateGCPBillingDatasource(context.Background(), GCPBillingDatasourceRequest{Name: "GCP Billing"}); err == nil {
+		t.Fatal("expected error for a status URL on another host, got nil")
+	}
+}
+
+func TestClientCreateBillingDatasourceAcceptedHonorsDeadline(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			w.Header().Set("Location", "/terraform/billingDatasources/operations/op-4")
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", server.Client())
+	client.acceptedPollInterval = 10 * time.Millisecond
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	if _, err := client.CreateGCPBillingDatasource(ctx, GCPBillingDatasourceRequest{Name: "GCP Billing"}); err == nil {
+		t.Fatal("expected deadline error, got nil")
+	}
+}
@@ -0,0 +1,36 @@
+package costoryapi
+
+import "strings"
+
+// WithAPIPathPrefix mounts every route, including the service account route, under prefix
+// instead of /terraform, for self-hosted deployments that serve the API elsewhere, such as
+// /api/v1/terraform. A trailing slash is ignored and an empty prefix keeps the default.
+func WithAPIPathPrefix(prefix string) Option {
+	return func(c *Client) {
+		prefix = strings.TrimRight(prefix, "/")
+		if prefix != "" && !strings.HasPrefix(prefix, "/") {
+			prefix = "/" + prefix
+		}
+		c.apiPathPrefix = prefix
+	}
+}
+
+// APIPathPrefix returns the path every route is mounted under.
+func (c *Client) APIPathPrefix() string {
+	if c.apiPathPrefix == "" {
+		return routePrefix
+	}
+
+	return c.apiPathPrefix
+}
+
+// resolveRoute moves path, one of the routes mounted under routePrefix, to the configured
+// prefix. Paths outside routePrefix are returned as is.
+func (c *Client) resolveRoute(path string) string {
+	rest, ok := strings.CutPrefix(path, routePrefix)
+	if !ok || c.apiPathPrefix == "" || !strings.HasPrefix(rest, "/") {
+		return path
+	}
+
+	return c.apiPathPrefix + rest
+}
@@ -0,0 +1,75 @@
+package costoryapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAPIPathPrefixResolvesAllRoutes(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/terraform/{$}", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("cursor") == "page-2" {
+			_, _ = w.Write([]byte(`{"service_account":"costory@example.iam.gserviceaccount.com","sub_ids":["sub-2"]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"service_account":"costory@example.iam.gserviceaccount.com","sub_ids":["sub-1"],"next_cursor":"page-2"}`))
+	})
+	mux.HandleFunc("GET /api/v1/terraform/billingDatasources/{id}", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":"` + r.PathValue("id") + `","type":"Cursor","name":"Cursor Billing"}`))
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request outside the prefix: %s %s", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", server.Client(), WithAPIPathPrefix("/api/v1/terraform/"))
+	ctx := context.Background()
+
+	serviceAccount, err := client.GetServiceAccount(ctx)
+	if err != nil {
+		t.Fatalf("unexpected service account error: %v", err)
+	}
+	if got := len(serviceAccount.SubIDs); got != 2 {
+		t.Fatalf("expected both service account pages under the prefix, got sub_ids %v", serviceAccount.SubIDs)
+	}
+
+	datasource, err := client.GetCursorBillingDatasource(ctx, "cursor-ds-1")
+	if err != nil {
+		t.Fatalf("unexpected datasource error: %v", err)
+	}
+	if datasource.ID != "cursor-ds-1" {
+		t.Fatalf("unexpected datasource id: got %q, want %q", datasource.ID, "cursor-ds-1")
+	}
+}
+
+func TestWithAPIPathPrefix(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		prefix   string
+		wantPath string
+	}{
+		"default":        {wantPath: "/terraform/teams"},
+		"prefixed":       {prefix: "/api/v1/terraform", wantPath: "/api/v1/terraform/teams"},
+		"trailing slash": {prefix: "/api/v1/terraform/", wantPath: "/api/v1/terraform/teams"},
+		"relative":       {prefix: "costory", wantPath: "/costory/teams"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			client := NewClient("https://api.example.com/", "test-token", nil, WithAPIPathPrefix(tc.prefix))
+			if got, want := client.endpoint(routeTeamsBase), "https://api.example.com"+tc.wantPath; got != want {
+				t.Fatalf("unexpected endpoint: got %q, want %q", got, want)
+			}
+		})
+	}
+}
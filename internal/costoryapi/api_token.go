@@ -0,0 +1,136 @@
+package costoryapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// APITokenRequest is the Terraform input used to create an API token.
+type APITokenRequest struct {
+	Name   string
+	Scopes []string
+}
+
+// APIToken is the normalized API token payload returned by the Costory API. Token holds the
+// secret value and is only ever populated by CreateAPIToken; Costory never returns it again.
+type APIToken struct {
+	ID     string
+	Name   string
+	Scopes []string
+	Token  string
+}
+
+type apiTokenAPIRequest struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+}
+
+type apiTokenAPIResponse struct {
+	ID     string   `json:"id"`
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+	Token  string   `json:"token"`
+}
+
+func (r APITokenRequest) toAPIRequest() apiTokenAPIRequest {
+	scopes := r.Scopes
+	if scopes == nil {
+		scopes = []string{}
+	}
+
+	return apiTokenAPIRequest{
+		Name:   r.Name,
+		Scopes: scopes,
+	}
+}
+
+// CreateAPIToken creates an API token and returns it together with its secret value.
+func (c *Client) CreateAPIToken(ctx context.Context, req APITokenRequest) (*APIToken, error) {
+	body, statusCode, err := doEndpoint(ctx, c, endpointCreateAPIToken, req.toAPIRequest())
+	if err != nil {
+		return nil, err
+	}
+
+	if statusCode < http.StatusOK || statusCode >= http.StatusMultipleChoices {
+		return nil, unexpectedStatusError(endpointCreateAPIToken.Name, statusCode, body)
+	}
+
+	var out apiTokenAPIResponse
+	if err := c.decodeResponse(body, &out); err != nil {
+		return nil, fmt.Errorf("decode response body: %w", err)
+	}
+
+	if out.ID == "" {
+		return nil, errors.New("create response did not include api token id")
+	}
+	if out.Token == "" {
+		return nil, errors.New("create response did not include the api token value")
+	}
+
+	scopes := out.Scopes
+	if scopes == nil {
+		scopes = req.toAPIRequest().Scopes
+	}
+
+	return &APIToken{
+		ID:     out.ID,
+		Name:   out.Name,
+		Scopes: scopes,
+		Token:  out.Token,
+	}, nil
+}
+
+// GetAPIToken gets an API token by ID. The returned token has no Token value, since Costory
+// only returns the secret when the token is created.
+func (c *Client) GetAPIToken(ctx context.Context, tokenID string) (*APIToken, error) {
+	routeParams := apiTokenByIDRouteParams{ID: tokenID}
+	body, statusCode, err := doEndpointWithRouteParams(ctx, c, endpointGetAPITokenByID, routeParams, noRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	if statusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	if !isReadSuccess(statusCode) {
+		return nil, unexpectedStatusError(endpointGetAPITokenByID.Name, statusCode, body)
+	}
+
+	var out apiTokenAPIResponse
+	if err := c.decodeResponse(body, &out); err != nil {
+		return nil, fmt.Errorf("decode response body: %w", err)
+	}
+
+	id := out.ID
+	if id == "" {
+		id = tokenID
+	}
+
+	return &APIToken{
+		ID:     id,
+		Name:   out.Name,
+		Scopes: out.Scopes,
+	}, nil
+}
+
+// DeleteAPIToken revokes an API token by ID.
+func (c *Client) DeleteAPIToken(ctx context.Context, tokenID string) error {
+	routeParams := apiTokenByIDRouteParams{ID: tokenID}
+	body, statusCode, err := doEndpointWithRouteParams(ctx, c, endpointDeleteAPITokenByID, routeParams, noRequest{})
+	if err != nil {
+		return err
+	}
+
+	if statusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+
+	if statusCode >= http.StatusOK && statusCode < http.StatusMultipleChoices {
+		return nil
+	}
+
+	return unexpectedStatusError(endpointDeleteAPITokenByID.Name, statusCode, body)
+}
@@ -0,0 +1,91 @@
+package costoryapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+func TestClientAPITokenLifecycle(t *testing.T) {
+	t.Parallel()
+
+	var deleteCalls int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == routeAPITokensBase:
+			var payload apiTokenAPIRequest
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				t.Fatalf("decode request: %v", err)
+			}
+			if payload.Name != "ci" || !slices.Equal(payload.Scopes, []string{"read", "write"}) {
+				t.Fatalf("unexpected create payload: %+v", payload)
+			}
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":"token-1","name":"ci","scopes":["read","write"],"token":"cst_secret"}`))
+		case r.Method == http.MethodGet && r.URL.Path == routeAPITokenByID("token-1"):
+			_, _ = w.Write([]byte(`{"id":"token-1","name":"ci","scopes":["read","write"]}`))
+		case r.Method == http.MethodGet && r.URL.Path == routeAPITokenByID("gone"):
+			w.WriteHeader(http.StatusNotFound)
+		case r.Method == http.MethodDelete && r.URL.Path == routeAPITokenByID("token-1"):
+			deleteCalls++
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodDelete && r.URL.Path == routeAPITokenByID("gone"):
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", server.Client(), WithStrictDecoding(true))
+
+	token, err := client.CreateAPIToken(context.Background(), APITokenRequest{Name: "ci", Scopes: []string{"read", "write"}})
+	if err != nil {
+		t.Fatalf("create api token: %v", err)
+	}
+	if token.ID != "token-1" || token.Token != "cst_secret" {
+		t.Fatalf("unexpected api token: %+v", token)
+	}
+
+	read, err := client.GetAPIToken(context.Background(), "token-1")
+	if err != nil {
+		t.Fatalf("get api token: %v", err)
+	}
+	if read.Name != "ci" || read.Token != "" || !slices.Equal(read.Scopes, []string{"read", "write"}) {
+		t.Fatalf("unexpected api token read: %+v", read)
+	}
+	if _, err := client.GetAPIToken(context.Background(), "gone"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+
+	if err := client.DeleteAPIToken(context.Background(), "token-1"); err != nil {
+		t.Fatalf("delete api token: %v", err)
+	}
+	if deleteCalls != 1 {
+		t.Fatalf("expected 1 delete call, got %d", deleteCalls)
+	}
+
+	if err := client.DeleteAPIToken(context.Background(), "gone"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestClientCreateAPITokenRequiresTokenValue(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":"token-1","name":"ci","scopes":["read"]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", server.Client())
+	if _, err := client.CreateAPIToken(context.Background(), APITokenRequest{Name: "ci", Scopes: []string{"read"}}); err == nil {
+		t.Fatal("expected an error when the create response has no token value")
+	}
+}
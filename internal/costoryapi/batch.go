@@ -0,0 +1,151 @@
+package costoryapi
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	defaultBatchConcurrency = 4
+	defaultBatchCreateRate  = 5
+)
+
+// BillingDatasourceCreateRequest is implemented by every billing datasource request type
+// accepted by CreateBillingDatasourcesBatch.
+type BillingDatasourceCreateRequest interface {
+	createBillingDatasource(ctx context.Context, c *Client) (any, error)
+	billingDatasourceType() string
+	apiRequest(datasourceType string) any
+}
+
+// BillingDatasourceBatchResult is the outcome of a single batch create item.
+// Datasource holds the typed datasource (for example *AWSBillingDatasource) when Err is nil.
+type BillingDatasourceBatchResult struct {
+	Datasource any
+	Err        error
+}
+
+// WithBatchConcurrency bounds the number of concurrent creates issued by CreateBillingDatasourcesBatch.
+func WithBatchConcurrency(n int) Option {
+	return func(c *Client) {
+		if n > 0 {
+			c.batchConcurrency = n
+		}
+	}
+}
+
+// WithBatchCreateRate limits CreateBillingDatasourcesBatch to perSecond creates per second, 5
+// by default, so onboarding many datasources does not trip the API rate limit. Rates above
+// one create per nanosecond leave creates unpaced.
+func WithBatchCreateRate(perSecond int) Option {
+	return func(c *Client) {
+		if perSecond > 0 {
+			c.batchCreateRate = perSecond
+		}
+	}
+}
+
+// CreateBillingDatasourcesBatch creates billing datasources concurrently, bounded by
+// WithBatchConcurrency and paced by WithBatchCreateRate, and returns one result per request,
+// in request order. It is intended for embedders onboarding many datasources at once; a
+// failed item does not stop the remaining creates.
+func (c *Client) CreateBillingDatasourcesBatch(ctx context.Context, reqs []BillingDatasourceCreateRequest) []BillingDatasourceBatchResult {
+	results := make([]BillingDatasourceBatchResult, len(reqs))
+
+	c.runBatch(ctx, len(reqs), batchInterval(c.batchCreateRate, defaultBatchCreateRate), func(i int) {
+		if err := ctx.Err(); err != nil {
+			results[i].Err = err
+			return
+		}
+		reqCtx := WithIdempotencyKey(ctx, NewIdempotencyKey())
+		datasource, err := reqs[i].createBillingDatasource(reqCtx, c)
+		if err != nil {
+			results[i].Err = err
+			return
+		}
+		results[i].Datasource = datasource
+	})
+
+	return results
+}
+
+// runBatch calls work once for each index in [0, n) from a pool bounded by
+// WithBatchConcurrency, and returns once every dispatched call has returned. A positive
+// interval paces dispatch to one item per interval; paced dispatch stops early once ctx is
+// done, leaving the remaining items unrun.
+func (c *Client) runBatch(ctx context.Context, n int, interval time.Duration, work func(i int)) {
+	workers := c.batchConcurrency
+	if workers <= 0 {
+		workers = defaultBatchConcurrency
+	}
+	workers = min(workers, n)
+
+	var tick <-chan time.Time
+	if interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				work(i)
+			}
+		}()
+	}
+
+dispatch:
+	for i := range n {
+		if i > 0 && tick != nil {
+			select {
+			case <-tick:
+			case <-ctx.Done():
+				break dispatch
+			}
+		}
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+}
+
+// batchInterval returns the runBatch dispatch interval for perSecond items per second, or
+// for defaultRate when perSecond is not positive. It is zero, which runBatch treats as
+// unpaced, when the rate exceeds one item per nanosecond.
+func batchInterval(perSecond, defaultRate int) time.Duration {
+	if perSecond <= 0 {
+		perSecond = defaultRate
+	}
+
+	return time.Second / time.Duration(perSecond)
+}
+
+func (r GCPBillingDatasourceRequest) createBillingDatasource(ctx context.Context, c *Client) (any, error) {
+	return c.CreateGCPBillingDatasource(ctx, r)
+}
+
+func (r AWSBillingDatasourceRequest) createBillingDatasource(ctx context.Context, c *Client) (any, error) {
+	return c.CreateAWSBillingDatasource(ctx, r)
+}
+
+func (r CursorBillingDatasourceRequest) createBillingDatasource(ctx context.Context, c *Client) (any, error) {
+	return c.CreateCursorBillingDatasource(ctx, r)
+}
+
+func (r AnthropicBillingDatasourceRequest) createBillingDatasource(ctx context.Context, c *Client) (any, error) {
+	return c.CreateAnthropicBillingDatasource(ctx, r)
+}
+
+func (r ElasticCloudBillingDatasourceRequest) createBillingDatasource(ctx context.Context, c *Client) (any, error) {
+	return c.CreateElasticCloudBillingDatasource(ctx, r)
+}
+
+func (r AzureBillingDatasourceRequest) createBillingDatasource(ctx context.Context, c *Client) (any, error) {
+	return c.CreateAzureBillingDatasource(ctx, r)
+}
@@ -0,0 +1,128 @@
+package costoryapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClientCreateBillingDatasourcesBatch(t *testing.T) {
+	t.Parallel()
+
+	const concurrency = 2
+
+	var inFlight atomic.Int32
+	var maxInFlight atomic.Int32
+	var createCalls atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != routeBillingDatasourceBase {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			return
+		}
+
+		createCalls.Add(1)
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			observed := maxInFlight.Load()
+			if current <= observed || maxInFlight.CompareAndSwap(observed, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+
+		var payload struct {
+			Type string `json:"type"`
+			Name string `json:"name"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("unable to decode request body: %v", err)
+			return
+		}
+
+		if payload.Name == "bad" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"invalid","reason":"bad datasource"}`))
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprintf(w, `{"id":"%s-id","type":%q,"name":%q}`, payload.Name, payload.Type, payload.Name)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", server.Client(), WithBatchConcurrency(concurrency), WithBatchCreateRate(1000))
+
+	reqs := []BillingDatasourceCreateRequest{
+		AWSBillingDatasourceRequest{Name: "aws-1", BucketName: "bucket", RoleARN: "arn:aws:iam::123456789012:role/costory", Prefix: "cur/"},
+		GCPBillingDatasourceRequest{Name: "gcp-1", BQURI: "project.dataset.table"},
+		CursorBillingDatasourceRequest{Name: "bad", AdminAPIKey: "key"},
+		AnthropicBillingDatasourceRequest{Name: "anthropic-1", AdminAPIKey: "key"},
+		AWSBillingDatasourceRequest{Name: "aws-2", BucketName: "bucket", RoleARN: "arn:aws:iam::123456789012:role/costory", Prefix: "cur/"},
+	}
+
+	results := client.CreateBillingDatasourcesBatch(context.Background(), reqs)
+
+	if len(results) != len(reqs) {
+		t.Fatalf("unexpected result count: got %d, want %d", len(results), len(reqs))
+	}
+
+	if got := createCalls.Load(); got != int32(len(reqs)) {
+		t.Fatalf("unexpected create calls: got %d, want %d", got, len(reqs))
+	}
+
+	if got := maxInFlight.Load(); got > concurrency {
+		t.Fatalf("concurrency bound exceeded: got %d, want <= %d", got, concurrency)
+	}
+
+	aws, ok := results[0].Datasource.(*AWSBillingDatasource)
+	if results[0].Err != nil || !ok || aws.ID != "aws-1-id" {
+		t.Fatalf("unexpected AWS result: %#v", results[0])
+	}
+
+	gcp, ok := results[1].Datasource.(*GCPBillingDatasource)
+	if results[1].Err != nil || !ok || gcp.ID != "gcp-1-id" {
+		t.Fatalf("unexpected GCP result: %#v", results[1])
+	}
+
+	if results[2].Err == nil || results[2].Datasource != nil {
+		t.Fatalf("expected failed item to carry only an error: %#v", results[2])
+	}
+
+	for _, i := range []int{3, 4} {
+		if results[i].Err != nil || results[i].Datasource == nil {
+			t.Fatalf("unexpected result %d: %#v", i, results[i])
+		}
+	}
+}
+
+func TestClientCreateBillingDatasourcesBatchRate(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":"gcp-ds","type":"GCP"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", server.Client(), WithBatchConcurrency(4), WithBatchCreateRate(20))
+	req := GCPBillingDatasourceRequest{Name: "GCP Billing", BQURI: "project.dataset.table"}
+
+	start := time.Now()
+	for _, result := range client.CreateBillingDatasourcesBatch(context.Background(), []BillingDatasourceCreateRequest{req, req, req, req}) {
+		if result.Err != nil {
+			t.Fatalf("unexpected create error: %v", result.Err)
+		}
+	}
+
+	// Four creates at 20 per second start at least three 50ms intervals apart.
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Fatalf("creates were not paced: finished in %s", elapsed)
+	}
+}
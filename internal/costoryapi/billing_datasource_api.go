@@ -0,0 +1,57 @@
+package costoryapi
+
+import (
+	"context"
+	"time"
+)
+
+// BillingDatasourceAPI is the part of Client the billing datasource resources depend on. Tests
+// and embedders can substitute another implementation, such as FakeClient, to exercise the
+// resources without a Costory API.
+type BillingDatasourceAPI interface {
+	OperationContext(ctx context.Context) (context.Context, context.CancelFunc)
+	Now() time.Time
+	StrictDrift() bool
+	RequireDeleteConfirmation() bool
+	FailDeleteOnMissing() bool
+	DefaultDetailedBilling() *bool
+	RecordWarning(summary, detail string)
+	BillingDatasourceRequestJSON(req BillingDatasourceCreateRequest) ([]byte, error)
+
+	CheckBillingDatasourceSupported(ctx context.Context, req BillingDatasourceCreateRequest) error
+	CheckFeatureSupported(ctx context.Context, feature, field string) error
+	CheckAWSRoleAccess(ctx context.Context, roleARN string) (*AWSRoleAccess, error)
+	UpdateBillingDatasourceOwner(ctx context.Context, datasourceID string, owner *string) error
+	UpdateBillingDatasourcePriority(ctx context.Context, datasourceID string, priority *int64) error
+	UpdateBillingDatasourceTags(ctx context.Context, datasourceID string, tags map[string]string) error
+	DeleteBillingDatasource(ctx context.Context, datasourceID string) error
+
+	ValidateGCPBillingDatasourceResult(ctx context.Context, req GCPBillingDatasourceRequest) (*ValidationResult, error)
+	CreateGCPBillingDatasource(ctx context.Context, req GCPBillingDatasourceRequest) (*GCPBillingDatasource, error)
+	GetGCPBillingDatasource(ctx context.Context, datasourceID string) (*GCPBillingDatasource, error)
+
+	ValidateAWSBillingDatasourceResult(ctx context.Context, req AWSBillingDatasourceRequest) (*ValidationResult, error)
+	CreateAWSBillingDatasource(ctx context.Context, req AWSBillingDatasourceRequest) (*AWSBillingDatasource, error)
+	GetAWSBillingDatasource(ctx context.Context, datasourceID string) (*AWSBillingDatasource, error)
+
+	ValidateCursorBillingDatasource(ctx context.Context, req CursorBillingDatasourceRequest) error
+	CreateCursorBillingDatasource(ctx context.Context, req CursorBillingDatasourceRequest) (*CursorBillingDatasource, error)
+	GetCursorBillingDatasource(ctx context.Context, datasourceID string) (*CursorBillingDatasource, error)
+
+	ValidateAnthropicBillingDatasource(ctx context.Context, req AnthropicBillingDatasourceRequest) error
+	CreateAnthropicBillingDatasource(ctx context.Context, req AnthropicBillingDatasourceRequest) (*AnthropicBillingDatasource, error)
+	GetAnthropicBillingDatasource(ctx context.Context, datasourceID string) (*AnthropicBillingDatasource, error)
+
+	ValidateElasticCloudBillingDatasource(ctx context.Context, req ElasticCloudBillingDatasourceRequest) error
+	CreateElasticCloudBillingDatasource(ctx context.Context, req ElasticCloudBillingDatasourceRequest) (*ElasticCloudBillingDatasource, error)
+	GetElasticCloudBillingDatasource(ctx context.Context, datasourceID string) (*ElasticCloudBillingDatasource, error)
+
+	ValidateAzureBillingDatasource(ctx context.Context, req AzureBillingDatasourceRequest) error
+	CreateAzureBillingDatasource(ctx context.Context, req AzureBillingDatasourceRequest) (*AzureBillingDatasource, error)
+	GetAzureBillingDatasource(ctx context.Context, datasourceID string) (*AzureBillingDatasource, error)
+}
+
+var (
+	_ BillingDatasourceAPI = (*Client)(nil)
+	_ BillingDatasourceAPI = (*FakeClient)(nil)
+)
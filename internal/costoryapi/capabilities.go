@@ -0,0 +1,120 @@
+package costoryapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+)
+
+// Capabilities describes the datasource types and feature flags supported by a Costory deployment.
+// AllSupported is true when the deployment predates the capabilities endpoint, in which case
+// every type and feature is assumed to be available.
+type Capabilities struct {
+	AllSupported    bool
+	DatasourceTypes []string
+	Features        map[string]bool
+}
+
+// SupportsDatasourceType reports whether the deployment accepts the given datasource type.
+func (c *Capabilities) SupportsDatasourceType(datasourceType string) bool {
+	return c.AllSupported || slices.Contains(c.DatasourceTypes, datasourceType)
+}
+
+// FeatureEnabled reports whether the deployment enables the given feature flag.
+func (c *Capabilities) FeatureEnabled(name string) bool {
+	return c.AllSupported || c.Features[name]
+}
+
+// UnsupportedDatasourceTypeError is returned when the deployment does not support a datasource type.
+type UnsupportedDatasourceTypeError struct {
+	DatasourceType string
+	Supported      []string
+}
+
+func (e *UnsupportedDatasourceTypeError) Error() string {
+	return fmt.Sprintf("the Costory deployment does not support %s datasources (supported types: %v)", e.DatasourceType, e.Supported)
+}
+
+type capabilitiesAPIResponse struct {
+	DatasourceTypes []string        `json:"datasourceTypes"`
+	Features        map[string]bool `json:"features"`
+}
+
+// GetCapabilities fetches the deployment capabilities. A 404 from older backends is treated
+// as all-supported. Successful results are cached for the lifetime of the client.
+func (c *Client) GetCapabilities(ctx context.Context) (*Capabilities, error) {
+	c.capabilitiesMu.Lock()
+	defer c.capabilitiesMu.Unlock()
+
+	if c.capabilities != nil {
+		return c.capabilities, nil
+	}
+
+	body, statusCode, err := doEndpoint(ctx, c, endpointGetCapabilities, noRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	capabilities := &Capabilities{DatasourceTypes: []string{}, Features: map[string]bool{}}
+	switch {
+	case statusCode == http.StatusNotFound:
+		capabilities.AllSupported = true
+	case statusCode != http.StatusOK:
+		return nil, unexpectedStatusError(endpointGetCapabilities.Name, statusCode, body)
+	default:
+		var out capabilitiesAPIResponse
+		if err := c.decodeResponse(body, &out); err != nil {
+			return nil, fmt.Errorf("decode response body: %w", err)
+		}
+		if out.DatasourceTypes != nil {
+			capabilities.DatasourceTypes = out.DatasourceTypes
+		}
+		if out.Features != nil {
+			capabilities.Features = out.Features
+		}
+	}
+
+	c.capabilities = capabilities
+	return capabilities, nil
+}
+
+// CheckBillingDatasourceSupported returns an *UnsupportedDatasourceTypeError when the
+// deployment does not support the datasource type of req.
+func (c *Client) CheckBillingDatasourceSupported(ctx context.Context, req BillingDatasourceCreateRequest) error {
+	capabilities, err := c.GetCapabilities(ctx)
+	if err != nil {
+		return err
+	}
+
+	datasourceType := c.datasourceType(req.billingDatasourceType())
+	if capabilities.SupportsDatasourceType(datasourceType) {
+		return nil
+	}
+
+	return &UnsupportedDatasourceTypeError{DatasourceType: datasourceType, Supported: capabilities.DatasourceTypes}
+}
+
+func (GCPBillingDatasourceRequest) billingDatasourceType() string {
+	return billingDatasourceTypeGCP
+}
+
+func (AWSBillingDatasourceRequest) billingDatasourceType() string {
+	return billingDatasourceTypeAWS
+}
+
+func (CursorBillingDatasourceRequest) billingDatasourceType() string {
+	return billingDatasourceTypeCursor
+}
+
+func (AnthropicBillingDatasourceRequest) billingDatasourceType() string {
+	return billingDatasourceTypeAnthropic
+}
+
+func (ElasticCloudBillingDatasourceRequest) billingDatasourceType() string {
+	return billingDatasourceTypeElasticCloud
+}
+
+func (AzureBillingDatasourceRequest) billingDatasourceType() string {
+	return billingDatasourceTypeAzure
+}
@@ -0,0 +1,105 @@
+package costoryapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientGetCapabilities(t *testing.T) {
+	t.Parallel()
+
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != routeCapabilities {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		calls++
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"datasourceTypes":["GCP","AWS"],"features":{"eksSplit":true}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", server.Client())
+
+	capabilities, err := client.GetCapabilities(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if capabilities.AllSupported {
+		t.Fatal("expected explicit capabilities")
+	}
+	if !capabilities.SupportsDatasourceType("AWS") || capabilities.SupportsDatasourceType("Azure") {
+		t.Fatalf("unexpected datasource types: %v", capabilities.DatasourceTypes)
+	}
+	if !capabilities.FeatureEnabled("eksSplit") || capabilities.FeatureEnabled("other") {
+		t.Fatalf("unexpected features: %v", capabilities.Features)
+	}
+
+	err = client.CheckBillingDatasourceSupported(context.Background(), AzureBillingDatasourceRequest{})
+	var unsupported *UnsupportedDatasourceTypeError
+	if !errors.As(err, &unsupported) || unsupported.DatasourceType != "Azure" {
+		t.Fatalf("expected UnsupportedDatasourceTypeError for Azure, got: %v", err)
+	}
+
+	if err := client.CheckBillingDatasourceSupported(context.Background(), GCPBillingDatasourceRequest{}); err != nil {
+		t.Fatalf("unexpected error for GCP: %v", err)
+	}
+
+	if calls != 1 {
+		t.Fatalf("expected capabilities to be cached, got %d calls", calls)
+	}
+}
+
+func TestClientGetCapabilitiesNotFoundAssumesAllSupported(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", server.Client())
+
+	capabilities, err := client.GetCapabilities(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !capabilities.AllSupported || !capabilities.SupportsDatasourceType("Azure") || !capabilities.FeatureEnabled("anything") {
+		t.Fatalf("expected all capabilities to be supported, got %#v", capabilities)
+	}
+}
+
+func TestClientGetCapabilitiesServerErrorIsNotCached(t *testing.T) {
+	t.Parallel()
+
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"datasourceTypes":["GCP"]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", server.Client())
+
+	if _, err := client.GetCapabilities(context.Background()); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	capabilities, err := client.GetCapabilities(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !capabilities.SupportsDatasourceType("GCP") {
+		t.Fatalf("unexpected datasource types: %v", capabilities.DatasourceTypes)
+	}
+}
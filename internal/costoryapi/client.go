@@ -9,7 +9,10 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
+
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -22,26 +25,121 @@ const (
 	metricsDatasourceTypeS3V2         = "AwsS3V2"
 	maxRetryAttempts                  = 4
 	maxResponseBodyBytes              = 1024 * 1024
+	headerCostorySlug                 = "X-Costory-Slug"
+	headerCostoryWorkspace            = "X-Costory-Workspace"
 )
 
+// maxServiceAccountPages bounds how many sub_ids pages GetServiceAccount follows, so a server
+// that keeps returning new cursors cannot loop forever.
+const maxServiceAccountPages = 100
+
 // ErrNotFound is returned when the requested Costory resource does not exist.
 var ErrNotFound = errors.New("costory resource not found")
 
+// ErrValidationUnavailable is returned when a validate endpoint is not deployed, so callers can
+// skip pre-validation and let create report any problem instead.
+var ErrValidationUnavailable = errors.New("costory validate endpoint unavailable")
+
+// ErrClientClosed is returned by requests issued after Close, and by retries interrupted by it.
+var ErrClientClosed = errors.New("costory client closed")
+
+// UnsupportedOperationError is returned when the API answers 405 Method Not Allowed, which
+// usually means the deployment runs an older API version or has the operation disabled.
+type UnsupportedOperationError struct {
+	Method string
+	Path   string
+}
+
+func (e *UnsupportedOperationError) Error() string {
+	return fmt.Sprintf(
+		"the Costory API does not support %s %s (405 Method Not Allowed): the deployment may run an older API version or have this operation disabled",
+		e.Method, e.Path,
+	)
+}
+
 type httpDoer interface {
 	Do(req *http.Request) (*http.Response, error)
 }
 
-// Client is a lightweight Costory API client used by the provider.
+// Client is a lightweight Costory API client used by the provider. A single client is shared
+// by every resource and data source, which Terraform may call concurrently, so it is safe for
+// concurrent use: fields set by options are read-only after NewClient, and any state that
+// changes afterwards must be guarded by its own mutex.
 type Client struct {
-	baseURL    string
-	token      string
-	httpClient httpDoer
-}
-
-// ServiceAccountResponse represents the service-account payload returned by the API.
+	baseURL     string
+	token       string
+	slug        string
+	strictDrift bool
+	workspaceID string
+	runID       string
+	httpClient  httpDoer
+	requestHook RequestHook
+	tracer      trace.Tracer
+
+	batchConcurrency int
+	batchReadRate    int
+	batchCreateRate  int
+	datasourceTypes  map[string]string
+	apiPathPrefix    string
+	operationTimeout time.Duration
+
+	requireDeleteConfirmation bool
+	failDeleteOnMissing       bool
+	providerVersion           string
+	verboseRetries            bool
+	location                  *time.Location
+	defaultDetailedBilling    *bool
+	strictDecoding            bool
+	retryableMethods          map[string]struct{}
+	retryableStatuses         map[int]struct{}
+
+	deprecationMu   sync.Mutex
+	deprecationSeen map[string]struct{}
+
+	capabilitiesMu sync.Mutex
+	capabilities   *Capabilities
+
+	warningsMu   sync.Mutex
+	warnings     []Warning
+	warningsNext int
+
+	metrics clientMetrics
+
+	tokenMu      sync.Mutex
+	tokenCommand []string
+
+	closeOnce sync.Once
+	closed    chan struct{}
+
+	acceptedPollInterval time.Duration
+}
+
+// Option configures optional Client behavior.
+type Option func(*Client)
+
+// RequestInfo describes a single HTTP attempt made by the client.
+type RequestInfo struct {
+	Method string
+	Path   string
+	// StatusCode is zero when the attempt failed before a response was received.
+	StatusCode int
+	// Attempt is the 1-based attempt number within a retried request.
+	Attempt  int
+	Duration time.Duration
+	Err      error
+}
+
+// RequestHook is invoked after every HTTP attempt made by the client.
+type RequestHook func(RequestInfo)
+
+// ServiceAccountResponse represents the service-account payload returned by the API. Its JSON
+// tags are also the stable snake_case shape used when embedders marshal it.
 type ServiceAccountResponse struct {
 	ServiceAccount string   `json:"service_account"`
 	SubIDs         []string `json:"sub_ids"`
+	// Tenant is the slug of the tenant the token belongs to. It is empty when the
+	// deployment does not report it.
+	Tenant string `json:"tenant,omitempty"`
 }
 
 type serviceAccountAPIResponse struct {
@@ -50,6 +148,11 @@ type serviceAccountAPIResponse struct {
 	ServiceAccountEmail string   `json:"serviceAccountEmail"`
 	SubIDs              []string `json:"sub_ids"`
 	SubIDsCamel         []string `json:"subIds"`
+	Tenant              string   `json:"tenant"`
+	TenantSlug          string   `json:"tenant_slug"`
+	TenantSlugCamel     string   `json:"tenantSlug"`
+	NextCursor          string   `json:"next_cursor"`
+	NextCursorCamel     string   `json:"nextCursor"`
 }
 
 // GCPBillingDatasourceRequest is the Terraform input used to create/validate a GCP billing datasource.
@@ -59,21 +162,47 @@ type GCPBillingDatasourceRequest struct {
 	IsDetailedBilling *bool
 	StartDate         *string
 	EndDate           *string
+	Owner             *string
+	BillingAccountID  *string
+	ExportProjectID   *string
+	Priority          *int64
+	Tags              map[string]string
 }
 
 // GCPBillingDatasource is the normalized datasource payload returned by the Costory API.
+// BackfillProgress is the historical backfill percentage, nil when the API does not report it.
+// LastIngestedAt is the time of the last successful ingestion in UTC, nil when the API does not
+// report it. DetectedColumns lists the export columns Costory detected, nil when the API does
+// not report them. Priority is the ingestion priority, nil when unset. ManagedBy is the origin
+// the API reports for the datasource, such as "terraform" or "ui", nil when it does not report
+// one. Warnings holds non-fatal warnings the API attached to the response, typically on
+// create. EarliestAvailableDate is the earliest date the export holds data for, when the API
+// reports it. The JSON tags define a stable snake_case shape for embedders; nil fields marshal
+// as null, except Warnings, which is omitted when empty.
 type GCPBillingDatasource struct {
-	ID                string
-	Type              string
-	Status            *string
-	Name              string
-	BQURI             string
-	IsDetailedBilling *bool
-	StartDate         *string
-	EndDate           *string
+	ID                    string            `json:"id"`
+	Type                  string            `json:"type"`
+	Status                *string           `json:"status"`
+	ManagedBy             *string           `json:"managed_by"`
+	Name                  string            `json:"name"`
+	BQURI                 string            `json:"bq_uri"`
+	IsDetailedBilling     *bool             `json:"is_detailed_billing"`
+	StartDate             *string           `json:"start_date"`
+	EndDate               *string           `json:"end_date"`
+	BackfillProgress      *float64          `json:"backfill_progress"`
+	Owner                 *string           `json:"owner"`
+	Priority              *int64            `json:"priority"`
+	BillingAccountID      *string           `json:"billing_account_id"`
+	ExportProjectID       *string           `json:"export_project_id"`
+	LastIngestedAt        *time.Time        `json:"last_ingested_at"`
+	DetectedColumns       []string          `json:"detected_columns"`
+	Tags                  map[string]string `json:"tags"`
+	Warnings              []string          `json:"warnings,omitempty"`
+	EarliestAvailableDate *string           `json:"earliest_available_date"`
 }
 
 // AWSBillingDatasourceRequest is the Terraform input used to create/validate an AWS billing datasource.
+// When PrefixIsGlob is set, Prefix is sent as a glob pattern (prefixPattern) instead of a literal prefix.
 type AWSBillingDatasourceRequest struct {
 	Name                string
 	BucketName          string
@@ -83,21 +212,48 @@ type AWSBillingDatasourceRequest struct {
 	StartDate           *string
 	EndDate             *string
 	EKSSplit            *bool
+	ReportName          *string
+	PrefixIsGlob        bool
+	Owner               *string
+	Priority            *int64
+	Tags                map[string]string
 }
 
 // AWSBillingDatasource is the normalized datasource payload returned by the Costory API.
+// BackfillProgress is the historical backfill percentage, nil when the API does not report it.
+// Prefix is nil when the API omits it, so an empty prefix returned by the server is preserved.
+// PrefixPattern is set instead of Prefix when the datasource uses a glob prefix.
+// LastIngestedAt is the time of the last successful ingestion in UTC, nil when the API does not
+// report it. DetectedColumns lists the export columns Costory detected, nil when the API does
+// not report them. Priority is the ingestion priority, nil when unset. ManagedBy is the origin
+// the API reports for the datasource, such as "terraform" or "ui", nil when it does not report
+// one. Warnings holds non-fatal warnings the API attached to the response, typically on
+// create. EarliestAvailableDate is the earliest date the export holds data for, when the API
+// reports it. The JSON tags define a stable snake_case shape for embedders; nil fields marshal
+// as null, except Warnings, which is omitted when empty.
 type AWSBillingDatasource struct {
-	ID                  string
-	Type                string
-	Status              *string
-	Name                string
-	BucketName          string
-	RoleARN             string
-	Prefix              string
-	EKSSplitDataEnabled *bool
-	StartDate           *string
-	EndDate             *string
-	EKSSplit            *bool
+	ID                    string            `json:"id"`
+	Type                  string            `json:"type"`
+	Status                *string           `json:"status"`
+	ManagedBy             *string           `json:"managed_by"`
+	Name                  string            `json:"name"`
+	BucketName            string            `json:"bucket_name"`
+	RoleARN               string            `json:"role_arn"`
+	Prefix                *string           `json:"prefix"`
+	EKSSplitDataEnabled   *bool             `json:"eks_split_data_enabled"`
+	StartDate             *string           `json:"start_date"`
+	EndDate               *string           `json:"end_date"`
+	EKSSplit              *bool             `json:"eks_split"`
+	ReportName            *string           `json:"report_name"`
+	PrefixPattern         *string           `json:"prefix_pattern"`
+	BackfillProgress      *float64          `json:"backfill_progress"`
+	Owner                 *string           `json:"owner"`
+	Priority              *int64            `json:"priority"`
+	LastIngestedAt        *time.Time        `json:"last_ingested_at"`
+	DetectedColumns       []string          `json:"detected_columns"`
+	Tags                  map[string]string `json:"tags"`
+	Warnings              []string          `json:"warnings,omitempty"`
+	EarliestAvailableDate *string           `json:"earliest_available_date"`
 }
 
 // CursorBillingDatasourceRequest is the Terraform input used to create/validate a Cursor billing datasource.
@@ -109,14 +265,21 @@ type CursorBillingDatasourceRequest struct {
 }
 
 // CursorBillingDatasource is the normalized datasource payload returned by the Costory API.
+// ManagedBy is the origin the API reports for the datasource, such as "terraform" or "ui", nil
+// when it does not report one. Warnings holds non-fatal warnings the API attached to the
+// response, typically on create.
+// EarliestAvailableDate is the earliest date the source holds data for, when the API reports it.
 type CursorBillingDatasource struct {
-	ID         string
-	Type       string
-	Status     *string
-	Name       string
-	BQTableURI string
-	StartDate  *string
-	EndDate    *string
+	ID                    string
+	Type                  string
+	Status                *string
+	ManagedBy             *string
+	Name                  string
+	BQTableURI            string
+	StartDate             *string
+	EndDate               *string
+	Warnings              []string
+	EarliestAvailableDate *string
 }
 
 // AnthropicBillingDatasourceRequest is the Terraform input used to create/validate an Anthropic billing datasource.
@@ -128,14 +291,21 @@ type AnthropicBillingDatasourceRequest struct {
 }
 
 // AnthropicBillingDatasource is the normalized datasource payload returned by the Costory API.
+// ManagedBy is the origin the API reports for the datasource, such as "terraform" or "ui", nil
+// when it does not report one. Warnings holds non-fatal warnings the API attached to the
+// response, typically on create.
+// EarliestAvailableDate is the earliest date the source holds data for, when the API reports it.
 type AnthropicBillingDatasource struct {
-	ID         string
-	Type       string
-	Status     *string
-	Name       string
-	BQTableURI string
-	StartDate  *string
-	EndDate    *string
+	ID                    string
+	Type                  string
+	Status                *string
+	ManagedBy             *string
+	Name                  string
+	BQTableURI            string
+	StartDate             *string
+	EndDate               *string
+	Warnings              []string
+	EarliestAvailableDate *string
 }
 
 // ElasticCloudBillingDatasourceRequest is the Terraform input used to create/validate an Elastic Cloud billing datasource.
@@ -147,14 +317,21 @@ type ElasticCloudBillingDatasourceRequest struct {
 }
 
 // ElasticCloudBillingDatasource is the normalized datasource payload returned by the Costory API.
+// ManagedBy is the origin the API reports for the datasource, such as "terraform" or "ui", nil
+// when it does not report one. Warnings holds non-fatal warnings the API attached to the
+// response, typically on create.
+// EarliestAvailableDate is the earliest date the source holds data for, when the API reports it.
 type ElasticCloudBillingDatasource struct {
-	ID             string
-	Type           string
-	Status         *string
-	Name           string
-	OrganizationID string
-	BQTableURI     string
-	StartDate      *string
+	ID                    string
+	Type                  string
+	Status                *string
+	ManagedBy             *string
+	Name                  string
+	OrganizationID        string
+	BQTableURI            string
+	StartDate             *string
+	Warnings              []string
+	EarliestAvailableDate *string
 }
 
 // AzureBillingDatasourceRequest is the Terraform input used to create/validate an Azure billing datasource.
@@ -168,15 +345,20 @@ type AzureBillingDatasourceRequest struct {
 }
 
 // AzureBillingDatasource is the normalized datasource payload returned by the Costory API.
+// ManagedBy is the origin the API reports for the datasource, such as "terraform" or "ui", nil
+// when it does not report one. Warnings holds non-fatal warnings the API attached to the
+// response, typically on create.
 type AzureBillingDatasource struct {
 	ID                 string
 	Type               string
 	Status             *string
+	ManagedBy          *string
 	Name               string
 	StorageAccountName string
 	ContainerName      string
 	ActualsPath        string
 	AmortizedPath      string
+	Warnings           []string
 }
 
 // TeamCreateRequest is the Terraform input used to create a team.
@@ -199,8 +381,24 @@ type Team struct {
 	Name        string
 	Description string
 	Visibility  string
-	CreatedAt   string
-	UpdatedAt   string
+	// CreatedAt and UpdatedAt are RFC3339 in UTC, whichever format the API sent.
+	CreatedAt string
+	UpdatedAt string
+}
+
+// AllocationRuleRequest is the Terraform input used to create or update a cost allocation rule.
+type AllocationRuleRequest struct {
+	Name        string
+	Match       string
+	TargetLabel string
+}
+
+// AllocationRule is the normalized allocation rule payload returned by the Costory API.
+type AllocationRule struct {
+	ID          string
+	Name        string
+	Match       string
+	TargetLabel string
 }
 
 // TeamMemberRequest is the Terraform input used to add a team member.
@@ -211,49 +409,81 @@ type TeamMemberRequest struct {
 }
 
 type gcpBillingDatasourceAPIRequest struct {
-	Type              string  `json:"type"`
-	Name              string  `json:"name"`
-	BQTablePath       string  `json:"bqTablePath"`
-	IsDetailedBilling *bool   `json:"isDetailedBilling,omitempty"`
-	StartDate         *string `json:"startDate,omitempty"`
-	EndDate           *string `json:"endDate,omitempty"`
+	Type              string            `json:"type"`
+	Name              string            `json:"name"`
+	BQTablePath       string            `json:"bqTablePath"`
+	IsDetailedBilling *bool             `json:"isDetailedBilling,omitempty"`
+	StartDate         *string           `json:"startDate,omitempty"`
+	EndDate           *string           `json:"endDate,omitempty"`
+	Owner             *string           `json:"owner,omitempty"`
+	Priority          *int64            `json:"priority,omitempty"`
+	BillingAccountID  *string           `json:"billingAccountId,omitempty"`
+	ExportProjectID   *string           `json:"exportProjectId,omitempty"`
+	Tags              map[string]string `json:"tags,omitempty"`
 }
 
 type gcpBillingDatasourceAPIResponse struct {
-	ID                string  `json:"id"`
-	Type              string  `json:"type"`
-	Status            *string `json:"status"`
-	Name              string  `json:"name"`
-	BQURI             string  `json:"bqUri"`
-	IsDetailedBilling *bool   `json:"isDetailedBilling"`
-	StartDate         *string `json:"startDate"`
-	EndDate           *string `json:"endDate"`
+	ID                    string            `json:"id"`
+	Type                  string            `json:"type"`
+	Status                *string           `json:"status"`
+	ManagedBy             *string           `json:"managedBy"`
+	Name                  string            `json:"name"`
+	BQURI                 string            `json:"bqUri"`
+	IsDetailedBilling     *bool             `json:"isDetailedBilling"`
+	StartDate             *string           `json:"startDate"`
+	EndDate               *string           `json:"endDate"`
+	BackfillProgress      *float64          `json:"backfillProgress"`
+	Owner                 *string           `json:"owner"`
+	Priority              *int64            `json:"priority"`
+	BillingAccountID      *string           `json:"billingAccountId"`
+	ExportProjectID       *string           `json:"exportProjectId"`
+	LastIngestedAt        *flexibleTime     `json:"lastIngestedAt"`
+	DetectedColumns       []string          `json:"detectedColumns"`
+	Tags                  map[string]string `json:"tags"`
+	Warnings              []string          `json:"warnings"`
+	EarliestAvailableDate *string           `json:"earliestAvailableDate"`
 }
 
 type awsBillingDatasourceAPIRequest struct {
-	Type                string  `json:"type"`
-	Name                string  `json:"name"`
-	BucketName          string  `json:"bucketName"`
-	RoleARN             string  `json:"roleArn"`
-	Prefix              string  `json:"prefix"`
-	EKSSplitDataEnabled *bool   `json:"eksSplitDataEnabled,omitempty"`
-	StartDate           *string `json:"startDate,omitempty"`
-	EndDate             *string `json:"endDate,omitempty"`
-	EKSSplit            *bool   `json:"eksSplit,omitempty"`
+	Type                string            `json:"type"`
+	Name                string            `json:"name"`
+	BucketName          string            `json:"bucketName"`
+	RoleARN             string            `json:"roleArn"`
+	Prefix              *string           `json:"prefix,omitempty"`
+	PrefixPattern       *string           `json:"prefixPattern,omitempty"`
+	EKSSplitDataEnabled *bool             `json:"eksSplitDataEnabled,omitempty"`
+	StartDate           *string           `json:"startDate,omitempty"`
+	EndDate             *string           `json:"endDate,omitempty"`
+	EKSSplit            *bool             `json:"eksSplit,omitempty"`
+	ReportName          *string           `json:"reportName,omitempty"`
+	Owner               *string           `json:"owner,omitempty"`
+	Priority            *int64            `json:"priority,omitempty"`
+	Tags                map[string]string `json:"tags,omitempty"`
 }
 
 type awsBillingDatasourceAPIResponse struct {
-	ID                  string  `json:"id"`
-	Type                string  `json:"type"`
-	Status              *string `json:"status"`
-	Name                string  `json:"name"`
-	BucketName          string  `json:"bucketName"`
-	RoleARN             string  `json:"roleArn"`
-	Prefix              string  `json:"prefix"`
-	EKSSplitDataEnabled *bool   `json:"eksSplitDataEnabled"`
-	StartDate           *string `json:"startDate"`
-	EndDate             *string `json:"endDate"`
-	EKSSplit            *bool   `json:"eksSplit"`
+	ID                    string            `json:"id"`
+	Type                  string            `json:"type"`
+	Status                *string           `json:"status"`
+	ManagedBy             *string           `json:"managedBy"`
+	Name                  string            `json:"name"`
+	BucketName            string            `json:"bucketName"`
+	RoleARN               string            `json:"roleArn"`
+	Prefix                *string           `json:"prefix"`
+	PrefixPattern         *string           `json:"prefixPattern"`
+	EKSSplitDataEnabled   *bool             `json:"eksSplitDataEnabled"`
+	StartDate             *string           `json:"startDate"`
+	EndDate               *string           `json:"endDate"`
+	EKSSplit              *bool             `json:"eksSplit"`
+	ReportName            *string           `json:"reportName"`
+	BackfillProgress      *float64          `json:"backfillProgress"`
+	Owner                 *string           `json:"owner"`
+	Priority              *int64            `json:"priority"`
+	LastIngestedAt        *flexibleTime     `json:"lastIngestedAt"`
+	DetectedColumns       []string          `json:"detectedColumns"`
+	Tags                  map[string]string `json:"tags"`
+	Warnings              []string          `json:"warnings"`
+	EarliestAvailableDate *string           `json:"earliestAvailableDate"`
 }
 
 type externalBillingDatasourceAPIRequest struct {
@@ -265,13 +495,16 @@ type externalBillingDatasourceAPIRequest struct {
 }
 
 type externalBillingDatasourceAPIResponse struct {
-	ID         string  `json:"id"`
-	Type       string  `json:"type"`
-	Status     *string `json:"status"`
-	Name       string  `json:"name"`
-	BQTableURI string  `json:"bqTableUri"`
-	StartDate  *string `json:"startDate"`
-	EndDate    *string `json:"endDate"`
+	ID                    string   `json:"id"`
+	Type                  string   `json:"type"`
+	Status                *string  `json:"status"`
+	ManagedBy             *string  `json:"managedBy"`
+	Name                  string   `json:"name"`
+	BQTableURI            string   `json:"bqTableUri"`
+	StartDate             *string  `json:"startDate"`
+	EndDate               *string  `json:"endDate"`
+	Warnings              []string `json:"warnings"`
+	EarliestAvailableDate *string  `json:"earliestAvailableDate"`
 }
 
 type elasticCloudBillingDatasourceAPIRequest struct {
@@ -283,13 +516,16 @@ type elasticCloudBillingDatasourceAPIRequest struct {
 }
 
 type elasticCloudBillingDatasourceAPIResponse struct {
-	ID             string  `json:"id"`
-	Type           string  `json:"type"`
-	Status         *string `json:"status"`
-	Name           string  `json:"name"`
-	OrganizationID string  `json:"organizationId"`
-	BQTableURI     string  `json:"bqTableUri"`
-	StartDate      *string `json:"startDate"`
+	ID                    string   `json:"id"`
+	Type                  string   `json:"type"`
+	Status                *string  `json:"status"`
+	ManagedBy             *string  `json:"managedBy"`
+	Name                  string   `json:"name"`
+	OrganizationID        string   `json:"organizationId"`
+	BQTableURI            string   `json:"bqTableUri"`
+	StartDate             *string  `json:"startDate"`
+	Warnings              []string `json:"warnings"`
+	EarliestAvailableDate *string  `json:"earliestAvailableDate"`
 }
 
 type azureBillingDatasourceAPIRequest struct {
@@ -303,14 +539,16 @@ type azureBillingDatasourceAPIRequest struct {
 }
 
 type azureBillingDatasourceAPIResponse struct {
-	ID                 string  `json:"id"`
-	Type               string  `json:"type"`
-	Status             *string `json:"status"`
-	Name               string  `json:"name"`
-	StorageAccountName string  `json:"storageAccountName"`
-	ContainerName      string  `json:"containerName"`
-	ActualsPath        string  `json:"actualsPath"`
-	AmortizedPath      string  `json:"amortizedPath"`
+	ID                 string   `json:"id"`
+	Type               string   `json:"type"`
+	Status             *string  `json:"status"`
+	ManagedBy          *string  `json:"managedBy"`
+	Name               string   `json:"name"`
+	StorageAccountName string   `json:"storageAccountName"`
+	ContainerName      string   `json:"containerName"`
+	ActualsPath        string   `json:"actualsPath"`
+	AmortizedPath      string   `json:"amortizedPath"`
+	Warnings           []string `json:"warnings"`
 }
 
 type teamCreateAPIRequest struct {
@@ -325,6 +563,19 @@ type teamUpdateAPIRequest struct {
 	Visibility  *string `json:"visibility,omitempty"`
 }
 
+type allocationRuleAPIRequest struct {
+	Name        string `json:"name"`
+	Match       string `json:"match"`
+	TargetLabel string `json:"targetLabel"`
+}
+
+type allocationRuleAPIResponse struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Match       string `json:"match"`
+	TargetLabel string `json:"targetLabel"`
+}
+
 type teamMemberAPIRequest struct {
 	UserID *string `json:"userId,omitempty"`
 	Email  *string `json:"email,omitempty"`
@@ -332,12 +583,12 @@ type teamMemberAPIRequest struct {
 }
 
 type teamAPIResponse struct {
-	ID          string `json:"id"`
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	Visibility  string `json:"visibility"`
-	CreatedAt   string `json:"createdAt"`
-	UpdatedAt   string `json:"updatedAt"`
+	ID          string        `json:"id"`
+	Name        string        `json:"name"`
+	Description string        `json:"description"`
+	Visibility  string        `json:"visibility"`
+	CreatedAt   *flexibleTime `json:"createdAt"`
+	UpdatedAt   *flexibleTime `json:"updatedAt"`
 }
 
 // MetricsDefinition is a single metric definition for an AwsS3V2 metrics datasource.
@@ -403,6 +654,18 @@ type metricsDatasourceAPIResponse struct {
 	MetricsDefinition []metricsDefinitionAPI `json:"metricsDefinition"`
 }
 
+type billingDatasourcePatchAPIRequest struct {
+	Owner *string `json:"owner"`
+}
+
+type billingDatasourcePriorityPatchAPIRequest struct {
+	Priority *int64 `json:"priority"`
+}
+
+type billingDatasourceTagsPatchAPIRequest struct {
+	Tags map[string]string `json:"tags"`
+}
+
 type metricsDatasourcePatchAPIRequest struct {
 	MetricsDefinition []metricsDefinitionAPI `json:"metricsDefinition"`
 }
@@ -422,71 +685,239 @@ type apiErrorResponse struct {
 }
 
 // NewClient creates a new Costory API client.
-func NewClient(baseURL, token string, httpClient httpDoer) *Client {
+func NewClient(baseURL, token string, httpClient httpDoer, opts ...Option) *Client {
 	if httpClient == nil {
 		httpClient = http.DefaultClient
 	}
 
-	return &Client{
+	c := &Client{
 		baseURL:    baseURL,
 		token:      token,
 		httpClient: httpClient,
+		runID:      newRunID(),
+		closed:     make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// Slug returns the Costory tenant slug configured with WithSlug, or an empty string.
+func (c *Client) Slug() string {
+	return c.slug
+}
+
+// WithSlug sets the Costory tenant slug sent with every request.
+func WithSlug(slug string) Option {
+	return func(c *Client) {
+		c.slug = slug
+	}
+}
+
+// WithWorkspaceID scopes every request to a Costory workspace within the tenant.
+func WithWorkspaceID(workspaceID string) Option {
+	return func(c *Client) {
+		c.workspaceID = workspaceID
+	}
+}
+
+// WithStrictDrift makes resources fail Read when server-side values differ from
+// prior state instead of silently reconciling them.
+func WithStrictDrift(strict bool) Option {
+	return func(c *Client) {
+		c.strictDrift = strict
+	}
+}
+
+// StrictDrift reports whether the client was configured with WithStrictDrift.
+func (c *Client) StrictDrift() bool {
+	return c.strictDrift
+}
+
+// WithRequireDeleteConfirmation makes resources refuse to delete billing datasources unless the
+// resource explicitly confirms the deletion.
+func WithRequireDeleteConfirmation(require bool) Option {
+	return func(c *Client) {
+		c.requireDeleteConfirmation = require
+	}
+}
+
+// RequireDeleteConfirmation reports whether the client was configured with
+// WithRequireDeleteConfirmation.
+func (c *Client) RequireDeleteConfirmation() bool {
+	return c.requireDeleteConfirmation
+}
+
+// WithFailDeleteOnMissing makes resources report a delete that finds the object already gone
+// (404) as an error, exposing state drift, instead of treating it as a successful delete.
+func WithFailDeleteOnMissing(fail bool) Option {
+	return func(c *Client) {
+		c.failDeleteOnMissing = fail
+	}
+}
+
+// FailDeleteOnMissing reports whether the client was configured with WithFailDeleteOnMissing.
+func (c *Client) FailDeleteOnMissing() bool {
+	return c.failDeleteOnMissing
+}
+
+// WithTimezone sets the time zone in which calendar dates, such as relative start_date
+// expressions, are resolved. A nil location keeps the default of UTC.
+func WithTimezone(loc *time.Location) Option {
+	return func(c *Client) {
+		if loc != nil {
+			c.location = loc
+		}
+	}
+}
+
+// Now returns the current time in the zone configured with WithTimezone, UTC by default.
+func (c *Client) Now() time.Time {
+	if c.location == nil {
+		return time.Now().UTC()
+	}
+
+	return time.Now().In(c.location)
+}
+
+// WithDefaultDetailedBilling sets the is_detailed_billing value applied to GCP billing
+// datasources that leave it unset. A nil value leaves the choice to the API.
+func WithDefaultDetailedBilling(detailed *bool) Option {
+	return func(c *Client) {
+		c.defaultDetailedBilling = detailed
+	}
+}
+
+// DefaultDetailedBilling returns the value configured with WithDefaultDetailedBilling, or nil.
+func (c *Client) DefaultDetailedBilling() *bool {
+	return c.defaultDetailedBilling
+}
+
+// WithRequestHook registers a hook invoked after each request attempt, including
+// attempts that fail before a response is received. Panics raised by the hook are
+// recovered so observability code cannot break API calls.
+func WithRequestHook(hook RequestHook) Option {
+	return func(c *Client) {
+		c.requestHook = hook
 	}
 }
 
 // GetServiceAccount fetches service-account data for the configured Costory tenant.
+// It returns ErrNotFound when the tenant (slug) does not exist or is not yet provisioned.
+// Paginated sub_ids are followed through next_cursor and returned in order without duplicates.
 func (c *Client) GetServiceAccount(ctx context.Context) (*ServiceAccountResponse, error) {
 	body, statusCode, err := doEndpoint(ctx, c, endpointGetServiceAccount, noRequest{})
+	out, err := c.decodeServiceAccountPage(endpointGetServiceAccount.Name, body, statusCode, err)
 	if err != nil {
 		return nil, err
 	}
-	if statusCode != http.StatusOK {
-		return nil, unexpectedStatusError(statusCode, body)
+
+	normalized := &ServiceAccountResponse{
+		ServiceAccount: firstNonEmptyString(out.ServiceAccount, out.ServiceAccountCamel, out.ServiceAccountEmail),
+		SubIDs:         []string{},
+		Tenant:         firstNonEmptyString(out.Tenant, out.TenantSlug, out.TenantSlugCamel),
 	}
 
-	var out serviceAccountAPIResponse
-	if err := json.Unmarshal(body, &out); err != nil {
-		return nil, fmt.Errorf("decode response body: %w", err)
+	seenSubIDs := make(map[string]struct{})
+	seenCursors := make(map[string]struct{})
+	for page := 1; ; page++ {
+		for _, subID := range firstStringSlice(out.SubIDs, out.SubIDsCamel) {
+			if _, ok := seenSubIDs[subID]; ok {
+				continue
+			}
+			seenSubIDs[subID] = struct{}{}
+			normalized.SubIDs = append(normalized.SubIDs, subID)
+		}
+
+		cursor := firstNonEmptyString(out.NextCursor, out.NextCursorCamel)
+		if cursor == "" {
+			return normalized, nil
+		}
+		if _, ok := seenCursors[cursor]; ok {
+			return nil, fmt.Errorf("service account pagination repeated cursor %q", cursor)
+		}
+		if page >= maxServiceAccountPages {
+			return nil, fmt.Errorf("service account pagination exceeded %d pages", maxServiceAccountPages)
+		}
+		seenCursors[cursor] = struct{}{}
+
+		body, statusCode, err := doEndpointWithRouteParams(ctx, c, endpointGetServiceAccountPage, serviceAccountPageRouteParams{Cursor: cursor}, noRequest{})
+		out, err = c.decodeServiceAccountPage(endpointGetServiceAccountPage.Name, body, statusCode, err)
+		if err != nil {
+			return nil, err
+		}
 	}
+}
 
-	normalized := &ServiceAccountResponse{
-		ServiceAccount: firstNonEmptyString(out.ServiceAccount, out.ServiceAccountCamel, out.ServiceAccountEmail),
-		SubIDs:         firstStringSlice(out.SubIDs, out.SubIDsCamel),
+func (c *Client) decodeServiceAccountPage(endpoint string, body []byte, statusCode int, err error) (*serviceAccountAPIResponse, error) {
+	if err != nil {
+		return nil, err
+	}
+	if statusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+	if statusCode != http.StatusOK {
+		return nil, unexpectedStatusError(endpoint, statusCode, body)
 	}
-	if normalized.SubIDs == nil {
-		normalized.SubIDs = []string{}
+
+	var out serviceAccountAPIResponse
+	if err := c.decodeResponse(body, &out); err != nil {
+		return nil, fmt.Errorf("decode response body: %w", err)
 	}
 
-	return normalized, nil
+	return &out, nil
 }
 
 // ValidateGCPBillingDatasource validates a GCP billing datasource before creation.
+// When the API lists several issues, the returned error is a *ValidationError.
 func (c *Client) ValidateGCPBillingDatasource(ctx context.Context, req GCPBillingDatasourceRequest) error {
-	body, statusCode, err := doEndpoint(ctx, c, endpointValidateGCPBillingDatasource, req.toAPIRequest())
+	result, err := c.ValidateGCPBillingDatasourceResult(ctx, req)
 	if err != nil {
 		return err
 	}
 
-	if statusCode >= http.StatusOK && statusCode < http.StatusMultipleChoices {
-		return nil
+	return result.Err()
+}
+
+// ValidateGCPBillingDatasourceResult validates a GCP billing datasource before creation and
+// returns the structured outcome. A rejected configuration is an invalid result, not an
+// error; errors mean validation could not run, including ErrValidationUnavailable.
+func (c *Client) ValidateGCPBillingDatasourceResult(ctx context.Context, req GCPBillingDatasourceRequest) (*ValidationResult, error) {
+	if err := req.checkFeatures(ctx, c); err != nil {
+		return nil, err
+	}
+
+	body, statusCode, err := doEndpoint(ctx, c, endpointValidateGCPBillingDatasource, req.toAPIRequest(c.datasourceType(billingDatasourceTypeGCP)))
+	var unsupported *UnsupportedOperationError
+	if errors.As(err, &unsupported) {
+		return nil, fmt.Errorf("%w: %w", ErrValidationUnavailable, err)
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	return unexpectedStatusError(statusCode, body)
+	return validateEndpointResult(endpointValidateGCPBillingDatasource.Name, statusCode, body)
 }
 
 // CreateGCPBillingDatasource creates a GCP billing datasource and returns its API representation.
 func (c *Client) CreateGCPBillingDatasource(ctx context.Context, req GCPBillingDatasourceRequest) (*GCPBillingDatasource, error) {
-	body, statusCode, err := doEndpoint(ctx, c, endpointCreateGCPBillingDatasource, req.toAPIRequest())
+	if err := req.checkFeatures(ctx, c); err != nil {
+		return nil, err
+	}
+
+	body, statusCode, err := doCreateBillingDatasourceEndpoint(ctx, c, endpointCreateGCPBillingDatasource, req.toAPIRequest(c.datasourceType(billingDatasourceTypeGCP)))
 	if err != nil {
 		return nil, err
 	}
 
 	if statusCode < http.StatusOK || statusCode >= http.StatusMultipleChoices {
-		return nil, unexpectedStatusError(statusCode, body)
+		return nil, validationFailure(endpointCreateGCPBillingDatasource.Name, statusCode, body)
 	}
 
 	var out gcpBillingDatasourceAPIResponse
-	if err := json.Unmarshal(body, &out); err != nil {
+	if err := c.decodeResponse(body, &out); err != nil {
 		return nil, fmt.Errorf("decode response body: %w", err)
 	}
 
@@ -495,6 +926,10 @@ func (c *Client) CreateGCPBillingDatasource(ctx context.Context, req GCPBillingD
 		return nil, errors.New("create response did not include datasource id")
 	}
 
+	if err := c.checkDatasourceType(billingDatasourceTypeGCP, normalized.Type); err != nil {
+		return nil, err
+	}
+
 	return normalized, nil
 }
 
@@ -510,12 +945,12 @@ func (c *Client) GetGCPBillingDatasource(ctx context.Context, datasourceID strin
 		return nil, ErrNotFound
 	}
 
-	if statusCode != http.StatusOK {
-		return nil, unexpectedStatusError(statusCode, body)
+	if !isReadSuccess(statusCode) {
+		return nil, unexpectedStatusError(endpointGetGCPBillingDatasourceByID.Name, statusCode, body)
 	}
 
 	var out gcpBillingDatasourceAPIResponse
-	if err := json.Unmarshal(body, &out); err != nil {
+	if err := c.decodeResponse(body, &out); err != nil {
 		return nil, fmt.Errorf("decode response body: %w", err)
 	}
 
@@ -524,36 +959,61 @@ func (c *Client) GetGCPBillingDatasource(ctx context.Context, datasourceID strin
 		normalized.ID = datasourceID
 	}
 
+	if err := c.checkDatasourceType(billingDatasourceTypeGCP, normalized.Type); err != nil {
+		return nil, err
+	}
+
 	return normalized, nil
 }
 
 // ValidateAWSBillingDatasource validates an AWS billing datasource before creation.
+// When the API lists several issues, the returned error is a *ValidationError.
 func (c *Client) ValidateAWSBillingDatasource(ctx context.Context, req AWSBillingDatasourceRequest) error {
-	body, statusCode, err := doEndpoint(ctx, c, endpointValidateAWSBillingDatasource, req.toAPIRequest())
+	result, err := c.ValidateAWSBillingDatasourceResult(ctx, req)
 	if err != nil {
 		return err
 	}
 
-	if statusCode >= http.StatusOK && statusCode < http.StatusMultipleChoices {
-		return nil
+	return result.Err()
+}
+
+// ValidateAWSBillingDatasourceResult validates an AWS billing datasource before creation and
+// returns the structured outcome. A rejected configuration is an invalid result, not an
+// error; errors mean validation could not run, including ErrValidationUnavailable.
+func (c *Client) ValidateAWSBillingDatasourceResult(ctx context.Context, req AWSBillingDatasourceRequest) (*ValidationResult, error) {
+	if err := req.checkFeatures(ctx, c); err != nil {
+		return nil, err
 	}
 
-	return unexpectedStatusError(statusCode, body)
+	body, statusCode, err := doEndpoint(ctx, c, endpointValidateAWSBillingDatasource, req.toAPIRequest(c.datasourceType(billingDatasourceTypeAWS)))
+	var unsupported *UnsupportedOperationError
+	if errors.As(err, &unsupported) {
+		return nil, fmt.Errorf("%w: %w", ErrValidationUnavailable, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return validateEndpointResult(endpointValidateAWSBillingDatasource.Name, statusCode, body)
 }
 
 // CreateAWSBillingDatasource creates an AWS billing datasource and returns its API representation.
 func (c *Client) CreateAWSBillingDatasource(ctx context.Context, req AWSBillingDatasourceRequest) (*AWSBillingDatasource, error) {
-	body, statusCode, err := doEndpoint(ctx, c, endpointCreateAWSBillingDatasource, req.toAPIRequest())
+	if err := req.checkFeatures(ctx, c); err != nil {
+		return nil, err
+	}
+
+	body, statusCode, err := doCreateBillingDatasourceEndpoint(ctx, c, endpointCreateAWSBillingDatasource, req.toAPIRequest(c.datasourceType(billingDatasourceTypeAWS)))
 	if err != nil {
 		return nil, err
 	}
 
 	if statusCode < http.StatusOK || statusCode >= http.StatusMultipleChoices {
-		return nil, unexpectedStatusError(statusCode, body)
+		return nil, validationFailure(endpointCreateAWSBillingDatasource.Name, statusCode, body)
 	}
 
 	var out awsBillingDatasourceAPIResponse
-	if err := json.Unmarshal(body, &out); err != nil {
+	if err := c.decodeResponse(body, &out); err != nil {
 		return nil, fmt.Errorf("decode response body: %w", err)
 	}
 
@@ -562,6 +1022,10 @@ func (c *Client) CreateAWSBillingDatasource(ctx context.Context, req AWSBillingD
 		return nil, errors.New("create response did not include datasource id")
 	}
 
+	if err := c.checkDatasourceType(billingDatasourceTypeAWS, normalized.Type); err != nil {
+		return nil, err
+	}
+
 	return normalized, nil
 }
 
@@ -577,12 +1041,12 @@ func (c *Client) GetAWSBillingDatasource(ctx context.Context, datasourceID strin
 		return nil, ErrNotFound
 	}
 
-	if statusCode != http.StatusOK {
-		return nil, unexpectedStatusError(statusCode, body)
+	if !isReadSuccess(statusCode) {
+		return nil, unexpectedStatusError(endpointGetAWSBillingDatasourceByID.Name, statusCode, body)
 	}
 
 	var out awsBillingDatasourceAPIResponse
-	if err := json.Unmarshal(body, &out); err != nil {
+	if err := c.decodeResponse(body, &out); err != nil {
 		return nil, fmt.Errorf("decode response body: %w", err)
 	}
 
@@ -591,12 +1055,16 @@ func (c *Client) GetAWSBillingDatasource(ctx context.Context, datasourceID strin
 		normalized.ID = datasourceID
 	}
 
+	if err := c.checkDatasourceType(billingDatasourceTypeAWS, normalized.Type); err != nil {
+		return nil, err
+	}
+
 	return normalized, nil
 }
 
 // ValidateCursorBillingDatasource validates a Cursor billing datasource before creation.
 func (c *Client) ValidateCursorBillingDatasource(ctx context.Context, req CursorBillingDatasourceRequest) error {
-	body, statusCode, err := doEndpoint(ctx, c, endpointValidateCursorBillingDatasource, req.toAPIRequest())
+	body, statusCode, err := doEndpoint(ctx, c, endpointValidateCursorBillingDatasource, req.toAPIRequest(c.datasourceType(billingDatasourceTypeCursor)))
 	if err != nil {
 		return err
 	}
@@ -605,22 +1073,22 @@ func (c *Client) ValidateCursorBillingDatasource(ctx context.Context, req Cursor
 		return nil
 	}
 
-	return unexpectedStatusError(statusCode, body)
+	return unexpectedStatusError(endpointValidateCursorBillingDatasource.Name, statusCode, body)
 }
 
 // CreateCursorBillingDatasource creates a Cursor billing datasource and returns its API representation.
 func (c *Client) CreateCursorBillingDatasource(ctx context.Context, req CursorBillingDatasourceRequest) (*CursorBillingDatasource, error) {
-	body, statusCode, err := doEndpoint(ctx, c, endpointCreateCursorBillingDatasource, req.toAPIRequest())
+	body, statusCode, err := doCreateBillingDatasourceEndpoint(ctx, c, endpointCreateCursorBillingDatasource, req.toAPIRequest(c.datasourceType(billingDatasourceTypeCursor)))
 	if err != nil {
 		return nil, err
 	}
 
 	if statusCode < http.StatusOK || statusCode >= http.StatusMultipleChoices {
-		return nil, unexpectedStatusError(statusCode, body)
+		return nil, unexpectedStatusError(endpointCreateCursorBillingDatasource.Name, statusCode, body)
 	}
 
 	var out externalBillingDatasourceAPIResponse
-	if err := json.Unmarshal(body, &out); err != nil {
+	if err := c.decodeResponse(body, &out); err != nil {
 		return nil, fmt.Errorf("decode response body: %w", err)
 	}
 
@@ -629,6 +1097,10 @@ func (c *Client) CreateCursorBillingDatasource(ctx context.Context, req CursorBi
 		return nil, errors.New("create response did not include datasource id")
 	}
 
+	if err := c.checkDatasourceType(billingDatasourceTypeCursor, normalized.Type); err != nil {
+		return nil, err
+	}
+
 	return normalized, nil
 }
 
@@ -644,12 +1116,12 @@ func (c *Client) GetCursorBillingDatasource(ctx context.Context, datasourceID st
 		return nil, ErrNotFound
 	}
 
-	if statusCode != http.StatusOK {
-		return nil, unexpectedStatusError(statusCode, body)
+	if !isReadSuccess(statusCode) {
+		return nil, unexpectedStatusError(endpointGetCursorBillingDatasourceByID.Name, statusCode, body)
 	}
 
 	var out externalBillingDatasourceAPIResponse
-	if err := json.Unmarshal(body, &out); err != nil {
+	if err := c.decodeResponse(body, &out); err != nil {
 		return nil, fmt.Errorf("decode response body: %w", err)
 	}
 
@@ -658,12 +1130,16 @@ func (c *Client) GetCursorBillingDatasource(ctx context.Context, datasourceID st
 		normalized.ID = datasourceID
 	}
 
+	if err := c.checkDatasourceType(billingDatasourceTypeCursor, normalized.Type); err != nil {
+		return nil, err
+	}
+
 	return normalized, nil
 }
 
 // ValidateAnthropicBillingDatasource validates an Anthropic billing datasource before creation.
 func (c *Client) ValidateAnthropicBillingDatasource(ctx context.Context, req AnthropicBillingDatasourceRequest) error {
-	body, statusCode, err := doEndpoint(ctx, c, endpointValidateAnthropicBillingDatasource, req.toAPIRequest())
+	body, statusCode, err := doEndpoint(ctx, c, endpointValidateAnthropicBillingDatasource, req.toAPIRequest(c.datasourceType(billingDatasourceTypeAnthropic)))
 	if err != nil {
 		return err
 	}
@@ -672,22 +1148,22 @@ func (c *Client) ValidateAnthropicBillingDatasource(ctx context.Context, req Ant
 		return nil
 	}
 
-	return unexpectedStatusError(statusCode, body)
+	return unexpectedStatusError(endpointValidateAnthropicBillingDatasource.Name, statusCode, body)
 }
 
 // CreateAnthropicBillingDatasource creates an Anthropic billing datasource and returns its API representation.
 func (c *Client) CreateAnthropicBillingDatasource(ctx context.Context, req AnthropicBillingDatasourceRequest) (*AnthropicBillingDatasource, error) {
-	body, statusCode, err := doEndpoint(ctx, c, endpointCreateAnthropicBillingDatasource, req.toAPIRequest())
+	body, statusCode, err := doCreateBillingDatasourceEndpoint(ctx, c, endpointCreateAnthropicBillingDatasource, req.toAPIRequest(c.datasourceType(billingDatasourceTypeAnthropic)))
 	if err != nil {
 		return nil, err
 	}
 
 	if statusCode < http.StatusOK || statusCode >= http.StatusMultipleChoices {
-		return nil, unexpectedStatusError(statusCode, body)
+		return nil, unexpectedStatusError(endpointCreateAnthropicBillingDatasource.Name, statusCode, body)
 	}
 
 	var out externalBillingDatasourceAPIResponse
-	if err := json.Unmarshal(body, &out); err != nil {
+	if err := c.decodeResponse(body, &out); err != nil {
 		return nil, fmt.Errorf("decode response body: %w", err)
 	}
 
@@ -696,6 +1172,10 @@ func (c *Client) CreateAnthropicBillingDatasource(ctx context.Context, req Anthr
 		return nil, errors.New("create response did not include datasource id")
 	}
 
+	if err := c.checkDatasourceType(billingDatasourceTypeAnthropic, normalized.Type); err != nil {
+		return nil, err
+	}
+
 	return normalized, nil
 }
 
@@ -711,12 +1191,12 @@ func (c *Client) GetAnthropicBillingDatasource(ctx context.Context, datasourceID
 		return nil, ErrNotFound
 	}
 
-	if statusCode != http.StatusOK {
-		return nil, unexpectedStatusError(statusCode, body)
+	if !isReadSuccess(statusCode) {
+		return nil, unexpectedStatusError(endpointGetAnthropicBillingDatasourceByID.Name, statusCode, body)
 	}
 
 	var out externalBillingDatasourceAPIResponse
-	if err := json.Unmarshal(body, &out); err != nil {
+	if err := c.decodeResponse(body, &out); err != nil {
 		return nil, fmt.Errorf("decode response body: %w", err)
 	}
 
@@ -725,12 +1205,16 @@ func (c *Client) GetAnthropicBillingDatasource(ctx context.Context, datasourceID
 		normalized.ID = datasourceID
 	}
 
+	if err := c.checkDatasourceType(billingDatasourceTypeAnthropic, normalized.Type); err != nil {
+		return nil, err
+	}
+
 	return normalized, nil
 }
 
 // ValidateElasticCloudBillingDatasource validates an Elastic Cloud billing datasource before creation.
 func (c *Client) ValidateElasticCloudBillingDatasource(ctx context.Context, req ElasticCloudBillingDatasourceRequest) error {
-	body, statusCode, err := doEndpoint(ctx, c, endpointValidateElasticCloudBillingDatasource, req.toAPIRequest())
+	body, statusCode, err := doEndpoint(ctx, c, endpointValidateElasticCloudBillingDatasource, req.toAPIRequest(c.datasourceType(billingDatasourceTypeElasticCloud)))
 	if err != nil {
 		return err
 	}
@@ -739,22 +1223,22 @@ func (c *Client) ValidateElasticCloudBillingDatasource(ctx context.Context, req
 		return nil
 	}
 
-	return unexpectedStatusError(statusCode, body)
+	return unexpectedStatusError(endpointValidateElasticCloudBillingDatasource.Name, statusCode, body)
 }
 
 // CreateElasticCloudBillingDatasource creates an Elastic Cloud billing datasource and returns its API representation.
 func (c *Client) CreateElasticCloudBillingDatasource(ctx context.Context, req ElasticCloudBillingDatasourceRequest) (*ElasticCloudBillingDatasource, error) {
-	body, statusCode, err := doEndpoint(ctx, c, endpointCreateElasticCloudBillingDatasource, req.toAPIRequest())
+	body, statusCode, err := doCreateBillingDatasourceEndpoint(ctx, c, endpointCreateElasticCloudBillingDatasource, req.toAPIRequest(c.datasourceType(billingDatasourceTypeElasticCloud)))
 	if err != nil {
 		return nil, err
 	}
 
 	if statusCode < http.StatusOK || statusCode >= http.StatusMultipleChoices {
-		return nil, unexpectedStatusError(statusCode, body)
+		return nil, unexpectedStatusError(endpointCreateElasticCloudBillingDatasource.Name, statusCode, body)
 	}
 
 	var out elasticCloudBillingDatasourceAPIResponse
-	if err := json.Unmarshal(body, &out); err != nil {
+	if err := c.decodeResponse(body, &out); err != nil {
 		return nil, fmt.Errorf("decode response body: %w", err)
 	}
 
@@ -763,6 +1247,10 @@ func (c *Client) CreateElasticCloudBillingDatasource(ctx context.Context, req El
 		return nil, errors.New("create response did not include datasource id")
 	}
 
+	if err := c.checkDatasourceType(billingDatasourceTypeElasticCloud, normalized.Type); err != nil {
+		return nil, err
+	}
+
 	return normalized, nil
 }
 
@@ -778,12 +1266,12 @@ func (c *Client) GetElasticCloudBillingDatasource(ctx context.Context, datasourc
 		return nil, ErrNotFound
 	}
 
-	if statusCode != http.StatusOK {
-		return nil, unexpectedStatusError(statusCode, body)
+	if !isReadSuccess(statusCode) {
+		return nil, unexpectedStatusError(endpointGetElasticCloudBillingDatasourceByID.Name, statusCode, body)
 	}
 
 	var out elasticCloudBillingDatasourceAPIResponse
-	if err := json.Unmarshal(body, &out); err != nil {
+	if err := c.decodeResponse(body, &out); err != nil {
 		return nil, fmt.Errorf("decode response body: %w", err)
 	}
 
@@ -792,12 +1280,16 @@ func (c *Client) GetElasticCloudBillingDatasource(ctx context.Context, datasourc
 		normalized.ID = datasourceID
 	}
 
+	if err := c.checkDatasourceType(billingDatasourceTypeElasticCloud, normalized.Type); err != nil {
+		return nil, err
+	}
+
 	return normalized, nil
 }
 
 // ValidateAzureBillingDatasource validates an Azure billing datasource before creation.
 func (c *Client) ValidateAzureBillingDatasource(ctx context.Context, req AzureBillingDatasourceRequest) error {
-	body, statusCode, err := doEndpoint(ctx, c, endpointValidateAzureBillingDatasource, req.toAPIRequest())
+	body, statusCode, err := doEndpoint(ctx, c, endpointValidateAzureBillingDatasource, req.toAPIRequest(c.datasourceType(billingDatasourceTypeAzure)))
 	if err != nil {
 		return err
 	}
@@ -806,22 +1298,22 @@ func (c *Client) ValidateAzureBillingDatasource(ctx context.Context, req AzureBi
 		return nil
 	}
 
-	return unexpectedStatusError(statusCode, body)
+	return unexpectedStatusError(endpointValidateAzureBillingDatasource.Name, statusCode, body)
 }
 
 // CreateAzureBillingDatasource creates an Azure billing datasource and returns its API representation.
 func (c *Client) CreateAzureBillingDatasource(ctx context.Context, req AzureBillingDatasourceRequest) (*AzureBillingDatasource, error) {
-	body, statusCode, err := doEndpoint(ctx, c, endpointCreateAzureBillingDatasource, req.toAPIRequest())
+	body, statusCode, err := doCreateBillingDatasourceEndpoint(ctx, c, endpointCreateAzureBillingDatasource, req.toAPIRequest(c.datasourceType(billingDatasourceTypeAzure)))
 	if err != nil {
 		return nil, err
 	}
 
 	if statusCode < http.StatusOK || statusCode >= http.StatusMultipleChoices {
-		return nil, unexpectedStatusError(statusCode, body)
+		return nil, unexpectedStatusError(endpointCreateAzureBillingDatasource.Name, statusCode, body)
 	}
 
 	var out azureBillingDatasourceAPIResponse
-	if err := json.Unmarshal(body, &out); err != nil {
+	if err := c.decodeResponse(body, &out); err != nil {
 		return nil, fmt.Errorf("decode response body: %w", err)
 	}
 
@@ -830,6 +1322,10 @@ func (c *Client) CreateAzureBillingDatasource(ctx context.Context, req AzureBill
 		return nil, errors.New("create response did not include datasource id")
 	}
 
+	if err := c.checkDatasourceType(billingDatasourceTypeAzure, normalized.Type); err != nil {
+		return nil, err
+	}
+
 	return normalized, nil
 }
 
@@ -845,12 +1341,12 @@ func (c *Client) GetAzureBillingDatasource(ctx context.Context, datasourceID str
 		return nil, ErrNotFound
 	}
 
-	if statusCode != http.StatusOK {
-		return nil, unexpectedStatusError(statusCode, body)
+	if !isReadSuccess(statusCode) {
+		return nil, unexpectedStatusError(endpointGetAzureBillingDatasourceByID.Name, statusCode, body)
 	}
 
 	var out azureBillingDatasourceAPIResponse
-	if err := json.Unmarshal(body, &out); err != nil {
+	if err := c.decodeResponse(body, &out); err != nil {
 		return nil, fmt.Errorf("decode response body: %w", err)
 	}
 
@@ -859,6 +1355,10 @@ func (c *Client) GetAzureBillingDatasource(ctx context.Context, datasourceID str
 		normalized.ID = datasourceID
 	}
 
+	if err := c.checkDatasourceType(billingDatasourceTypeAzure, normalized.Type); err != nil {
+		return nil, err
+	}
+
 	return normalized, nil
 }
 
@@ -870,11 +1370,11 @@ func (c *Client) CreateTeam(ctx context.Context, req TeamCreateRequest) (*Team,
 	}
 
 	if statusCode < http.StatusOK || statusCode >= http.StatusMultipleChoices {
-		return nil, unexpectedStatusError(statusCode, body)
+		return nil, unexpectedStatusError(endpointCreateTeam.Name, statusCode, body)
 	}
 
 	var out teamAPIResponse
-	if err := json.Unmarshal(body, &out); err != nil {
+	if err := c.decodeResponse(body, &out); err != nil {
 		return nil, fmt.Errorf("decode response body: %w", err)
 	}
 
@@ -898,12 +1398,12 @@ func (c *Client) GetTeam(ctx context.Context, teamID string) (*Team, error) {
 		return nil, ErrNotFound
 	}
 
-	if statusCode != http.StatusOK {
-		return nil, unexpectedStatusError(statusCode, body)
+	if !isReadSuccess(statusCode) {
+		return nil, unexpectedStatusError(endpointGetTeamByID.Name, statusCode, body)
 	}
 
 	var out teamAPIResponse
-	if err := json.Unmarshal(body, &out); err != nil {
+	if err := c.decodeResponse(body, &out); err != nil {
 		return nil, fmt.Errorf("decode response body: %w", err)
 	}
 
@@ -928,11 +1428,11 @@ func (c *Client) UpdateTeam(ctx context.Context, teamID string, req TeamUpdateRe
 	}
 
 	if statusCode < http.StatusOK || statusCode >= http.StatusMultipleChoices {
-		return nil, unexpectedStatusError(statusCode, body)
+		return nil, unexpectedStatusError(endpointPatchTeamByID.Name, statusCode, body)
 	}
 
 	var out teamAPIResponse
-	if err := json.Unmarshal(body, &out); err != nil {
+	if err := c.decodeResponse(body, &out); err != nil {
 		return nil, fmt.Errorf("decode response body: %w", err)
 	}
 
@@ -960,7 +1460,108 @@ func (c *Client) DeleteTeam(ctx context.Context, teamID string) error {
 		return nil
 	}
 
-	return unexpectedStatusError(statusCode, body)
+	return unexpectedStatusError(endpointDeleteTeamByID.Name, statusCode, body)
+}
+
+// CreateAllocationRule creates a cost allocation rule and returns its API representation.
+func (c *Client) CreateAllocationRule(ctx context.Context, req AllocationRuleRequest) (*AllocationRule, error) {
+	body, statusCode, err := doEndpoint(ctx, c, endpointCreateAllocationRule, req.toAPIRequest())
+	if err != nil {
+		return nil, err
+	}
+
+	if statusCode < http.StatusOK || statusCode >= http.StatusMultipleChoices {
+		return nil, unexpectedStatusError(endpointCreateAllocationRule.Name, statusCode, body)
+	}
+
+	var out allocationRuleAPIResponse
+	if err := c.decodeResponse(body, &out); err != nil {
+		return nil, fmt.Errorf("decode response body: %w", err)
+	}
+
+	normalized := out.toAllocationRule()
+	if normalized.ID == "" {
+		return nil, errors.New("create response did not include allocation rule id")
+	}
+
+	return normalized, nil
+}
+
+// GetAllocationRule gets a cost allocation rule by ID.
+func (c *Client) GetAllocationRule(ctx context.Context, ruleID string) (*AllocationRule, error) {
+	routeParams := allocationRuleByIDRouteParams{ID: ruleID}
+	body, statusCode, err := doEndpointWithRouteParams(ctx, c, endpointGetAllocationRuleByID, routeParams, noRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	if statusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	if !isReadSuccess(statusCode) {
+		return nil, unexpectedStatusError(endpointGetAllocationRuleByID.Name, statusCode, body)
+	}
+
+	var out allocationRuleAPIResponse
+	if err := c.decodeResponse(body, &out); err != nil {
+		return nil, fmt.Errorf("decode response body: %w", err)
+	}
+
+	normalized := out.toAllocationRule()
+	if normalized.ID == "" {
+		normalized.ID = ruleID
+	}
+
+	return normalized, nil
+}
+
+// UpdateAllocationRule updates a cost allocation rule and returns its API representation.
+func (c *Client) UpdateAllocationRule(ctx context.Context, ruleID string, req AllocationRuleRequest) (*AllocationRule, error) {
+	routeParams := allocationRuleByIDRouteParams{ID: ruleID}
+	body, statusCode, err := doEndpointWithRouteParams(ctx, c, endpointPatchAllocationRuleByID, routeParams, req.toAPIRequest())
+	if err != nil {
+		return nil, err
+	}
+
+	if statusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	if statusCode < http.StatusOK || statusCode >= http.StatusMultipleChoices {
+		return nil, unexpectedStatusError(endpointPatchAllocationRuleByID.Name, statusCode, body)
+	}
+
+	var out allocationRuleAPIResponse
+	if err := c.decodeResponse(body, &out); err != nil {
+		return nil, fmt.Errorf("decode response body: %w", err)
+	}
+
+	normalized := out.toAllocationRule()
+	if normalized.ID == "" {
+		normalized.ID = ruleID
+	}
+
+	return normalized, nil
+}
+
+// DeleteAllocationRule deletes a cost allocation rule by ID.
+func (c *Client) DeleteAllocationRule(ctx context.Context, ruleID string) error {
+	routeParams := allocationRuleByIDRouteParams{ID: ruleID}
+	body, statusCode, err := doEndpointWithRouteParams(ctx, c, endpointDeleteAllocationRuleByID, routeParams, noRequest{})
+	if err != nil {
+		return err
+	}
+
+	if statusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+
+	if statusCode >= http.StatusOK && statusCode < http.StatusMultipleChoices {
+		return nil
+	}
+
+	return unexpectedStatusError(endpointDeleteAllocationRuleByID.Name, statusCode, body)
 }
 
 // AddTeamMember adds a member to the team.
@@ -975,7 +1576,7 @@ func (c *Client) AddTeamMember(ctx context.Context, teamID string, req TeamMembe
 		return nil
 	}
 
-	return unexpectedStatusError(statusCode, body)
+	return unexpectedStatusError(endpointAddTeamMember.Name, statusCode, body)
 }
 
 // RemoveTeamMember removes a member from the team by user ID.
@@ -994,7 +1595,7 @@ func (c *Client) RemoveTeamMember(ctx context.Context, teamID, userID string) er
 		return nil
 	}
 
-	return unexpectedStatusError(statusCode, body)
+	return unexpectedStatusError(endpointRemoveTeamMember.Name, statusCode, body)
 }
 
 // DeleteBillingDatasource deletes a billing datasource by ID.
@@ -1013,7 +1614,70 @@ func (c *Client) DeleteBillingDatasource(ctx context.Context, datasourceID strin
 		return nil
 	}
 
-	return unexpectedStatusError(statusCode, body)
+	return unexpectedStatusError(endpointDeleteBillingDatasourceByID.Name, statusCode, body)
+}
+
+// UpdateBillingDatasourceOwner sets the owner of a billing datasource via PATCH. A nil owner clears it.
+func (c *Client) UpdateBillingDatasourceOwner(ctx context.Context, datasourceID string, owner *string) error {
+	routeParams := billingDatasourceByIDRouteParams{ID: datasourceID}
+	body, statusCode, err := doEndpointWithRouteParams(ctx, c, endpointPatchBillingDatasourceByID, routeParams, billingDatasourcePatchAPIRequest{Owner: owner})
+	if err != nil {
+		return err
+	}
+
+	if statusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+
+	if statusCode >= http.StatusOK && statusCode < http.StatusMultipleChoices {
+		return nil
+	}
+
+	return unexpectedStatusError(endpointPatchBillingDatasourceByID.Name, statusCode, body)
+}
+
+// UpdateBillingDatasourcePriority sets the ingestion priority of a billing datasource via
+// PATCH, without re-ingesting it. A nil priority clears it.
+func (c *Client) UpdateBillingDatasourcePriority(ctx context.Context, datasourceID string, priority *int64) error {
+	routeParams := billingDatasourceByIDRouteParams{ID: datasourceID}
+	body, statusCode, err := doEndpointWithRouteParams(ctx, c, endpointPatchBillingDatasourcePriorityByID, routeParams, billingDatasourcePriorityPatchAPIRequest{Priority: priority})
+	if err != nil {
+		return err
+	}
+
+	if statusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+
+	if statusCode >= http.StatusOK && statusCode < http.StatusMultipleChoices {
+		return nil
+	}
+
+	return unexpectedStatusError(endpointPatchBillingDatasourcePriorityByID.Name, statusCode, body)
+}
+
+// UpdateBillingDatasourceTags replaces the tags of a billing datasource via PATCH, without
+// re-ingesting it. Nil or empty tags clear them.
+func (c *Client) UpdateBillingDatasourceTags(ctx context.Context, datasourceID string, tags map[string]string) error {
+	if tags == nil {
+		tags = map[string]string{}
+	}
+
+	routeParams := billingDatasourceByIDRouteParams{ID: datasourceID}
+	body, statusCode, err := doEndpointWithRouteParams(ctx, c, endpointPatchBillingDatasourceTagsByID, routeParams, billingDatasourceTagsPatchAPIRequest{Tags: tags})
+	if err != nil {
+		return err
+	}
+
+	if statusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+
+	if statusCode >= http.StatusOK && statusCode < http.StatusMultipleChoices {
+		return nil
+	}
+
+	return unexpectedStatusError(endpointPatchBillingDatasourceTagsByID.Name, statusCode, body)
 }
 
 // ValidateMetricsDatasource validates a metrics datasource before create/update.
@@ -1025,11 +1689,11 @@ func (c *Client) ValidateMetricsDatasource(ctx context.Context, req MetricsDatas
 	}
 
 	if statusCode < http.StatusOK || statusCode >= http.StatusMultipleChoices {
-		return unexpectedStatusError(statusCode, body)
+		return unexpectedStatusError(endpointValidateMetricsDatasource.Name, statusCode, body)
 	}
 
 	var out metricsDatasourceValidateAPIResponse
-	if err := json.Unmarshal(body, &out); err != nil {
+	if err := c.decodeResponse(body, &out); err != nil {
 		return fmt.Errorf("decode validation response: %w", err)
 	}
 
@@ -1052,11 +1716,11 @@ func (c *Client) CreateMetricsDatasource(ctx context.Context, req MetricsDatasou
 	}
 
 	if statusCode < http.StatusOK || statusCode >= http.StatusMultipleChoices {
-		return nil, unexpectedStatusError(statusCode, body)
+		return nil, unexpectedStatusError(endpointCreateMetricsDatasource.Name, statusCode, body)
 	}
 
 	var out metricsDatasourceAPIResponse
-	if err := json.Unmarshal(body, &out); err != nil {
+	if err := c.decodeResponse(body, &out); err != nil {
 		return nil, fmt.Errorf("decode response body: %w", err)
 	}
 
@@ -1080,12 +1744,12 @@ func (c *Client) GetMetricsDatasource(ctx context.Context, datasourceID string)
 		return nil, ErrNotFound
 	}
 
-	if statusCode != http.StatusOK {
-		return nil, unexpectedStatusError(statusCode, body)
+	if !isReadSuccess(statusCode) {
+		return nil, unexpectedStatusError(endpointGetMetricsDatasourceByID.Name, statusCode, body)
 	}
 
 	var out metricsDatasourceAPIResponse
-	if err := json.Unmarshal(body, &out); err != nil {
+	if err := c.decodeResponse(body, &out); err != nil {
 		return nil, fmt.Errorf("decode response body: %w", err)
 	}
 
@@ -1114,7 +1778,7 @@ func (c *Client) UpdateMetricsDatasource(ctx context.Context, datasourceID strin
 		return nil
 	}
 
-	return unexpectedStatusError(statusCode, body)
+	return unexpectedStatusError(endpointPatchMetricsDatasourceByID.Name, statusCode, body)
 }
 
 // DeleteMetricsDatasource deletes a metrics datasource by ID.
@@ -1133,12 +1797,12 @@ func (c *Client) DeleteMetricsDatasource(ctx context.Context, datasourceID strin
 		return nil
 	}
 
-	return unexpectedStatusError(statusCode, body)
+	return unexpectedStatusError(endpointDeleteMetricsDatasourceByID.Name, statusCode, body)
 }
 
 func (c *Client) endpoint(path string) string {
 	base := strings.TrimRight(c.baseURL, "/")
-	return base + "/" + strings.TrimLeft(path, "/")
+	return base + "/" + strings.TrimLeft(c.resolveRoute(path), "/")
 }
 
 func doEndpoint[TReq any, TResp any](
@@ -1147,6 +1811,11 @@ func doEndpoint[TReq any, TResp any](
 	endpoint endpointContract[TReq, TResp],
 	request TReq,
 ) ([]byte, int, error) {
+	ctx = withEndpointName(ctx, endpoint.Name)
+	if endpoint.ReadOnly {
+		ctx = withReadOnlyRequest(ctx)
+	}
+
 	switch endpoint.RequestTransport {
 	case requestTransportNone:
 		return c.doJSON(ctx, endpoint.Method, endpoint.Path, nil)
@@ -1168,6 +1837,7 @@ func doEndpointWithRouteParams[TParams any, TReq any, TResp any](
 		return nil, 0, fmt.Errorf("unsupported route params transport for endpoint %s", endpoint.Method)
 	}
 
+	ctx = withEndpointName(ctx, endpoint.Name)
 	path := endpoint.Path(params)
 	switch endpoint.RequestBodyTransport {
 	case requestTransportNone:
@@ -1180,87 +1850,190 @@ func doEndpointWithRouteParams[TParams any, TReq any, TResp any](
 }
 
 func (c *Client) doJSON(ctx context.Context, method, path string, requestBody any) ([]byte, int, error) {
+	body, statusCode, _, err := c.doJSONWithHeader(ctx, method, path, requestBody)
+	return body, statusCode, err
+}
+
+func (c *Client) doJSONWithHeader(ctx context.Context, method, path string, requestBody any) ([]byte, int, http.Header, error) {
 	var payload []byte
 	if requestBody != nil {
 		var err error
 		payload, err = json.Marshal(requestBody)
 		if err != nil {
-			return nil, 0, fmt.Errorf("marshal request body: %w", err)
+			return nil, 0, nil, fmt.Errorf("marshal request body: %w", err)
 		}
 	}
 
+	refreshedToken := false
+	var lastStatusCode int
+	var lastBody []byte
 	for attempt := range maxRetryAttempts {
+		if c.isClosed() {
+			return nil, 0, nil, ErrClientClosed
+		}
+
+		token, err := c.bearerToken(ctx)
+		if err != nil {
+			return nil, 0, nil, err
+		}
+		ctx := maskToken(ctx, token)
+
 		var bodyReader io.Reader
 		if payload != nil {
 			bodyReader = bytes.NewReader(payload)
 		}
 
-		req, err := http.NewRequestWithContext(ctx, method, c.endpoint(path), bodyReader)
+		attemptCtx, span := c.startAttemptSpan(ctx, method, path, attempt)
+		req, err := http.NewRequestWithContext(attemptCtx, method, c.endpoint(path), bodyReader)
 		if err != nil {
-			return nil, 0, fmt.Errorf("create request: %w", err)
+			endAttemptSpan(span, 0, err)
+			return nil, 0, nil, fmt.Errorf("create request: %w", err)
 		}
 
+		for name, value := range requestHeadersFromContext(ctx) {
+			req.Header.Set(name, value)
+		}
 		req.Header.Set("Accept", "application/json")
-		req.Header.Set("Authorization", "Bearer "+c.token)
+		req.Header.Set("Authorization", "Bearer "+token)
+		if c.slug != "" {
+			req.Header.Set(headerCostorySlug, c.slug)
+		}
+		if c.workspaceID != "" {
+			req.Header.Set(headerCostoryWorkspace, c.workspaceID)
+		}
+		if c.runID != "" {
+			req.Header.Set(headerTerraformRunID, c.runID)
+		}
 		if payload != nil {
 			req.Header.Set("Content-Type", "application/json")
 		}
 
+		info := RequestInfo{Method: method, Path: path, Attempt: attempt + 1}
+		start := time.Now()
+
 		resp, err := c.httpClient.Do(req)
 		if err != nil {
-			return nil, 0, fmt.Errorf("execute request: %w", err)
+			info.Duration = time.Since(start)
+			info.Err = err
+			c.finishAttempt(span, info)
+			return nil, 0, nil, fmt.Errorf("execute request: %w", err)
 		}
 
 		body, readErr := io.ReadAll(io.LimitReader(resp.Body, maxResponseBodyBytes))
 		closeErr := resp.Body.Close()
+		info.Duration = time.Since(start)
+		info.StatusCode = resp.StatusCode
+		info.Err = errors.Join(readErr, closeErr)
+		c.finishAttempt(span, info)
 		if readErr != nil {
-			return nil, 0, fmt.Errorf("read response body: %w", readErr)
+			return nil, 0, nil, fmt.Errorf("read response body: %w", readErr)
 		}
 		if closeErr != nil {
-			return nil, 0, fmt.Errorf("close response body: %w", closeErr)
+			return nil, 0, nil, fmt.Errorf("close response body: %w", closeErr)
 		}
+		body, err = decodeContentEncoding(resp.Header, body)
+		if err != nil {
+			return nil, 0, nil, err
+		}
+
+		c.warnDeprecatedEndpoint(ctx, method, path, resp.Header)
+
+		// A token obtained from token_command may expire mid-run; refresh it once per request.
+		if resp.StatusCode == http.StatusUnauthorized && c.tokenCommand != nil && !refreshedToken && attempt < maxRetryAttempts-1 {
+			refreshedToken = true
+			if err := c.refreshToken(ctx, token); err != nil {
+				return nil, 0, nil, err
+			}
+			continue
+		}
+
+		if c.retryableStatus(resp.StatusCode) && c.mayRetry(req, resp.StatusCode) {
+			lastStatusCode, lastBody = resp.StatusCode, body
+			if attempt == maxRetryAttempts-1 {
+				break
+			}
+			recordRetry(ctx, Retry{Method: method, Path: path, StatusCode: resp.StatusCode})
 
-		if resp.StatusCode >= http.StatusInternalServerError && attempt < maxRetryAttempts-1 {
-			if err := waitForRetry(ctx, attempt); err != nil {
-				return nil, 0, err
+			delay := c.retryDelay(ctx, method, path, resp.StatusCode, resp.Header, attempt)
+			if err := waitForRetry(ctx, c.closed, delay); err != nil {
+				return nil, 0, nil, err
 			}
 			continue
 		}
 
-		return body, resp.StatusCode, nil
+		if resp.StatusCode == http.StatusMethodNotAllowed {
+			return nil, resp.StatusCode, resp.Header, &UnsupportedOperationError{Method: method, Path: path}
+		}
+
+		return body, resp.StatusCode, resp.Header, nil
+	}
+
+	return nil, 0, nil, newRetriesExhaustedError(maxRetryAttempts, lastStatusCode, lastBody)
+}
+
+func (c *Client) finishAttempt(span trace.Span, info RequestInfo) {
+	endAttemptSpan(span, info.StatusCode, info.Err)
+	c.metrics.recordAttempt(info)
+	c.runRequestHook(info)
+}
+
+func (c *Client) runRequestHook(info RequestInfo) {
+	if c.requestHook == nil {
+		return
 	}
 
-	return nil, 0, errors.New("request retries exhausted")
+	defer func() {
+		_ = recover()
+	}()
+
+	c.requestHook(info)
 }
 
-func (r GCPBillingDatasourceRequest) toAPIRequest() gcpBillingDatasourceAPIRequest {
+func (r GCPBillingDatasourceRequest) toAPIRequest(datasourceType string) gcpBillingDatasourceAPIRequest {
 	return gcpBillingDatasourceAPIRequest{
-		Type:              billingDatasourceTypeGCP,
+		Type:              datasourceType,
 		Name:              r.Name,
 		BQTablePath:       r.BQURI,
 		IsDetailedBilling: r.IsDetailedBilling,
 		StartDate:         r.StartDate,
 		EndDate:           r.EndDate,
+		Owner:             r.Owner,
+		Priority:          r.Priority,
+		BillingAccountID:  r.BillingAccountID,
+		ExportProjectID:   r.ExportProjectID,
+		Tags:              r.Tags,
 	}
 }
 
-func (r AWSBillingDatasourceRequest) toAPIRequest() awsBillingDatasourceAPIRequest {
-	return awsBillingDatasourceAPIRequest{
-		Type:                billingDatasourceTypeAWS,
+func (r AWSBillingDatasourceRequest) toAPIRequest(datasourceType string) awsBillingDatasourceAPIRequest {
+	req := awsBillingDatasourceAPIRequest{
+		Type:                datasourceType,
 		Name:                r.Name,
 		BucketName:          r.BucketName,
 		RoleARN:             r.RoleARN,
-		Prefix:              r.Prefix,
 		EKSSplitDataEnabled: r.EKSSplitDataEnabled,
 		StartDate:           r.StartDate,
 		EndDate:             r.EndDate,
 		EKSSplit:            r.EKSSplit,
+		ReportName:          r.ReportName,
+		Owner:               r.Owner,
+		Priority:            r.Priority,
+		Tags:                r.Tags,
+	}
+
+	prefix := r.Prefix
+	if r.PrefixIsGlob {
+		req.PrefixPattern = &prefix
+	} else {
+		req.Prefix = &prefix
 	}
+
+	return req
 }
 
-func (r CursorBillingDatasourceRequest) toAPIRequest() externalBillingDatasourceAPIRequest {
+func (r CursorBillingDatasourceRequest) toAPIRequest(datasourceType string) externalBillingDatasourceAPIRequest {
 	return externalBillingDatasourceAPIRequest{
-		Type:        billingDatasourceTypeCursor,
+		Type:        datasourceType,
 		Name:        r.Name,
 		AdminAPIKey: r.AdminAPIKey,
 		StartDate:   r.StartDate,
@@ -1268,9 +2041,9 @@ func (r CursorBillingDatasourceRequest) toAPIRequest() externalBillingDatasource
 	}
 }
 
-func (r AnthropicBillingDatasourceRequest) toAPIRequest() externalBillingDatasourceAPIRequest {
+func (r AnthropicBillingDatasourceRequest) toAPIRequest(datasourceType string) externalBillingDatasourceAPIRequest {
 	return externalBillingDatasourceAPIRequest{
-		Type:        billingDatasourceTypeAnthropic,
+		Type:        datasourceType,
 		Name:        r.Name,
 		AdminAPIKey: r.AdminAPIKey,
 		StartDate:   r.StartDate,
@@ -1278,9 +2051,9 @@ func (r AnthropicBillingDatasourceRequest) toAPIRequest() externalBillingDatasou
 	}
 }
 
-func (r ElasticCloudBillingDatasourceRequest) toAPIRequest() elasticCloudBillingDatasourceAPIRequest {
+func (r ElasticCloudBillingDatasourceRequest) toAPIRequest(datasourceType string) elasticCloudBillingDatasourceAPIRequest {
 	return elasticCloudBillingDatasourceAPIRequest{
-		Type:           billingDatasourceTypeElasticCloud,
+		Type:           datasourceType,
 		Name:           r.Name,
 		APIKey:         r.APIKey,
 		OrganizationID: r.OrganizationID,
@@ -1288,9 +2061,9 @@ func (r ElasticCloudBillingDatasourceRequest) toAPIRequest() elasticCloudBilling
 	}
 }
 
-func (r AzureBillingDatasourceRequest) toAPIRequest() azureBillingDatasourceAPIRequest {
+func (r AzureBillingDatasourceRequest) toAPIRequest(datasourceType string) azureBillingDatasourceAPIRequest {
 	return azureBillingDatasourceAPIRequest{
-		Type:               billingDatasourceTypeAzure,
+		Type:               datasourceType,
 		Name:               r.Name,
 		SASURL:             r.SASURL,
 		StorageAccountName: r.StorageAccountName,
@@ -1308,72 +2081,107 @@ func (r TeamUpdateRequest) toAPIRequest() teamUpdateAPIRequest {
 	return teamUpdateAPIRequest(r)
 }
 
+func (r AllocationRuleRequest) toAPIRequest() allocationRuleAPIRequest {
+	return allocationRuleAPIRequest(r)
+}
+
 func (r TeamMemberRequest) toAPIRequest() teamMemberAPIRequest {
 	return teamMemberAPIRequest(r)
 }
 
 func (r gcpBillingDatasourceAPIResponse) toGCPBillingDatasource() *GCPBillingDatasource {
 	return &GCPBillingDatasource{
-		ID:                r.ID,
-		Type:              r.Type,
-		Status:            r.Status,
-		Name:              r.Name,
-		BQURI:             r.BQURI,
-		IsDetailedBilling: r.IsDetailedBilling,
-		StartDate:         r.StartDate,
-		EndDate:           r.EndDate,
+		ID:                    r.ID,
+		Type:                  r.Type,
+		Status:                r.Status,
+		ManagedBy:             r.ManagedBy,
+		Name:                  r.Name,
+		BQURI:                 r.BQURI,
+		IsDetailedBilling:     r.IsDetailedBilling,
+		StartDate:             r.StartDate,
+		EndDate:               r.EndDate,
+		BackfillProgress:      r.BackfillProgress,
+		Owner:                 r.Owner,
+		Priority:              r.Priority,
+		BillingAccountID:      r.BillingAccountID,
+		ExportProjectID:       r.ExportProjectID,
+		LastIngestedAt:        r.LastIngestedAt.timePointer(),
+		DetectedColumns:       r.DetectedColumns,
+		Tags:                  r.Tags,
+		Warnings:              r.Warnings,
+		EarliestAvailableDate: r.EarliestAvailableDate,
 	}
 }
 
 func (r awsBillingDatasourceAPIResponse) toAWSBillingDatasource() *AWSBillingDatasource {
 	return &AWSBillingDatasource{
-		ID:                  r.ID,
-		Type:                r.Type,
-		Status:              r.Status,
-		Name:                r.Name,
-		BucketName:          r.BucketName,
-		RoleARN:             r.RoleARN,
-		Prefix:              r.Prefix,
-		EKSSplitDataEnabled: r.EKSSplitDataEnabled,
-		StartDate:           r.StartDate,
-		EndDate:             r.EndDate,
-		EKSSplit:            r.EKSSplit,
+		ID:                    r.ID,
+		Type:                  r.Type,
+		Status:                r.Status,
+		ManagedBy:             r.ManagedBy,
+		Name:                  r.Name,
+		BucketName:            r.BucketName,
+		RoleARN:               r.RoleARN,
+		Prefix:                r.Prefix,
+		EKSSplitDataEnabled:   r.EKSSplitDataEnabled,
+		StartDate:             r.StartDate,
+		EndDate:               r.EndDate,
+		EKSSplit:              r.EKSSplit,
+		ReportName:            r.ReportName,
+		PrefixPattern:         r.PrefixPattern,
+		BackfillProgress:      r.BackfillProgress,
+		Owner:                 r.Owner,
+		Priority:              r.Priority,
+		LastIngestedAt:        r.LastIngestedAt.timePointer(),
+		DetectedColumns:       r.DetectedColumns,
+		Tags:                  r.Tags,
+		Warnings:              r.Warnings,
+		EarliestAvailableDate: r.EarliestAvailableDate,
 	}
 }
 
 func (r externalBillingDatasourceAPIResponse) toCursorBillingDatasource() *CursorBillingDatasource {
 	return &CursorBillingDatasource{
-		ID:         r.ID,
-		Type:       r.Type,
-		Status:     r.Status,
-		Name:       r.Name,
-		BQTableURI: r.BQTableURI,
-		StartDate:  r.StartDate,
-		EndDate:    r.EndDate,
+		ID:                    r.ID,
+		Type:                  r.Type,
+		Status:                r.Status,
+		ManagedBy:             r.ManagedBy,
+		Name:                  r.Name,
+		BQTableURI:            r.BQTableURI,
+		StartDate:             r.StartDate,
+		EndDate:               r.EndDate,
+		Warnings:              r.Warnings,
+		EarliestAvailableDate: r.EarliestAvailableDate,
 	}
 }
 
 func (r externalBillingDatasourceAPIResponse) toAnthropicBillingDatasource() *AnthropicBillingDatasource {
 	return &AnthropicBillingDatasource{
-		ID:         r.ID,
-		Type:       r.Type,
-		Status:     r.Status,
-		Name:       r.Name,
-		BQTableURI: r.BQTableURI,
-		StartDate:  r.StartDate,
-		EndDate:    r.EndDate,
+		ID:                    r.ID,
+		Type:                  r.Type,
+		Status:                r.Status,
+		ManagedBy:             r.ManagedBy,
+		Name:                  r.Name,
+		BQTableURI:            r.BQTableURI,
+		StartDate:             r.StartDate,
+		EndDate:               r.EndDate,
+		Warnings:              r.Warnings,
+		EarliestAvailableDate: r.EarliestAvailableDate,
 	}
 }
 
 func (r elasticCloudBillingDatasourceAPIResponse) toElasticCloudBillingDatasource() *ElasticCloudBillingDatasource {
 	return &ElasticCloudBillingDatasource{
-		ID:             r.ID,
-		Type:           r.Type,
-		Status:         r.Status,
-		Name:           r.Name,
-		OrganizationID: r.OrganizationID,
-		BQTableURI:     r.BQTableURI,
-		StartDate:      r.StartDate,
+		ID:                    r.ID,
+		Type:                  r.Type,
+		Status:                r.Status,
+		ManagedBy:             r.ManagedBy,
+		Name:                  r.Name,
+		OrganizationID:        r.OrganizationID,
+		BQTableURI:            r.BQTableURI,
+		StartDate:             r.StartDate,
+		Warnings:              r.Warnings,
+		EarliestAvailableDate: r.EarliestAvailableDate,
 	}
 }
 
@@ -1382,11 +2190,13 @@ func (r azureBillingDatasourceAPIResponse) toAzureBillingDatasource() *AzureBill
 		ID:                 r.ID,
 		Type:               r.Type,
 		Status:             r.Status,
+		ManagedBy:          r.ManagedBy,
 		Name:               r.Name,
 		StorageAccountName: r.StorageAccountName,
 		ContainerName:      r.ContainerName,
 		ActualsPath:        r.ActualsPath,
 		AmortizedPath:      r.AmortizedPath,
+		Warnings:           r.Warnings,
 	}
 }
 
@@ -1396,8 +2206,17 @@ func (r teamAPIResponse) toTeam() *Team {
 		Name:        r.Name,
 		Description: r.Description,
 		Visibility:  r.Visibility,
-		CreatedAt:   r.CreatedAt,
-		UpdatedAt:   r.UpdatedAt,
+		CreatedAt:   r.CreatedAt.rfc3339(),
+		UpdatedAt:   r.UpdatedAt.rfc3339(),
+	}
+}
+
+func (r allocationRuleAPIResponse) toAllocationRule() *AllocationRule {
+	return &AllocationRule{
+		ID:          r.ID,
+		Name:        r.Name,
+		Match:       r.Match,
+		TargetLabel: r.TargetLabel,
 	}
 }
 
@@ -1467,26 +2286,59 @@ func firstStringSlice(values ...[]string) []string {
 	return nil
 }
 
-func waitForRetry(ctx context.Context, attempt int) error {
-	backoff := time.Duration(1<<attempt) * 500 * time.Millisecond
-	timer := time.NewTimer(backoff)
+// isRetryableStatus reports whether a response status is transient and the request can be
+// replayed: server errors, rate limiting, and timeouts or early-data rejections that
+// upstream proxies and gateways return before the request reaches Costory.
+func isRetryableStatus(statusCode int) bool {
+	switch statusCode {
+	case http.StatusRequestTimeout, http.StatusTooEarly, http.StatusTooManyRequests:
+		return true
+	default:
+		return statusCode >= http.StatusInternalServerError
+	}
+}
+
+// waitForRetry sleeps for delay unless the context or client ends first. A delay that would
+// outlast the context deadline fails immediately instead of sleeping until the timeout.
+func waitForRetry(ctx context.Context, closed <-chan struct{}, delay time.Duration) error {
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+		return fmt.Errorf("retry canceled: the Costory API asked to retry in %s, which exceeds the remaining operation time: %w", delay, context.DeadlineExceeded)
+	}
+
+	timer := time.NewTimer(delay)
 	defer timer.Stop()
 
 	select {
 	case <-ctx.Done():
 		return fmt.Errorf("retry canceled: %w", ctx.Err())
+	case <-closed:
+		return fmt.Errorf("retry canceled: %w", ErrClientClosed)
 	case <-timer.C:
 		return nil
 	}
 }
 
-func unexpectedStatusError(statusCode int, body []byte) error {
+// isReadSuccess reports whether a read by ID returned the resource. Any 2xx does, so cached
+// or partial responses rewritten by proxies (for example 203 or 206) still decode, except
+// 202, which means the request is still being processed, and 204, which has no body.
+func isReadSuccess(statusCode int) bool {
+	switch statusCode {
+	case http.StatusAccepted, http.StatusNoContent:
+		return false
+	default:
+		return statusCode >= http.StatusOK && statusCode < http.StatusMultipleChoices
+	}
+}
+
+// unexpectedStatusError describes a response the caller does not handle, prefixed with the
+// endpoint name so diagnostics say which operation failed.
+func unexpectedStatusError(endpoint string, statusCode int, body []byte) error {
 	var apiErr apiErrorResponse
 	if err := json.Unmarshal(body, &apiErr); err == nil {
 		apiErr.Error = strings.TrimSpace(apiErr.Error)
 		apiErr.Reason = strings.TrimSpace(apiErr.Reason)
 		if apiErr.Error != "" || apiErr.Reason != "" {
-			return fmt.Errorf("unexpected status code %d: error=%s reason=%s", statusCode, apiErr.Error, apiErr.Reason)
+			return fmt.Errorf("%s: unexpected status code %d: error=%s reason=%s", endpoint, statusCode, apiErr.Error, apiErr.Reason)
 		}
 	}
 
@@ -1495,5 +2347,5 @@ func unexpectedStatusError(statusCode int, body []byte) error {
 		message = http.StatusText(statusCode)
 	}
 
-	return fmt.Errorf("unexpected status code %d: %s", statusCode, message)
+	return fmt.Errorf("%s: unexpected status code %d: %s", endpoint, statusCode, message)
 }
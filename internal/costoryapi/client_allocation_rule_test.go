@@ -0,0 +1,133 @@
+package costoryapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientAllocationRuleCRUD(t *testing.T) {
+	t.Parallel()
+
+	var createCalls int
+	var getCalls int
+	var patchCalls int
+	var deleteCalls int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == routeAllocationRulesBase:
+			createCalls++
+			assertAllocationRuleRequest(t, r, "team = 'platform'")
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":"rule-1","name":"Platform","match":"team = 'platform'","targetLabel":"cost_center"}`))
+		case r.Method == http.MethodGet && r.URL.Path == routeAllocationRuleByID("rule-1"):
+			getCalls++
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"id":"rule-1","name":"Platform","match":"team = 'platform'","targetLabel":"cost_center"}`))
+		case r.Method == http.MethodPatch && r.URL.Path == routeAllocationRuleByID("rule-1"):
+			patchCalls++
+			assertAllocationRuleRequest(t, r, "team IN ('platform', 'infra')")
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"id":"rule-1","name":"Platform","match":"team IN ('platform', 'infra')","targetLabel":"cost_center"}`))
+		case r.Method == http.MethodDelete && r.URL.Path == routeAllocationRuleByID("rule-1"):
+			deleteCalls++
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", server.Client())
+
+	created, err := client.CreateAllocationRule(context.Background(), AllocationRuleRequest{
+		Name:        "Platform",
+		Match:       "team = 'platform'",
+		TargetLabel: "cost_center",
+	})
+	if err != nil {
+		t.Fatalf("unexpected create error: %v", err)
+	}
+
+	if created.ID != "rule-1" {
+		t.Fatalf("unexpected allocation rule id: got %q, want %q", created.ID, "rule-1")
+	}
+
+	current, err := client.GetAllocationRule(context.Background(), "rule-1")
+	if err != nil {
+		t.Fatalf("unexpected get error: %v", err)
+	}
+
+	if current.TargetLabel != "cost_center" {
+		t.Fatalf("unexpected target label: got %q", current.TargetLabel)
+	}
+
+	updated, err := client.UpdateAllocationRule(context.Background(), "rule-1", AllocationRuleRequest{
+		Name:        "Platform",
+		Match:       "team IN ('platform', 'infra')",
+		TargetLabel: "cost_center",
+	})
+	if err != nil {
+		t.Fatalf("unexpected update error: %v", err)
+	}
+
+	if updated.Match != "team IN ('platform', 'infra')" {
+		t.Fatalf("unexpected updated match: got %q", updated.Match)
+	}
+
+	if err := client.DeleteAllocationRule(context.Background(), "rule-1"); err != nil {
+		t.Fatalf("unexpected delete error: %v", err)
+	}
+
+	if createCalls != 1 || getCalls != 1 || patchCalls != 1 || deleteCalls != 1 {
+		t.Fatalf(
+			"unexpected call counters create/get/patch/delete: %d/%d/%d/%d",
+			createCalls, getCalls, patchCalls, deleteCalls,
+		)
+	}
+}
+
+func TestClientGetAllocationRuleNotFound(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", server.Client())
+
+	_, err := client.GetAllocationRule(context.Background(), "missing")
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got: %v", err)
+	}
+}
+
+func assertAllocationRuleRequest(t *testing.T, r *http.Request, wantMatch string) {
+	t.Helper()
+
+	if got, want := r.Header.Get("Authorization"), "Bearer test-token"; got != want {
+		t.Fatalf("unexpected auth header: got %q, want %q", got, want)
+	}
+
+	var payload allocationRuleAPIRequest
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		t.Fatalf("unable to decode request body: %v", err)
+	}
+
+	if payload.Name != "Platform" {
+		t.Fatalf("unexpected allocation rule name: got %q", payload.Name)
+	}
+
+	if payload.Match != wantMatch {
+		t.Fatalf("unexpected allocation rule match: got %q, want %q", payload.Match, wantMatch)
+	}
+
+	if payload.TargetLabel != "cost_center" {
+		t.Fatalf("unexpected allocation rule target label: got %q", payload.TargetLabel)
+	}
+}
@@ -6,37 +6,16 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestClientAWSBillingDatasourceCRUD(t *testing.T) {
 	t.Parallel()
 
-	var createCalls int
-	var getCalls int
-	var deleteCalls int
-
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		switch {
-		case r.Method == http.MethodPost && r.URL.Path == routeBillingDatasourceBase:
-			createCalls++
-			assertAWSCreateRequest(t, r)
-			w.WriteHeader(http.StatusCreated)
-			_, _ = w.Write([]byte(`{"id":"aws-ds-1","type":"AWS","status":"PENDING","name":"AWS Billing","bucketName":"billing-bucket","roleArn":"arn:aws:iam::123456789012:role/costory","prefix":"cur/","eksSplitDataEnabled":false}`))
-		case r.Method == http.MethodGet && r.URL.Path == routeBillingDatasourceByID("aws-ds-1"):
-			getCalls++
-			w.WriteHeader(http.StatusOK)
-			_, _ = w.Write([]byte(`{"id":"aws-ds-1","type":"AWS","status":"ACTIVE","name":"AWS Billing","bucketName":"billing-bucket","roleArn":"arn:aws:iam::123456789012:role/costory","prefix":"cur/","eksSplitDataEnabled":false,"startDate":"2025-01-01","eksSplit":true}`))
-		case r.Method == http.MethodDelete && r.URL.Path == routeBillingDatasourceByID("aws-ds-1"):
-			deleteCalls++
-			w.WriteHeader(http.StatusNoContent)
-		default:
-			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
-		}
-	}))
-	defer server.Close()
-
-	client := NewClient(server.URL, "test-token", server.Client())
+	client := NewClient("https://costory.test", "test-token", newReplayDoer(t, "aws_billing_datasource_crud.json"), WithStrictDecoding(true))
 
 	createRequest := AWSBillingDatasourceRequest{
 		Name:                "AWS Billing",
@@ -89,12 +68,26 @@ func TestClientAWSBillingDatasourceCRUD(t *testing.T) {
 	if err := client.DeleteBillingDatasource(context.Background(), "aws-ds-1"); err != nil {
 		t.Fatalf("unexpected delete error: %v", err)
 	}
+}
+
+func TestClientGetAWSBillingDatasourceEmptyPrefix(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"id":"aws-ds-1","type":"AWS","prefix":""}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", server.Client(), WithStrictDecoding(true))
+
+	current, err := client.GetAWSBillingDatasource(context.Background(), "aws-ds-1")
+	if err != nil {
+		t.Fatalf("unexpected get error: %v", err)
+	}
 
-	if createCalls != 1 || getCalls != 1 || deleteCalls != 1 {
-		t.Fatalf(
-			"unexpected call counters create/get/delete: %d/%d/%d",
-			createCalls, getCalls, deleteCalls,
-		)
+	if current.Prefix == nil || *current.Prefix != "" {
+		t.Fatalf("expected returned empty prefix to be preserved, got %#v", current.Prefix)
 	}
 }
 
@@ -106,7 +99,7 @@ func TestClientGetAWSBillingDatasourceNotFound(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token", server.Client())
+	client := NewClient(server.URL, "test-token", server.Client(), WithStrictDecoding(true))
 
 	_, err := client.GetAWSBillingDatasource(context.Background(), "missing-id")
 	if !errors.Is(err, ErrNotFound) {
@@ -114,6 +107,47 @@ func TestClientGetAWSBillingDatasourceNotFound(t *testing.T) {
 	}
 }
 
+func TestClientGetAWSBillingDatasourceReadStatuses(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		status  int
+		wantErr bool
+	}{
+		"ok":                {status: http.StatusOK},
+		"non-authoritative": {status: http.StatusNonAuthoritativeInfo},
+		"partial content":   {status: http.StatusPartialContent},
+		"no content":        {status: http.StatusNoContent, wantErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tc.status)
+				_, _ = w.Write([]byte(`{"id":"aws-ds-1","type":"AWS","name":"AWS Billing","bucketName":"billing-bucket","roleArn":"arn:aws:iam::123456789012:role/costory","prefix":"cur/"}`))
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL, "test-token", server.Client())
+			datasource, err := client.GetAWSBillingDatasource(context.Background(), "aws-ds-1")
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("expected read error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if datasource.ID != "aws-ds-1" || datasource.Name != "AWS Billing" {
+				t.Fatalf("unexpected datasource: %+v", datasource)
+			}
+		})
+	}
+}
+
 func TestClientCreateAWSBillingDatasourceValidationError(t *testing.T) {
 	t.Parallel()
 
@@ -126,7 +160,7 @@ func TestClientCreateAWSBillingDatasourceValidationError(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token", server.Client())
+	client := NewClient(server.URL, "test-token", server.Client(), WithStrictDecoding(true))
 
 	_, err := client.CreateAWSBillingDatasource(context.Background(), AWSBillingDatasourceRequest{
 		Name:       "AWS Billing",
@@ -138,28 +172,298 @@ func TestClientCreateAWSBillingDatasourceValidationError(t *testing.T) {
 		t.Fatal("expected create error, got nil")
 	}
 
-	if got, want := err.Error(), "unexpected status code 403: error=aws_access_denied reason=Cannot access bucket with provided role"; got != want {
+	if got, want := err.Error(), "create AWS billing datasource: unexpected status code 403: error=aws_access_denied reason=Cannot access bucket with provided role"; got != want {
 		t.Fatalf("unexpected create error message: got %q, want %q", got, want)
 	}
 }
 
-func assertAWSCreateRequest(t *testing.T, r *http.Request) {
-	t.Helper()
+func TestClientAWSBillingDatasourceReportName(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		reportName *string
+	}{
+		"set":   {reportName: stringPointer("costory-cur")},
+		"unset": {reportName: nil},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var payload map[string]any
+				if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+					t.Fatalf("unable to decode request body: %v", err)
+				}
+
+				reportName, ok := payload["reportName"]
+				if tc.reportName == nil && ok {
+					t.Fatalf("expected reportName to be omitted, got %v", reportName)
+				}
+				if tc.reportName != nil && reportName != *tc.reportName {
+					t.Fatalf("unexpected reportName: got %v, want %q", reportName, *tc.reportName)
+				}
+
+				w.WriteHeader(http.StatusCreated)
+				if tc.reportName == nil {
+					_, _ = w.Write([]byte(`{"id":"aws-ds-1","type":"AWS"}`))
+					return
+				}
+				_, _ = w.Write([]byte(`{"id":"aws-ds-1","type":"AWS","reportName":"costory-cur"}`))
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL, "test-token", server.Client(), WithStrictDecoding(true))
+
+			created, err := client.CreateAWSBillingDatasource(context.Background(), AWSBillingDatasourceRequest{
+				Name:       "AWS Billing",
+				BucketName: "billing-bucket",
+				RoleARN:    "arn:aws:iam::123456789012:role/costory",
+				Prefix:     "cur/",
+				ReportName: tc.reportName,
+			})
+			if err != nil {
+				t.Fatalf("unexpected create error: %v", err)
+			}
+
+			if tc.reportName == nil && created.ReportName != nil {
+				t.Fatalf("expected no report name, got %q", *created.ReportName)
+			}
+			if tc.reportName != nil && (created.ReportName == nil || *created.ReportName != *tc.reportName) {
+				t.Fatalf("unexpected report name: got %#v", created.ReportName)
+			}
+		})
+	}
+}
+
+func TestClientValidateAWSBillingDatasourceErrorWithoutIssues(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":"invalid","reason":"bucket not found"}`))
+	}))
+	defer server.Close()
 
-	if got, want := r.Header.Get("Authorization"), "Bearer test-token"; got != want {
-		t.Fatalf("unexpected auth header: got %q, want %q", got, want)
+	client := NewClient(server.URL, "test-token", server.Client(), WithStrictDecoding(true))
+
+	err := client.ValidateAWSBillingDatasource(context.Background(), AWSBillingDatasourceRequest{Name: "AWS Billing"})
+	if err == nil {
+		t.Fatal("expected error, got nil")
 	}
 
-	var payload awsBillingDatasourceAPIRequest
-	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
-		t.Fatalf("unable to decode request body: %v", err)
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		t.Fatalf("expected a plain status error, got: %#v", validationErr)
 	}
+	if !strings.Contains(err.Error(), "bucket not found") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestClientValidateAWSBillingDatasourceNotFoundWithReason(t *testing.T) {
+	t.Parallel()
 
-	if payload.Type != billingDatasourceTypeAWS {
-		t.Fatalf("unexpected datasource type: got %q, want %q", payload.Type, billingDatasourceTypeAWS)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error":"No manifests found under s3://billing-bucket/cur/","reason":"NO_MANIFESTS_FOUND"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", server.Client())
+
+	result, err := client.ValidateAWSBillingDatasourceResult(context.Background(), AWSBillingDatasourceRequest{Name: "AWS Billing", Prefix: "cur/"})
+	if err != nil {
+		t.Fatalf("expected an invalid result rather than an error, got: %v", err)
 	}
+	if result.Valid || len(result.Issues) == 0 || result.Issues[0].Code != ValidationReasonNoManifestsFound {
+		t.Fatalf("unexpected result: %#v", result)
+	}
+}
+
+func TestClientValidateAWSBillingDatasourceNoManifestsReason(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		body        string
+		wantMessage string
+		wantCode    string
+	}{
+		"no manifests": {
+			body:        `{"error":"No manifests found under s3://billing-bucket/cur/","reason":"NO_MANIFESTS_FOUND"}`,
+			wantMessage: "No manifests found under s3://billing-bucket/cur/",
+			wantCode:    ValidationReasonNoManifestsFound,
+		},
+		"prefix empty without message": {
+			body:        `{"reason":"PREFIX_EMPTY"}`,
+			wantMessage: "no billing manifests were found under the prefix",
+			wantCode:    ValidationReasonPrefixEmpty,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				_, _ = w.Write([]byte(tc.body))
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL, "test-token", server.Client(), WithStrictDecoding(true))
+
+			err := client.ValidateAWSBillingDatasource(context.Background(), AWSBillingDatasourceRequest{Name: "AWS Billing", Prefix: "cur/"})
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("expected *ValidationError, got: %v", err)
+			}
+			want := []ValidationIssue{{Field: "prefix", Message: tc.wantMessage, Code: tc.wantCode}}
+			if !reflect.DeepEqual(validationErr.Issues, want) {
+				t.Fatalf("unexpected issues: got %#v, want %#v", validationErr.Issues, want)
+			}
+		})
+	}
+}
+
+func TestAWSBillingDatasourceRequestPrefixMarshaling(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		request     AWSBillingDatasourceRequest
+		wantField   string
+		absentField string
+	}{
+		"literal": {
+			request:     AWSBillingDatasourceRequest{Prefix: "cur/"},
+			wantField:   "prefix",
+			absentField: "prefixPattern",
+		},
+		"glob": {
+			request:     AWSBillingDatasourceRequest{Prefix: "cur/*/year=2025", PrefixIsGlob: true},
+			wantField:   "prefixPattern",
+			absentField: "prefix",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			body, err := json.Marshal(tc.request.toAPIRequest(billingDatasourceTypeAWS))
+			if err != nil {
+				t.Fatalf("unexpected marshal error: %v", err)
+			}
+
+			var payload map[string]any
+			if err := json.Unmarshal(body, &payload); err != nil {
+				t.Fatalf("unable to decode payload: %v", err)
+			}
+
+			if got := payload[tc.wantField]; got != tc.request.Prefix {
+				t.Fatalf("unexpected %s: got %v, want %q", tc.wantField, got, tc.request.Prefix)
+			}
+			if value, ok := payload[tc.absentField]; ok {
+				t.Fatalf("expected %s to be omitted, got %v", tc.absentField, value)
+			}
+		})
+	}
+}
+
+func TestClientGetAWSBillingDatasourcePrefixPattern(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"id":"aws-ds-1","type":"AWS","prefixPattern":"cur/*/year=2025"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", server.Client(), WithStrictDecoding(true))
+
+	current, err := client.GetAWSBillingDatasource(context.Background(), "aws-ds-1")
+	if err != nil {
+		t.Fatalf("unexpected get error: %v", err)
+	}
+
+	if current.Prefix != nil {
+		t.Fatalf("expected prefix to be nil, got %q", *current.Prefix)
+	}
+	if current.PrefixPattern == nil || *current.PrefixPattern != "cur/*/year=2025" {
+		t.Fatalf("unexpected prefix pattern: got %#v", current.PrefixPattern)
+	}
+}
+
+func TestClientGetAWSBillingDatasourceBackfillProgress(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"id":"aws-ds-1","type":"AWS","status":"PENDING","backfillProgress":42.5}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", server.Client(), WithStrictDecoding(true))
+
+	current, err := client.GetAWSBillingDatasource(context.Background(), "aws-ds-1")
+	if err != nil {
+		t.Fatalf("unexpected get error: %v", err)
+	}
+
+	if current.BackfillProgress == nil || *current.BackfillProgress != 42.5 {
+		t.Fatalf("unexpected backfill progress: got %#v", current.BackfillProgress)
+	}
+}
+
+func TestClientGetAWSBillingDatasourceLastIngestedAt(t *testing.T) {
+	t.Parallel()
+
+	want := time.Date(2025, 3, 4, 5, 6, 7, 0, time.UTC)
+
+	tests := map[string]struct {
+		body    string
+		want    *time.Time
+		wantErr bool
+	}{
+		"present":   {body: `{"id":"aws-ds-1","type":"AWS","lastIngestedAt":"2025-03-04T05:06:07Z"}`, want: &want},
+		"offset":    {body: `{"id":"aws-ds-1","type":"AWS","lastIngestedAt":"2025-03-04T06:06:07+01:00"}`, want: &want},
+		"epoch":     {body: `{"id":"aws-ds-1","type":"AWS","lastIngestedAt":1741064767}`, want: &want},
+		"absent":    {body: `{"id":"aws-ds-1","type":"AWS"}`},
+		"null":      {body: `{"id":"aws-ds-1","type":"AWS","lastIngestedAt":null}`},
+		"malformed": {body: `{"id":"aws-ds-1","type":"AWS","lastIngestedAt":"yesterday"}`, wantErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte(tc.body))
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL, "test-token", server.Client(), WithStrictDecoding(true))
+
+			current, err := client.GetAWSBillingDatasource(context.Background(), "aws-ds-1")
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("expected decode error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected get error: %v", err)
+			}
 
-	if payload.Name != "AWS Billing" || payload.BucketName != "billing-bucket" || payload.RoleARN != "arn:aws:iam::123456789012:role/costory" || payload.Prefix != "cur/" {
-		t.Fatalf("unexpected create payload: %#v", payload)
+			switch {
+			case tc.want == nil && current.LastIngestedAt != nil:
+				t.Fatalf("expected no last ingested time, got %s", current.LastIngestedAt)
+			case tc.want != nil && (current.LastIngestedAt == nil || !current.LastIngestedAt.Equal(*tc.want)):
+				t.Fatalf("unexpected last ingested time: got %v, want %s", current.LastIngestedAt, tc.want)
+			}
+		})
 	}
 }
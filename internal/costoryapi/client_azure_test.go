@@ -40,7 +40,7 @@ func TestClientAzureBillingDatasourceCRUD(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token", server.Client())
+	client := NewClient(server.URL, "test-token", server.Client(), WithStrictDecoding(true))
 
 	createRequest := AzureBillingDatasourceRequest{
 		Name:               "Azure Billing",
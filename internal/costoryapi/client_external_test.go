@@ -40,7 +40,7 @@ func TestClientCursorBillingDatasourceCRUD(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token", server.Client())
+	client := NewClient(server.URL, "test-token", server.Client(), WithStrictDecoding(true))
 
 	createRequest := CursorBillingDatasourceRequest{
 		Name:        "Cursor Billing",
@@ -123,7 +123,7 @@ func TestClientAnthropicBillingDatasourceCRUD(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token", server.Client())
+	client := NewClient(server.URL, "test-token", server.Client(), WithStrictDecoding(true))
 
 	createRequest := AnthropicBillingDatasourceRequest{
 		Name:        "Anthropic Billing",
@@ -201,7 +201,7 @@ func TestClientElasticCloudBillingDatasourceCRUD(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token", server.Client())
+	client := NewClient(server.URL, "test-token", server.Client(), WithStrictDecoding(true))
 
 	createRequest := ElasticCloudBillingDatasourceRequest{
 		Name:           "Elastic Cloud Billing",
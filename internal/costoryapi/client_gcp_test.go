@@ -4,8 +4,11 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -41,7 +44,7 @@ func TestClientGCPBillingDatasourceCRUD(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token", server.Client())
+	client := NewClient(server.URL, "test-token", server.Client(), WithStrictDecoding(true))
 
 	createRequest := GCPBillingDatasourceRequest{
 		Name:              "GCP Billing",
@@ -100,7 +103,7 @@ func TestClientGetGCPBillingDatasourceNotFound(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token", server.Client())
+	client := NewClient(server.URL, "test-token", server.Client(), WithStrictDecoding(true))
 
 	_, err := client.GetGCPBillingDatasource(context.Background(), "missing-id")
 	if !errors.Is(err, ErrNotFound) {
@@ -108,6 +111,277 @@ func TestClientGetGCPBillingDatasourceNotFound(t *testing.T) {
 	}
 }
 
+func TestClientGetGCPBillingDatasourceReadStatuses(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		status  int
+		wantErr bool
+	}{
+		"ok":                {status: http.StatusOK},
+		"non-authoritative": {status: http.StatusNonAuthoritativeInfo},
+		"partial content":   {status: http.StatusPartialContent},
+		"no content":        {status: http.StatusNoContent, wantErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tc.status)
+				_, _ = w.Write([]byte(`{"id":"gcp-ds-1","type":"GCP","name":"GCP Billing","bqUri":"project.dataset.table"}`))
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL, "test-token", server.Client())
+			datasource, err := client.GetGCPBillingDatasource(context.Background(), "gcp-ds-1")
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("expected read error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if datasource.ID != "gcp-ds-1" || datasource.Name != "GCP Billing" {
+				t.Fatalf("unexpected datasource: %+v", datasource)
+			}
+		})
+	}
+}
+
+func TestClientValidateGCPBillingDatasourceMethodNotAllowed(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != routeBillingDatasourceValidate {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", server.Client(), WithStrictDecoding(true))
+
+	err := client.ValidateGCPBillingDatasource(context.Background(), GCPBillingDatasourceRequest{
+		Name:  "GCP Billing",
+		BQURI: "project.dataset.table",
+	})
+
+	var unsupported *UnsupportedOperationError
+	if !errors.As(err, &unsupported) {
+		t.Fatalf("expected UnsupportedOperationError, got: %v", err)
+	}
+
+	if unsupported.Method != http.MethodPost || unsupported.Path != routeBillingDatasourceValidate {
+		t.Fatalf("unexpected unsupported operation: %#v", unsupported)
+	}
+}
+
+func TestClientValidateGCPBillingDatasourceMultipleIssues(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"issues":[{"field":"bqTablePath","message":"table not found"},{"message":"service account lacks bigquery.tables.getData"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", server.Client(), WithStrictDecoding(true))
+
+	err := client.ValidateGCPBillingDatasource(context.Background(), GCPBillingDatasourceRequest{
+		Name:  "GCP Billing",
+		BQURI: "project.dataset.table",
+	})
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected ValidationError, got: %v", err)
+	}
+
+	want := []ValidationIssue{
+		{Field: "bqTablePath", Message: "table not found"},
+		{Message: "service account lacks bigquery.tables.getData"},
+	}
+	if !reflect.DeepEqual(validationErr.Issues, want) {
+		t.Fatalf("unexpected issues: got %#v, want %#v", validationErr.Issues, want)
+	}
+	if validationErr.StatusCode != http.StatusBadRequest {
+		t.Fatalf("unexpected status code: got %d", validationErr.StatusCode)
+	}
+}
+
+func TestClientValidateGCPBillingDatasourceDetailedExportReason(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		body        string
+		wantMessage string
+	}{
+		"with message": {
+			body:        `{"error":"Detailed billing requires detailed export","reason":"DETAILED_BILLING_REQUIRES_DETAILED_EXPORT"}`,
+			wantMessage: "Detailed billing requires detailed export",
+		},
+		"without message": {
+			body:        `{"reason":"DETAILED_BILLING_REQUIRES_DETAILED_EXPORT"}`,
+			wantMessage: "detailed billing requires a detailed usage cost export table; point bq_uri at the detailed export or set is_detailed_billing to false",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				_, _ = w.Write([]byte(tc.body))
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL, "test-token", server.Client(), WithStrictDecoding(true))
+
+			err := client.ValidateGCPBillingDatasource(context.Background(), GCPBillingDatasourceRequest{
+				Name:              "GCP Billing",
+				BQURI:             "project.dataset.gcp_billing_export_v1",
+				IsDetailedBilling: boolPointer(true),
+			})
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("expected ValidationError, got: %v", err)
+			}
+
+			want := []ValidationIssue{
+				{Field: "bqTablePath", Message: tc.wantMessage, Code: ValidationReasonDetailedBillingRequiresDetailedExport},
+				{Field: "isDetailedBilling", Message: tc.wantMessage, Code: ValidationReasonDetailedBillingRequiresDetailedExport},
+			}
+			if !reflect.DeepEqual(validationErr.Issues, want) {
+				t.Fatalf("unexpected issues: got %#v, want %#v", validationErr.Issues, want)
+			}
+		})
+	}
+}
+
+func TestClientValidateGCPBillingDatasourceResult(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		status          int
+		body            string
+		wantResult      *ValidationResult
+		wantErr         bool
+		wantUnavailable bool
+	}{
+		"valid": {
+			status:     http.StatusOK,
+			body:       `{}`,
+			wantResult: &ValidationResult{Valid: true, StatusCode: http.StatusOK, Issues: []ValidationIssue{}},
+		},
+		"issues with codes": {
+			status: http.StatusUnprocessableEntity,
+			body:   `{"issues":[{"field":"bqTablePath","message":"table not found","code":"TABLE_NOT_FOUND"}]}`,
+			wantResult: &ValidationResult{
+				StatusCode: http.StatusUnprocessableEntity,
+				Issues:     []ValidationIssue{{Field: "bqTablePath", Message: "table not found", Code: "TABLE_NOT_FOUND"}},
+			},
+		},
+		"rejection without issues": {
+			status:  http.StatusForbidden,
+			body:    `{"error":"forbidden"}`,
+			wantErr: true,
+		},
+		"not found with issues": {
+			status: http.StatusNotFound,
+			body:   `{"issues":[{"field":"bqTablePath","message":"table not found"}]}`,
+			wantResult: &ValidationResult{
+				StatusCode: http.StatusNotFound,
+				Issues:     []ValidationIssue{{Field: "bqTablePath", Message: "table not found"}},
+			},
+		},
+		"not found without issues": {
+			status:          http.StatusNotFound,
+			body:            `{"error":"not found"}`,
+			wantErr:         true,
+			wantUnavailable: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tc.status)
+				_, _ = w.Write([]byte(tc.body))
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL, "test-token", server.Client())
+			result, err := client.ValidateGCPBillingDatasourceResult(context.Background(), GCPBillingDatasourceRequest{
+				Name:  "GCP Billing",
+				BQURI: "project.dataset.table",
+			})
+
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got result %#v", result)
+				}
+				if got := errors.Is(err, ErrValidationUnavailable); got != tc.wantUnavailable {
+					t.Fatalf("unexpected ErrValidationUnavailable match: got %t, want %t: %v", got, tc.wantUnavailable, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(result, tc.wantResult) {
+				t.Fatalf("unexpected result: got %#v, want %#v", result, tc.wantResult)
+			}
+
+			var validationErr *ValidationError
+			if got := errors.As(result.Err(), &validationErr); got == tc.wantResult.Valid {
+				t.Fatalf("unexpected Err() for valid=%t: %v", tc.wantResult.Valid, result.Err())
+			}
+		})
+	}
+}
+
+func TestClientGetGCPBillingDatasourceDetectedColumns(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		body string
+		want []string
+	}{
+		"present": {body: `{"id":"gcp-ds-1","type":"GCP","detectedColumns":["cost","currency","usage_start_time"]}`, want: []string{"cost", "currency", "usage_start_time"}},
+		"empty":   {body: `{"id":"gcp-ds-1","type":"GCP","detectedColumns":[]}`, want: []string{}},
+		"absent":  {body: `{"id":"gcp-ds-1","type":"GCP"}`},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte(tc.body))
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL, "test-token", server.Client(), WithStrictDecoding(true))
+			datasource, err := client.GetGCPBillingDatasource(context.Background(), "gcp-ds-1")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(datasource.DetectedColumns, tc.want) {
+				t.Fatalf("unexpected detected columns: got %#v, want %#v", datasource.DetectedColumns, tc.want)
+			}
+		})
+	}
+}
+
 func assertGCPCreateRequest(t *testing.T, r *http.Request) {
 	t.Helper()
 
@@ -136,3 +410,432 @@ func boolPointer(value bool) *bool {
 func stringPointer(value string) *string {
 	return &value
 }
+
+func int64Pointer(value int64) *int64 {
+	return &value
+}
+
+func TestClientGetGCPBillingDatasourceBackfillProgress(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		body string
+		want *float64
+	}{
+		"present": {body: `{"id":"gcp-ds-1","type":"GCP","status":"PENDING","backfillProgress":42}`, want: float64Pointer(42)},
+		"absent":  {body: `{"id":"gcp-ds-1","type":"GCP","status":"PENDING"}`},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte(tc.body))
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL, "test-token", server.Client(), WithStrictDecoding(true))
+
+			current, err := client.GetGCPBillingDatasource(context.Background(), "gcp-ds-1")
+			if err != nil {
+				t.Fatalf("unexpected get error: %v", err)
+			}
+
+			if !reflect.DeepEqual(current.BackfillProgress, tc.want) {
+				t.Fatalf("unexpected backfill progress: got %#v, want %#v", current.BackfillProgress, tc.want)
+			}
+		})
+	}
+}
+
+func float64Pointer(value float64) *float64 {
+	return &value
+}
+
+func TestClientCreateGCPBillingDatasourceWorkspaceHeader(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		options       []Option
+		wantWorkspace string
+	}{
+		"configured":     {options: []Option{WithWorkspaceID("ws-1")}, wantWorkspace: "ws-1"},
+		"not configured": {},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				values, present := r.Header[headerCostoryWorkspace]
+				if tc.wantWorkspace == "" && present {
+					t.Fatalf("expected no workspace header, got %q", values)
+				}
+				if got := r.Header.Get(headerCostoryWorkspace); got != tc.wantWorkspace {
+					t.Fatalf("unexpected workspace header: got %q, want %q", got, tc.wantWorkspace)
+				}
+
+				w.WriteHeader(http.StatusCreated)
+				_, _ = w.Write([]byte(`{"id":"gcp-ds-1","type":"GCP"}`))
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL, "test-token", server.Client(), append(tc.options, WithStrictDecoding(true))...)
+
+			if _, err := client.CreateGCPBillingDatasource(context.Background(), GCPBillingDatasourceRequest{Name: "GCP Billing"}); err != nil {
+				t.Fatalf("unexpected create error: %v", err)
+			}
+		})
+	}
+}
+
+func TestClientCreateGCPBillingDatasourceOwner(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		owner *string
+	}{
+		"with owner":    {owner: stringPointer("finops")},
+		"without owner": {owner: nil},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == routeCapabilities {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+
+				var payload map[string]any
+				if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+					t.Fatalf("unable to decode request body: %v", err)
+				}
+
+				owner, ok := payload["owner"]
+				if tc.owner == nil && ok {
+					t.Fatalf("expected owner to be omitted, got %v", owner)
+				}
+				if tc.owner != nil && owner != *tc.owner {
+					t.Fatalf("unexpected owner: got %v, want %q", owner, *tc.owner)
+				}
+
+				w.WriteHeader(http.StatusCreated)
+				if tc.owner == nil {
+					_, _ = w.Write([]byte(`{"id":"gcp-ds-1","type":"GCP"}`))
+					return
+				}
+				_, _ = w.Write([]byte(`{"id":"gcp-ds-1","type":"GCP","owner":"finops"}`))
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL, "test-token", server.Client(), WithStrictDecoding(true))
+
+			created, err := client.CreateGCPBillingDatasource(context.Background(), GCPBillingDatasourceRequest{Name: "GCP Billing", Owner: tc.owner})
+			if err != nil {
+				t.Fatalf("unexpected create error: %v", err)
+			}
+
+			if !reflect.DeepEqual(created.Owner, tc.owner) {
+				t.Fatalf("unexpected owner: got %#v, want %#v", created.Owner, tc.owner)
+			}
+		})
+	}
+}
+
+func TestClientUpdateBillingDatasourceOwner(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != routeBillingDatasourceByID("gcp-ds-1") {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+
+		var payload billingDatasourcePatchAPIRequest
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("unable to decode request body: %v", err)
+		}
+		if payload.Owner == nil || *payload.Owner != "platform" {
+			t.Fatalf("unexpected owner payload: %#v", payload.Owner)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", server.Client(), WithStrictDecoding(true))
+
+	if err := client.UpdateBillingDatasourceOwner(context.Background(), "gcp-ds-1", stringPointer("platform")); err != nil {
+		t.Fatalf("unexpected update error: %v", err)
+	}
+}
+
+func TestClientCreateGCPBillingDatasourceExportLocation(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("unable to decode request body: %v", err)
+		}
+
+		if got := payload["billingAccountId"]; got != "01A2B3-C4D5E6-F7A8B9" {
+			t.Fatalf("unexpected billingAccountId: got %v", got)
+		}
+		if _, ok := payload["exportProjectId"]; ok {
+			t.Fatalf("expected exportProjectId to be omitted, got %v", payload["exportProjectId"])
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":"gcp-ds-1","type":"GCP","billingAccountId":"01A2B3-C4D5E6-F7A8B9","exportProjectId":"billing-export"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", server.Client(), WithStrictDecoding(true))
+
+	created, err := client.CreateGCPBillingDatasource(context.Background(), GCPBillingDatasourceRequest{
+		Name:             "GCP Billing",
+		BQURI:            "project.dataset.table",
+		BillingAccountID: stringPointer("01A2B3-C4D5E6-F7A8B9"),
+	})
+	if err != nil {
+		t.Fatalf("unexpected create error: %v", err)
+	}
+
+	if created.BillingAccountID == nil || *created.BillingAccountID != "01A2B3-C4D5E6-F7A8B9" {
+		t.Fatalf("unexpected billing account ID: %#v", created.BillingAccountID)
+	}
+	if created.ExportProjectID == nil || *created.ExportProjectID != "billing-export" {
+		t.Fatalf("unexpected export project ID: %#v", created.ExportProjectID)
+	}
+}
+
+func TestClientCreateGCPBillingDatasourceEarliestAvailableDate(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		response string
+		want     *string
+	}{
+		"reported": {
+			response: `{"id":"gcp-ds-1","type":"GCP","startDate":"2024-01-01","earliestAvailableDate":"2024-06-01"}`,
+			want:     stringPointer("2024-06-01"),
+		},
+		"omitted": {
+			response: `{"id":"gcp-ds-1","type":"GCP","startDate":"2024-01-01"}`,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == routeCapabilities {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+
+				w.WriteHeader(http.StatusCreated)
+				_, _ = w.Write([]byte(tc.response))
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL, "test-token", server.Client(), WithStrictDecoding(true))
+
+			created, err := client.CreateGCPBillingDatasource(context.Background(), GCPBillingDatasourceRequest{Name: "GCP Billing", StartDate: stringPointer("2024-01-01")})
+			if err != nil {
+				t.Fatalf("unexpected create error: %v", err)
+			}
+
+			if !reflect.DeepEqual(created.EarliestAvailableDate, tc.want) {
+				t.Fatalf("unexpected earliest available date: got %#v, want %#v", created.EarliestAvailableDate, tc.want)
+			}
+		})
+	}
+}
+
+func TestClientCreateGCPBillingDatasourcePriority(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		priority *int64
+		wantBody string
+	}{
+		"with priority":    {priority: int64Pointer(7), wantBody: `"priority":7`},
+		"without priority": {priority: nil},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == routeCapabilities {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+
+				body, err := io.ReadAll(r.Body)
+				if err != nil {
+					t.Fatalf("unable to read request body: %v", err)
+				}
+				if tc.wantBody == "" && strings.Contains(string(body), `"priority"`) {
+					t.Fatalf("expected priority to be omitted, got %s", body)
+				}
+				if tc.wantBody != "" && !strings.Contains(string(body), tc.wantBody) {
+					t.Fatalf("expected request body to contain %s, got %s", tc.wantBody, body)
+				}
+
+				w.WriteHeader(http.StatusCreated)
+				if tc.priority == nil {
+					_, _ = w.Write([]byte(`{"id":"gcp-ds-1","type":"GCP"}`))
+					return
+				}
+				_, _ = w.Write([]byte(`{"id":"gcp-ds-1","type":"GCP","priority":7}`))
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL, "test-token", server.Client(), WithStrictDecoding(true))
+
+			created, err := client.CreateGCPBillingDatasource(context.Background(), GCPBillingDatasourceRequest{Name: "GCP Billing", Priority: tc.priority})
+			if err != nil {
+				t.Fatalf("unexpected create error: %v", err)
+			}
+
+			if !reflect.DeepEqual(created.Priority, tc.priority) {
+				t.Fatalf("unexpected priority: got %#v, want %#v", created.Priority, tc.priority)
+			}
+		})
+	}
+}
+
+func TestClientUpdateBillingDatasourcePriority(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		priority *int64
+		wantBody string
+	}{
+		"set":   {priority: int64Pointer(3), wantBody: `{"priority":3}`},
+		"clear": {priority: nil, wantBody: `{"priority":null}`},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPatch || r.URL.Path != routeBillingDatasourceByID("gcp-ds-1") {
+					t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+				}
+
+				body, err := io.ReadAll(r.Body)
+				if err != nil {
+					t.Fatalf("unable to read request body: %v", err)
+				}
+				if got := strings.TrimSpace(string(body)); got != tc.wantBody {
+					t.Fatalf("unexpected request body: got %s, want %s", got, tc.wantBody)
+				}
+
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL, "test-token", server.Client())
+
+			if err := client.UpdateBillingDatasourcePriority(context.Background(), "gcp-ds-1", tc.priority); err != nil {
+				t.Fatalf("unexpected update error: %v", err)
+			}
+		})
+	}
+}
+
+func TestClientUpdateBillingDatasourceTags(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		tags     map[string]string
+		wantBody string
+	}{
+		"set":   {tags: map[string]string{"team": "finops"}, wantBody: `{"tags":{"team":"finops"}}`},
+		"clear": {tags: nil, wantBody: `{"tags":{}}`},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPatch || r.URL.Path != routeBillingDatasourceByID("gcp-ds-1") {
+					t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+				}
+
+				body, err := io.ReadAll(r.Body)
+				if err != nil {
+					t.Fatalf("unable to read request body: %v", err)
+				}
+				if got := strings.TrimSpace(string(body)); got != tc.wantBody {
+					t.Fatalf("unexpected request body: got %s, want %s", got, tc.wantBody)
+				}
+
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL, "test-token", server.Client())
+
+			if err := client.UpdateBillingDatasourceTags(context.Background(), "gcp-ds-1", tc.tags); err != nil {
+				t.Fatalf("unexpected update error: %v", err)
+			}
+		})
+	}
+}
+
+func TestClientGetGCPBillingDatasourceManagedBy(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		response string
+		want     *string
+	}{
+		"created in the app": {
+			response: `{"id":"gcp-ds-1","type":"GCP","managedBy":"ui"}`,
+			want:     stringPointer("ui"),
+		},
+		"created by terraform": {
+			response: `{"id":"gcp-ds-1","type":"GCP","managedBy":"terraform"}`,
+			want:     stringPointer("terraform"),
+		},
+		"omitted": {
+			response: `{"id":"gcp-ds-1","type":"GCP"}`,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte(tc.response))
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL, "test-token", server.Client(), WithStrictDecoding(true))
+
+			datasource, err := client.GetGCPBillingDatasource(context.Background(), "gcp-ds-1")
+			if err != nil {
+				t.Fatalf("unexpected get error: %v", err)
+			}
+
+			if !reflect.DeepEqual(datasource.ManagedBy, tc.want) {
+				t.Fatalf("unexpected managed by: got %#v, want %#v", datasource.ManagedBy, tc.want)
+			}
+		})
+	}
+}
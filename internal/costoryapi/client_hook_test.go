@@ -0,0 +1,92 @@
+package costoryapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientRequestHookFiresPerAttempt(t *testing.T) {
+	t.Parallel()
+
+	var serverCalls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		serverCalls++
+		if serverCalls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"service_account":"sa-test","sub_ids":[]}`))
+	}))
+	defer server.Close()
+
+	var infos []RequestInfo
+	client := NewClient(server.URL, "test-token", server.Client(), WithRequestHook(func(info RequestInfo) {
+		infos = append(infos, info)
+	}))
+
+	if _, err := client.GetServiceAccount(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(infos) != 2 {
+		t.Fatalf("unexpected hook calls: got %d, want 2", len(infos))
+	}
+
+	for i, wantStatus := range []int{http.StatusServiceUnavailable, http.StatusOK} {
+		info := infos[i]
+		if info.Method != http.MethodGet || info.Path != routeServiceAccount {
+			t.Fatalf("unexpected hook request %d: %s %s", i, info.Method, info.Path)
+		}
+		if info.Attempt != i+1 {
+			t.Fatalf("unexpected hook attempt %d: got %d", i, info.Attempt)
+		}
+		if info.StatusCode != wantStatus {
+			t.Fatalf("unexpected hook status %d: got %d, want %d", i, info.StatusCode, wantStatus)
+		}
+		if info.Err != nil {
+			t.Fatalf("unexpected hook error %d: %v", i, info.Err)
+		}
+	}
+}
+
+func TestClientRequestHookPanicIsRecovered(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"service_account":"sa-test","sub_ids":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", server.Client(), WithRequestHook(func(RequestInfo) {
+		panic("hook failure")
+	}))
+
+	if _, err := client.GetServiceAccount(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestClientRequestHookFiresOnTransportError(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	serverURL := server.URL
+	server.Close()
+
+	var infos []RequestInfo
+	client := NewClient(serverURL, "test-token", nil, WithRequestHook(func(info RequestInfo) {
+		infos = append(infos, info)
+	}))
+
+	if _, err := client.GetServiceAccount(context.Background()); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	if len(infos) != 1 || infos[0].Err == nil || infos[0].StatusCode != 0 {
+		t.Fatalf("unexpected hook calls: %#v", infos)
+	}
+}
@@ -2,9 +2,11 @@ package costoryapi
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
+	"sync/atomic"
 	"testing"
 )
 
@@ -46,12 +48,56 @@ func TestClientGetServiceAccount(t *testing.T) {
 	}
 }
 
+func TestClientGetServiceAccountSendsSlugHeader(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.URL.Path, routeServiceAccount; got != want {
+			t.Fatalf("unexpected path: got %q, want %q", got, want)
+		}
+
+		if got, want := r.Header.Get("Authorization"), "Bearer test-token"; got != want {
+			t.Fatalf("unexpected auth header: got %q, want %q", got, want)
+		}
+
+		if got, want := r.Header.Get(headerCostorySlug), "acme"; got != want {
+			t.Fatalf("unexpected slug header: got %q, want %q", got, want)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"service_account":"sa-test","sub_ids":["sub-1"]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", server.Client(), WithSlug("acme"))
+
+	if _, err := client.GetServiceAccount(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestClientGetServiceAccountNotFound(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error":"not_found","reason":"unknown tenant"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", server.Client(), WithSlug("acme"))
+
+	if _, err := client.GetServiceAccount(context.Background()); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got: %v", err)
+	}
+}
+
 func TestClientGetServiceAccountCamelCase(t *testing.T) {
 	t.Parallel()
 
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
 		w.WriteHeader(http.StatusOK)
-		_, _ = w.Write([]byte(`{"serviceAccountEmail":"sa-camel","subIds":["sub-a","sub-b"]}`))
+		_, _ = w.Write([]byte(`{"serviceAccountEmail":"sa-camel","subIds":["sub-a","sub-b"],"tenantSlug":"acme"}`))
 	}))
 	defer server.Close()
 
@@ -65,6 +111,7 @@ func TestClientGetServiceAccountCamelCase(t *testing.T) {
 	want := &ServiceAccountResponse{
 		ServiceAccount: "sa-camel",
 		SubIDs:         []string{"sub-a", "sub-b"},
+		Tenant:         "acme",
 	}
 
 	if !reflect.DeepEqual(got, want) {
@@ -72,6 +119,54 @@ func TestClientGetServiceAccountCamelCase(t *testing.T) {
 	}
 }
 
+func TestClientGetServiceAccountFollowsPagination(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		switch cursor := r.URL.Query().Get("cursor"); cursor {
+		case "":
+			_, _ = w.Write([]byte(`{"service_account":"sa-test","sub_ids":["sub-1","sub-2"],"next_cursor":"page-2"}`))
+		case "page-2":
+			_, _ = w.Write([]byte(`{"service_account":"sa-test","sub_ids":["sub-2","sub-3"]}`))
+		default:
+			t.Errorf("unexpected cursor %q", cursor)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", server.Client(), WithStrictDecoding(true))
+
+	got, err := client.GetServiceAccount(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []string{"sub-1", "sub-2", "sub-3"}; !reflect.DeepEqual(got.SubIDs, want) {
+		t.Fatalf("unexpected sub IDs: got %v, want %v", got.SubIDs, want)
+	}
+}
+
+func TestClientGetServiceAccountStopsRepeatedCursor(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"service_account":"sa-test","sub_ids":["sub-1"],"nextCursor":"same"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", server.Client())
+
+	if _, err := client.GetServiceAccount(context.Background()); err == nil {
+		t.Fatal("expected error for a repeated cursor, got nil")
+	}
+	if got, want := calls.Load(), int32(2); got != want {
+		t.Fatalf("unexpected request count: got %d, want %d", got, want)
+	}
+}
+
 func TestClientGetServiceAccountUnexpectedStatus(t *testing.T) {
 	t.Parallel()
 
@@ -87,3 +182,43 @@ func TestClientGetServiceAccountUnexpectedStatus(t *testing.T) {
 		t.Fatal("expected error, got nil")
 	}
 }
+
+func TestClientRetriesTransientClientStatuses(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]int{
+		"request timeout":   http.StatusRequestTimeout,
+		"too early":         http.StatusTooEarly,
+		"too many requests": http.StatusTooManyRequests,
+	}
+
+	for name, status := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				if calls.Add(1) <= 2 {
+					w.WriteHeader(status)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte(`{"service_account":"sa-test","sub_ids":[]}`))
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL, "test-token", server.Client())
+
+			got, err := client.GetServiceAccount(context.Background())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.ServiceAccount != "sa-test" {
+				t.Fatalf("unexpected service account: got %q, want %q", got.ServiceAccount, "sa-test")
+			}
+			if got, want := calls.Load(), int32(3); got != want {
+				t.Fatalf("unexpected request count: got %d, want %d", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package costoryapi
+
+// Close stops background work owned by the client: pending retry waits return
+// ErrClientClosed, later requests fail with ErrClientClosed, and idle connections of the
+// underlying HTTP client are released when it supports CloseIdleConnections.
+//
+// The Terraform plugin framework does not notify providers on shutdown, so the provider
+// itself never calls Close; embedders that build a Client directly should call it when
+// done. Close is idempotent and safe for concurrent use.
+func (c *Client) Close() error {
+	c.closeOnce.Do(func() {
+		if c.closed != nil {
+			close(c.closed)
+		}
+
+		if closer, ok := c.httpClient.(interface{ CloseIdleConnections() }); ok {
+			closer.CloseIdleConnections()
+		}
+	})
+
+	return nil
+}
+
+func (c *Client) isClosed() bool {
+	select {
+	case <-c.closed:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,87 @@
+package costoryapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type idleClosingDoer struct {
+	idleCloses atomic.Int32
+	do         func(*http.Request) (*http.Response, error)
+}
+
+func (d *idleClosingDoer) Do(req *http.Request) (*http.Response, error) {
+	return d.do(req)
+}
+
+func (d *idleClosingDoer) CloseIdleConnections() {
+	d.idleCloses.Add(1)
+}
+
+func TestClientCloseIsIdempotent(t *testing.T) {
+	t.Parallel()
+
+	doer := &idleClosingDoer{do: func(*http.Request) (*http.Response, error) {
+		t.Fatal("unexpected request after Close")
+		return nil, nil
+	}}
+	client := NewClient("https://costory.test", "test-token", doer)
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := client.Close(); err != nil {
+				t.Errorf("unexpected close error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if err := client.Close(); err != nil {
+		t.Fatalf("unexpected error on repeated close: %v", err)
+	}
+
+	if got := doer.idleCloses.Load(); got != 1 {
+		t.Fatalf("unexpected idle connection closes: got %d, want 1", got)
+	}
+
+	if _, err := client.GetServiceAccount(context.Background()); !errors.Is(err, ErrClientClosed) {
+		t.Fatalf("expected ErrClientClosed, got: %v", err)
+	}
+}
+
+func TestClientCloseInterruptsRetryWait(t *testing.T) {
+	t.Parallel()
+
+	attempted := make(chan struct{}, maxRetryAttempts)
+	doer := &idleClosingDoer{do: func(req *http.Request) (*http.Response, error) {
+		attempted <- struct{}{}
+		return &http.Response{StatusCode: http.StatusServiceUnavailable, Body: http.NoBody, Request: req}, nil
+	}}
+	client := NewClient("https://costory.test", "test-token", doer)
+
+	errs := make(chan error, 1)
+	go func() {
+		_, err := client.GetServiceAccount(context.Background())
+		errs <- err
+	}()
+
+	<-attempted
+	_ = client.Close()
+
+	select {
+	case err := <-errs:
+		if !errors.Is(err, ErrClientClosed) {
+			t.Fatalf("expected ErrClientClosed, got: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("retry wait was not interrupted by Close")
+	}
+}
@@ -0,0 +1,83 @@
+package costoryapi
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+// TestClientConcurrentUse shares one client across goroutines the way the provider shares it
+// across resources. Run with -race to catch unsynchronized client state.
+func TestClientConcurrentUse(t *testing.T) {
+	t.Parallel()
+
+	script := filepath.Join(t.TempDir(), "token.sh")
+	if err := os.WriteFile(script, []byte("#!/bin/sh\necho command-token\n"), 0o700); err != nil {
+		t.Fatalf("write token command: %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == routeServiceAccount:
+			_, _ = w.Write([]byte(`{"service_account":"sa-test","sub_ids":["sub-1"]}`))
+		case r.Method == http.MethodGet && r.URL.Path == routeCapabilities:
+			w.WriteHeader(http.StatusNotFound)
+		case r.Method == http.MethodPost && r.URL.Path == routeTeamsBase:
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":"team-1","name":"Engineering","visibility":"PRIVATE"}`))
+		case r.Method == http.MethodGet && r.URL.Path == routeTeamByID("team-1"):
+			w.Header().Set("Deprecation", "true")
+			_, _ = w.Write([]byte(`{"id":"team-1","name":"Engineering","visibility":"PRIVATE"}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "", server.Client(), WithTokenCommand([]string{script}))
+
+	const workers = 8
+	ctx := context.Background()
+	errs := make(chan error, workers*4)
+
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			_ = client.EffectiveConfig()
+			if _, err := client.GetServiceAccount(ctx); err != nil {
+				errs <- err
+			}
+			if _, err := client.CreateTeam(ctx, TeamCreateRequest{Name: "Engineering"}); err != nil {
+				errs <- err
+			}
+			if _, err := client.GetTeam(ctx, "team-1"); err != nil {
+				errs <- err
+			}
+			if _, err := client.GetCapabilities(ctx); err != nil {
+				errs <- err
+			}
+
+			client.RecordWarning("concurrent warning", "detail")
+			_ = client.Warnings()
+
+			var metrics bytes.Buffer
+			if err := client.WritePrometheusMetrics(&metrics); err != nil {
+				errs <- err
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,33 @@
+package costoryapi
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// decodeContentEncoding returns body decompressed according to its Content-Encoding. The
+// standard transport only decompresses responses it negotiated itself, so a gzip body sent
+// through a transport with compression disabled, or by a proxy, arrives still encoded. Error
+// bodies go through here too, so their error and reason fields stay readable.
+func decodeContentEncoding(header http.Header, body []byte) ([]byte, error) {
+	if !strings.EqualFold(strings.TrimSpace(header.Get("Content-Encoding")), "gzip") || len(body) == 0 {
+		return body, nil
+	}
+
+	reader, err := gzip.NewReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("decompress gzip response body: %w", err)
+	}
+	defer reader.Close()
+
+	decoded, err := io.ReadAll(io.LimitReader(reader, maxResponseBodyBytes))
+	if err != nil {
+		return nil, fmt.Errorf("decompress gzip response body: %w", err)
+	}
+
+	return decoded, nil
+}
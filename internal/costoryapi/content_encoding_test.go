@@ -0,0 +1,54 @@
+package costoryapi
+
+import (
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestClientDecodesGzipErrorBody(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Encoding", "gzip")
+		w.WriteHeader(http.StatusForbidden)
+
+		writer := gzip.NewWriter(w)
+		_, _ = writer.Write([]byte(`{"error":"token cannot read this tenant","reason":"FORBIDDEN_TENANT"}`))
+		_ = writer.Close()
+	}))
+	defer server.Close()
+
+	// Disabling compression keeps the transport from decompressing the body itself, as a
+	// proxy forwarding an already-encoded response would.
+	httpClient := server.Client()
+	httpClient.Transport.(*http.Transport).DisableCompression = true
+	client := NewClient(server.URL, "test-token", httpClient)
+
+	_, err := client.GetServiceAccount(context.Background())
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	want := "unexpected status code 403: error=token cannot read this tenant reason=FORBIDDEN_TENANT"
+	if !strings.Contains(err.Error(), want) {
+		t.Fatalf("unexpected error: got %q, want it to contain %q", err, want)
+	}
+}
+
+func TestDecodeContentEncodingPassesPlainBodies(t *testing.T) {
+	t.Parallel()
+
+	body := []byte(`{"error":"boom"}`)
+	got, err := decodeContentEncoding(http.Header{}, body)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(got) != string(body) {
+		t.Fatalf("unexpected body: got %q, want %q", got, body)
+	}
+}
@@ -0,0 +1,103 @@
+package costoryapi
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// UnexpectedDatasourceTypeError reports a datasource returned with a different type than the
+// one requested, for example when an ID of another datasource type is read.
+type UnexpectedDatasourceTypeError struct {
+	Expected string
+	Got      string
+}
+
+func (e *UnexpectedDatasourceTypeError) Error() string {
+	return fmt.Sprintf("unexpected datasource type: got %q, want %q", e.Got, e.Expected)
+}
+
+// BillingDatasourceTypes returns the default billing datasource type identifiers, which are
+// the keys accepted by WithDatasourceTypeOverrides.
+func BillingDatasourceTypes() []string {
+	types := []string{
+		billingDatasourceTypeGCP,
+		billingDatasourceTypeAWS,
+		billingDatasourceTypeCursor,
+		billingDatasourceTypeAnthropic,
+		billingDatasourceTypeElasticCloud,
+		billingDatasourceTypeAzure,
+	}
+	slices.Sort(types)
+	return types
+}
+
+// CloudProvider returns the lowercase, snake_case name of the provider a billing datasource
+// request type ingests from, such as "aws" or "elastic_cloud". It is derived from the default
+// type identifier, so it stays stable when WithDatasourceTypeOverrides changes the wire type.
+func CloudProvider(kind BillingDatasourceCreateRequest) string {
+	return cloudProviderName(kind.billingDatasourceType())
+}
+
+// cloudProviderName converts a default type identifier such as "ElasticCloud" to its cloud
+// provider name.
+func cloudProviderName(defaultType string) string {
+	var name strings.Builder
+	for i, r := range defaultType {
+		if i > 0 && unicode.IsUpper(r) && unicode.IsLower(rune(defaultType[i-1])) {
+			name.WriteByte('_')
+		}
+		name.WriteRune(unicode.ToLower(r))
+	}
+
+	return name.String()
+}
+
+// WithDatasourceTypeOverrides replaces the type identifiers sent to and expected from the API,
+// for deployments that use identifiers other than the defaults (for example "aws" instead of
+// "AWS"). Keys are default identifiers from BillingDatasourceTypes; empty values are ignored.
+func WithDatasourceTypeOverrides(overrides map[string]string) Option {
+	return func(c *Client) {
+		for defaultType, override := range overrides {
+			if override == "" {
+				continue
+			}
+			if c.datasourceTypes == nil {
+				c.datasourceTypes = make(map[string]string, len(overrides))
+			}
+			c.datasourceTypes[defaultType] = override
+		}
+	}
+}
+
+// datasourceType returns the type identifier used on the wire for defaultType.
+func (c *Client) datasourceType(defaultType string) string {
+	if override, ok := c.datasourceTypes[defaultType]; ok {
+		return override
+	}
+
+	return defaultType
+}
+
+// cloudProvider returns the cloud provider name for a type identifier returned by the API, or
+// "" when the identifier is not a known billing datasource type.
+func (c *Client) cloudProvider(wireType string) string {
+	for _, defaultType := range BillingDatasourceTypes() {
+		if c.datasourceType(defaultType) == wireType {
+			return cloudProviderName(defaultType)
+		}
+	}
+
+	return ""
+}
+
+// checkDatasourceType guards against decoding a datasource of another type. An empty type is
+// accepted because older API versions omit it.
+func (c *Client) checkDatasourceType(defaultType, got string) error {
+	if want := c.datasourceType(defaultType); got != "" && got != want {
+		return &UnexpectedDatasourceTypeError{Expected: want, Got: got}
+	}
+
+	return nil
+}
@@ -0,0 +1,132 @@
+package costoryapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientDatasourceTypeOverride(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"id":"aws-ds-1","type":"aws","bucketName":"billing-bucket"}`))
+			return
+		}
+
+		var payload awsBillingDatasourceAPIRequest
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("unable to decode request body: %v", err)
+		}
+		if payload.Type != "aws" {
+			t.Fatalf("unexpected datasource type on the wire: got %q, want %q", payload.Type, "aws")
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":"aws-ds-1","type":"aws"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", server.Client(), WithDatasourceTypeOverrides(map[string]string{billingDatasourceTypeAWS: "aws"}))
+
+	created, err := client.CreateAWSBillingDatasource(context.Background(), AWSBillingDatasourceRequest{Name: "AWS Billing", RoleARN: "arn"})
+	if err != nil {
+		t.Fatalf("unexpected create error: %v", err)
+	}
+	if created.Type != "aws" {
+		t.Fatalf("unexpected created type: got %q", created.Type)
+	}
+
+	if _, err := client.GetAWSBillingDatasource(context.Background(), "aws-ds-1"); err != nil {
+		t.Fatalf("unexpected get error: %v", err)
+	}
+}
+
+func TestClientDatasourceTypeGuard(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"id":"gcp-ds-1","type":"GCP"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", server.Client())
+
+	_, err := client.GetAWSBillingDatasource(context.Background(), "gcp-ds-1")
+
+	var typeErr *UnexpectedDatasourceTypeError
+	if !errors.As(err, &typeErr) {
+		t.Fatalf("expected *UnexpectedDatasourceTypeError, got %v", err)
+	}
+	if typeErr.Expected != billingDatasourceTypeAWS || typeErr.Got != billingDatasourceTypeGCP {
+		t.Fatalf("unexpected type error: %#v", typeErr)
+	}
+}
+
+func TestWithDatasourceTypeOverridesIgnoresEmptyValues(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient("https://costory.test", "test-token", nil, WithDatasourceTypeOverrides(map[string]string{billingDatasourceTypeGCP: ""}))
+
+	if got := client.datasourceType(billingDatasourceTypeGCP); got != billingDatasourceTypeGCP {
+		t.Fatalf("unexpected datasource type: got %q, want %q", got, billingDatasourceTypeGCP)
+	}
+}
+
+func TestCloudProvider(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		kind BillingDatasourceCreateRequest
+		want string
+	}{
+		"gcp":           {kind: GCPBillingDatasourceRequest{}, want: "gcp"},
+		"aws":           {kind: AWSBillingDatasourceRequest{}, want: "aws"},
+		"azure":         {kind: AzureBillingDatasourceRequest{}, want: "azure"},
+		"cursor":        {kind: CursorBillingDatasourceRequest{}, want: "cursor"},
+		"anthropic":     {kind: AnthropicBillingDatasourceRequest{}, want: "anthropic"},
+		"elastic cloud": {kind: ElasticCloudBillingDatasourceRequest{}, want: "elastic_cloud"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := CloudProvider(tc.kind); got != tc.want {
+				t.Fatalf("unexpected cloud provider: got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestClientCloudProviderFromWireType(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient("https://example.invalid", "test-token", nil, WithDatasourceTypeOverrides(map[string]string{"ElasticCloud": "elastic"}))
+
+	tests := map[string]struct {
+		wireType string
+		want     string
+	}{
+		"default type":    {wireType: "AWS", want: "aws"},
+		"overridden type": {wireType: "elastic", want: "elastic_cloud"},
+		"replaced type":   {wireType: "ElasticCloud"},
+		"unknown type":    {wireType: "Snowflake"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := client.cloudProvider(tc.wireType); got != tc.want {
+				t.Fatalf("unexpected cloud provider: got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package costoryapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+)
+
+const defaultBatchReadRate = 10
+
+// BillingDatasource is the fields shared by every billing datasource type. CloudProvider is
+// derived from Type as by CloudProvider, and is empty when Type is not a known type.
+type BillingDatasource struct {
+	ID            string
+	Type          string
+	CloudProvider string
+	Name          string
+	Status        *string
+}
+
+type billingDatasourceAPIResponse struct {
+	ID     string  `json:"id"`
+	Type   string  `json:"type"`
+	Name   string  `json:"name"`
+	Status *string `json:"status"`
+}
+
+// BillingDatasourcesByIDs is the outcome of GetBillingDatasourcesByIDs. NotFound lists the
+// requested IDs the API does not know, in request order.
+type BillingDatasourcesByIDs struct {
+	Datasources map[string]*BillingDatasource
+	NotFound    []string
+}
+
+// WithBatchReadRate limits GetBillingDatasourcesByIDs to perSecond reads per second, 10 by
+// default, so a long ID list does not trip the API rate limit. Rates above one read per
+// nanosecond leave reads unpaced.
+func WithBatchReadRate(perSecond int) Option {
+	return func(c *Client) {
+		if perSecond > 0 {
+			c.batchReadRate = perSecond
+		}
+	}
+}
+
+// GetBillingDatasource gets a billing datasource of any type by ID.
+func (c *Client) GetBillingDatasource(ctx context.Context, datasourceID string) (*BillingDatasource, error) {
+	routeParams := billingDatasourceByIDRouteParams{ID: datasourceID}
+	body, statusCode, err := doEndpointWithRouteParams(ctx, c, endpointGetBillingDatasourceByID, routeParams, noRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	if statusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	if !isReadSuccess(statusCode) {
+		return nil, unexpectedStatusError(endpointGetBillingDatasourceByID.Name, statusCode, body)
+	}
+
+	var out billingDatasourceAPIResponse
+	if err := c.decodeResponse(body, &out); err != nil {
+		return nil, fmt.Errorf("decode response body: %w", err)
+	}
+
+	datasource := &BillingDatasource{
+		ID:            out.ID,
+		Type:          out.Type,
+		CloudProvider: c.cloudProvider(out.Type),
+		Name:          out.Name,
+		Status:        out.Status,
+	}
+	if datasource.ID == "" {
+		datasource.ID = datasourceID
+	}
+
+	return datasource, nil
+}
+
+// GetBillingDatasourcesByIDs gets several billing datasources of any type concurrently,
+// bounded by WithBatchConcurrency and paced by WithBatchReadRate. Duplicate IDs are read
+// once. IDs that are not found are reported in NotFound instead of failing the call; any
+// other read error fails the whole call.
+func (c *Client) GetBillingDatasourcesByIDs(ctx context.Context, ids []string) (*BillingDatasourcesByIDs, error) {
+	unique := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if !slices.Contains(unique, id) {
+			unique = append(unique, id)
+		}
+	}
+
+	datasources := make([]*BillingDatasource, len(unique))
+	errs := make([]error, len(unique))
+
+	c.runBatch(ctx, len(unique), batchInterval(c.batchReadRate, defaultBatchReadRate), func(i int) {
+		datasources[i], errs[i] = c.GetBillingDatasource(ctx, unique[i])
+	})
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	result := &BillingDatasourcesByIDs{
+		Datasources: make(map[string]*BillingDatasource, len(unique)),
+		NotFound:    []string{},
+	}
+	for i, id := range unique {
+		switch {
+		case errors.Is(errs[i], ErrNotFound):
+			result.NotFound = append(result.NotFound, id)
+		case errs[i] != nil:
+			return nil, fmt.Errorf("get billing datasource %q: %w", id, errs[i])
+		default:
+			result.Datasources[id] = datasources[i]
+		}
+	}
+
+	return result, nil
+}
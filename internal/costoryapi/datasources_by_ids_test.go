@@ -0,0 +1,136 @@
+package costoryapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClientGetBillingDatasourcesByIDs(t *testing.T) {
+	t.Parallel()
+
+	const concurrency = 2
+
+	var calls atomic.Int32
+	var inFlight atomic.Int32
+	var maxInFlight atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			observed := maxInFlight.Load()
+			if current <= observed || maxInFlight.CompareAndSwap(observed, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+
+		switch r.URL.Path {
+		case routeBillingDatasourceByID("ds-aws"):
+			_, _ = w.Write([]byte(`{"id":"ds-aws","type":"AWS","name":"aws","status":"ACTIVE"}`))
+		case routeBillingDatasourceByID("ds-gcp"):
+			_, _ = w.Write([]byte(`{"type":"GCP","name":"gcp"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", server.Client(), WithBatchConcurrency(concurrency), WithBatchReadRate(1000))
+
+	result, err := client.GetBillingDatasourcesByIDs(context.Background(), []string{"ds-aws", "missing-1", "ds-gcp", "ds-aws", "missing-2"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := calls.Load(); got != 4 {
+		t.Fatalf("unexpected calls: got %d, want 4", got)
+	}
+	if got := maxInFlight.Load(); got > concurrency {
+		t.Fatalf("unexpected concurrency: got %d, want at most %d", got, concurrency)
+	}
+
+	if got := strings.Join(result.NotFound, ","); got != "missing-1,missing-2" {
+		t.Fatalf("unexpected not found IDs: %q", got)
+	}
+	if len(result.Datasources) != 2 {
+		t.Fatalf("unexpected datasources: %+v", result.Datasources)
+	}
+	if ds := result.Datasources["ds-aws"]; ds.Type != "AWS" || ds.CloudProvider != "aws" || ds.Name != "aws" || ds.Status == nil || *ds.Status != "ACTIVE" {
+		t.Fatalf("unexpected aws datasource: %+v", ds)
+	}
+	if ds := result.Datasources["ds-gcp"]; ds.ID != "ds-gcp" || ds.Type != "GCP" || ds.CloudProvider != "gcp" || ds.Status != nil {
+		t.Fatalf("unexpected gcp datasource: %+v", ds)
+	}
+}
+
+func TestClientGetBillingDatasourcesByIDsError(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == routeBillingDatasourceByID("ds-forbidden") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", server.Client(), WithBatchReadRate(1000))
+
+	_, err := client.GetBillingDatasourcesByIDs(context.Background(), []string{"missing", "ds-forbidden"})
+	if err == nil || !strings.Contains(err.Error(), `"ds-forbidden"`) {
+		t.Fatalf("expected an error naming the failed ID, got %v", err)
+	}
+	if errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected a non-not-found error, got %v", err)
+	}
+}
+
+func TestClientGetBillingDatasourcesByIDsRate(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", server.Client(), WithBatchConcurrency(4), WithBatchReadRate(20))
+
+	start := time.Now()
+	if _, err := client.GetBillingDatasourcesByIDs(context.Background(), []string{"a", "b", "c", "d"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Four reads at 20 per second start at least three 50ms intervals apart.
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Fatalf("reads were not paced: finished in %s", elapsed)
+	}
+}
+
+func TestClientGetBillingDatasourcesByIDsUnpacedRate(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	// More than one read per nanosecond rounds the interval down to zero.
+	client := NewClient(server.URL, "test-token", server.Client(), WithBatchReadRate(2_000_000_000))
+
+	result, err := client.GetBillingDatasourcesByIDs(context.Background(), []string{"a", "b"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.NotFound) != 2 {
+		t.Fatalf("unexpected not found IDs: %v", result.NotFound)
+	}
+}
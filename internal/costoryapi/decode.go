@@ -0,0 +1,27 @@
+package costoryapi
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// WithStrictDecoding makes the client reject API responses containing fields its response
+// types do not model. It is meant for tests, where it flags API drift; production clients
+// leave it off so new server fields do not break older providers.
+func WithStrictDecoding(strict bool) Option {
+	return func(c *Client) {
+		c.strictDecoding = strict
+	}
+}
+
+// decodeResponse decodes a successful API response body into out, rejecting unknown fields
+// when the client was configured with WithStrictDecoding.
+func (c *Client) decodeResponse(body []byte, out any) error {
+	if !c.strictDecoding {
+		return json.Unmarshal(body, out)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(out)
+}
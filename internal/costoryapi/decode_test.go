@@ -0,0 +1,49 @@
+package costoryapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestStrictDecodingRejectsUnknownFields(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		strict  bool
+		wantErr bool
+	}{
+		"strict":  {strict: true, wantErr: true},
+		"lenient": {strict: false},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte(`{"id":"cursor-ds-1","type":"Cursor","name":"Cursor Billing","unexpectedField":1}`))
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL, "test-token", server.Client(), WithStrictDecoding(tc.strict))
+			datasource, err := client.GetCursorBillingDatasource(context.Background(), "cursor-ds-1")
+
+			if tc.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "unknown field") {
+					t.Fatalf("expected unknown field error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := datasource.ID; got != "cursor-ds-1" {
+				t.Fatalf("unexpected ID: got %q, want %q", got, "cursor-ds-1")
+			}
+		})
+	}
+}
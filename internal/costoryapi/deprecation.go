@@ -0,0 +1,71 @@
+package costoryapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	headerDeprecation = "Deprecation"
+	headerSunset      = "Sunset"
+)
+
+type endpointNameContextKey struct{}
+
+// withEndpointName names the endpoint contract requests sent with ctx are made for, so that
+// paths embedding different IDs still count as a single endpoint.
+func withEndpointName(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, endpointNameContextKey{}, name)
+}
+
+// warnDeprecatedEndpoint logs a warning the first time an endpoint responds with
+// Deprecation or Sunset headers, so users get advance notice to upgrade the provider.
+func (c *Client) warnDeprecatedEndpoint(ctx context.Context, method, path string, header http.Header) {
+	deprecation := strings.TrimSpace(header.Get(headerDeprecation))
+	sunset := strings.TrimSpace(header.Get(headerSunset))
+	if deprecation == "" && sunset == "" {
+		return
+	}
+
+	key := method + " " + path
+	endpoint, _ := ctx.Value(endpointNameContextKey{}).(string)
+	if endpoint != "" {
+		key = endpoint
+	}
+	c.deprecationMu.Lock()
+	if c.deprecationSeen == nil {
+		c.deprecationSeen = map[string]struct{}{}
+	}
+	_, seen := c.deprecationSeen[key]
+	c.deprecationSeen[key] = struct{}{}
+	c.deprecationMu.Unlock()
+	if seen {
+		return
+	}
+
+	fields := map[string]any{
+		"method": method,
+		"path":   path,
+	}
+	if endpoint != "" {
+		fields["endpoint"] = endpoint
+	}
+	if deprecation != "" {
+		fields["deprecation"] = deprecation
+	}
+	if sunset != "" {
+		fields["sunset"] = sunset
+	}
+
+	tflog.Warn(ctx, "Costory API endpoint is deprecated; upgrade the Costory provider before the sunset date", fields)
+
+	detail := fmt.Sprintf("The Costory API reported %s %s as deprecated", method, path)
+	if sunset != "" {
+		detail += fmt.Sprintf(" with a sunset date of %s", sunset)
+	}
+	c.RecordWarning("Costory API endpoint is deprecated", detail+". Upgrade the Costory provider before the endpoint is removed.")
+}
@@ -0,0 +1,86 @@
+package costoryapi
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestClientWarnsOnceForSunsetHeader(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Sunset", "Wed, 01 Jul 2026 00:00:00 GMT")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"service_account":"sa-test","sub_ids":[]}`))
+	}))
+	defer server.Close()
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	client := NewClient(server.URL, "test-token", server.Client())
+	for range 2 {
+		if _, err := client.GetServiceAccount(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatalf("unable to decode log output: %v", err)
+	}
+
+	if len(entries) != 1 {
+		t.Fatalf("unexpected log entries: got %d, want 1: %v", len(entries), entries)
+	}
+
+	entry := entries[0]
+	if entry["@level"] != "warn" {
+		t.Fatalf("unexpected log level: %v", entry["@level"])
+	}
+	if entry["sunset"] != "Wed, 01 Jul 2026 00:00:00 GMT" || entry["path"] != routeServiceAccount {
+		t.Fatalf("unexpected log fields: %v", entry)
+	}
+}
+
+func TestClientWarnsOncePerEndpointAcrossIDs(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Deprecation", "true")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"id":"` + path.Base(r.URL.Path) + `","type":"GCP"}`))
+	}))
+	defer server.Close()
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	client := NewClient(server.URL, "test-token", server.Client())
+	for _, id := range []string{"ds-1", "ds-2"} {
+		if _, err := client.GetBillingDatasource(ctx, id); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatalf("unable to decode log output: %v", err)
+	}
+
+	if len(entries) != 1 {
+		t.Fatalf("unexpected log entries: got %d, want 1: %v", len(entries), entries)
+	}
+	if entries[0]["endpoint"] != endpointGetBillingDatasourceByID.Name {
+		t.Fatalf("unexpected log fields: %v", entries[0])
+	}
+	if got := len(client.Warnings()); got != 1 {
+		t.Fatalf("unexpected warnings: got %d, want 1", got)
+	}
+}
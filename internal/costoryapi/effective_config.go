@@ -0,0 +1,44 @@
+package costoryapi
+
+import (
+	"net/http"
+	"time"
+)
+
+// EffectiveConfig is the non-sensitive configuration a client resolved to. It never carries
+// the token itself, only whether one is available.
+type EffectiveConfig struct {
+	BaseURL     string
+	Slug        string
+	WorkspaceID string
+	// MaxRetries is the number of times a transient failure is retried after the first attempt.
+	MaxRetries int
+	// RequestTimeout bounds a single HTTP attempt. Zero means unbounded.
+	RequestTimeout time.Duration
+	// OperationTimeout bounds a whole Terraform operation. Zero means unbounded.
+	OperationTimeout time.Duration
+	// TokenSet reports whether a static token or a token command is configured.
+	TokenSet bool
+}
+
+// EffectiveConfig returns the configuration the client is using.
+func (c *Client) EffectiveConfig() EffectiveConfig {
+	// The token command fills in c.token lazily from concurrent requests.
+	c.tokenMu.Lock()
+	tokenSet := c.token != "" || len(c.tokenCommand) > 0
+	c.tokenMu.Unlock()
+
+	config := EffectiveConfig{
+		BaseURL:          c.baseURL,
+		Slug:             c.slug,
+		WorkspaceID:      c.workspaceID,
+		MaxRetries:       maxRetryAttempts - 1,
+		OperationTimeout: max(c.operationTimeout, 0),
+		TokenSet:         tokenSet,
+	}
+	if httpClient, ok := c.httpClient.(*http.Client); ok {
+		config.RequestTimeout = httpClient.Timeout
+	}
+
+	return config
+}
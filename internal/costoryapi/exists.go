@@ -0,0 +1,33 @@
+package costoryapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// BillingDatasourceExists reports whether a billing datasource of any type exists, without
+// downloading and decoding it. It issues a HEAD request and falls back to a GET whose body is
+// discarded when the deployment does not allow HEAD (405). A 404 reports false.
+func (c *Client) BillingDatasourceExists(ctx context.Context, datasourceID string) (bool, error) {
+	routeParams := billingDatasourceByIDRouteParams{ID: datasourceID}
+	endpointName := endpointHeadBillingDatasourceByID.Name
+	body, statusCode, err := doEndpointWithRouteParams(ctx, c, endpointHeadBillingDatasourceByID, routeParams, noRequest{})
+	var unsupported *UnsupportedOperationError
+	if errors.As(err, &unsupported) {
+		endpointName = endpointGetBillingDatasourceByID.Name
+		body, statusCode, err = doEndpointWithRouteParams(ctx, c, endpointGetBillingDatasourceByID, routeParams, noRequest{})
+	}
+	if err != nil {
+		return false, err
+	}
+
+	switch {
+	case statusCode == http.StatusNotFound:
+		return false, nil
+	case isReadSuccess(statusCode):
+		return true, nil
+	default:
+		return false, unexpectedStatusError(endpointName, statusCode, body)
+	}
+}
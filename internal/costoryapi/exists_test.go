@@ -0,0 +1,72 @@
+package costoryapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestClientBillingDatasourceExists(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		headStatus int
+		getStatus  int
+		want       bool
+		wantGet    bool
+		wantErr    string
+	}{
+		"existing":             {headStatus: http.StatusOK, want: true},
+		"missing":              {headStatus: http.StatusNotFound, want: false},
+		"head not allowed":     {headStatus: http.StatusMethodNotAllowed, getStatus: http.StatusOK, want: true, wantGet: true},
+		"head not allowed 404": {headStatus: http.StatusMethodNotAllowed, getStatus: http.StatusNotFound, want: false, wantGet: true},
+		"forbidden":            {headStatus: http.StatusForbidden, wantErr: "check billing datasource exists: unexpected status code 403"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var gotGet bool
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != routeBillingDatasourceByID("ds-1") {
+					t.Fatalf("unexpected path: %s", r.URL.Path)
+				}
+
+				switch r.Method {
+				case http.MethodHead:
+					w.WriteHeader(tc.headStatus)
+				case http.MethodGet:
+					gotGet = true
+					w.WriteHeader(tc.getStatus)
+					_, _ = w.Write([]byte(`{"id":"ds-1","type":"GCP","name":"GCP Billing"}`))
+				default:
+					t.Fatalf("unexpected method: %s", r.Method)
+				}
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL, "test-token", server.Client())
+
+			exists, err := client.BillingDatasourceExists(context.Background(), "ds-1")
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if exists != tc.want {
+				t.Fatalf("unexpected exists: got %t, want %t", exists, tc.want)
+			}
+			if gotGet != tc.wantGet {
+				t.Fatalf("unexpected GET fallback: got %t, want %t", gotGet, tc.wantGet)
+			}
+		})
+	}
+}
@@ -0,0 +1,517 @@
+package costoryapi
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+)
+
+// FakeClient is an in-memory BillingDatasourceAPI for tests. Created billing datasources are
+// kept by ID instead of being sent to the Costory API, and reads return copies of them.
+// Settings such as WithStrictDrift or WithTimezone are taken from the same options as
+// NewClient. A FakeClient is safe for concurrent use.
+type FakeClient struct {
+	// ValidationIssues, when set, makes every validate call reject the request with them.
+	ValidationIssues []ValidationIssue
+	// RoleAccess is returned by CheckAWSRoleAccess. When nil, the check reports it was not
+	// performed, like an API without the role access endpoint.
+	RoleAccess *AWSRoleAccess
+	// DisabledFeatures lists the feature flags CheckFeatureSupported reports as unsupported.
+	DisabledFeatures []string
+
+	settings *Client
+
+	mu          sync.Mutex
+	nextID      int
+	datasources map[string]any
+}
+
+// NewFakeClient returns an empty FakeClient configured with opts. Options that only affect
+// HTTP requests, such as WithSlug, have no effect.
+func NewFakeClient(opts ...Option) *FakeClient {
+	return &FakeClient{
+		settings:    NewClient("", "", nil, opts...),
+		datasources: make(map[string]any),
+	}
+}
+
+// PutDatasource stores datasource under its ID, replacing any datasource with that ID, for
+// example to simulate a change made outside Terraform. datasource must be a pointer to one of
+// the billing datasource types, such as *GCPBillingDatasource.
+func (f *FakeClient) PutDatasource(datasource any) error {
+	var id string
+	switch ds := datasource.(type) {
+	case *GCPBillingDatasource:
+		id, datasource = ds.ID, ds.clone()
+	case *AWSBillingDatasource:
+		id, datasource = ds.ID, ds.clone()
+	case *CursorBillingDatasource:
+		id, datasource = ds.ID, ds.clone()
+	case *AnthropicBillingDatasource:
+		id, datasource = ds.ID, ds.clone()
+	case *ElasticCloudBillingDatasource:
+		id, datasource = ds.ID, ds.clone()
+	case *AzureBillingDatasource:
+		id, datasource = ds.ID, ds.clone()
+	default:
+		return fmt.Errorf("unsupported billing datasource %T", datasource)
+	}
+	if id == "" {
+		return fmt.Errorf("billing datasource %T has no id", datasource)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.datasources[id] = datasource
+	return nil
+}
+
+// Datasource returns a copy of the stored datasource with the given ID, or nil when there is
+// none.
+func (f *FakeClient) Datasource(datasourceID string) any {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch ds := f.datasources[datasourceID].(type) {
+	case *GCPBillingDatasource:
+		return ds.clone()
+	case *AWSBillingDatasource:
+		return ds.clone()
+	case *CursorBillingDatasource:
+		return ds.clone()
+	case *AnthropicBillingDatasource:
+		return ds.clone()
+	case *ElasticCloudBillingDatasource:
+		return ds.clone()
+	case *AzureBillingDatasource:
+		return ds.clone()
+	default:
+		return nil
+	}
+}
+
+// Warnings returns the warnings recorded with RecordWarning, oldest first.
+func (f *FakeClient) Warnings() []Warning {
+	return f.settings.Warnings()
+}
+
+// OperationContext behaves like Client.OperationContext for the configured options.
+func (f *FakeClient) OperationContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return f.settings.OperationContext(ctx)
+}
+
+// Now behaves like Client.Now for the configured options.
+func (f *FakeClient) Now() time.Time {
+	return f.settings.Now()
+}
+
+// StrictDrift behaves like Client.StrictDrift for the configured options.
+func (f *FakeClient) StrictDrift() bool {
+	return f.settings.StrictDrift()
+}
+
+// RequireDeleteConfirmation behaves like Client.RequireDeleteConfirmation for the configured options.
+func (f *FakeClient) RequireDeleteConfirmation() bool {
+	return f.settings.RequireDeleteConfirmation()
+}
+
+// FailDeleteOnMissing behaves like Client.FailDeleteOnMissing for the configured options.
+func (f *FakeClient) FailDeleteOnMissing() bool {
+	return f.settings.FailDeleteOnMissing()
+}
+
+// ProviderVersion behaves like Client.ProviderVersion for the configured options.
+func (f *FakeClient) ProviderVersion() string {
+	return f.settings.ProviderVersion()
+}
+
+// DefaultDetailedBilling behaves like Client.DefaultDetailedBilling for the configured options.
+func (f *FakeClient) DefaultDetailedBilling() *bool {
+	return f.settings.DefaultDetailedBilling()
+}
+
+// RecordWarning retains a warning like Client.RecordWarning.
+func (f *FakeClient) RecordWarning(summary, detail string) {
+	f.settings.RecordWarning(summary, detail)
+}
+
+// BillingDatasourceRequestJSON behaves like Client.BillingDatasourceRequestJSON for the
+// configured options.
+func (f *FakeClient) BillingDatasourceRequestJSON(req BillingDatasourceCreateRequest) ([]byte, error) {
+	return f.settings.BillingDatasourceRequestJSON(req)
+}
+
+// CheckBillingDatasourceSupported reports every datasource type as supported.
+func (f *FakeClient) CheckBillingDatasourceSupported(_ context.Context, _ BillingDatasourceCreateRequest) error {
+	return nil
+}
+
+// CheckFeatureSupported returns an *UnsupportedFeatureError for features in DisabledFeatures.
+func (f *FakeClient) CheckFeatureSupported(_ context.Context, feature, field string) error {
+	if slices.Contains(f.DisabledFeatures, feature) {
+		return &UnsupportedFeatureError{Feature: feature, Field: field}
+	}
+
+	return nil
+}
+
+// CheckAWSRoleAccess returns a copy of RoleAccess.
+func (f *FakeClient) CheckAWSRoleAccess(_ context.Context, _ string) (*AWSRoleAccess, error) {
+	if f.RoleAccess == nil {
+		return &AWSRoleAccess{}, nil
+	}
+
+	access := *f.RoleAccess
+	return &access, nil
+}
+
+// UpdateBillingDatasourceOwner sets the owner of a stored GCP or AWS datasource. Other types
+// have no owner and are left unchanged.
+func (f *FakeClient) UpdateBillingDatasourceOwner(_ context.Context, datasourceID string, owner *string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch ds := f.datasources[datasourceID].(type) {
+	case nil:
+		return ErrNotFound
+	case *GCPBillingDatasource:
+		ds.Owner = clonePointer(owner)
+	case *AWSBillingDatasource:
+		ds.Owner = clonePointer(owner)
+	}
+
+	return nil
+}
+
+// UpdateBillingDatasourcePriority sets the priority of a stored GCP or AWS datasource. Other
+// types have no priority and are left unchanged.
+func (f *FakeClient) UpdateBillingDatasourcePriority(_ context.Context, datasourceID string, priority *int64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch ds := f.datasources[datasourceID].(type) {
+	case nil:
+		return ErrNotFound
+	case *GCPBillingDatasource:
+		ds.Priority = clonePointer(priority)
+	case *AWSBillingDatasource:
+		ds.Priority = clonePointer(priority)
+	}
+
+	return nil
+}
+
+// UpdateBillingDatasourceTags replaces the tags of a stored GCP or AWS datasource. Other
+// types have no tags and are left unchanged.
+func (f *FakeClient) UpdateBillingDatasourceTags(_ context.Context, datasourceID string, tags map[string]string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch ds := f.datasources[datasourceID].(type) {
+	case nil:
+		return ErrNotFound
+	case *GCPBillingDatasource:
+		ds.Tags = maps.Clone(tags)
+	case *AWSBillingDatasource:
+		ds.Tags = maps.Clone(tags)
+	}
+
+	return nil
+}
+
+// DeleteBillingDatasource removes a stored datasource, returning ErrNotFound when there is none.
+func (f *FakeClient) DeleteBillingDatasource(_ context.Context, datasourceID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.datasources[datasourceID]; !ok {
+		return ErrNotFound
+	}
+
+	delete(f.datasources, datasourceID)
+	return nil
+}
+
+// ValidateGCPBillingDatasourceResult rejects the request with ValidationIssues when they are set.
+func (f *FakeClient) ValidateGCPBillingDatasourceResult(_ context.Context, _ GCPBillingDatasourceRequest) (*ValidationResult, error) {
+	return f.validationResult(), nil
+}
+
+// CreateGCPBillingDatasource stores a GCP datasource built from req.
+func (f *FakeClient) CreateGCPBillingDatasource(_ context.Context, req GCPBillingDatasourceRequest) (*GCPBillingDatasource, error) {
+	return fakeCreate(f, &GCPBillingDatasource{
+		Type:              billingDatasourceTypeGCP,
+		Name:              req.Name,
+		BQURI:             req.BQURI,
+		IsDetailedBilling: req.IsDetailedBilling,
+		StartDate:         req.StartDate,
+		EndDate:           req.EndDate,
+		Owner:             req.Owner,
+		Priority:          req.Priority,
+		BillingAccountID:  req.BillingAccountID,
+		ExportProjectID:   req.ExportProjectID,
+		Tags:              req.Tags,
+	}, func(ds *GCPBillingDatasource, id string) { ds.ID = id })
+}
+
+// GetGCPBillingDatasource returns a copy of a stored GCP datasource.
+func (f *FakeClient) GetGCPBillingDatasource(_ context.Context, datasourceID string) (*GCPBillingDatasource, error) {
+	return fakeGet[GCPBillingDatasource](f, datasourceID)
+}
+
+// ValidateAWSBillingDatasourceResult rejects the request with ValidationIssues when they are set.
+func (f *FakeClient) ValidateAWSBillingDatasourceResult(_ context.Context, _ AWSBillingDatasourceRequest) (*ValidationResult, error) {
+	return f.validationResult(), nil
+}
+
+// CreateAWSBillingDatasource stores an AWS datasource built from req. A glob prefix is stored
+// as PrefixPattern, as the API reports it.
+func (f *FakeClient) CreateAWSBillingDatasource(_ context.Context, req AWSBillingDatasourceRequest) (*AWSBillingDatasource, error) {
+	datasource := &AWSBillingDatasource{
+		Type:                billingDatasourceTypeAWS,
+		Name:                req.Name,
+		BucketName:          req.BucketName,
+		RoleARN:             req.RoleARN,
+		EKSSplitDataEnabled: req.EKSSplitDataEnabled,
+		StartDate:           req.StartDate,
+		EndDate:             req.EndDate,
+		EKSSplit:            req.EKSSplit,
+		ReportName:          req.ReportName,
+		Owner:               req.Owner,
+		Priority:            req.Priority,
+		Tags:                req.Tags,
+	}
+	prefix := req.Prefix
+	if req.PrefixIsGlob {
+		datasource.PrefixPattern = &prefix
+	} else {
+		datasource.Prefix = &prefix
+	}
+
+	return fakeCreate(f, datasource, func(ds *AWSBillingDatasource, id string) { ds.ID = id })
+}
+
+// GetAWSBillingDatasource returns a copy of a stored AWS datasource.
+func (f *FakeClient) GetAWSBillingDatasource(_ context.Context, datasourceID string) (*AWSBillingDatasource, error) {
+	return fakeGet[AWSBillingDatasource](f, datasourceID)
+}
+
+// ValidateCursorBillingDatasource rejects the request with ValidationIssues when they are set.
+func (f *FakeClient) ValidateCursorBillingDatasource(_ context.Context, _ CursorBillingDatasourceRequest) error {
+	return f.validationResult().Err()
+}
+
+// CreateCursorBillingDatasource stores a Cursor datasource built from req.
+func (f *FakeClient) CreateCursorBillingDatasource(_ context.Context, req CursorBillingDatasourceRequest) (*CursorBillingDatasource, error) {
+	return fakeCreate(f, &CursorBillingDatasource{
+		Type:      billingDatasourceTypeCursor,
+		Name:      req.Name,
+		StartDate: req.StartDate,
+		EndDate:   req.EndDate,
+	}, func(ds *CursorBillingDatasource, id string) { ds.ID = id })
+}
+
+// GetCursorBillingDatasource returns a copy of a stored Cursor datasource.
+func (f *FakeClient) GetCursorBillingDatasource(_ context.Context, datasourceID string) (*CursorBillingDatasource, error) {
+	return fakeGet[CursorBillingDatasource](f, datasourceID)
+}
+
+// ValidateAnthropicBillingDatasource rejects the request with ValidationIssues when they are set.
+func (f *FakeClient) ValidateAnthropicBillingDatasource(_ context.Context, _ AnthropicBillingDatasourceRequest) error {
+	return f.validationResult().Err()
+}
+
+// CreateAnthropicBillingDatasource stores an Anthropic datasource built from req.
+func (f *FakeClient) CreateAnthropicBillingDatasource(_ context.Context, req AnthropicBillingDatasourceRequest) (*AnthropicBillingDatasource, error) {
+	return fakeCreate(f, &AnthropicBillingDatasource{
+		Type:      billingDatasourceTypeAnthropic,
+		Name:      req.Name,
+		StartDate: req.StartDate,
+		EndDate:   req.EndDate,
+	}, func(ds *AnthropicBillingDatasource, id string) { ds.ID = id })
+}
+
+// GetAnthropicBillingDatasource returns a copy of a stored Anthropic datasource.
+func (f *FakeClient) GetAnthropicBillingDatasource(_ context.Context, datasourceID string) (*AnthropicBillingDatasource, error) {
+	return fakeGet[AnthropicBillingDatasource](f, datasourceID)
+}
+
+// ValidateElasticCloudBillingDatasource rejects the request with ValidationIssues when they are set.
+func (f *FakeClient) ValidateElasticCloudBillingDatasource(_ context.Context, _ ElasticCloudBillingDatasourceRequest) error {
+	return f.validationResult().Err()
+}
+
+// CreateElasticCloudBillingDatasource stores an Elastic Cloud datasource built from req.
+func (f *FakeClient) CreateElasticCloudBillingDatasource(_ context.Context, req ElasticCloudBillingDatasourceRequest) (*ElasticCloudBillingDatasource, error) {
+	return fakeCreate(f, &ElasticCloudBillingDatasource{
+		Type:           billingDatasourceTypeElasticCloud,
+		Name:           req.Name,
+		OrganizationID: req.OrganizationID,
+		StartDate:      req.StartDate,
+	}, func(ds *ElasticCloudBillingDatasource, id string) { ds.ID = id })
+}
+
+// GetElasticCloudBillingDatasource returns a copy of a stored Elastic Cloud datasource.
+func (f *FakeClient) GetElasticCloudBillingDatasource(_ context.Context, datasourceID string) (*ElasticCloudBillingDatasource, error) {
+	return fakeGet[ElasticCloudBillingDatasource](f, datasourceID)
+}
+
+// ValidateAzureBillingDatasource rejects the request with ValidationIssues when they are set.
+func (f *FakeClient) ValidateAzureBillingDatasource(_ context.Context, _ AzureBillingDatasourceRequest) error {
+	return f.validationResult().Err()
+}
+
+// CreateAzureBillingDatasource stores an Azure datasource built from req.
+func (f *FakeClient) CreateAzureBillingDatasource(_ context.Context, req AzureBillingDatasourceRequest) (*AzureBillingDatasource, error) {
+	return fakeCreate(f, &AzureBillingDatasource{
+		Type:               billingDatasourceTypeAzure,
+		Name:               req.Name,
+		StorageAccountName: req.StorageAccountName,
+		ContainerName:      req.ContainerName,
+		ActualsPath:        req.ActualsPath,
+		AmortizedPath:      req.AmortizedPath,
+	}, func(ds *AzureBillingDatasource, id string) { ds.ID = id })
+}
+
+// GetAzureBillingDatasource returns a copy of a stored Azure datasource.
+func (f *FakeClient) GetAzureBillingDatasource(_ context.Context, datasourceID string) (*AzureBillingDatasource, error) {
+	return fakeGet[AzureBillingDatasource](f, datasourceID)
+}
+
+func (f *FakeClient) validationResult() *ValidationResult {
+	if len(f.ValidationIssues) == 0 {
+		return &ValidationResult{Valid: true, StatusCode: http.StatusNoContent}
+	}
+
+	return &ValidationResult{StatusCode: http.StatusBadRequest, Issues: f.ValidationIssues}
+}
+
+// fakeDatasource is a pointer to a billing datasource type that the FakeClient can copy.
+type fakeDatasource[T any] interface {
+	*T
+	clone() *T
+}
+
+// fakeCreate assigns datasource the next fake ID, stores a copy of it, and returns another, so
+// neither the caller's request values nor the returned datasource alias the stored one.
+func fakeCreate[T any, P fakeDatasource[T]](f *FakeClient, datasource P, setID func(*T, string)) (*T, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.nextID++
+	id := fmt.Sprintf("fake-ds-%d", f.nextID)
+	setID(datasource, id)
+	f.datasources[id] = datasource.clone()
+
+	return datasource.clone(), nil
+}
+
+// fakeGet returns a copy of the stored datasource of type T, or ErrNotFound.
+func fakeGet[T any, P fakeDatasource[T]](f *FakeClient, datasourceID string) (*T, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	datasource, ok := f.datasources[datasourceID].(*T)
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	return P(datasource).clone(), nil
+}
+
+// clonePointer returns a pointer to a copy of *p, or nil when p is nil.
+func clonePointer[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+
+	v := *p
+	return &v
+}
+
+func (ds *GCPBillingDatasource) clone() *GCPBillingDatasource {
+	out := *ds
+	out.Status = clonePointer(ds.Status)
+	out.ManagedBy = clonePointer(ds.ManagedBy)
+	out.IsDetailedBilling = clonePointer(ds.IsDetailedBilling)
+	out.StartDate = clonePointer(ds.StartDate)
+	out.EndDate = clonePointer(ds.EndDate)
+	out.BackfillProgress = clonePointer(ds.BackfillProgress)
+	out.Owner = clonePointer(ds.Owner)
+	out.Priority = clonePointer(ds.Priority)
+	out.BillingAccountID = clonePointer(ds.BillingAccountID)
+	out.ExportProjectID = clonePointer(ds.ExportProjectID)
+	out.LastIngestedAt = clonePointer(ds.LastIngestedAt)
+	out.DetectedColumns = slices.Clone(ds.DetectedColumns)
+	out.Tags = maps.Clone(ds.Tags)
+	out.Warnings = slices.Clone(ds.Warnings)
+	out.EarliestAvailableDate = clonePointer(ds.EarliestAvailableDate)
+	return &out
+}
+
+func (ds *AWSBillingDatasource) clone() *AWSBillingDatasource {
+	out := *ds
+	out.Status = clonePointer(ds.Status)
+	out.ManagedBy = clonePointer(ds.ManagedBy)
+	out.Prefix = clonePointer(ds.Prefix)
+	out.EKSSplitDataEnabled = clonePointer(ds.EKSSplitDataEnabled)
+	out.StartDate = clonePointer(ds.StartDate)
+	out.EndDate = clonePointer(ds.EndDate)
+	out.EKSSplit = clonePointer(ds.EKSSplit)
+	out.ReportName = clonePointer(ds.ReportName)
+	out.PrefixPattern = clonePointer(ds.PrefixPattern)
+	out.BackfillProgress = clonePointer(ds.BackfillProgress)
+	out.Owner = clonePointer(ds.Owner)
+	out.Priority = clonePointer(ds.Priority)
+	out.LastIngestedAt = clonePointer(ds.LastIngestedAt)
+	out.DetectedColumns = slices.Clone(ds.DetectedColumns)
+	out.Tags = maps.Clone(ds.Tags)
+	out.Warnings = slices.Clone(ds.Warnings)
+	out.EarliestAvailableDate = clonePointer(ds.EarliestAvailableDate)
+	return &out
+}
+
+func (ds *CursorBillingDatasource) clone() *CursorBillingDatasource {
+	out := *ds
+	out.Status = clonePointer(ds.Status)
+	out.ManagedBy = clonePointer(ds.ManagedBy)
+	out.StartDate = clonePointer(ds.StartDate)
+	out.EndDate = clonePointer(ds.EndDate)
+	out.Warnings = slices.Clone(ds.Warnings)
+	out.EarliestAvailableDate = clonePointer(ds.EarliestAvailableDate)
+	return &out
+}
+
+func (ds *AnthropicBillingDatasource) clone() *AnthropicBillingDatasource {
+	out := *ds
+	out.Status = clonePointer(ds.Status)
+	out.ManagedBy = clonePointer(ds.ManagedBy)
+	out.StartDate = clonePointer(ds.StartDate)
+	out.EndDate = clonePointer(ds.EndDate)
+	out.Warnings = slices.Clone(ds.Warnings)
+	out.EarliestAvailableDate = clonePointer(ds.EarliestAvailableDate)
+	return &out
+}
+
+func (ds *ElasticCloudBillingDatasource) clone() *ElasticCloudBillingDatasource {
+	out := *ds
+	out.Status = clonePointer(ds.Status)
+	out.ManagedBy = clonePointer(ds.ManagedBy)
+	out.StartDate = clonePointer(ds.StartDate)
+	out.Warnings = slices.Clone(ds.Warnings)
+	out.EarliestAvailableDate = clonePointer(ds.EarliestAvailableDate)
+	return &out
+}
+
+func (ds *AzureBillingDatasource) clone() *AzureBillingDatasource {
+	out := *ds
+	out.Status = clonePointer(ds.Status)
+	out.ManagedBy = clonePointer(ds.ManagedBy)
+	out.Warnings = slices.Clone(ds.Warnings)
+	return &out
+}
@@ -0,0 +1,96 @@
+package costoryapi
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestFakeClientBillingDatasourceCRUD(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client := NewFakeClient()
+
+	created, err := client.CreateAWSBillingDatasource(ctx, AWSBillingDatasourceRequest{Name: "AWS Billing", Prefix: "cur/*", PrefixIsGlob: true})
+	if err != nil {
+		t.Fatalf("unexpected create error: %v", err)
+	}
+	if created.ID == "" || created.Type != billingDatasourceTypeAWS {
+		t.Fatalf("unexpected created datasource: %#v", created)
+	}
+	if created.Prefix != nil || created.PrefixPattern == nil || *created.PrefixPattern != "cur/*" {
+		t.Fatalf("expected a glob prefix to be stored as the prefix pattern, got %#v / %#v", created.Prefix, created.PrefixPattern)
+	}
+
+	if err := client.UpdateBillingDatasourceOwner(ctx, created.ID, stringPointer("finops")); err != nil {
+		t.Fatalf("unexpected owner update error: %v", err)
+	}
+	current, err := client.GetAWSBillingDatasource(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("unexpected get error: %v", err)
+	}
+	if current.Owner == nil || *current.Owner != "finops" {
+		t.Fatalf("unexpected owner: got %#v", current.Owner)
+	}
+
+	if _, err := client.GetGCPBillingDatasource(ctx, created.ID); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected a read of another type to be not found, got %v", err)
+	}
+
+	if err := client.DeleteBillingDatasource(ctx, created.ID); err != nil {
+		t.Fatalf("unexpected delete error: %v", err)
+	}
+	if err := client.DeleteBillingDatasource(ctx, created.ID); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected a second delete to be not found, got %v", err)
+	}
+
+	if err := client.PutDatasource(&Team{ID: "team-1"}); err == nil {
+		t.Fatal("expected a non-datasource value to be rejected")
+	}
+}
+
+func TestFakeClientDoesNotAliasStoredDatasources(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client := NewFakeClient()
+
+	owner := "finops"
+	req := GCPBillingDatasourceRequest{Name: "GCP Billing", BQURI: "project.dataset.table", Owner: &owner, Tags: map[string]string{"team": "finops"}}
+	created, err := client.CreateGCPBillingDatasource(ctx, req)
+	if err != nil {
+		t.Fatalf("unexpected create error: %v", err)
+	}
+
+	owner = "changed"
+	req.Tags["team"] = "changed"
+	*created.Owner = "changed"
+	created.Tags["env"] = "prod"
+
+	read, err := client.GetGCPBillingDatasource(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("unexpected read error: %v", err)
+	}
+	if got := *read.Owner; got != "finops" {
+		t.Fatalf("expected the stored owner to be unchanged, got %q", got)
+	}
+	if len(read.Tags) != 1 || read.Tags["team"] != "finops" {
+		t.Fatalf("expected the stored tags to be unchanged, got %v", read.Tags)
+	}
+
+	read.DetectedColumns = append(read.DetectedColumns, "cost")
+	read.Tags["team"] = "changed"
+	if again, _ := client.GetGCPBillingDatasource(ctx, created.ID); len(again.DetectedColumns) != 0 || again.Tags["team"] != "finops" {
+		t.Fatalf("expected reads to return independent copies, got %#v", again)
+	}
+
+	put := &AWSBillingDatasource{ID: "aws-ds-1", Tags: map[string]string{"team": "finops"}}
+	if err := client.PutDatasource(put); err != nil {
+		t.Fatalf("unexpected put error: %v", err)
+	}
+	put.Tags["team"] = "changed"
+	if stored := client.Datasource("aws-ds-1").(*AWSBillingDatasource); stored.Tags["team"] != "finops" {
+		t.Fatalf("expected the stored datasource to be a copy, got %v", stored.Tags)
+	}
+}
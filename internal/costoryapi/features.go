@@ -0,0 +1,70 @@
+package costoryapi
+
+import (
+	"context"
+	"fmt"
+)
+
+// Feature flags reported by the capabilities endpoint for request fields added after the
+// endpoint itself. Deployments that do not enable a feature reject requests carrying its
+// fields.
+const (
+	FeatureOwner    = "owner"
+	FeatureTags     = "tags"
+	FeaturePriority = "priority"
+)
+
+// UnsupportedFeatureError is returned when a request sets a field gated by a feature the
+// deployment does not enable.
+type UnsupportedFeatureError struct {
+	Feature string
+	Field   string
+}
+
+func (e *UnsupportedFeatureError) Error() string {
+	return fmt.Sprintf("the Costory deployment does not enable the %q feature, so %s cannot be set; remove it from the configuration or upgrade the Costory deployment", e.Feature, e.Field)
+}
+
+// CheckFeatureSupported returns an *UnsupportedFeatureError when the deployment does not
+// enable feature. Unknown capabilities, for example when the capabilities endpoint is
+// unreachable, allow every field so the API remains the judge.
+func (c *Client) CheckFeatureSupported(ctx context.Context, feature, field string) error {
+	capabilities, err := c.GetCapabilities(ctx)
+	if err != nil || capabilities.FeatureEnabled(feature) {
+		return nil
+	}
+
+	return &UnsupportedFeatureError{Feature: feature, Field: field}
+}
+
+// checkFeatures returns an *UnsupportedFeatureError for the first field of r the deployment
+// does not support, rather than sending a request that would silently drop it.
+func (r GCPBillingDatasourceRequest) checkFeatures(ctx context.Context, c *Client) error {
+	return checkFeatureFields(ctx, c, r.Owner != nil, r.Tags != nil, r.Priority != nil)
+}
+
+// checkFeatures returns an *UnsupportedFeatureError for the first field of r the deployment
+// does not support, rather than sending a request that would silently drop it.
+func (r AWSBillingDatasourceRequest) checkFeatures(ctx context.Context, c *Client) error {
+	return checkFeatureFields(ctx, c, r.Owner != nil, r.Tags != nil, r.Priority != nil)
+}
+
+func checkFeatureFields(ctx context.Context, c *Client, owner, tags, priority bool) error {
+	if owner {
+		if err := c.CheckFeatureSupported(ctx, FeatureOwner, "owner"); err != nil {
+			return err
+		}
+	}
+	if tags {
+		if err := c.CheckFeatureSupported(ctx, FeatureTags, "tags"); err != nil {
+			return err
+		}
+	}
+	if priority {
+		if err := c.CheckFeatureSupported(ctx, FeaturePriority, "priority"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
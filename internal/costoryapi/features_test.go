@@ -0,0 +1,98 @@
+package costoryapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientCreateRejectsUnsupportedFeatureFields(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		capabilitiesStatus int
+		capabilitiesBody   string
+		wantField          string
+	}{
+		"features enabled": {
+			capabilitiesStatus: http.StatusOK,
+			capabilitiesBody:   `{"datasourceTypes":["GCP"],"features":{"tags":true,"owner":true}}`,
+		},
+		"tags disabled": {
+			capabilitiesStatus: http.StatusOK,
+			capabilitiesBody:   `{"datasourceTypes":["GCP"],"features":{"tags":false,"owner":true}}`,
+			wantField:          "tags",
+		},
+		"features not listed": {
+			capabilitiesStatus: http.StatusOK,
+			capabilitiesBody:   `{"datasourceTypes":["GCP"]}`,
+			wantField:          "owner",
+		},
+		"capabilities endpoint missing": {
+			capabilitiesStatus: http.StatusNotFound,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			payloads := make(chan map[string]any, 1)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == routeCapabilities {
+					w.WriteHeader(tc.capabilitiesStatus)
+					_, _ = w.Write([]byte(tc.capabilitiesBody))
+					return
+				}
+
+				var payload map[string]any
+				if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+					t.Fatalf("unable to decode request body: %v", err)
+				}
+				payloads <- payload
+
+				w.WriteHeader(http.StatusCreated)
+				_, _ = w.Write([]byte(`{"id":"gcp-ds-1","type":"GCP"}`))
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL, "test-token", server.Client())
+			req := GCPBillingDatasourceRequest{
+				Name:  "GCP Billing",
+				BQURI: "project.dataset.table",
+				Owner: stringPointer("finops"),
+				Tags:  map[string]string{"team": "finops"},
+			}
+
+			_, err := client.CreateGCPBillingDatasource(context.Background(), req)
+			if tc.wantField == "" {
+				if err != nil {
+					t.Fatalf("unexpected create error: %v", err)
+				}
+
+				payload := <-payloads
+				if _, ok := payload["tags"]; !ok {
+					t.Fatalf("expected tags to be sent: %v", payload)
+				}
+				if _, ok := payload["owner"]; !ok {
+					t.Fatalf("expected owner to be sent: %v", payload)
+				}
+				return
+			}
+
+			var unsupported *UnsupportedFeatureError
+			if !errors.As(err, &unsupported) {
+				t.Fatalf("expected unsupported feature error, got %v", err)
+			}
+			if unsupported.Field != tc.wantField {
+				t.Fatalf("unexpected unsupported field: got %q, want %q", unsupported.Field, tc.wantField)
+			}
+			if len(payloads) != 0 {
+				t.Fatal("expected the create request not to be sent")
+			}
+		})
+	}
+}
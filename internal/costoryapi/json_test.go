@@ -0,0 +1,66 @@
+package costoryapi
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestNormalizedStructsMarshalSnakeCase(t *testing.T) {
+	t.Parallel()
+
+	lastIngestedAt := time.Date(2026, 3, 7, 12, 34, 56, 0, time.UTC)
+
+	tests := map[string]struct {
+		value any
+		want  string
+	}{
+		"service account": {
+			value: ServiceAccountResponse{ServiceAccount: "sa@costory.iam.gserviceaccount.com", SubIDs: []string{"sub-1"}},
+			want:  `{"service_account":"sa@costory.iam.gserviceaccount.com","sub_ids":["sub-1"]}`,
+		},
+		"gcp": {
+			value: GCPBillingDatasource{
+				ID:                "gcp-ds-1",
+				Type:              "GCP",
+				Status:            stringPointer("ACTIVE"),
+				Name:              "GCP Billing",
+				BQURI:             "project.dataset.table",
+				IsDetailedBilling: boolPointer(true),
+				BackfillProgress:  float64Pointer(42.5),
+				BillingAccountID:  stringPointer("01A2B3-C4D5E6-F7A8B9"),
+				LastIngestedAt:    &lastIngestedAt,
+				DetectedColumns:   []string{"cost", "currency"},
+			},
+			want: `{"id":"gcp-ds-1","type":"GCP","status":"ACTIVE","managed_by":null,"name":"GCP Billing","bq_uri":"project.dataset.table","is_detailed_billing":true,"start_date":null,"end_date":null,"backfill_progress":42.5,"owner":null,"priority":null,"billing_account_id":"01A2B3-C4D5E6-F7A8B9","export_project_id":null,"last_ingested_at":"2026-03-07T12:34:56Z","detected_columns":["cost","currency"],"tags":null,"earliest_available_date":null}`,
+		},
+		"aws": {
+			value: AWSBillingDatasource{
+				ID:         "aws-ds-1",
+				Type:       "AWS",
+				Name:       "AWS Billing",
+				BucketName: "billing-bucket",
+				RoleARN:    "arn:aws:iam::123456789012:role/costory",
+				Prefix:     stringPointer("cur/"),
+				EKSSplit:   boolPointer(false),
+				ReportName: stringPointer("daily"),
+				Owner:      stringPointer("finops"),
+			},
+			want: `{"id":"aws-ds-1","type":"AWS","status":null,"managed_by":null,"name":"AWS Billing","bucket_name":"billing-bucket","role_arn":"arn:aws:iam::123456789012:role/costory","prefix":"cur/","eks_split_data_enabled":null,"start_date":null,"end_date":null,"eks_split":false,"report_name":"daily","prefix_pattern":null,"backfill_progress":null,"owner":"finops","priority":null,"last_ingested_at":null,"detected_columns":null,"tags":null,"earliest_available_date":null}`,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := json.Marshal(tc.value)
+			if err != nil {
+				t.Fatalf("unexpected marshal error: %v", err)
+			}
+			if string(got) != tc.want {
+				t.Fatalf("unexpected JSON:\ngot  %s\nwant %s", got, tc.want)
+			}
+		})
+	}
+}
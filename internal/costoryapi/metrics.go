@@ -0,0 +1,86 @@
+package costoryapi
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// metricsTransportErrorStatus labels attempts that failed before a response was received.
+const metricsTransportErrorStatus = "transport"
+
+// clientMetrics accumulates request counters over the lifetime of a client.
+type clientMetrics struct {
+	mu             sync.Mutex
+	requests       uint64
+	retries        uint64
+	errorsByStatus map[string]uint64
+}
+
+// recordAttempt counts one HTTP attempt. Retries are attempts after the first, and errors
+// are attempts that failed in transport or returned a 4xx or 5xx status.
+func (m *clientMetrics) recordAttempt(info RequestInfo) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests++
+	if info.Attempt > 1 {
+		m.retries++
+	}
+
+	status := ""
+	switch {
+	case info.StatusCode == 0:
+		status = metricsTransportErrorStatus
+	case info.StatusCode >= http.StatusBadRequest:
+		status = strconv.Itoa(info.StatusCode)
+	default:
+		return
+	}
+
+	if m.errorsByStatus == nil {
+		m.errorsByStatus = map[string]uint64{}
+	}
+	m.errorsByStatus[status]++
+}
+
+// WritePrometheusMetrics writes the client's request counters in the Prometheus text
+// exposition format, for embedders that expose the client from a long-running service.
+// Counters cover every HTTP attempt the client has made, including retries.
+func (c *Client) WritePrometheusMetrics(w io.Writer) error {
+	c.metrics.mu.Lock()
+	requests := c.metrics.requests
+	retries := c.metrics.retries
+	errorsByStatus := make(map[string]uint64, len(c.metrics.errorsByStatus))
+	for status, count := range c.metrics.errorsByStatus {
+		errorsByStatus[status] = count
+	}
+	c.metrics.mu.Unlock()
+
+	var b strings.Builder
+	writeCounter(&b, "costory_client_requests_total", "HTTP requests sent to the Costory API, including retries.")
+	fmt.Fprintf(&b, "costory_client_requests_total %d\n", requests)
+	writeCounter(&b, "costory_client_retries_total", "HTTP requests to the Costory API that retried a transient failure.")
+	fmt.Fprintf(&b, "costory_client_retries_total %d\n", retries)
+	writeCounter(&b, "costory_client_errors_total", "Failed HTTP requests to the Costory API by response status, or transport when no response was received.")
+
+	statuses := make([]string, 0, len(errorsByStatus))
+	for status := range errorsByStatus {
+		statuses = append(statuses, status)
+	}
+	slices.Sort(statuses)
+	for _, status := range statuses {
+		fmt.Fprintf(&b, "costory_client_errors_total{status=%q} %d\n", status, errorsByStatus[status])
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeCounter(b *strings.Builder, name, help string) {
+	fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s counter\n", name, help, name)
+}
@@ -0,0 +1,91 @@
+package costoryapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestClientWritePrometheusMetrics(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		switch calls.Add(1) {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 3:
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"service_account":"sa-test","sub_ids":[]}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", server.Client())
+
+	var previous int
+	for i := range 3 {
+		_, _ = client.GetServiceAccount(context.Background())
+
+		var b strings.Builder
+		if err := client.WritePrometheusMetrics(&b); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		output := b.String()
+
+		for _, want := range []string{
+			"# TYPE costory_client_requests_total counter\n",
+			"# TYPE costory_client_retries_total counter\n",
+			"# TYPE costory_client_errors_total counter\n",
+		} {
+			if !strings.Contains(output, want) {
+				t.Fatalf("expected output to contain %q, got:\n%s", want, output)
+			}
+		}
+
+		requests := metricValue(t, output, "costory_client_requests_total")
+		if requests <= previous {
+			t.Fatalf("expected request counter to increase after call %d: got %d, previous %d", i+1, requests, previous)
+		}
+		previous = requests
+	}
+
+	var b strings.Builder
+	if err := client.WritePrometheusMetrics(&b); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	output := b.String()
+
+	for name, want := range map[string]int{
+		"costory_client_requests_total":             4,
+		"costory_client_retries_total":              1,
+		`costory_client_errors_total{status="403"}`: 1,
+		`costory_client_errors_total{status="503"}`: 1,
+	} {
+		if got := metricValue(t, output, name); got != want {
+			t.Fatalf("unexpected %s: got %d, want %d", name, got, want)
+		}
+	}
+}
+
+func metricValue(t *testing.T, output, name string) int {
+	t.Helper()
+
+	match := regexp.MustCompile(`(?m)^` + regexp.QuoteMeta(name) + ` (\d+)$`).FindStringSubmatch(output)
+	if match == nil {
+		t.Fatalf("metric %s not found in output:\n%s", name, output)
+	}
+
+	value, err := strconv.Atoi(match[1])
+	if err != nil {
+		t.Fatalf("unable to parse metric %s: %v", name, err)
+	}
+	return value
+}
@@ -0,0 +1,33 @@
+package costoryapi
+
+import (
+	"context"
+	"time"
+)
+
+// WithOperationTimeout bounds every Terraform operation started with OperationContext. A
+// zero or negative timeout leaves operations unbounded.
+func WithOperationTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.operationTimeout = timeout
+	}
+}
+
+// OperationContext derives the context for a single resource operation, such as a Create or
+// Read, which may issue several API calls. With WithOperationTimeout the context expires after
+// the timeout; a deadline already set on ctx, for example by Terraform, still applies when it
+// is earlier. The context collects warnings raised by the operation's API calls, which
+// OperationWarnings reports, and with WithVerboseRetries also the operation's retries, which
+// Retries reports. Callers must call the returned cancel function.
+func (c *Client) OperationContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx = withOperationWarnings(ctx)
+	if c.verboseRetries {
+		ctx = withRetryHistory(ctx)
+	}
+
+	if c.operationTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeout(ctx, c.operationTimeout)
+}
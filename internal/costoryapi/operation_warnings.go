@@ -0,0 +1,52 @@
+package costoryapi
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+type operationWarningsKey struct{}
+
+// operationWarnings collects the warnings raised by the API calls of one operation, so the
+// resource running it can report them as diagnostics. Requests of an operation may run
+// concurrently, so it is guarded by a mutex.
+type operationWarnings struct {
+	mu       sync.Mutex
+	warnings []Warning
+}
+
+// OperationWarnings returns the warnings raised so far by the operation ctx was derived for,
+// oldest first. It returns nil when ctx was not derived from OperationContext.
+func OperationWarnings(ctx context.Context) []Warning {
+	collected, ok := ctx.Value(operationWarningsKey{}).(*operationWarnings)
+	if !ok {
+		return nil
+	}
+
+	collected.mu.Lock()
+	defer collected.mu.Unlock()
+
+	return slices.Clone(collected.warnings)
+}
+
+func withOperationWarnings(ctx context.Context) context.Context {
+	return context.WithValue(ctx, operationWarningsKey{}, &operationWarnings{})
+}
+
+// recordOperationWarning retains a warning with RecordWarning and also attaches it to the
+// operation ctx was derived for, if any.
+func (c *Client) recordOperationWarning(ctx context.Context, summary, detail string) {
+	c.RecordWarning(summary, detail)
+
+	collected, ok := ctx.Value(operationWarningsKey{}).(*operationWarnings)
+	if !ok {
+		return
+	}
+
+	collected.mu.Lock()
+	defer collected.mu.Unlock()
+
+	collected.warnings = append(collected.warnings, Warning{Summary: summary, Detail: detail, RecordedAt: time.Now().UTC()})
+}
@@ -0,0 +1,27 @@
+package costoryapi
+
+// WithProviderVersion records the version of the Terraform provider using the client, so
+// diagnostics reporting provider bugs can name it.
+func WithProviderVersion(version string) Option {
+	return func(c *Client) {
+		c.providerVersion = version
+	}
+}
+
+// ProviderVersion returns the version configured with WithProviderVersion, or an empty string.
+func (c *Client) ProviderVersion() string {
+	return c.providerVersion
+}
+
+// VersionedSummary appends the provider version reported by providerData to a diagnostic
+// summary, as in "Unexpected resource configure type (costory provider 1.2.0)", so bug
+// reports name the version in use. The summary is returned unchanged when providerData does
+// not report a version.
+func VersionedSummary(summary string, providerData any) string {
+	versioned, ok := providerData.(interface{ ProviderVersion() string })
+	if !ok || versioned.ProviderVersion() == "" {
+		return summary
+	}
+
+	return summary + " (costory provider " + versioned.ProviderVersion() + ")"
+}
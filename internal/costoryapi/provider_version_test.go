@@ -0,0 +1,28 @@
+package costoryapi
+
+import "testing"
+
+func TestVersionedSummary(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		providerData any
+		want         string
+	}{
+		"client with version":    {providerData: NewClient("", "", nil, WithProviderVersion("1.2.0")), want: "Unexpected configure type (costory provider 1.2.0)"},
+		"fake with version":      {providerData: NewFakeClient(WithProviderVersion("dev")), want: "Unexpected configure type (costory provider dev)"},
+		"client without version": {providerData: NewClient("", "", nil), want: "Unexpected configure type"},
+		"unrelated value":        {providerData: "not a client", want: "Unexpected configure type"},
+		"nil":                    {providerData: nil, want: "Unexpected configure type"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := VersionedSummary("Unexpected configure type", tc.providerData); got != tc.want {
+				t.Fatalf("unexpected summary: got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
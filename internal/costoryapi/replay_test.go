@@ -0,0 +1,236 @@
+package costoryapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// httpFixture is a recorded sequence of HTTP interactions stored under testdata/.
+type httpFixture struct {
+	Interactions []httpInteraction `json:"interactions"`
+}
+
+type httpInteraction struct {
+	Request  fixtureRequest  `json:"request"`
+	Response fixtureResponse `json:"response"`
+}
+
+type fixtureRequest struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	// Headers lists request headers that must match; other headers are ignored.
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    json.RawMessage   `json:"body,omitempty"`
+}
+
+type fixtureResponse struct {
+	Status  int               `json:"status"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    json.RawMessage   `json:"body,omitempty"`
+}
+
+// loadHTTPFixture reads a fixture from the package testdata directory.
+func loadHTTPFixture(t *testing.T, name string) httpFixture {
+	t.Helper()
+
+	return loadHTTPFixtureFile(t, filepath.Join("testdata", name))
+}
+
+func loadHTTPFixtureFile(t *testing.T, path string) httpFixture {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("unable to read fixture %s: %v", path, err)
+	}
+
+	var fixture httpFixture
+	if err := json.Unmarshal(data, &fixture); err != nil {
+		t.Fatalf("unable to decode fixture %s: %v", path, err)
+	}
+
+	return fixture
+}
+
+// replayDoer serves recorded interactions in order and fails the test when a request
+// does not match the next recorded one.
+type replayDoer struct {
+	t            *testing.T
+	mu           sync.Mutex
+	interactions []httpInteraction
+	next         int
+}
+
+func newReplayDoer(t *testing.T, fixtureName string) *replayDoer {
+	t.Helper()
+
+	doer := &replayDoer{t: t, interactions: loadHTTPFixture(t, fixtureName).Interactions}
+	t.Cleanup(doer.assertConsumed)
+	return doer
+}
+
+func (d *replayDoer) Do(req *http.Request) (*http.Response, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.next >= len(d.interactions) {
+		d.t.Fatalf("unexpected request beyond fixture: %s %s", req.Method, req.URL.Path)
+	}
+	interaction := d.interactions[d.next]
+	d.next++
+
+	want := interaction.Request
+	if req.Method != want.Method || req.URL.Path != want.Path {
+		d.t.Fatalf("unexpected request %d: got %s %s, want %s %s", d.next, req.Method, req.URL.Path, want.Method, want.Path)
+	}
+
+	for name, value := range want.Headers {
+		if got := req.Header.Get(name); got != value {
+			d.t.Fatalf("unexpected %s header on request %d: got %q, want %q", name, d.next, got, value)
+		}
+	}
+
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			d.t.Fatalf("unable to read request body: %v", err)
+		}
+	}
+	if len(want.Body) > 0 && !equalJSON(d.t, body, want.Body) {
+		d.t.Fatalf("unexpected body on request %d: got %s, want %s", d.next, body, want.Body)
+	}
+
+	header := http.Header{}
+	for name, value := range interaction.Response.Headers {
+		header.Set(name, value)
+	}
+
+	return &http.Response{
+		StatusCode: interaction.Response.Status,
+		Header:     header,
+		Body:       io.NopCloser(bytes.NewReader(interaction.Response.Body)),
+		Request:    req,
+	}, nil
+}
+
+func (d *replayDoer) assertConsumed() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.next != len(d.interactions) {
+		d.t.Errorf("fixture interactions not consumed: used %d of %d", d.next, len(d.interactions))
+	}
+}
+
+// recordingDoer forwards requests to another doer and records the interactions so they
+// can be written as a fixture for replayDoer.
+type recordingDoer struct {
+	next         httpDoer
+	mu           sync.Mutex
+	interactions []httpInteraction
+}
+
+func (d *recordingDoer) Do(req *http.Request) (*http.Response, error) {
+	recorded := fixtureRequest{Method: req.Method, Path: req.URL.Path}
+	if req.Body != nil {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		recorded.Body = body
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	resp, err := d.next.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	response := fixtureResponse{Status: resp.StatusCode}
+	if json.Valid(body) {
+		response.Body = body
+	}
+
+	d.mu.Lock()
+	d.interactions = append(d.interactions, httpInteraction{Request: recorded, Response: response})
+	d.mu.Unlock()
+
+	return resp, nil
+}
+
+func (d *recordingDoer) writeFixture(t *testing.T, path string) {
+	t.Helper()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	data, err := json.MarshalIndent(httpFixture{Interactions: d.interactions}, "", "  ")
+	if err != nil {
+		t.Fatalf("unable to encode fixture: %v", err)
+	}
+
+	if err := os.WriteFile(path, append(data, '\n'), 0o600); err != nil {
+		t.Fatalf("unable to write fixture: %v", err)
+	}
+}
+
+func equalJSON(t *testing.T, got, want []byte) bool {
+	t.Helper()
+
+	var gotValue, wantValue any
+	if err := json.Unmarshal(got, &gotValue); err != nil {
+		return false
+	}
+	if err := json.Unmarshal(want, &wantValue); err != nil {
+		t.Fatalf("invalid fixture JSON: %v", err)
+	}
+
+	return reflect.DeepEqual(gotValue, wantValue)
+}
+
+func TestRecordingDoerFixtureReplays(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"service_account":"sa-test","sub_ids":["sub-1"]}`))
+	}))
+	defer server.Close()
+
+	recorder := &recordingDoer{next: server.Client()}
+	client := NewClient(server.URL, "test-token", recorder)
+	if _, err := client.GetServiceAccount(context.Background()); err != nil {
+		t.Fatalf("unexpected record error: %v", err)
+	}
+
+	dir := t.TempDir()
+	recorder.writeFixture(t, filepath.Join(dir, "service_account.json"))
+
+	replay := &replayDoer{t: t, interactions: loadHTTPFixtureFile(t, filepath.Join(dir, "service_account.json")).Interactions}
+	t.Cleanup(replay.assertConsumed)
+
+	account, err := NewClient("https://costory.test", "test-token", replay).GetServiceAccount(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected replay error: %v", err)
+	}
+	if account.ServiceAccount != "sa-test" {
+		t.Fatalf("unexpected service account: got %q, want %q", account.ServiceAccount, "sa-test")
+	}
+}
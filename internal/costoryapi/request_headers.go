@@ -0,0 +1,66 @@
+package costoryapi
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+type requestHeadersContextKey struct{}
+
+// reservedRequestHeaders are set by the client itself and cannot be overridden by extra
+// headers, so a misconfigured header cannot replace credentials or tenant routing, nor make a
+// request retryable with a static idempotency key.
+var reservedRequestHeaders = map[string]struct{}{
+	"Accept":               {},
+	"Authorization":        {},
+	"Content-Type":         {},
+	headerCostorySlug:      {},
+	headerCostoryWorkspace: {},
+	headerIdempotencyKey:   {},
+	headerTerraformRunID:   {},
+}
+
+// IsReservedRequestHeader reports whether name is a header the client sets itself.
+func IsReservedRequestHeader(name string) bool {
+	_, ok := reservedRequestHeaders[http.CanonicalHeaderKey(strings.TrimSpace(name))]
+	return ok
+}
+
+// WithRequestHeaders returns a context whose API calls also send headers. Headers already on
+// ctx are kept unless headers sets the same name, and reserved headers are ignored.
+func WithRequestHeaders(ctx context.Context, headers map[string]string) context.Context {
+	if len(headers) == 0 {
+		return ctx
+	}
+
+	merged := make(map[string]string)
+	for name, value := range requestHeadersFromContext(ctx) {
+		merged[name] = value
+	}
+	for name, value := range headers {
+		if IsReservedRequestHeader(name) {
+			continue
+		}
+		merged[http.CanonicalHeaderKey(strings.TrimSpace(name))] = value
+	}
+
+	return context.WithValue(ctx, requestHeadersContextKey{}, merged)
+}
+
+// withRequestHeader returns a context whose API calls also send the header name, even when it
+// is reserved, for the headers the client sets from its own options.
+func withRequestHeader(ctx context.Context, name, value string) context.Context {
+	merged := make(map[string]string)
+	for existing, v := range requestHeadersFromContext(ctx) {
+		merged[existing] = v
+	}
+	merged[http.CanonicalHeaderKey(name)] = value
+
+	return context.WithValue(ctx, requestHeadersContextKey{}, merged)
+}
+
+func requestHeadersFromContext(ctx context.Context) map[string]string {
+	headers, _ := ctx.Value(requestHeadersContextKey{}).(map[string]string)
+	return headers
+}
@@ -0,0 +1,54 @@
+package costoryapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientSendsContextRequestHeaders(t *testing.T) {
+	t.Parallel()
+
+	requests := make(chan http.Header, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests <- r.Header.Clone()
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"service_account":"sa-test","sub_ids":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", server.Client(), WithSlug("acme"))
+
+	ctx := WithRequestHeaders(context.Background(), map[string]string{"x-gateway-route": "base", "X-Team": "finops"})
+	ctx = WithRequestHeaders(ctx, map[string]string{
+		"X-Gateway-Route": "billing",
+		"authorization":   "Bearer stolen",
+		"X-Costory-Slug":  "other",
+		"Idempotency-Key": "static",
+	})
+	if _, err := client.GetServiceAccount(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	header := <-requests
+	tests := map[string]string{
+		"X-Gateway-Route": "billing",
+		"X-Team":          "finops",
+		"Authorization":   "Bearer test-token",
+		"X-Costory-Slug":  "acme",
+		"Idempotency-Key": "",
+	}
+	for name, want := range tests {
+		if got := header.Get(name); got != want {
+			t.Fatalf("unexpected %s header: got %q, want %q", name, got, want)
+		}
+	}
+
+	if _, err := client.GetServiceAccount(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := (<-requests).Get("X-Gateway-Route"); got != "" {
+		t.Fatalf("expected request headers to stay scoped to their context, got %q", got)
+	}
+}
@@ -0,0 +1,42 @@
+package costoryapi
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// BillingDatasourceRequestJSON returns the indented JSON body that creating req would send,
+// after datasource type overrides are applied. It contains no credentials beyond what req
+// carries; callers showing it to users are responsible for redacting secret fields.
+func (c *Client) BillingDatasourceRequestJSON(req BillingDatasourceCreateRequest) ([]byte, error) {
+	body, err := json.MarshalIndent(req.apiRequest(c.datasourceType(req.billingDatasourceType())), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("encode request body: %w", err)
+	}
+
+	return body, nil
+}
+
+func (r GCPBillingDatasourceRequest) apiRequest(datasourceType string) any {
+	return r.toAPIRequest(datasourceType)
+}
+
+func (r AWSBillingDatasourceRequest) apiRequest(datasourceType string) any {
+	return r.toAPIRequest(datasourceType)
+}
+
+func (r CursorBillingDatasourceRequest) apiRequest(datasourceType string) any {
+	return r.toAPIRequest(datasourceType)
+}
+
+func (r AnthropicBillingDatasourceRequest) apiRequest(datasourceType string) any {
+	return r.toAPIRequest(datasourceType)
+}
+
+func (r ElasticCloudBillingDatasourceRequest) apiRequest(datasourceType string) any {
+	return r.toAPIRequest(datasourceType)
+}
+
+func (r AzureBillingDatasourceRequest) apiRequest(datasourceType string) any {
+	return r.toAPIRequest(datasourceType)
+}
@@ -0,0 +1,36 @@
+package costoryapi
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestClientBillingDatasourceRequestJSON(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient("https://example.invalid", "test-token", nil, WithDatasourceTypeOverrides(map[string]string{"GCP": "gcp"}))
+
+	body, err := client.BillingDatasourceRequestJSON(GCPBillingDatasourceRequest{
+		Name:  "GCP Billing",
+		BQURI: "project.dataset.table",
+		Tags:  map[string]string{"team": "finops"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var payload map[string]any
+	if err := json.Unmarshal(body, &payload); err != nil {
+		t.Fatalf("unable to decode preview: %v", err)
+	}
+
+	if got := payload["type"]; got != "gcp" {
+		t.Fatalf("unexpected type: got %v, want %q", got, "gcp")
+	}
+	if got := payload["bqTablePath"]; got != "project.dataset.table" {
+		t.Fatalf("unexpected bqTablePath: got %v", got)
+	}
+	if got, ok := payload["tags"].(map[string]any); !ok || got["team"] != "finops" {
+		t.Fatalf("unexpected tags: got %v", payload["tags"])
+	}
+}
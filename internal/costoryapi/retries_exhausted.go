@@ -0,0 +1,43 @@
+package costoryapi
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxExhaustedBodyBytes bounds how much of the last response body is kept in a
+// RetriesExhaustedError so a large HTML error page does not flood diagnostics.
+const maxExhaustedBodyBytes = 512
+
+// RetriesExhaustedError reports that every attempt at a request got a retryable status. It
+// keeps the last status and body so users can see what kept failing.
+type RetriesExhaustedError struct {
+	Attempts   int
+	StatusCode int
+	Body       string
+}
+
+func (e *RetriesExhaustedError) Error() string {
+	message := e.Body
+	if message == "" {
+		message = http.StatusText(e.StatusCode)
+	}
+
+	return fmt.Sprintf("request retries exhausted after %d attempts: last status code %d: %s", e.Attempts, e.StatusCode, message)
+}
+
+func newRetriesExhaustedError(attempts, statusCode int, body []byte) *RetriesExhaustedError {
+	message := strings.TrimSpace(string(body))
+	if len(message) > maxExhaustedBodyBytes {
+		// Cut on a rune boundary so a multi-byte character is not split into invalid UTF-8.
+		cut := maxExhaustedBodyBytes
+		for cut > 0 && !utf8.RuneStart(message[cut]) {
+			cut--
+		}
+		message = message[:cut] + "...(truncated)"
+	}
+
+	return &RetriesExhaustedError{Attempts: attempts, StatusCode: statusCode, Body: message}
+}
@@ -0,0 +1,87 @@
+package costoryapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestClientRetriesExhaustedReportsLastStatus(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte("upstream unavailable " + strings.Repeat("x", 2*maxExhaustedBodyBytes)))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", server.Client())
+
+	_, err := client.GetServiceAccount(context.Background())
+
+	var exhausted *RetriesExhaustedError
+	if !errors.As(err, &exhausted) {
+		t.Fatalf("expected RetriesExhaustedError, got %v", err)
+	}
+	if got, want := exhausted.StatusCode, http.StatusServiceUnavailable; got != want {
+		t.Fatalf("unexpected status code: got %d, want %d", got, want)
+	}
+	if got, want := exhausted.Attempts, maxRetryAttempts; got != want {
+		t.Fatalf("unexpected attempts: got %d, want %d", got, want)
+	}
+	if got, want := calls.Load(), int32(maxRetryAttempts); got != want {
+		t.Fatalf("unexpected request count: got %d, want %d", got, want)
+	}
+	if !strings.Contains(err.Error(), "503") || !strings.Contains(err.Error(), "after 4 attempts") {
+		t.Fatalf("expected error to report status and attempts, got %q", err)
+	}
+	if !strings.HasPrefix(exhausted.Body, "upstream unavailable") || !strings.HasSuffix(exhausted.Body, "...(truncated)") {
+		t.Fatalf("expected truncated body, got %q", exhausted.Body)
+	}
+}
+
+func TestNewRetriesExhaustedErrorTruncatesOnRuneBoundary(t *testing.T) {
+	t.Parallel()
+
+	// The three-byte "€" straddles the byte limit.
+	body := strings.Repeat("x", maxExhaustedBodyBytes-1) + strings.Repeat("€", 4)
+
+	exhausted := newRetriesExhaustedError(maxRetryAttempts, http.StatusBadGateway, []byte(body))
+
+	if !utf8.ValidString(exhausted.Body) {
+		t.Fatalf("expected valid UTF-8, got %q", exhausted.Body)
+	}
+	if want := strings.Repeat("x", maxExhaustedBodyBytes-1) + "...(truncated)"; exhausted.Body != want {
+		t.Fatalf("unexpected body: got %q, want %q", exhausted.Body, want)
+	}
+}
+
+func TestClientRetriesCanceledContextIsNotExhaustion(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		cancel()
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", server.Client())
+
+	_, err := client.GetServiceAccount(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context cancellation, got %v", err)
+	}
+
+	var exhausted *RetriesExhaustedError
+	if errors.As(err, &exhausted) {
+		t.Fatalf("expected cancellation to be reported separately, got %v", err)
+	}
+}
@@ -0,0 +1,78 @@
+package costoryapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	headerRetryAfter = "Retry-After"
+
+	// maintenanceRetryThreshold is the retry delay above which a wait is reported as likely
+	// maintenance, so a long Retry-After does not look like the provider has hung.
+	maintenanceRetryThreshold = 60 * time.Second
+
+	// maxRetryAfter caps the delay honored from Retry-After, so a misconfigured server or proxy
+	// cannot stall an operation that has no timeout for hours.
+	maxRetryAfter = 10 * time.Minute
+)
+
+// retryDelay returns how long to wait before replaying a request. The exponential backoff is
+// extended to the server's Retry-After when that is longer, up to maxRetryAfter, and long
+// waits are reported.
+func (c *Client) retryDelay(ctx context.Context, method, path string, statusCode int, header http.Header, attempt int) time.Duration {
+	delay := time.Duration(1<<attempt) * 500 * time.Millisecond
+	if retryAfter, ok := parseRetryAfter(header.Get(headerRetryAfter), time.Now()); ok && retryAfter > delay {
+		delay = min(retryAfter, maxRetryAfter)
+	}
+
+	if delay > maintenanceRetryThreshold {
+		tflog.Warn(ctx, "Costory API asked the provider to wait before retrying; the service is likely in maintenance", map[string]any{
+			"method":      method,
+			"path":        path,
+			"status_code": statusCode,
+			"retry_in":    delay.String(),
+		})
+
+		outcome := "The operation will wait before retrying."
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			outcome = "That exceeds the remaining operation time, so the operation fails instead of waiting. Retry once the maintenance is over."
+		}
+		c.recordOperationWarning(ctx,
+			"Costory API is likely in maintenance",
+			fmt.Sprintf("%s %s returned status %d and asked the provider to retry in %s. %s", method, path, statusCode, delay, outcome),
+		)
+	}
+
+	return delay
+}
+
+// parseRetryAfter reads a Retry-After header given either as delay seconds or as an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	at, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	if delay := at.Sub(now); delay > 0 {
+		return delay, true
+	}
+	return 0, true
+}
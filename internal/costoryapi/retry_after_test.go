@@ -0,0 +1,120 @@
+package costoryapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, time.January, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := map[string]struct {
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		"empty":        {value: ""},
+		"seconds":      {value: "600", want: 10 * time.Minute, wantOK: true},
+		"negative":     {value: "-5"},
+		"http date":    {value: now.Add(2 * time.Minute).Format(http.TimeFormat), want: 2 * time.Minute, wantOK: true},
+		"past date":    {value: now.Add(-time.Minute).Format(http.TimeFormat), want: 0, wantOK: true},
+		"unparseable":  {value: "soon"},
+		"padded value": {value: " 30 ", want: 30 * time.Second, wantOK: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := parseRetryAfter(tc.value, now)
+			if ok != tc.wantOK || got != tc.want {
+				t.Fatalf("unexpected delay: got %s (%t), want %s (%t)", got, ok, tc.want, tc.wantOK)
+			}
+		})
+	}
+}
+
+func TestClientLongRetryAfterWarnsAndRespectsTimeout(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.Header().Set(headerRetryAfter, "600")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", server.Client())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.GetServiceAccount(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "retry in 10m0s") {
+		t.Fatalf("expected error to report the requested delay, got %q", err.Error())
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("expected the provider to fail fast rather than sleep, took %s", elapsed)
+	}
+	if got, want := calls.Load(), int32(1); got != want {
+		t.Fatalf("unexpected request count: got %d, want %d", got, want)
+	}
+
+	warnings := client.Warnings()
+	if len(warnings) != 1 {
+		t.Fatalf("unexpected warnings: got %d, want 1: %#v", len(warnings), warnings)
+	}
+	if got, want := warnings[0].Summary, "Costory API is likely in maintenance"; got != want {
+		t.Fatalf("unexpected warning summary: got %q, want %q", got, want)
+	}
+	if !strings.Contains(warnings[0].Detail, "retry in 10m0s") || !strings.Contains(warnings[0].Detail, "fails instead of waiting") {
+		t.Fatalf("expected warning detail to report the requested delay and the failure, got %q", warnings[0].Detail)
+	}
+}
+
+func TestRetryDelayCapsRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient("https://example.invalid", "test-token", nil)
+	header := http.Header{headerRetryAfter: []string{"86400"}}
+
+	if got := client.retryDelay(context.Background(), http.MethodGet, "/terraform/teams", http.StatusServiceUnavailable, header, 0); got != maxRetryAfter {
+		t.Fatalf("unexpected delay: got %s, want %s", got, maxRetryAfter)
+	}
+}
+
+func TestRetryDelayReportsMaintenanceToOperation(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient("https://example.invalid", "test-token", nil)
+	header := http.Header{headerRetryAfter: []string{"120"}}
+
+	ctx, cancel := client.OperationContext(context.Background())
+	defer cancel()
+
+	client.retryDelay(ctx, http.MethodGet, "/terraform/teams", http.StatusServiceUnavailable, header, 0)
+
+	warnings := OperationWarnings(ctx)
+	if len(warnings) != 1 {
+		t.Fatalf("unexpected operation warnings: got %d, want 1: %#v", len(warnings), warnings)
+	}
+	if !strings.Contains(warnings[0].Detail, "will wait before retrying") {
+		t.Fatalf("expected the warning to report the wait, got %q", warnings[0].Detail)
+	}
+	if got := OperationWarnings(context.Background()); got != nil {
+		t.Fatalf("expected no warnings outside an operation, got %#v", got)
+	}
+}
@@ -0,0 +1,66 @@
+package costoryapi
+
+import (
+	"context"
+	"slices"
+	"sync"
+)
+
+// Retry is a request that got a retryable status and was sent again.
+type Retry struct {
+	Method     string
+	Path       string
+	StatusCode int
+}
+
+type retryHistoryKey struct{}
+
+// retryHistory collects the retries of one operation. Requests of an operation may run
+// concurrently, so it is guarded by a mutex.
+type retryHistory struct {
+	mu      sync.Mutex
+	retries []Retry
+}
+
+// WithVerboseRetries makes OperationContext collect the retries of each operation, so
+// resources can report an operation that only succeeded after retrying.
+func WithVerboseRetries(verbose bool) Option {
+	return func(c *Client) {
+		c.verboseRetries = verbose
+	}
+}
+
+// VerboseRetries reports whether the client was configured with WithVerboseRetries.
+func (c *Client) VerboseRetries() bool {
+	return c.verboseRetries
+}
+
+// Retries returns the retries made so far by the operation ctx was derived for, oldest first.
+// It returns nil when the context does not collect retries.
+func Retries(ctx context.Context) []Retry {
+	history, ok := ctx.Value(retryHistoryKey{}).(*retryHistory)
+	if !ok {
+		return nil
+	}
+
+	history.mu.Lock()
+	defer history.mu.Unlock()
+
+	return slices.Clone(history.retries)
+}
+
+func withRetryHistory(ctx context.Context) context.Context {
+	return context.WithValue(ctx, retryHistoryKey{}, &retryHistory{})
+}
+
+func recordRetry(ctx context.Context, retry Retry) {
+	history, ok := ctx.Value(retryHistoryKey{}).(*retryHistory)
+	if !ok {
+		return
+	}
+
+	history.mu.Lock()
+	defer history.mu.Unlock()
+
+	history.retries = append(history.retries, retry)
+}
@@ -0,0 +1,66 @@
+package costoryapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestRetriesRecordedByOperationContext(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		options     []Option
+		wantRetries []Retry
+	}{
+		"verbose": {
+			options: []Option{WithVerboseRetries(true)},
+			wantRetries: []Retry{
+				{Method: http.MethodGet, Path: "/terraform/teams", StatusCode: http.StatusServiceUnavailable},
+				{Method: http.MethodGet, Path: "/terraform/teams", StatusCode: http.StatusBadGateway},
+			},
+		},
+		"default": {},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var attempts atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				switch attempts.Add(1) {
+				case 1:
+					w.WriteHeader(http.StatusServiceUnavailable)
+				case 2:
+					w.WriteHeader(http.StatusBadGateway)
+				default:
+					w.WriteHeader(http.StatusOK)
+					_, _ = w.Write([]byte(`{}`))
+				}
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL, "test-token", server.Client(), tc.options...)
+
+			ctx, cancel := client.OperationContext(context.Background())
+			defer cancel()
+
+			if _, _, err := client.doJSON(ctx, http.MethodGet, "/terraform/teams", nil); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			got := Retries(ctx)
+			if len(got) != len(tc.wantRetries) {
+				t.Fatalf("unexpected retries: got %#v, want %#v", got, tc.wantRetries)
+			}
+			for i := range got {
+				if got[i] != tc.wantRetries[i] {
+					t.Fatalf("unexpected retry %d: got %#v, want %#v", i, got[i], tc.wantRetries[i])
+				}
+			}
+		})
+	}
+}
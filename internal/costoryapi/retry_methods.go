@@ -0,0 +1,87 @@
+package costoryapi
+
+import (
+	"context"
+	"crypto/rand"
+	"net/http"
+	"strings"
+)
+
+const headerIdempotencyKey = "Idempotency-Key"
+
+// defaultRetryableMethods are the idempotent methods retried on a transient failure without
+// an idempotency key.
+var defaultRetryableMethods = map[string]struct{}{
+	http.MethodGet:     {},
+	http.MethodHead:    {},
+	http.MethodOptions: {},
+	http.MethodPut:     {},
+	http.MethodDelete:  {},
+}
+
+// WithRetryableMethods replaces the HTTP methods retried after a transient failure, which are
+// GET, HEAD, OPTIONS, PUT and DELETE by default. Other methods, such as POST creates, may
+// already have been applied when the server fails, so they are only retried when the request
+// carries an idempotency key (see WithIdempotencyKey) or its endpoint is read-only, like
+// validation. Only responses saying the request was not processed (408, 425 and 429) are
+// retried for every method.
+func WithRetryableMethods(methods ...string) Option {
+	return func(c *Client) {
+		c.retryableMethods = make(map[string]struct{}, len(methods))
+		for _, method := range methods {
+			c.retryableMethods[strings.ToUpper(strings.TrimSpace(method))] = struct{}{}
+		}
+	}
+}
+
+// WithIdempotencyKey returns a context whose API calls send key as the Idempotency-Key
+// header, which lets the client retry a non-idempotent request after a 5xx response.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	if strings.TrimSpace(key) == "" {
+		return ctx
+	}
+
+	return withRequestHeader(ctx, headerIdempotencyKey, key)
+}
+
+// NewIdempotencyKey returns a random idempotency key for one create operation. Every retry
+// of the operation sends the same key, while a later create with an identical request, for
+// example after a replacement, gets a new one and is not deduplicated against the first.
+func NewIdempotencyKey() string {
+	return rand.Text()
+}
+
+type readOnlyRequestContextKey struct{}
+
+// withReadOnlyRequest marks requests sent with ctx as changing nothing on the server.
+func withReadOnlyRequest(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readOnlyRequestContextKey{}, true)
+}
+
+// unprocessedStatuses are the responses saying the server did not process the request, so
+// it is safe to send again whatever its method.
+var unprocessedStatuses = map[int]struct{}{
+	http.StatusRequestTimeout:  {},
+	http.StatusTooEarly:        {},
+	http.StatusTooManyRequests: {},
+}
+
+// mayRetry reports whether req may be sent again after a retryable statusCode.
+func (c *Client) mayRetry(req *http.Request, statusCode int) bool {
+	if _, ok := unprocessedStatuses[statusCode]; ok {
+		return true
+	}
+
+	methods := c.retryableMethods
+	if methods == nil {
+		methods = defaultRetryableMethods
+	}
+	if _, ok := methods[req.Method]; ok {
+		return true
+	}
+	if readOnly, _ := req.Context().Value(readOnlyRequestContextKey{}).(bool); readOnly {
+		return true
+	}
+
+	return req.Header.Get(headerIdempotencyKey) != ""
+}
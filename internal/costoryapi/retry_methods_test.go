@@ -0,0 +1,139 @@
+package costoryapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestRetryPolicyByMethod(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		method         string
+		firstStatus    int
+		idempotencyKey string
+		readOnly       bool
+		options        []Option
+		wantAttempts   int32
+	}{
+		"get 503 is retried":               {method: http.MethodGet, firstStatus: http.StatusServiceUnavailable, wantAttempts: 2},
+		"delete 503 is retried":            {method: http.MethodDelete, firstStatus: http.StatusServiceUnavailable, wantAttempts: 2},
+		"keyless post 503 is not retried":  {method: http.MethodPost, firstStatus: http.StatusServiceUnavailable, wantAttempts: 1},
+		"keyless patch 503 is not retried": {method: http.MethodPatch, firstStatus: http.StatusServiceUnavailable, wantAttempts: 1},
+		"keyed post 503 is retried":        {method: http.MethodPost, firstStatus: http.StatusServiceUnavailable, idempotencyKey: "create-1", wantAttempts: 2},
+		"keyless post 429 is retried":      {method: http.MethodPost, firstStatus: http.StatusTooManyRequests, wantAttempts: 2},
+		"keyless post custom 409 is not retried": {
+			method: http.MethodPost, firstStatus: http.StatusConflict,
+			options: []Option{WithRetryableStatusCodes(http.StatusConflict)}, wantAttempts: 1,
+		},
+		"keyed post custom 409 is retried": {
+			method: http.MethodPost, firstStatus: http.StatusConflict, idempotencyKey: "create-1",
+			options: []Option{WithRetryableStatusCodes(http.StatusConflict)}, wantAttempts: 2,
+		},
+		"get custom 409 is retried": {
+			method: http.MethodGet, firstStatus: http.StatusConflict,
+			options: []Option{WithRetryableStatusCodes(http.StatusConflict)}, wantAttempts: 2,
+		},
+		"post allowed by option":             {method: http.MethodPost, firstStatus: http.StatusServiceUnavailable, options: []Option{WithRetryableMethods("post")}, wantAttempts: 2},
+		"get excluded by option":             {method: http.MethodGet, firstStatus: http.StatusServiceUnavailable, options: []Option{WithRetryableMethods(http.MethodPut)}, wantAttempts: 1},
+		"keyed post retried without methods": {method: http.MethodPost, firstStatus: http.StatusBadGateway, idempotencyKey: "create-1", options: []Option{WithRetryableMethods()}, wantAttempts: 2},
+		"read-only post 503 is retried":      {method: http.MethodPost, firstStatus: http.StatusServiceUnavailable, readOnly: true, wantAttempts: 2},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var attempts atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if got := r.Header.Get(headerIdempotencyKey); got != tc.idempotencyKey {
+					t.Errorf("unexpected idempotency key: got %q, want %q", got, tc.idempotencyKey)
+				}
+				if attempts.Add(1) == 1 {
+					w.WriteHeader(tc.firstStatus)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte(`{}`))
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL, "test-token", server.Client(), tc.options...)
+			ctx := WithIdempotencyKey(context.Background(), tc.idempotencyKey)
+			if tc.readOnly {
+				ctx = withReadOnlyRequest(ctx)
+			}
+
+			_, statusCode, err := client.doJSON(ctx, tc.method, "/terraform/teams", nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got := attempts.Load(); got != tc.wantAttempts {
+				t.Fatalf("unexpected attempts: got %d, want %d", got, tc.wantAttempts)
+			}
+			wantStatus := http.StatusOK
+			if tc.wantAttempts == 1 {
+				wantStatus = tc.firstStatus
+			}
+			if statusCode != wantStatus {
+				t.Fatalf("unexpected status code: got %d, want %d", statusCode, wantStatus)
+			}
+		})
+	}
+}
+
+func TestReadOnlyEndpointsAreRetried(t *testing.T) {
+	t.Parallel()
+
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if attempts.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"statuses":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", server.Client())
+
+	if _, err := client.GetBillingDatasourceStatuses(context.Background(), []string{"ds-1"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := attempts.Load(); got != 2 {
+		t.Fatalf("unexpected attempts: got %d, want 2", got)
+	}
+}
+
+func TestCreateBillingDatasourcesBatchSendsIdempotencyKeys(t *testing.T) {
+	t.Parallel()
+
+	keys := make(chan string, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys <- r.Header.Get(headerIdempotencyKey)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":"gcp-ds-1","type":"GCP"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", server.Client())
+	req := GCPBillingDatasourceRequest{Name: "GCP Billing", BQURI: "project.dataset.table"}
+
+	for _, result := range client.CreateBillingDatasourcesBatch(context.Background(), []BillingDatasourceCreateRequest{req, req}) {
+		if result.Err != nil {
+			t.Fatalf("unexpected create error: %v", result.Err)
+		}
+	}
+
+	first, second := <-keys, <-keys
+	if first == "" || second == "" {
+		t.Fatalf("expected every create to carry an idempotency key, got %q and %q", first, second)
+	}
+	if first == second {
+		t.Fatalf("expected identical creates to use distinct idempotency keys, got %q twice", first)
+	}
+}
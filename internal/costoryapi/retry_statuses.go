@@ -0,0 +1,37 @@
+package costoryapi
+
+import "net/http"
+
+// WithRetryableStatusCodes replaces the response statuses treated as transient, which are
+// 408, 425, 429 and every 5xx by default, for deployments behind gateways with their own
+// conventions. Codes outside the 4xx and 5xx ranges are ignored, and an empty list leaves the
+// default in place. Retrying any status but 408, 425 and 429 still depends on the request
+// method (see WithRetryableMethods).
+func WithRetryableStatusCodes(codes ...int) Option {
+	return func(c *Client) {
+		statuses := make(map[int]struct{}, len(codes))
+		for _, code := range codes {
+			if isErrorStatus(code) {
+				statuses[code] = struct{}{}
+			}
+		}
+		if len(statuses) > 0 {
+			c.retryableStatuses = statuses
+		}
+	}
+}
+
+// retryableStatus reports whether statusCode is transient for this client.
+func (c *Client) retryableStatus(statusCode int) bool {
+	if c.retryableStatuses == nil {
+		return isRetryableStatus(statusCode)
+	}
+
+	_, ok := c.retryableStatuses[statusCode]
+	return ok
+}
+
+// isErrorStatus reports whether statusCode is a 4xx or 5xx status.
+func isErrorStatus(statusCode int) bool {
+	return statusCode >= http.StatusBadRequest && statusCode <= 599
+}
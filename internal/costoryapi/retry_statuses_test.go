@@ -0,0 +1,59 @@
+package costoryapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestRetryableStatusCodes(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		firstStatus  int
+		options      []Option
+		wantAttempts int32
+	}{
+		"418 not retried by default":     {firstStatus: http.StatusTeapot, wantAttempts: 1},
+		"418 retried when configured":    {firstStatus: http.StatusTeapot, options: []Option{WithRetryableStatusCodes(http.StatusTeapot)}, wantAttempts: 2},
+		"503 not retried when replaced":  {firstStatus: http.StatusServiceUnavailable, options: []Option{WithRetryableStatusCodes(http.StatusTeapot)}, wantAttempts: 1},
+		"out of range codes are ignored": {firstStatus: http.StatusServiceUnavailable, options: []Option{WithRetryableStatusCodes(200, 302, 600)}, wantAttempts: 2},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var attempts atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				if attempts.Add(1) == 1 {
+					w.WriteHeader(tc.firstStatus)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte(`{}`))
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL, "test-token", server.Client(), tc.options...)
+
+			_, statusCode, err := client.doJSON(context.Background(), http.MethodGet, "/terraform/teams", nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got := attempts.Load(); got != tc.wantAttempts {
+				t.Fatalf("unexpected attempts: got %d, want %d", got, tc.wantAttempts)
+			}
+			wantStatus := http.StatusOK
+			if tc.wantAttempts == 1 {
+				wantStatus = tc.firstStatus
+			}
+			if statusCode != wantStatus {
+				t.Fatalf("unexpected status code: got %d, want %d", statusCode, wantStatus)
+			}
+		})
+	}
+}
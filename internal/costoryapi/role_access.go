@@ -0,0 +1,56 @@
+package costoryapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// AWSRoleAccess is the result of checking whether an IAM role's account is permitted for the
+// tenant. Checked is false when the deployment predates the role access endpoint, in which
+// case Allowed carries no information.
+type AWSRoleAccess struct {
+	Checked   bool
+	Allowed   bool
+	AccountID string
+	Reason    string
+}
+
+type awsRoleAccessAPIRequest struct {
+	RoleARN string `json:"roleArn"`
+}
+
+type awsRoleAccessAPIResponse struct {
+	Allowed   bool   `json:"allowed"`
+	AccountID string `json:"accountId"`
+	Reason    string `json:"reason"`
+}
+
+// CheckAWSRoleAccess asks Costory whether the AWS account owning roleARN is permitted for the
+// tenant. A 404 from older backends is reported as an unchecked result rather than an error.
+func (c *Client) CheckAWSRoleAccess(ctx context.Context, roleARN string) (*AWSRoleAccess, error) {
+	body, statusCode, err := doEndpoint(ctx, c, endpointCheckAWSRoleAccess, awsRoleAccessAPIRequest{RoleARN: roleARN})
+	if err != nil {
+		return nil, err
+	}
+
+	if statusCode == http.StatusNotFound {
+		return &AWSRoleAccess{}, nil
+	}
+
+	if statusCode != http.StatusOK {
+		return nil, unexpectedStatusError(endpointCheckAWSRoleAccess.Name, statusCode, body)
+	}
+
+	var out awsRoleAccessAPIResponse
+	if err := c.decodeResponse(body, &out); err != nil {
+		return nil, fmt.Errorf("decode response body: %w", err)
+	}
+
+	return &AWSRoleAccess{
+		Checked:   true,
+		Allowed:   out.Allowed,
+		AccountID: out.AccountID,
+		Reason:    out.Reason,
+	}, nil
+}
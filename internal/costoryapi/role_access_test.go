@@ -0,0 +1,70 @@
+package costoryapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientCheckAWSRoleAccess(t *testing.T) {
+	t.Parallel()
+
+	const roleARN = "arn:aws:iam::123456789012:role/costory"
+
+	tests := map[string]struct {
+		statusCode  int
+		body        string
+		wantChecked bool
+		wantAllowed bool
+		wantAccount string
+		wantError   bool
+	}{
+		"allowed":         {statusCode: http.StatusOK, body: `{"allowed":true,"accountId":"123456789012"}`, wantChecked: true, wantAllowed: true, wantAccount: "123456789012"},
+		"disallowed":      {statusCode: http.StatusOK, body: `{"allowed":false,"accountId":"123456789012","reason":"account not linked"}`, wantChecked: true, wantAccount: "123456789012"},
+		"endpoint absent": {statusCode: http.StatusNotFound},
+		"server error":    {statusCode: http.StatusInternalServerError, body: `{"error":"boom"}`, wantError: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.URL.Path != routeAWSRoleAccess {
+					t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+				}
+
+				var payload awsRoleAccessAPIRequest
+				if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+					t.Fatalf("unable to decode request body: %v", err)
+				}
+				if payload.RoleARN != roleARN {
+					t.Fatalf("unexpected role ARN: got %q, want %q", payload.RoleARN, roleARN)
+				}
+
+				w.WriteHeader(tc.statusCode)
+				_, _ = w.Write([]byte(tc.body))
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL, "test-token", server.Client())
+
+			access, err := client.CheckAWSRoleAccess(context.Background(), roleARN)
+			if tc.wantError {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if access.Checked != tc.wantChecked || access.Allowed != tc.wantAllowed || access.AccountID != tc.wantAccount {
+				t.Fatalf("unexpected role access: %#v", access)
+			}
+		})
+	}
+}
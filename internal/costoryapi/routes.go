@@ -5,13 +5,22 @@ import (
 	"net/url"
 )
 
+// routePrefix is the path every route is mounted under, unless WithAPIPathPrefix replaces it.
+const routePrefix = "/terraform"
+
 const (
-	routeServiceAccount            = "/terraform/"
-	routeBillingDatasourceBase     = "/terraform/billingDatasources"
-	routeBillingDatasourceValidate = "/terraform/billingDatasources/validate"
-	routeMetricsDatasourceBase     = "/terraform/metricsDatasources"
-	routeMetricsDatasourceValidate = "/terraform/metricsDatasources/validate"
-	routeTeamsBase                 = "/terraform/teams"
+	routeServiceAccount            = routePrefix + "/"
+	routeBillingDatasourceBase     = routePrefix + "/billingDatasources"
+	routeBillingDatasourceValidate = routePrefix + "/billingDatasources/validate"
+	routeAWSRoleAccess             = routePrefix + "/billingDatasources/aws/roleAccess"
+	routeBillingDatasourceStatuses = routePrefix + "/billingDatasources/statuses"
+	routeMetricsDatasourceBase     = routePrefix + "/metricsDatasources"
+	routeMetricsDatasourceValidate = routePrefix + "/metricsDatasources/validate"
+	routeTeamsBase                 = routePrefix + "/teams"
+	routeAllocationRulesBase       = routePrefix + "/allocationRules"
+	routeCapabilities              = routePrefix + "/capabilities"
+	routeTokenScopes               = routePrefix + "/token/scopes"
+	routeAPITokensBase             = routePrefix + "/tokens"
 )
 
 type requestTransport string
@@ -25,10 +34,20 @@ const (
 type noRequest struct{}
 type noResponse struct{}
 
+type serviceAccountPageRouteParams struct {
+	Cursor string
+}
+
 type billingDatasourceByIDRouteParams struct {
 	ID string
 }
 
+type billingDatasourceSummaryRouteParams struct {
+	ID    string
+	Start string
+	End   string
+}
+
 type metricsDatasourceByIDRouteParams struct {
 	ID string
 }
@@ -42,13 +61,28 @@ type teamMemberRouteParams struct {
 	UserID string
 }
 
+type allocationRuleByIDRouteParams struct {
+	ID string
+}
+
+type apiTokenByIDRouteParams struct {
+	ID string
+}
+
 type endpointContract[Req any, Resp any] struct {
+	// Name is the logical operation, for example "create team", used to prefix errors.
+	Name             string
 	Method           string
 	Path             string
 	RequestTransport requestTransport
+	// ReadOnly marks a POST that changes nothing on the server, such as a validation, so it
+	// is retried after a 5xx response like an idempotent method.
+	ReadOnly bool
 }
 
 type endpointWithRouteParamsContract[Params any, Req any, Resp any] struct {
+	// Name is the logical operation, for example "get team", used to prefix errors.
+	Name                 string
 	Method               string
 	Path                 func(params Params) string
 	ParamsTransport      requestTransport
@@ -56,84 +90,166 @@ type endpointWithRouteParamsContract[Params any, Req any, Resp any] struct {
 }
 
 var endpointGetServiceAccount = endpointContract[noRequest, ServiceAccountResponse]{
+	Name:             "get service account",
 	Method:           http.MethodGet,
 	Path:             routeServiceAccount,
 	RequestTransport: requestTransportNone,
 }
 
+var endpointGetServiceAccountPage = endpointWithRouteParamsContract[serviceAccountPageRouteParams, noRequest, serviceAccountAPIResponse]{
+	Name:                 "list service account sub IDs",
+	Method:               http.MethodGet,
+	Path:                 routeServiceAccountPageFromParams,
+	ParamsTransport:      requestTransportRouteParams,
+	RequestBodyTransport: requestTransportNone,
+}
+
+var endpointGetCapabilities = endpointContract[noRequest, capabilitiesAPIResponse]{
+	Name:             "get capabilities",
+	Method:           http.MethodGet,
+	Path:             routeCapabilities,
+	RequestTransport: requestTransportNone,
+}
+
+var endpointGetTokenScopes = endpointContract[noRequest, tokenScopesAPIResponse]{
+	Name:             "get token scopes",
+	Method:           http.MethodGet,
+	Path:             routeTokenScopes,
+	RequestTransport: requestTransportNone,
+}
+
+var endpointCheckAWSRoleAccess = endpointContract[awsRoleAccessAPIRequest, awsRoleAccessAPIResponse]{
+	Name:             "check AWS role access",
+	Method:           http.MethodPost,
+	Path:             routeAWSRoleAccess,
+	RequestTransport: requestTransportJSONBody,
+	ReadOnly:         true,
+}
+
+var endpointGetBillingDatasourceStatuses = endpointContract[billingDatasourceStatusesAPIRequest, billingDatasourceStatusesAPIResponse]{
+	Name:             "get billing datasource statuses",
+	Method:           http.MethodPost,
+	Path:             routeBillingDatasourceStatuses,
+	RequestTransport: requestTransportJSONBody,
+	ReadOnly:         true,
+}
+
+var endpointGetBillingDatasourceSummary = endpointWithRouteParamsContract[billingDatasourceSummaryRouteParams, noRequest, billingDatasourceSummaryAPIResponse]{
+	Name:                 "get billing datasource summary",
+	Method:               http.MethodGet,
+	Path:                 routeBillingDatasourceSummaryFromParams,
+	ParamsTransport:      requestTransportRouteParams,
+	RequestBodyTransport: requestTransportNone,
+}
+
 var endpointValidateGCPBillingDatasource = endpointContract[gcpBillingDatasourceAPIRequest, noResponse]{
+	Name:             "validate GCP billing datasource",
 	Method:           http.MethodPost,
 	Path:             routeBillingDatasourceValidate,
 	RequestTransport: requestTransportJSONBody,
+	ReadOnly:         true,
 }
 
 var endpointValidateAWSBillingDatasource = endpointContract[awsBillingDatasourceAPIRequest, noResponse]{
+	Name:             "validate AWS billing datasource",
 	Method:           http.MethodPost,
 	Path:             routeBillingDatasourceValidate,
 	RequestTransport: requestTransportJSONBody,
+	ReadOnly:         true,
 }
 
 var endpointValidateCursorBillingDatasource = endpointContract[externalBillingDatasourceAPIRequest, noResponse]{
+	Name:             "validate Cursor billing datasource",
 	Method:           http.MethodPost,
 	Path:             routeBillingDatasourceValidate,
 	RequestTransport: requestTransportJSONBody,
+	ReadOnly:         true,
 }
 
 var endpointValidateAnthropicBillingDatasource = endpointContract[externalBillingDatasourceAPIRequest, noResponse]{
+	Name:             "validate Anthropic billing datasource",
 	Method:           http.MethodPost,
 	Path:             routeBillingDatasourceValidate,
 	RequestTransport: requestTransportJSONBody,
+	ReadOnly:         true,
 }
 
 var endpointValidateElasticCloudBillingDatasource = endpointContract[elasticCloudBillingDatasourceAPIRequest, noResponse]{
+	Name:             "validate Elastic Cloud billing datasource",
 	Method:           http.MethodPost,
 	Path:             routeBillingDatasourceValidate,
 	RequestTransport: requestTransportJSONBody,
+	ReadOnly:         true,
 }
 
 var endpointValidateAzureBillingDatasource = endpointContract[azureBillingDatasourceAPIRequest, noResponse]{
+	Name:             "validate Azure billing datasource",
 	Method:           http.MethodPost,
 	Path:             routeBillingDatasourceValidate,
 	RequestTransport: requestTransportJSONBody,
+	ReadOnly:         true,
 }
 
 var endpointCreateGCPBillingDatasource = endpointContract[gcpBillingDatasourceAPIRequest, gcpBillingDatasourceAPIResponse]{
+	Name:             "create GCP billing datasource",
 	Method:           http.MethodPost,
 	Path:             routeBillingDatasourceBase,
 	RequestTransport: requestTransportJSONBody,
 }
 
 var endpointCreateAWSBillingDatasource = endpointContract[awsBillingDatasourceAPIRequest, awsBillingDatasourceAPIResponse]{
+	Name:             "create AWS billing datasource",
 	Method:           http.MethodPost,
 	Path:             routeBillingDatasourceBase,
 	RequestTransport: requestTransportJSONBody,
 }
 
 var endpointCreateCursorBillingDatasource = endpointContract[externalBillingDatasourceAPIRequest, externalBillingDatasourceAPIResponse]{
+	Name:             "create Cursor billing datasource",
 	Method:           http.MethodPost,
 	Path:             routeBillingDatasourceBase,
 	RequestTransport: requestTransportJSONBody,
 }
 
 var endpointCreateAnthropicBillingDatasource = endpointContract[externalBillingDatasourceAPIRequest, externalBillingDatasourceAPIResponse]{
+	Name:             "create Anthropic billing datasource",
 	Method:           http.MethodPost,
 	Path:             routeBillingDatasourceBase,
 	RequestTransport: requestTransportJSONBody,
 }
 
 var endpointCreateElasticCloudBillingDatasource = endpointContract[elasticCloudBillingDatasourceAPIRequest, elasticCloudBillingDatasourceAPIResponse]{
+	Name:             "create Elastic Cloud billing datasource",
 	Method:           http.MethodPost,
 	Path:             routeBillingDatasourceBase,
 	RequestTransport: requestTransportJSONBody,
 }
 
 var endpointCreateAzureBillingDatasource = endpointContract[azureBillingDatasourceAPIRequest, azureBillingDatasourceAPIResponse]{
+	Name:             "create Azure billing datasource",
 	Method:           http.MethodPost,
 	Path:             routeBillingDatasourceBase,
 	RequestTransport: requestTransportJSONBody,
 }
 
+var endpointGetBillingDatasourceByID = endpointWithRouteParamsContract[billingDatasourceByIDRouteParams, noRequest, billingDatasourceAPIResponse]{
+	Name:                 "get billing datasource",
+	Method:               http.MethodGet,
+	Path:                 routeBillingDatasourceByIDFromParams,
+	ParamsTransport:      requestTransportRouteParams,
+	RequestBodyTransport: requestTransportNone,
+}
+
+var endpointHeadBillingDatasourceByID = endpointWithRouteParamsContract[billingDatasourceByIDRouteParams, noRequest, noResponse]{
+	Name:                 "check billing datasource exists",
+	Method:               http.MethodHead,
+	Path:                 routeBillingDatasourceByIDFromParams,
+	ParamsTransport:      requestTransportRouteParams,
+	RequestBodyTransport: requestTransportNone,
+}
+
 var endpointGetGCPBillingDatasourceByID = endpointWithRouteParamsContract[billingDatasourceByIDRouteParams, noRequest, gcpBillingDatasourceAPIResponse]{
+	Name:                 "get GCP billing datasource",
 	Method:               http.MethodGet,
 	Path:                 routeBillingDatasourceByIDFromParams,
 	ParamsTransport:      requestTransportRouteParams,
@@ -141,6 +257,7 @@ var endpointGetGCPBillingDatasourceByID = endpointWithRouteParamsContract[billin
 }
 
 var endpointGetAWSBillingDatasourceByID = endpointWithRouteParamsContract[billingDatasourceByIDRouteParams, noRequest, awsBillingDatasourceAPIResponse]{
+	Name:                 "get AWS billing datasource",
 	Method:               http.MethodGet,
 	Path:                 routeBillingDatasourceByIDFromParams,
 	ParamsTransport:      requestTransportRouteParams,
@@ -148,6 +265,7 @@ var endpointGetAWSBillingDatasourceByID = endpointWithRouteParamsContract[billin
 }
 
 var endpointGetCursorBillingDatasourceByID = endpointWithRouteParamsContract[billingDatasourceByIDRouteParams, noRequest, externalBillingDatasourceAPIResponse]{
+	Name:                 "get Cursor billing datasource",
 	Method:               http.MethodGet,
 	Path:                 routeBillingDatasourceByIDFromParams,
 	ParamsTransport:      requestTransportRouteParams,
@@ -155,6 +273,7 @@ var endpointGetCursorBillingDatasourceByID = endpointWithRouteParamsContract[bil
 }
 
 var endpointGetAnthropicBillingDatasourceByID = endpointWithRouteParamsContract[billingDatasourceByIDRouteParams, noRequest, externalBillingDatasourceAPIResponse]{
+	Name:                 "get Anthropic billing datasource",
 	Method:               http.MethodGet,
 	Path:                 routeBillingDatasourceByIDFromParams,
 	ParamsTransport:      requestTransportRouteParams,
@@ -162,6 +281,7 @@ var endpointGetAnthropicBillingDatasourceByID = endpointWithRouteParamsContract[
 }
 
 var endpointGetElasticCloudBillingDatasourceByID = endpointWithRouteParamsContract[billingDatasourceByIDRouteParams, noRequest, elasticCloudBillingDatasourceAPIResponse]{
+	Name:                 "get Elastic Cloud billing datasource",
 	Method:               http.MethodGet,
 	Path:                 routeBillingDatasourceByIDFromParams,
 	ParamsTransport:      requestTransportRouteParams,
@@ -169,13 +289,39 @@ var endpointGetElasticCloudBillingDatasourceByID = endpointWithRouteParamsContra
 }
 
 var endpointGetAzureBillingDatasourceByID = endpointWithRouteParamsContract[billingDatasourceByIDRouteParams, noRequest, azureBillingDatasourceAPIResponse]{
+	Name:                 "get Azure billing datasource",
 	Method:               http.MethodGet,
 	Path:                 routeBillingDatasourceByIDFromParams,
 	ParamsTransport:      requestTransportRouteParams,
 	RequestBodyTransport: requestTransportNone,
 }
 
+var endpointPatchBillingDatasourceByID = endpointWithRouteParamsContract[billingDatasourceByIDRouteParams, billingDatasourcePatchAPIRequest, noResponse]{
+	Name:                 "update billing datasource",
+	Method:               http.MethodPatch,
+	Path:                 routeBillingDatasourceByIDFromParams,
+	ParamsTransport:      requestTransportRouteParams,
+	RequestBodyTransport: requestTransportJSONBody,
+}
+
+var endpointPatchBillingDatasourcePriorityByID = endpointWithRouteParamsContract[billingDatasourceByIDRouteParams, billingDatasourcePriorityPatchAPIRequest, noResponse]{
+	Name:                 "update billing datasource priority",
+	Method:               http.MethodPatch,
+	Path:                 routeBillingDatasourceByIDFromParams,
+	ParamsTransport:      requestTransportRouteParams,
+	RequestBodyTransport: requestTransportJSONBody,
+}
+
+var endpointPatchBillingDatasourceTagsByID = endpointWithRouteParamsContract[billingDatasourceByIDRouteParams, billingDatasourceTagsPatchAPIRequest, noResponse]{
+	Name:                 "update billing datasource tags",
+	Method:               http.MethodPatch,
+	Path:                 routeBillingDatasourceByIDFromParams,
+	ParamsTransport:      requestTransportRouteParams,
+	RequestBodyTransport: requestTransportJSONBody,
+}
+
 var endpointDeleteBillingDatasourceByID = endpointWithRouteParamsContract[billingDatasourceByIDRouteParams, noRequest, noResponse]{
+	Name:                 "delete billing datasource",
 	Method:               http.MethodDelete,
 	Path:                 routeBillingDatasourceByIDFromParams,
 	ParamsTransport:      requestTransportRouteParams,
@@ -183,18 +329,22 @@ var endpointDeleteBillingDatasourceByID = endpointWithRouteParamsContract[billin
 }
 
 var endpointValidateMetricsDatasource = endpointContract[metricsDatasourceAPIRequest, metricsDatasourceValidateAPIResponse]{
+	Name:             "validate metrics datasource",
 	Method:           http.MethodPost,
 	Path:             routeMetricsDatasourceValidate,
 	RequestTransport: requestTransportJSONBody,
+	ReadOnly:         true,
 }
 
 var endpointCreateMetricsDatasource = endpointContract[metricsDatasourceAPIRequest, metricsDatasourceAPIResponse]{
+	Name:             "create metrics datasource",
 	Method:           http.MethodPost,
 	Path:             routeMetricsDatasourceBase,
 	RequestTransport: requestTransportJSONBody,
 }
 
 var endpointGetMetricsDatasourceByID = endpointWithRouteParamsContract[metricsDatasourceByIDRouteParams, noRequest, metricsDatasourceAPIResponse]{
+	Name:                 "get metrics datasource",
 	Method:               http.MethodGet,
 	Path:                 routeMetricsDatasourceByIDFromParams,
 	ParamsTransport:      requestTransportRouteParams,
@@ -202,6 +352,7 @@ var endpointGetMetricsDatasourceByID = endpointWithRouteParamsContract[metricsDa
 }
 
 var endpointPatchMetricsDatasourceByID = endpointWithRouteParamsContract[metricsDatasourceByIDRouteParams, metricsDatasourcePatchAPIRequest, noResponse]{
+	Name:                 "update metrics datasource",
 	Method:               http.MethodPatch,
 	Path:                 routeMetricsDatasourceByIDFromParams,
 	ParamsTransport:      requestTransportRouteParams,
@@ -209,6 +360,7 @@ var endpointPatchMetricsDatasourceByID = endpointWithRouteParamsContract[metrics
 }
 
 var endpointDeleteMetricsDatasourceByID = endpointWithRouteParamsContract[metricsDatasourceByIDRouteParams, noRequest, noResponse]{
+	Name:                 "delete metrics datasource",
 	Method:               http.MethodDelete,
 	Path:                 routeMetricsDatasourceByIDFromParams,
 	ParamsTransport:      requestTransportRouteParams,
@@ -216,12 +368,14 @@ var endpointDeleteMetricsDatasourceByID = endpointWithRouteParamsContract[metric
 }
 
 var endpointCreateTeam = endpointContract[teamCreateAPIRequest, teamAPIResponse]{
+	Name:             "create team",
 	Method:           http.MethodPost,
 	Path:             routeTeamsBase,
 	RequestTransport: requestTransportJSONBody,
 }
 
 var endpointGetTeamByID = endpointWithRouteParamsContract[teamByIDRouteParams, noRequest, teamAPIResponse]{
+	Name:                 "get team",
 	Method:               http.MethodGet,
 	Path:                 routeTeamByIDFromParams,
 	ParamsTransport:      requestTransportRouteParams,
@@ -229,6 +383,7 @@ var endpointGetTeamByID = endpointWithRouteParamsContract[teamByIDRouteParams, n
 }
 
 var endpointPatchTeamByID = endpointWithRouteParamsContract[teamByIDRouteParams, teamUpdateAPIRequest, teamAPIResponse]{
+	Name:                 "update team",
 	Method:               http.MethodPatch,
 	Path:                 routeTeamByIDFromParams,
 	ParamsTransport:      requestTransportRouteParams,
@@ -236,6 +391,7 @@ var endpointPatchTeamByID = endpointWithRouteParamsContract[teamByIDRouteParams,
 }
 
 var endpointDeleteTeamByID = endpointWithRouteParamsContract[teamByIDRouteParams, noRequest, noResponse]{
+	Name:                 "delete team",
 	Method:               http.MethodDelete,
 	Path:                 routeTeamByIDFromParams,
 	ParamsTransport:      requestTransportRouteParams,
@@ -243,6 +399,7 @@ var endpointDeleteTeamByID = endpointWithRouteParamsContract[teamByIDRouteParams
 }
 
 var endpointAddTeamMember = endpointWithRouteParamsContract[teamByIDRouteParams, teamMemberAPIRequest, successResponse]{
+	Name:                 "add team member",
 	Method:               http.MethodPost,
 	Path:                 routeTeamMembersByIDFromParams,
 	ParamsTransport:      requestTransportRouteParams,
@@ -250,20 +407,96 @@ var endpointAddTeamMember = endpointWithRouteParamsContract[teamByIDRouteParams,
 }
 
 var endpointRemoveTeamMember = endpointWithRouteParamsContract[teamMemberRouteParams, noRequest, successResponse]{
+	Name:                 "remove team member",
 	Method:               http.MethodDelete,
 	Path:                 routeTeamMemberByIDFromParams,
 	ParamsTransport:      requestTransportRouteParams,
 	RequestBodyTransport: requestTransportNone,
 }
 
+var endpointCreateAllocationRule = endpointContract[allocationRuleAPIRequest, allocationRuleAPIResponse]{
+	Name:             "create allocation rule",
+	Method:           http.MethodPost,
+	Path:             routeAllocationRulesBase,
+	RequestTransport: requestTransportJSONBody,
+}
+
+var endpointGetAllocationRuleByID = endpointWithRouteParamsContract[allocationRuleByIDRouteParams, noRequest, allocationRuleAPIResponse]{
+	Name:                 "get allocation rule",
+	Method:               http.MethodGet,
+	Path:                 routeAllocationRuleByIDFromParams,
+	ParamsTransport:      requestTransportRouteParams,
+	RequestBodyTransport: requestTransportNone,
+}
+
+var endpointPatchAllocationRuleByID = endpointWithRouteParamsContract[allocationRuleByIDRouteParams, allocationRuleAPIRequest, allocationRuleAPIResponse]{
+	Name:                 "update allocation rule",
+	Method:               http.MethodPatch,
+	Path:                 routeAllocationRuleByIDFromParams,
+	ParamsTransport:      requestTransportRouteParams,
+	RequestBodyTransport: requestTransportJSONBody,
+}
+
+var endpointDeleteAllocationRuleByID = endpointWithRouteParamsContract[allocationRuleByIDRouteParams, noRequest, noResponse]{
+	Name:                 "delete allocation rule",
+	Method:               http.MethodDelete,
+	Path:                 routeAllocationRuleByIDFromParams,
+	ParamsTransport:      requestTransportRouteParams,
+	RequestBodyTransport: requestTransportNone,
+}
+
+var endpointCreateAPIToken = endpointContract[apiTokenAPIRequest, apiTokenAPIResponse]{
+	Name:             "create API token",
+	Method:           http.MethodPost,
+	Path:             routeAPITokensBase,
+	RequestTransport: requestTransportJSONBody,
+}
+
+var endpointGetAPITokenByID = endpointWithRouteParamsContract[apiTokenByIDRouteParams, noRequest, apiTokenAPIResponse]{
+	Name:                 "get API token",
+	Method:               http.MethodGet,
+	Path:                 routeAPITokenByIDFromParams,
+	ParamsTransport:      requestTransportRouteParams,
+	RequestBodyTransport: requestTransportNone,
+}
+
+var endpointDeleteAPITokenByID = endpointWithRouteParamsContract[apiTokenByIDRouteParams, noRequest, noResponse]{
+	Name:                 "delete API token",
+	Method:               http.MethodDelete,
+	Path:                 routeAPITokenByIDFromParams,
+	ParamsTransport:      requestTransportRouteParams,
+	RequestBodyTransport: requestTransportNone,
+}
+
 func routeBillingDatasourceByID(id string) string {
 	return routeBillingDatasourceBase + "/" + url.PathEscape(id)
 }
 
+func routeServiceAccountPageFromParams(params serviceAccountPageRouteParams) string {
+	return routeServiceAccount + "?cursor=" + url.QueryEscape(params.Cursor)
+}
+
 func routeBillingDatasourceByIDFromParams(params billingDatasourceByIDRouteParams) string {
 	return routeBillingDatasourceByID(params.ID)
 }
 
+func routeBillingDatasourceSummaryFromParams(params billingDatasourceSummaryRouteParams) string {
+	query := url.Values{}
+	if params.Start != "" {
+		query.Set("start", params.Start)
+	}
+	if params.End != "" {
+		query.Set("end", params.End)
+	}
+
+	route := routeBillingDatasourceByID(params.ID) + "/summary"
+	if len(query) == 0 {
+		return route
+	}
+
+	return route + "?" + query.Encode()
+}
+
 func routeMetricsDatasourceByID(id string) string {
 	return routeMetricsDatasourceBase + "/" + url.PathEscape(id)
 }
@@ -295,3 +528,19 @@ func routeTeamMemberByID(teamID, userID string) string {
 func routeTeamMemberByIDFromParams(params teamMemberRouteParams) string {
 	return routeTeamMemberByID(params.TeamID, params.UserID)
 }
+
+func routeAllocationRuleByID(id string) string {
+	return routeAllocationRulesBase + "/" + url.PathEscape(id)
+}
+
+func routeAllocationRuleByIDFromParams(params allocationRuleByIDRouteParams) string {
+	return routeAllocationRuleByID(params.ID)
+}
+
+func routeAPITokenByID(id string) string {
+	return routeAPITokensBase + "/" + url.PathEscape(id)
+}
+
+func routeAPITokenByIDFromParams(params apiTokenByIDRouteParams) string {
+	return routeAPITokenByID(params.ID)
+}
@@ -0,0 +1,34 @@
+package costoryapi
+
+import (
+	"crypto/rand"
+	"fmt"
+)
+
+const headerTerraformRunID = "X-Terraform-Run-Id"
+
+// WithRunID sets the identifier sent as the X-Terraform-Run-Id header, for embedders that
+// already track a run identifier of their own. An empty ID disables the header.
+func WithRunID(runID string) Option {
+	return func(c *Client) {
+		c.runID = runID
+	}
+}
+
+// RunID returns the identifier sent as the X-Terraform-Run-Id header. Unless set with
+// WithRunID, it is generated when the client is created. Terraform starts a provider process,
+// and so a client, per command, so every call made during one plan or apply shares it and
+// Costory can correlate them.
+func (c *Client) RunID() string {
+	return c.runID
+}
+
+// newRunID returns a random version 4 UUID.
+func newRunID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
@@ -0,0 +1,78 @@
+package costoryapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sync"
+	"testing"
+)
+
+func TestClientSendsStableRunID(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	var runIDs []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		runIDs = append(runIDs, r.Header.Get(headerTerraformRunID))
+		mu.Unlock()
+
+		switch r.Method {
+		case http.MethodPost:
+			w.WriteHeader(http.StatusCreated)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+		_, _ = w.Write([]byte(`{"id":"cursor-ds-1","type":"Cursor","name":"Cursor Billing","status":"ACTIVE"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", server.Client())
+
+	created, err := client.CreateCursorBillingDatasource(context.Background(), CursorBillingDatasourceRequest{Name: "Cursor Billing", AdminAPIKey: "sk_cursor_admin_123"})
+	if err != nil {
+		t.Fatalf("unexpected create error: %v", err)
+	}
+	if _, err := client.GetCursorBillingDatasource(context.Background(), created.ID); err != nil {
+		t.Fatalf("unexpected read error: %v", err)
+	}
+
+	if len(runIDs) != 2 {
+		t.Fatalf("unexpected request count: got %d, want 2", len(runIDs))
+	}
+	if !regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`).MatchString(runIDs[0]) {
+		t.Fatalf("expected a UUID run ID, got %q", runIDs[0])
+	}
+	if runIDs[1] != runIDs[0] {
+		t.Fatalf("expected the run ID to be stable: got %q then %q", runIDs[0], runIDs[1])
+	}
+	if got := client.RunID(); got != runIDs[0] {
+		t.Fatalf("unexpected RunID: got %q, want %q", got, runIDs[0])
+	}
+
+	if other := NewClient(server.URL, "test-token", server.Client()).RunID(); other == runIDs[0] {
+		t.Fatalf("expected a new client to generate a new run ID, got %q twice", other)
+	}
+}
+
+func TestClientWithRunID(t *testing.T) {
+	t.Parallel()
+
+	var got string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get(headerTerraformRunID)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"service_account":"sa-test","sub_ids":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", server.Client(), WithRunID("run-123"))
+	if _, err := client.GetServiceAccount(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "run-123" {
+		t.Fatalf("unexpected run ID header: got %q, want %q", got, "run-123")
+	}
+}
@@ -0,0 +1,57 @@
+package costoryapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+type billingDatasourceStatusesAPIRequest struct {
+	IDs []string `json:"ids"`
+}
+
+type billingDatasourceStatusesAPIResponse struct {
+	Statuses []billingDatasourceStatusAPIResponse `json:"statuses"`
+}
+
+type billingDatasourceStatusAPIResponse struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+}
+
+// GetBillingDatasourceStatuses fetches the status of several billing datasources in one
+// request, keyed by ID, so callers refreshing many datasources can avoid one read each. IDs
+// the API does not know are left out of the map rather than failing the whole call. A
+// deployment without the batch endpoint returns ErrNotFound, and callers should fall back
+// to reading each datasource.
+func (c *Client) GetBillingDatasourceStatuses(ctx context.Context, ids []string) (map[string]string, error) {
+	statuses := make(map[string]string, len(ids))
+	if len(ids) == 0 {
+		return statuses, nil
+	}
+
+	body, statusCode, err := doEndpoint(ctx, c, endpointGetBillingDatasourceStatuses, billingDatasourceStatusesAPIRequest{IDs: ids})
+	if err != nil {
+		return nil, err
+	}
+	if statusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+	if statusCode != http.StatusOK {
+		return nil, unexpectedStatusError(endpointGetBillingDatasourceStatuses.Name, statusCode, body)
+	}
+
+	var out billingDatasourceStatusesAPIResponse
+	if err := c.decodeResponse(body, &out); err != nil {
+		return nil, fmt.Errorf("decode response body: %w", err)
+	}
+
+	for _, item := range out.Statuses {
+		if item.ID == "" || item.Status == "" {
+			continue
+		}
+		statuses[item.ID] = item.Status
+	}
+
+	return statuses, nil
+}
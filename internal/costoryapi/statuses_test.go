@@ -0,0 +1,82 @@
+package costoryapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestClientGetBillingDatasourceStatuses(t *testing.T) {
+	t.Parallel()
+
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Method != http.MethodPost || r.URL.Path != routeBillingDatasourceStatuses {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+
+		var req billingDatasourceStatusesAPIRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("unable to decode request: %v", err)
+		}
+		if want := []string{"ds-1", "ds-2", "ds-missing"}; !reflect.DeepEqual(req.IDs, want) {
+			t.Errorf("unexpected ids: got %v, want %v", req.IDs, want)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"statuses":[{"id":"ds-1","status":"ACTIVE"},{"id":"ds-2","status":"FAILED"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", server.Client())
+
+	got, err := client.GetBillingDatasourceStatuses(context.Background(), []string{"ds-1", "ds-2", "ds-missing"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[string]string{"ds-1": "ACTIVE", "ds-2": "FAILED"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected statuses: got %v, want %v", got, want)
+	}
+	if _, ok := got["ds-missing"]; ok {
+		t.Fatal("expected the missing ID to be left out")
+	}
+	if requests != 1 {
+		t.Fatalf("unexpected request count: got %d, want 1", requests)
+	}
+}
+
+func TestClientGetBillingDatasourceStatusesEndpointMissing(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", server.Client())
+
+	if _, err := client.GetBillingDatasourceStatuses(context.Background(), []string{"ds-1"}); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestClientGetBillingDatasourceStatusesNoIDs(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient("https://example.invalid", "test-token", nil)
+
+	got, err := client.GetBillingDatasourceStatuses(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 0 {
+		t.Fatalf("expected no statuses, got %v", got)
+	}
+}
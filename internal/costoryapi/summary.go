@@ -0,0 +1,53 @@
+package costoryapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// BillingDatasourceSummary is the cost a billing datasource ingested over a period. An empty
+// period has a zero cost and row count and may have no currency.
+type BillingDatasourceSummary struct {
+	TotalCost float64
+	Currency  string
+	RowCount  int64
+}
+
+type billingDatasourceSummaryAPIResponse struct {
+	TotalCost float64 `json:"totalCost"`
+	Currency  string  `json:"currency"`
+	RowCount  int64   `json:"rowCount"`
+}
+
+// GetBillingDatasourceSummary fetches the total cost and row count a billing datasource
+// ingested between start and end (YYYY-MM-DD, either may be empty for an open bound). It
+// returns ErrNotFound when the datasource does not exist or the deployment has no summary
+// endpoint.
+func (c *Client) GetBillingDatasourceSummary(ctx context.Context, datasourceID, start, end string) (*BillingDatasourceSummary, error) {
+	routeParams := billingDatasourceSummaryRouteParams{ID: datasourceID, Start: start, End: end}
+	body, statusCode, err := doEndpointWithRouteParams(ctx, c, endpointGetBillingDatasourceSummary, routeParams, noRequest{})
+	if err != nil {
+		return nil, err
+	}
+	if statusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+	if statusCode == http.StatusNoContent {
+		return &BillingDatasourceSummary{}, nil
+	}
+	if statusCode != http.StatusOK {
+		return nil, unexpectedStatusError(endpointGetBillingDatasourceSummary.Name, statusCode, body)
+	}
+
+	var out billingDatasourceSummaryAPIResponse
+	if err := c.decodeResponse(body, &out); err != nil {
+		return nil, fmt.Errorf("decode response body: %w", err)
+	}
+
+	return &BillingDatasourceSummary{
+		TotalCost: out.TotalCost,
+		Currency:  out.Currency,
+		RowCount:  out.RowCount,
+	}, nil
+}
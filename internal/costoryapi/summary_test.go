@@ -0,0 +1,80 @@
+package costoryapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestClientGetBillingDatasourceSummary(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		status   int
+		body     string
+		start    string
+		end      string
+		wantPath string
+		want     *BillingDatasourceSummary
+		wantErr  error
+	}{
+		"period": {
+			status:   http.StatusOK,
+			body:     `{"totalCost":1234.5,"currency":"USD","rowCount":42}`,
+			start:    "2025-01-01",
+			end:      "2025-02-01",
+			wantPath: "/terraform/billingDatasources/ds-1/summary?end=2025-02-01&start=2025-01-01",
+			want:     &BillingDatasourceSummary{TotalCost: 1234.5, Currency: "USD", RowCount: 42},
+		},
+		"open period": {
+			status:   http.StatusOK,
+			body:     `{"totalCost":10,"currency":"EUR","rowCount":1}`,
+			wantPath: "/terraform/billingDatasources/ds-1/summary",
+			want:     &BillingDatasourceSummary{TotalCost: 10, Currency: "EUR", RowCount: 1},
+		},
+		"empty period": {
+			status:   http.StatusNoContent,
+			wantPath: "/terraform/billingDatasources/ds-1/summary",
+			want:     &BillingDatasourceSummary{},
+		},
+		"not found": {
+			status:   http.StatusNotFound,
+			wantPath: "/terraform/billingDatasources/ds-1/summary",
+			wantErr:  ErrNotFound,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if got := r.URL.RequestURI(); got != tc.wantPath {
+					t.Errorf("unexpected request URI: got %q, want %q", got, tc.wantPath)
+				}
+				w.WriteHeader(tc.status)
+				_, _ = w.Write([]byte(tc.body))
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL, "test-token", server.Client(), WithStrictDecoding(true))
+
+			got, err := client.GetBillingDatasourceSummary(context.Background(), "ds-1", tc.start, tc.end)
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("unexpected error: got %v, want %v", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("unexpected summary: got %#v, want %#v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package costoryapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// epochMillisThreshold separates epoch seconds from epoch milliseconds: 1e11 seconds is in
+// the year 5138, while 1e11 milliseconds is in 1973.
+const epochMillisThreshold = 1e11
+
+// parseFlexibleTime parses a timestamp sent by the API either as RFC3339 or as epoch
+// seconds or milliseconds, and returns it in UTC.
+func parseFlexibleTime(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+
+	if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+		return parsed.UTC(), nil
+	}
+
+	epoch, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid timestamp %q: expected RFC3339 or epoch seconds or milliseconds", value)
+	}
+
+	if epoch >= epochMillisThreshold || epoch <= -epochMillisThreshold {
+		return time.UnixMilli(epoch).UTC(), nil
+	}
+
+	return time.Unix(epoch, 0).UTC(), nil
+}
+
+// flexibleTime decodes a JSON timestamp with parseFlexibleTime. Strings and numbers are both
+// accepted; unparseable values fail decoding instead of being dropped.
+type flexibleTime time.Time
+
+func (t *flexibleTime) UnmarshalJSON(data []byte) error {
+	value := string(data)
+	if bytes.HasPrefix(data, []byte(`"`)) {
+		if err := json.Unmarshal(data, &value); err != nil {
+			return err
+		}
+	}
+
+	parsed, err := parseFlexibleTime(value)
+	if err != nil {
+		return err
+	}
+
+	*t = flexibleTime(parsed)
+	return nil
+}
+
+// timePointer converts an optional decoded timestamp to *time.Time.
+func (t *flexibleTime) timePointer() *time.Time {
+	if t == nil {
+		return nil
+	}
+
+	value := time.Time(*t)
+	return &value
+}
+
+// rfc3339 formats an optional decoded timestamp for state, or returns "" when it is absent.
+func (t *flexibleTime) rfc3339() string {
+	if t == nil {
+		return ""
+	}
+
+	return time.Time(*t).UTC().Format(time.RFC3339)
+}
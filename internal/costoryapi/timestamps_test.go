@@ -0,0 +1,136 @@
+package costoryapi
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestParseFlexibleTime(t *testing.T) {
+	t.Parallel()
+
+	want := time.Date(2025, 3, 4, 5, 6, 7, 0, time.UTC)
+
+	tests := map[string]struct {
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		"rfc3339 utc":        {value: "2025-03-04T05:06:07Z", want: want},
+		"rfc3339 offset":     {value: "2025-03-04T06:06:07+01:00", want: want},
+		"rfc3339 fractional": {value: "2025-03-04T05:06:07.250Z", want: want.Add(250 * time.Millisecond)},
+		"epoch seconds":      {value: "1741064767", want: want},
+		"epoch millis":       {value: "1741064767250", want: want.Add(250 * time.Millisecond)},
+		"epoch zero":         {value: "0", want: time.Unix(0, 0).UTC()},
+		"surrounding spaces": {value: " 1741064767 ", want: want},
+		"date only":          {value: "2025-03-04", wantErr: true},
+		"fractional epoch":   {value: "1741064767.5", wantErr: true},
+		"garbage":            {value: "yesterday", wantErr: true},
+		"empty":              {value: "", wantErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := parseFlexibleTime(tc.value)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected error for %q, got %s", tc.value, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !got.Equal(tc.want) || got.Location() != time.UTC {
+				t.Fatalf("unexpected time: got %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFlexibleTimeUnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	want := time.Date(2025, 3, 4, 5, 6, 7, 0, time.UTC)
+
+	tests := map[string]struct {
+		body    string
+		want    *time.Time
+		wantErr bool
+	}{
+		"string":         {body: `{"at":"2025-03-04T05:06:07Z"}`, want: &want},
+		"number seconds": {body: `{"at":1741064767}`, want: &want},
+		"number millis":  {body: `{"at":1741064767000}`, want: &want},
+		"string epoch":   {body: `{"at":"1741064767"}`, want: &want},
+		"null":           {body: `{"at":null}`},
+		"absent":         {body: `{}`},
+		"garbage":        {body: `{"at":"soon"}`, wantErr: true},
+		"boolean":        {body: `{"at":true}`, wantErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var out struct {
+				At *flexibleTime `json:"at"`
+			}
+			err := json.Unmarshal([]byte(tc.body), &out)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("expected decode error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected decode error: %v", err)
+			}
+
+			got := out.At.timePointer()
+			switch {
+			case tc.want == nil && got != nil:
+				t.Fatalf("expected no time, got %s", got)
+			case tc.want != nil && (got == nil || !got.Equal(*tc.want)):
+				t.Fatalf("unexpected time: got %v, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTeamTimestampsAreNormalized(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		body        string
+		wantCreated string
+		wantUpdated string
+	}{
+		"rfc3339 with offset": {
+			body:        `{"createdAt":"2025-03-04T06:06:07+01:00","updatedAt":"2025-03-04T05:06:07.250Z"}`,
+			wantCreated: "2025-03-04T05:06:07Z", wantUpdated: "2025-03-04T05:06:07Z",
+		},
+		"epoch": {
+			body:        `{"createdAt":1741064767,"updatedAt":"1741064767000"}`,
+			wantCreated: "2025-03-04T05:06:07Z", wantUpdated: "2025-03-04T05:06:07Z",
+		},
+		"absent": {body: `{"updatedAt":null}`},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var out teamAPIResponse
+			if err := json.Unmarshal([]byte(tc.body), &out); err != nil {
+				t.Fatalf("unexpected decode error: %v", err)
+			}
+
+			team := out.toTeam()
+			if team.CreatedAt != tc.wantCreated || team.UpdatedAt != tc.wantUpdated {
+				t.Fatalf("unexpected timestamps: got %q and %q, want %q and %q", team.CreatedAt, team.UpdatedAt, tc.wantCreated, tc.wantUpdated)
+			}
+		})
+	}
+}
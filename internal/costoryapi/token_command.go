@@ -0,0 +1,91 @@
+package costoryapi
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// WithTokenCommand obtains the bearer token by running command, an executable followed by
+// its arguments, and reading the token from its standard output. The token is cached until
+// the API answers 401, which runs the command again once for that request. The command is
+// run lazily on the first request, so the token passed to NewClient may be empty.
+func WithTokenCommand(command []string) Option {
+	return func(c *Client) {
+		if len(command) > 0 {
+			c.tokenCommand = command
+		}
+	}
+}
+
+// bearerToken returns the token to send, running the token command when no token has been
+// obtained yet.
+func (c *Client) bearerToken(ctx context.Context) (string, error) {
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+
+	if c.token != "" || c.tokenCommand == nil {
+		return c.token, nil
+	}
+
+	token, err := c.runTokenCommand(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	c.token = token
+	return token, nil
+}
+
+// refreshToken replaces a rejected token with a fresh one from the token command. When a
+// concurrent request already replaced stale, the newer token is kept and the command is not
+// run again.
+func (c *Client) refreshToken(ctx context.Context, stale string) error {
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+
+	if c.token != stale {
+		return nil
+	}
+
+	token, err := c.runTokenCommand(ctx)
+	if err != nil {
+		return err
+	}
+
+	c.token = token
+	return nil
+}
+
+// runTokenCommand runs the token command and returns its trimmed standard output. The output
+// is a credential, so it is never included in errors; standard error is left out as well in
+// case the command echoes the token there.
+func (c *Client) runTokenCommand(ctx context.Context) (string, error) {
+	var stdout bytes.Buffer
+	cmd := exec.CommandContext(ctx, c.tokenCommand[0], c.tokenCommand[1:]...)
+	cmd.Stdout = &stdout
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("run token command %q: %w", c.tokenCommand[0], err)
+	}
+
+	token := strings.TrimSpace(stdout.String())
+	if token == "" {
+		return "", fmt.Errorf("run token command %q: no token printed to standard output", c.tokenCommand[0])
+	}
+
+	return token, nil
+}
+
+// maskToken redacts token from every log message and field written with the returned context.
+func maskToken(ctx context.Context, token string) context.Context {
+	if token == "" {
+		return ctx
+	}
+
+	return tflog.MaskMessageStrings(tflog.MaskAllFieldValuesStrings(ctx, token), token)
+}
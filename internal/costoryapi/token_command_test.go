@@ -0,0 +1,122 @@
+package costoryapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// writeTokenScript writes a shell script printing token-1 on its first run and token-2 on
+// later runs, counting runs in a file next to it.
+func writeTokenScript(t *testing.T) (string, func() int) {
+	t.Helper()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("token command tests use a POSIX shell script")
+	}
+
+	dir := t.TempDir()
+	counter := filepath.Join(dir, "runs")
+	script := filepath.Join(dir, "token.sh")
+	body := "#!/bin/sh\necho x >> '" + counter + "'\nruns=$(wc -l < '" + counter + "')\necho \"token-$((runs))\"\n"
+	if err := os.WriteFile(script, []byte(body), 0o700); err != nil {
+		t.Fatalf("unable to write token script: %v", err)
+	}
+
+	runs := func() int {
+		data, err := os.ReadFile(counter)
+		if err != nil {
+			return 0
+		}
+		return strings.Count(string(data), "\n")
+	}
+
+	return script, runs
+}
+
+func TestClientTokenCommandRefreshesAfterUnauthorized(t *testing.T) {
+	t.Parallel()
+
+	script, runs := writeTokenScript(t)
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if got := r.Header.Get("Authorization"); got != "Bearer token-2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"service_account":"sa-test","sub_ids":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "", server.Client(), WithTokenCommand([]string{script}))
+
+	if _, err := client.GetServiceAccount(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := runs(), 2; got != want {
+		t.Fatalf("unexpected token command runs: got %d, want %d", got, want)
+	}
+	if got, want := calls.Load(), int32(2); got != want {
+		t.Fatalf("unexpected request count: got %d, want %d", got, want)
+	}
+
+	// The refreshed token is cached for later requests.
+	if _, err := client.GetServiceAccount(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := runs(), 2; got != want {
+		t.Fatalf("unexpected token command runs after caching: got %d, want %d", got, want)
+	}
+}
+
+func TestClientTokenCommandRefreshesOnce(t *testing.T) {
+	t.Parallel()
+
+	script, runs := writeTokenScript(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "", server.Client(), WithTokenCommand([]string{script}))
+
+	if _, err := client.GetServiceAccount(context.Background()); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if got, want := runs(), 2; got != want {
+		t.Fatalf("unexpected token command runs: got %d, want %d", got, want)
+	}
+}
+
+func TestClientTokenCommandErrorsDoNotLeakOutput(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("token command tests use a POSIX shell script")
+	}
+
+	script := filepath.Join(t.TempDir(), "failing.sh")
+	if err := os.WriteFile(script, []byte("#!/bin/sh\necho secret-token\necho secret-token >&2\nexit 3\n"), 0o700); err != nil {
+		t.Fatalf("unable to write token script: %v", err)
+	}
+
+	client := NewClient("https://example.invalid", "", nil, WithTokenCommand([]string{script}))
+
+	_, err := client.GetServiceAccount(context.Background())
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if strings.Contains(err.Error(), "secret-token") {
+		t.Fatalf("expected token command output to be redacted, got %q", err.Error())
+	}
+}
@@ -0,0 +1,60 @@
+package costoryapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+)
+
+// Token scopes granted by the Costory API.
+const (
+	TokenScopeRead  = "read"
+	TokenScopeWrite = "write"
+)
+
+// TokenScopes describes the scopes granted to the configured API token. Known is false when
+// the deployment predates the token introspection endpoint, in which case nothing can be
+// said about the token ahead of the first request.
+type TokenScopes struct {
+	Known  bool
+	Scopes []string
+}
+
+// Has reports whether the token is known to carry the given scope.
+func (s *TokenScopes) Has(scope string) bool {
+	return s.Known && slices.Contains(s.Scopes, scope)
+}
+
+type tokenScopesAPIResponse struct {
+	Scopes []string `json:"scopes"`
+}
+
+// GetTokenScopes fetches the scopes granted to the configured token. A 404 from older
+// backends is reported as unknown scopes rather than an error.
+func (c *Client) GetTokenScopes(ctx context.Context) (*TokenScopes, error) {
+	body, statusCode, err := doEndpoint(ctx, c, endpointGetTokenScopes, noRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	if statusCode == http.StatusNotFound {
+		return &TokenScopes{Scopes: []string{}}, nil
+	}
+
+	if statusCode != http.StatusOK {
+		return nil, unexpectedStatusError(endpointGetTokenScopes.Name, statusCode, body)
+	}
+
+	var out tokenScopesAPIResponse
+	if err := c.decodeResponse(body, &out); err != nil {
+		return nil, fmt.Errorf("decode response body: %w", err)
+	}
+
+	scopes := &TokenScopes{Known: true, Scopes: []string{}}
+	if out.Scopes != nil {
+		scopes.Scopes = out.Scopes
+	}
+
+	return scopes, nil
+}
@@ -0,0 +1,65 @@
+package costoryapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientGetTokenScopes(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		statusCode int
+		body       string
+		wantKnown  bool
+		wantRead   bool
+		wantWrite  bool
+		wantError  bool
+	}{
+		"full scope":      {statusCode: http.StatusOK, body: `{"scopes":["read","write"]}`, wantKnown: true, wantRead: true, wantWrite: true},
+		"read only":       {statusCode: http.StatusOK, body: `{"scopes":["read"]}`, wantKnown: true, wantRead: true},
+		"no scopes":       {statusCode: http.StatusOK, body: `{}`, wantKnown: true},
+		"endpoint absent": {statusCode: http.StatusNotFound},
+		"server error":    {statusCode: http.StatusInternalServerError, body: `{"error":"boom"}`, wantError: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodGet || r.URL.Path != routeTokenScopes {
+					t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+				}
+				w.WriteHeader(tc.statusCode)
+				_, _ = w.Write([]byte(tc.body))
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL, "test-token", server.Client())
+
+			scopes, err := client.GetTokenScopes(context.Background())
+			if tc.wantError {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if scopes.Known != tc.wantKnown {
+				t.Fatalf("unexpected known: got %t, want %t", scopes.Known, tc.wantKnown)
+			}
+			if got := scopes.Has(TokenScopeRead); got != tc.wantRead {
+				t.Fatalf("unexpected read scope: got %t, want %t", got, tc.wantRead)
+			}
+			if got := scopes.Has(TokenScopeWrite); got != tc.wantWrite {
+				t.Fatalf("unexpected write scope: got %t, want %t", got, tc.wantWrite)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package costoryapi
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// WithTracer wraps every HTTP attempt in an OpenTelemetry span. Without a tracer, no spans
+// are created.
+func WithTracer(tracer trace.Tracer) Option {
+	return func(c *Client) {
+		c.tracer = tracer
+	}
+}
+
+func (c *Client) startAttemptSpan(ctx context.Context, method, path string, attempt int) (context.Context, trace.Span) {
+	if c.tracer == nil {
+		return ctx, nil
+	}
+
+	return c.tracer.Start(ctx, method+" "+path,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.request.method", method),
+			attribute.String("url.path", path),
+			attribute.Int("costory.attempt", attempt+1),
+			attribute.Int("costory.retry_count", attempt),
+		),
+	)
+}
+
+func endAttemptSpan(span trace.Span, statusCode int, err error) {
+	if span == nil {
+		return
+	}
+	defer span.End()
+
+	if statusCode != 0 {
+		span.SetAttributes(attribute.Int("http.response.status_code", statusCode))
+	}
+
+	switch {
+	case err != nil:
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	case statusCode >= http.StatusInternalServerError:
+		span.SetStatus(codes.Error, http.StatusText(statusCode))
+	}
+}
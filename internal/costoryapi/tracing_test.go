@@ -0,0 +1,72 @@
+package costoryapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestClientTracerRecordsSpanPerAttempt(t *testing.T) {
+	t.Parallel()
+
+	var serverCalls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		serverCalls++
+		if serverCalls == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"service_account":"sa-test","sub_ids":[]}`))
+	}))
+	defer server.Close()
+
+	recorder := tracetest.NewSpanRecorder()
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	client := NewClient(server.URL, "test-token", server.Client(), WithTracer(tracerProvider.Tracer("costoryapi-test")))
+
+	if _, err := client.GetServiceAccount(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("unexpected span count: got %d, want 2", len(spans))
+	}
+
+	for i, want := range []struct {
+		status int
+		code   codes.Code
+	}{
+		{status: http.StatusBadGateway, code: codes.Error},
+		{status: http.StatusOK, code: codes.Unset},
+	} {
+		span := spans[i]
+		if got, wantName := span.Name(), "GET "+routeServiceAccount; got != wantName {
+			t.Fatalf("unexpected span name: got %q, want %q", got, wantName)
+		}
+		if got := span.Status().Code; got != want.code {
+			t.Fatalf("unexpected span %d status: got %v, want %v", i, got, want.code)
+		}
+
+		attrs := map[attribute.Key]attribute.Value{}
+		for _, kv := range span.Attributes() {
+			attrs[kv.Key] = kv.Value
+		}
+		if got := attrs["http.response.status_code"].AsInt64(); got != int64(want.status) {
+			t.Fatalf("unexpected span %d status code: got %d, want %d", i, got, want.status)
+		}
+		if got := attrs["costory.attempt"].AsInt64(); got != int64(i+1) {
+			t.Fatalf("unexpected span %d attempt: got %d", i, got)
+		}
+		if got := attrs["costory.retry_count"].AsInt64(); got != int64(i) {
+			t.Fatalf("unexpected span %d retry count: got %d", i, got)
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package costoryapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ValidationIssue is a single problem reported by a validate endpoint. Field is the
+// API field name (for example "bqTablePath") and is empty when the issue is not tied to one.
+// Code is the machine-readable issue code when the API sends one.
+type ValidationIssue struct {
+	Field   string
+	Message string
+	Code    string
+}
+
+// ValidationResult is the outcome of a validate endpoint that answered. Issues is empty when
+// Valid is true.
+type ValidationResult struct {
+	Valid      bool
+	StatusCode int
+	Issues     []ValidationIssue
+}
+
+// Err returns nil for a valid result and a *ValidationError listing the issues otherwise.
+func (r *ValidationResult) Err() error {
+	if r.Valid {
+		return nil
+	}
+
+	return &ValidationError{StatusCode: r.StatusCode, Issues: r.Issues}
+}
+
+// ValidationError is returned when a validate endpoint rejects a request with a list of
+// issues, so callers can report every problem at once instead of one per apply.
+type ValidationError struct {
+	StatusCode int
+	Issues     []ValidationIssue
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, 0, len(e.Issues))
+	for _, issue := range e.Issues {
+		if issue.Field == "" {
+			messages = append(messages, issue.Message)
+			continue
+		}
+		messages = append(messages, fmt.Sprintf("%s: %s", issue.Field, issue.Message))
+	}
+
+	return fmt.Sprintf("validation failed with status code %d: %s", e.StatusCode, strings.Join(messages, "; "))
+}
+
+// Reasons that validate endpoints report without an issue list. Each concerns the API fields
+// listed in validationReasons, so the problem is still attributed to them.
+const (
+	ValidationReasonPrefixEmpty      = "PREFIX_EMPTY"
+	ValidationReasonNoManifestsFound = "NO_MANIFESTS_FOUND"
+
+	// ValidationReasonDetailedBillingRequiresDetailedExport reports detailed billing requested
+	// against a standard export table, which lacks the resource-level columns it needs.
+	ValidationReasonDetailedBillingRequiresDetailedExport = "DETAILED_BILLING_REQUIRES_DETAILED_EXPORT"
+)
+
+// validationReason is what a reason concerns: the API fields it is reported on, one issue
+// each, and the message used when the response carries none.
+type validationReason struct {
+	fields         []string
+	defaultMessage string
+}
+
+var validationReasons = map[string]validationReason{
+	ValidationReasonPrefixEmpty: {
+		fields:         []string{"prefix"},
+		defaultMessage: "no billing manifests were found under the prefix",
+	},
+	ValidationReasonNoManifestsFound: {
+		fields:         []string{"prefix"},
+		defaultMessage: "no billing manifests were found under the prefix",
+	},
+	ValidationReasonDetailedBillingRequiresDetailedExport: {
+		fields:         []string{"bqTablePath", "isDetailedBilling"},
+		defaultMessage: "detailed billing requires a detailed usage cost export table; point bq_uri at the detailed export or set is_detailed_billing to false",
+	},
+}
+
+type validationIssuesAPIResponse struct {
+	Issues []validationIssueAPI `json:"issues"`
+	Error  string               `json:"error"`
+	Reason string               `json:"reason"`
+}
+
+type validationIssueAPI struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+	Code    string `json:"code"`
+}
+
+// validationResult converts a validate response into a ValidationResult. A 2xx is valid and a
+// rejection that lists issues, or carries a reason tied to a field, is invalid. Any other
+// rejection is returned as unexpectedStatusError.
+func validationResult(endpoint string, statusCode int, body []byte) (*ValidationResult, error) {
+	if statusCode >= http.StatusOK && statusCode < http.StatusMultipleChoices {
+		return &ValidationResult{Valid: true, StatusCode: statusCode, Issues: []ValidationIssue{}}, nil
+	}
+
+	issues, ok := parseValidationIssues(body)
+	if !ok {
+		return nil, unexpectedStatusError(endpoint, statusCode, body)
+	}
+
+	return &ValidationResult{StatusCode: statusCode, Issues: issues}, nil
+}
+
+// validateEndpointResult is validationResult for a validate endpoint response. A 404 without
+// issues means the endpoint is not deployed and is reported as ErrValidationUnavailable; a
+// 404 that lists issues, such as a missing table, still rejects the configuration.
+func validateEndpointResult(endpoint string, statusCode int, body []byte) (*ValidationResult, error) {
+	if _, ok := parseValidationIssues(body); statusCode == http.StatusNotFound && !ok {
+		return nil, fmt.Errorf("%w: %w", ErrValidationUnavailable, unexpectedStatusError(endpoint, statusCode, body))
+	}
+
+	return validationResult(endpoint, statusCode, body)
+}
+
+// validationFailure converts a rejected validate response into a *ValidationError when the
+// body lists issues or carries a reason tied to a field, and falls back to
+// unexpectedStatusError otherwise.
+func validationFailure(endpoint string, statusCode int, body []byte) error {
+	issues, ok := parseValidationIssues(body)
+	if !ok {
+		return unexpectedStatusError(endpoint, statusCode, body)
+	}
+
+	return &ValidationError{StatusCode: statusCode, Issues: issues}
+}
+
+// parseValidationIssues extracts the issues from a rejected response body. A known reason
+// becomes one issue per field it concerns. It reports false when the body neither lists
+// issues nor carries a known reason.
+func parseValidationIssues(body []byte) ([]ValidationIssue, bool) {
+	var out validationIssuesAPIResponse
+	if err := json.Unmarshal(body, &out); err != nil {
+		return nil, false
+	}
+
+	if len(out.Issues) == 0 {
+		code := strings.TrimSpace(out.Reason)
+		reason, ok := validationReasons[code]
+		if !ok {
+			return nil, false
+		}
+
+		message := strings.TrimSpace(out.Error)
+		if message == "" {
+			message = reason.defaultMessage
+		}
+
+		issues := make([]ValidationIssue, 0, len(reason.fields))
+		for _, field := range reason.fields {
+			issues = append(issues, ValidationIssue{Field: field, Message: message, Code: code})
+		}
+		return issues, true
+	}
+
+	issues := make([]ValidationIssue, 0, len(out.Issues))
+	for _, issue := range out.Issues {
+		issues = append(issues, ValidationIssue{
+			Field:   strings.TrimSpace(issue.Field),
+			Message: strings.TrimSpace(issue.Message),
+			Code:    strings.TrimSpace(issue.Code),
+		})
+	}
+
+	return issues, true
+}
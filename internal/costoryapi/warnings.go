@@ -0,0 +1,40 @@
+package costoryapi
+
+import "time"
+
+// warningBufferSize bounds the number of warnings retained by a client.
+const warningBufferSize = 50
+
+// Warning is a provider-collected warning, retained so it can be reported in aggregate.
+type Warning struct {
+	Summary    string
+	Detail     string
+	RecordedAt time.Time
+}
+
+// RecordWarning retains a warning in the client's bounded buffer, evicting the oldest
+// warning once the buffer is full. Recording is best-effort and scoped to this client.
+func (c *Client) RecordWarning(summary, detail string) {
+	c.warningsMu.Lock()
+	defer c.warningsMu.Unlock()
+
+	warning := Warning{Summary: summary, Detail: detail, RecordedAt: time.Now().UTC()}
+	if len(c.warnings) < warningBufferSize {
+		c.warnings = append(c.warnings, warning)
+		return
+	}
+
+	c.warnings[c.warningsNext] = warning
+	c.warningsNext = (c.warningsNext + 1) % warningBufferSize
+}
+
+// Warnings returns the retained warnings, oldest first.
+func (c *Client) Warnings() []Warning {
+	c.warningsMu.Lock()
+	defer c.warningsMu.Unlock()
+
+	warnings := make([]Warning, 0, len(c.warnings))
+	warnings = append(warnings, c.warnings[c.warningsNext:]...)
+	warnings = append(warnings, c.warnings[:c.warningsNext]...)
+	return warnings
+}
@@ -0,0 +1,63 @@
+package costoryapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestClientWarningsRingBuffer(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient("https://example.invalid", "test-token", nil)
+
+	if got := client.Warnings(); len(got) != 0 {
+		t.Fatalf("expected no warnings, got %v", got)
+	}
+
+	for i := range warningBufferSize + 3 {
+		client.RecordWarning(fmt.Sprintf("warning %d", i), "")
+	}
+
+	warnings := client.Warnings()
+	if len(warnings) != warningBufferSize {
+		t.Fatalf("unexpected warning count: got %d, want %d", len(warnings), warningBufferSize)
+	}
+	if got, want := warnings[0].Summary, "warning 3"; got != want {
+		t.Fatalf("unexpected oldest warning: got %q, want %q", got, want)
+	}
+	if got, want := warnings[len(warnings)-1].Summary, fmt.Sprintf("warning %d", warningBufferSize+2); got != want {
+		t.Fatalf("unexpected newest warning: got %q, want %q", got, want)
+	}
+}
+
+func TestClientRecordsDeprecationWarning(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set(headerDeprecation, "true")
+		w.Header().Set(headerSunset, "Wed, 01 Jul 2026 00:00:00 GMT")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", server.Client())
+
+	for range 2 {
+		if _, err := client.GetTokenScopes(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	warnings := client.Warnings()
+	if len(warnings) != 1 {
+		t.Fatalf("expected one deprecation warning, got %v", warnings)
+	}
+	if !strings.Contains(warnings[0].Detail, "GET "+routeTokenScopes) || !strings.Contains(warnings[0].Detail, "01 Jul 2026") {
+		t.Fatalf("unexpected warning detail: %q", warnings[0].Detail)
+	}
+}
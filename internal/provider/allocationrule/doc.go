@@ -0,0 +1,2 @@
+// Package allocationrule contains the Costory cost allocation rule Terraform resource.
+package allocationrule
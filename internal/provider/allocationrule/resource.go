@@ -0,0 +1,266 @@
+package allocationrule
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/costory-io/costory-terraform/internal/costoryapi"
+	"github.com/costory-io/costory-terraform/internal/provider/retrywarning"
+	"github.com/costory-io/costory-terraform/internal/provider/statediff"
+	"github.com/costory-io/costory-terraform/internal/provider/versiondiag"
+)
+
+var (
+	_ resource.Resource                = &allocationRuleResource{}
+	_ resource.ResourceWithConfigure   = &allocationRuleResource{}
+	_ resource.ResourceWithImportState = &allocationRuleResource{}
+)
+
+type allocationRuleResource struct {
+	client *costoryapi.Client
+}
+
+type allocationRuleResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	ImportID    types.String `tfsdk:"import_id"`
+	Name        types.String `tfsdk:"name"`
+	Match       types.String `tfsdk:"match"`
+	TargetLabel types.String `tfsdk:"target_label"`
+}
+
+// NewResource returns the cost allocation rule resource.
+func NewResource() resource.Resource {
+	return &allocationRuleResource{}
+}
+
+func (r *allocationRuleResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_allocation_rule", req.ProviderTypeName)
+}
+
+func (r *allocationRuleResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a Costory cost allocation rule.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Allocation rule ID returned by Costory.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"import_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Value to pass to `terraform import` for this allocation rule. Always the rule ID.",
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Allocation rule display name.",
+			},
+			"match": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Filter expression selecting the costs the rule applies to.",
+			},
+			"target_label": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Label assigned to costs matched by the rule.",
+			},
+		},
+	}
+}
+
+func (r *allocationRuleResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*costoryapi.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			costoryapi.VersionedSummary("Unexpected resource configure type", req.ProviderData),
+			fmt.Sprintf("Expected *costoryapi.Client, got: %T. This is always a provider implementation bug.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *allocationRuleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured Costory client",
+			"The provider did not configure the Costory API client for the resource.",
+		)
+		return
+	}
+
+	ctx, cancel := r.client.OperationContext(ctx)
+	defer cancel()
+	defer retrywarning.Add(ctx, &resp.Diagnostics)
+	defer versiondiag.Add(r.client, &resp.Diagnostics)
+
+	var plan allocationRuleResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createCtx := costoryapi.WithIdempotencyKey(ctx, costoryapi.NewIdempotencyKey())
+	created, err := r.client.CreateAllocationRule(createCtx, plan.toRequest())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to create allocation rule",
+			err.Error(),
+		)
+		return
+	}
+
+	plan.mergeAPIResponse(created)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *allocationRuleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured Costory client",
+			"The provider did not configure the Costory API client for the resource.",
+		)
+		return
+	}
+
+	ctx, cancel := r.client.OperationContext(ctx)
+	defer cancel()
+	defer retrywarning.Add(ctx, &resp.Diagnostics)
+	defer versiondiag.Add(r.client, &resp.Diagnostics)
+
+	var state allocationRuleResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current, err := r.client.GetAllocationRule(ctx, state.ID.ValueString())
+	if err != nil {
+		if errors.Is(err, costoryapi.ErrNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Unable to read allocation rule",
+			err.Error(),
+		)
+		return
+	}
+
+	state.mergeAPIResponse(current)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	statediff.Log(ctx, "read", req.State, resp.State)
+}
+
+func (r *allocationRuleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured Costory client",
+			"The provider did not configure the Costory API client for the resource.",
+		)
+		return
+	}
+
+	ctx, cancel := r.client.OperationContext(ctx)
+	defer cancel()
+	defer retrywarning.Add(ctx, &resp.Diagnostics)
+	defer versiondiag.Add(r.client, &resp.Diagnostics)
+
+	var plan allocationRuleResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state allocationRuleResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updated, err := r.client.UpdateAllocationRule(ctx, state.ID.ValueString(), plan.toRequest())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to update allocation rule",
+			err.Error(),
+		)
+		return
+	}
+
+	plan.mergeAPIResponse(updated)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	statediff.Log(ctx, "update", req.State, resp.State)
+}
+
+func (r *allocationRuleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured Costory client",
+			"The provider did not configure the Costory API client for the resource.",
+		)
+		return
+	}
+
+	ctx, cancel := r.client.OperationContext(ctx)
+	defer cancel()
+	defer retrywarning.Add(ctx, &resp.Diagnostics)
+	defer versiondiag.Add(r.client, &resp.Diagnostics)
+
+	var state allocationRuleResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteAllocationRule(ctx, state.ID.ValueString())
+	if err != nil && (!errors.Is(err, costoryapi.ErrNotFound) || r.client.FailDeleteOnMissing()) {
+		resp.Diagnostics.AddError(
+			"Unable to delete allocation rule",
+			err.Error(),
+		)
+		return
+	}
+}
+
+func (r *allocationRuleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+func (m allocationRuleResourceModel) toRequest() costoryapi.AllocationRuleRequest {
+	return costoryapi.AllocationRuleRequest{
+		Name:        m.Name.ValueString(),
+		Match:       m.Match.ValueString(),
+		TargetLabel: m.TargetLabel.ValueString(),
+	}
+}
+
+func (m *allocationRuleResourceModel) mergeAPIResponse(apiResponse *costoryapi.AllocationRule) {
+	if apiResponse == nil {
+		return
+	}
+
+	if apiResponse.ID != "" {
+		m.ID = types.StringValue(apiResponse.ID)
+	}
+
+	m.Name = types.StringValue(apiResponse.Name)
+	m.Match = types.StringValue(apiResponse.Match)
+	m.TargetLabel = types.StringValue(apiResponse.TargetLabel)
+	m.ImportID = m.ID
+}
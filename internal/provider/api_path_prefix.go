@@ -0,0 +1,32 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// parseAPIPathPrefix parses api_path_prefix as an absolute URL path such as
+// "/api/v1/terraform". A null or unknown value yields "", which keeps the default /terraform.
+func parseAPIPathPrefix(value types.String) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if value.IsNull() || value.IsUnknown() {
+		return "", diags
+	}
+
+	prefix := strings.TrimSpace(value.ValueString())
+	if !strings.HasPrefix(prefix, "/") || prefix == "/" || strings.ContainsAny(prefix, "?# \t") || strings.Contains(prefix, "//") {
+		diags.AddAttributeError(
+			path.Root("api_path_prefix"),
+			"Invalid Costory API path prefix",
+			fmt.Sprintf("api_path_prefix must be an absolute URL path other than the root, such as \"/api/v1/terraform\", without a query or fragment, got %q.", value.ValueString()),
+		)
+		return "", diags
+	}
+
+	return strings.TrimRight(prefix, "/"), diags
+}
@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/costory-io/costory-terraform/internal/costoryapi"
+)
+
+func TestParseAPIPathPrefix(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		value     types.String
+		want      string
+		wantError bool
+	}{
+		"unset":          {value: types.StringNull(), want: "/terraform"},
+		"unknown":        {value: types.StringUnknown(), want: "/terraform"},
+		"prefixed":       {value: types.StringValue("/api/v1/terraform"), want: "/api/v1/terraform"},
+		"trailing slash": {value: types.StringValue("/api/v1/terraform/"), want: "/api/v1/terraform"},
+		"surrounding":    {value: types.StringValue(" /costory "), want: "/costory"},
+		"relative":       {value: types.StringValue("api/v1/terraform"), wantError: true},
+		"query":          {value: types.StringValue("/terraform?tenant=acme"), wantError: true},
+		"double slash":   {value: types.StringValue("/api//terraform"), wantError: true},
+		"root":           {value: types.StringValue("/"), wantError: true},
+		"empty":          {value: types.StringValue(""), wantError: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			prefix, diags := parseAPIPathPrefix(tc.value)
+			if diags.HasError() != tc.wantError {
+				t.Fatalf("unexpected error result: got %t, want %t: %v", diags.HasError(), tc.wantError, diags)
+			}
+			if tc.wantError {
+				return
+			}
+
+			client := costoryapi.NewClient("https://example.invalid", "test-token", nil, costoryapi.WithAPIPathPrefix(prefix))
+			if got := client.APIPathPrefix(); got != tc.want {
+				t.Fatalf("unexpected client path prefix: got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
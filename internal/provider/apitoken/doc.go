@@ -0,0 +1,2 @@
+// Package apitoken contains the Costory API token Terraform resource.
+package apitoken
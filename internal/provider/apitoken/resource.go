@@ -0,0 +1,245 @@
+package apitoken
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/costory-io/costory-terraform/internal/costoryapi"
+	"github.com/costory-io/costory-terraform/internal/provider/retrywarning"
+	"github.com/costory-io/costory-terraform/internal/provider/statediff"
+	"github.com/costory-io/costory-terraform/internal/provider/versiondiag"
+)
+
+var (
+	_ resource.Resource              = &apiTokenResource{}
+	_ resource.ResourceWithConfigure = &apiTokenResource{}
+)
+
+type apiTokenResource struct {
+	client *costoryapi.Client
+}
+
+type apiTokenResourceModel struct {
+	ID     types.String `tfsdk:"id"`
+	Name   types.String `tfsdk:"name"`
+	Scopes types.List   `tfsdk:"scopes"`
+	Token  types.String `tfsdk:"token"`
+}
+
+// NewResource returns the API token resource.
+func NewResource() resource.Resource {
+	return &apiTokenResource{}
+}
+
+func (r *apiTokenResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_api_token", req.ProviderTypeName)
+}
+
+func (r *apiTokenResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a Costory API token. The token value is only returned when the token is created; Costory never returns it again, so the token cannot be imported and any change replaces it with a new one.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "API token ID returned by Costory.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "API token display name.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"scopes": schema.ListAttribute{
+				Required:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Scopes granted to the token, for example `read` and `write`.",
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"token": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "Secret token value. Set from the create response only and kept as-is afterwards, since Costory never returns it again.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *apiTokenResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*costoryapi.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			costoryapi.VersionedSummary("Unexpected resource configure type", req.ProviderData),
+			fmt.Sprintf("Expected *costoryapi.Client, got: %T. This is always a provider implementation bug.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *apiTokenResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured Costory client",
+			"The provider did not configure the Costory API client for the resource.",
+		)
+		return
+	}
+
+	ctx, cancel := r.client.OperationContext(ctx)
+	defer cancel()
+	defer retrywarning.Add(ctx, &resp.Diagnostics)
+	defer versiondiag.Add(r.client, &resp.Diagnostics)
+
+	var plan apiTokenResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var scopes []string
+	resp.Diagnostics.Append(plan.Scopes.ElementsAs(ctx, &scopes, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createCtx := costoryapi.WithIdempotencyKey(ctx, costoryapi.NewIdempotencyKey())
+	token, err := r.client.CreateAPIToken(createCtx, costoryapi.APITokenRequest{
+		Name:   plan.Name.ValueString(),
+		Scopes: scopes,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to create API token",
+			err.Error(),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(token.ID)
+	plan.Token = types.StringValue(token.Token)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the token name and scopes and removes a revoked token from state. The
+// secret value is kept from prior state, since Costory never returns it again.
+func (r *apiTokenResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured Costory client",
+			"The provider did not configure the Costory API client for the resource.",
+		)
+		return
+	}
+
+	ctx, cancel := r.client.OperationContext(ctx)
+	defer cancel()
+	defer retrywarning.Add(ctx, &resp.Diagnostics)
+	defer versiondiag.Add(r.client, &resp.Diagnostics)
+
+	var state apiTokenResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current, err := r.client.GetAPIToken(ctx, state.ID.ValueString())
+	if err != nil {
+		if errors.Is(err, costoryapi.ErrNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Unable to read API token",
+			err.Error(),
+		)
+		return
+	}
+
+	if current.Name != "" {
+		state.Name = types.StringValue(current.Name)
+	}
+	if current.Scopes != nil && !sameScopes(ctx, state.Scopes, current.Scopes) {
+		scopes, diags := types.ListValueFrom(ctx, types.StringType, current.Scopes)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		state.Scopes = scopes
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	statediff.Log(ctx, "read", req.State, resp.State)
+}
+
+func (r *apiTokenResource) Update(_ context.Context, _ resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.AddError(
+		"Update not supported",
+		"All attributes of an API token are immutable. Terraform should replace the resource instead.",
+	)
+}
+
+func (r *apiTokenResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured Costory client",
+			"The provider did not configure the Costory API client for the resource.",
+		)
+		return
+	}
+
+	ctx, cancel := r.client.OperationContext(ctx)
+	defer cancel()
+	defer retrywarning.Add(ctx, &resp.Diagnostics)
+	defer versiondiag.Add(r.client, &resp.Diagnostics)
+
+	var state apiTokenResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteAPIToken(ctx, state.ID.ValueString())
+	if err != nil && (!errors.Is(err, costoryapi.ErrNotFound) || r.client.FailDeleteOnMissing()) {
+		resp.Diagnostics.AddError(
+			"Unable to delete API token",
+			err.Error(),
+		)
+		return
+	}
+}
+
+// sameScopes reports whether scopes holds exactly the scopes the API returned, in any order,
+// so a server that reorders them does not replace the token.
+func sameScopes(ctx context.Context, scopes types.List, current []string) bool {
+	var configured []string
+	if diags := scopes.ElementsAs(ctx, &configured, false); diags.HasError() {
+		return false
+	}
+
+	return slices.Equal(slices.Sorted(slices.Values(configured)), slices.Sorted(slices.Values(current)))
+}
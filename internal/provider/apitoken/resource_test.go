@@ -0,0 +1,209 @@
+package apitoken
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/costory-io/costory-terraform/internal/costoryapi"
+)
+
+func newTestResource(t *testing.T, handler http.HandlerFunc, opts ...costoryapi.Option) (*apiTokenResource, schema.Schema) {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	r := &apiTokenResource{client: costoryapi.NewClient(server.URL, "test-token", server.Client(), opts...)}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+
+	return r, schemaResp.Schema
+}
+
+func testScopes(t *testing.T) types.List {
+	t.Helper()
+
+	scopes, diags := types.ListValueFrom(context.Background(), types.StringType, []string{"read"})
+	if diags.HasError() {
+		t.Fatalf("unable to build scopes: %v", diags)
+	}
+
+	return scopes
+}
+
+func TestAPITokenResourceCreate(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	r, s := newTestResource(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/terraform/tokens" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":"token-1","name":"ci","scopes":["read"],"token":"cst_secret"}`))
+	})
+
+	plan := tfsdk.Plan{Schema: s}
+	if diags := plan.Set(ctx, &apiTokenResourceModel{
+		ID:     types.StringUnknown(),
+		Name:   types.StringValue("ci"),
+		Scopes: testScopes(t),
+		Token:  types.StringUnknown(),
+	}); diags.HasError() {
+		t.Fatalf("unable to build plan: %v", diags)
+	}
+
+	resp := resource.CreateResponse{State: tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var got apiTokenResourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &got)...)
+	if got.ID.ValueString() != "token-1" || got.Token.ValueString() != "cst_secret" {
+		t.Fatalf("unexpected state after create: %+v", got)
+	}
+}
+
+func TestAPITokenResourceReadKeepsToken(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	var calls atomic.Int32
+	r, s := newTestResource(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/terraform/tokens/token-1" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		calls.Add(1)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"id":"token-1","name":"ci-renamed","scopes":["read"]}`))
+	})
+
+	if token, ok := s.Attributes["token"].(schema.StringAttribute); !ok || !token.Sensitive {
+		t.Fatal("expected token to be a sensitive string attribute")
+	}
+
+	for name, token := range map[string]types.String{
+		"after create": types.StringValue("cst_secret"),
+		"absent":       types.StringNull(),
+	} {
+		state := tfsdk.State{Schema: s}
+		if diags := state.Set(ctx, &apiTokenResourceModel{
+			ID:     types.StringValue("token-1"),
+			Name:   types.StringValue("ci"),
+			Scopes: testScopes(t),
+			Token:  token,
+		}); diags.HasError() {
+			t.Fatalf("%s: unable to build state: %v", name, diags)
+		}
+
+		resp := resource.ReadResponse{State: tfsdk.State{Schema: s}}
+		r.Read(ctx, resource.ReadRequest{State: state}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("%s: unexpected diagnostics: %v", name, resp.Diagnostics)
+		}
+
+		var got apiTokenResourceModel
+		resp.Diagnostics.Append(resp.State.Get(ctx, &got)...)
+		if !got.Token.Equal(token) {
+			t.Fatalf("%s: unexpected token after read: got %s, want %s", name, got.Token, token)
+		}
+		if got.Name.ValueString() != "ci-renamed" {
+			t.Fatalf("%s: expected read to refresh the name, got %s", name, got.Name)
+		}
+	}
+
+	if got := calls.Load(); got != 2 {
+		t.Fatalf("expected one API call per read, got %d calls", got)
+	}
+}
+
+func TestAPITokenResourceReadRemovesRevokedToken(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	r, s := newTestResource(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	state := tfsdk.State{Schema: s}
+	if diags := state.Set(ctx, &apiTokenResourceModel{
+		ID:     types.StringValue("token-1"),
+		Name:   types.StringValue("ci"),
+		Scopes: testScopes(t),
+		Token:  types.StringValue("cst_secret"),
+	}); diags.HasError() {
+		t.Fatalf("unable to build state: %v", diags)
+	}
+
+	resp := resource.ReadResponse{State: state}
+	r.Read(ctx, resource.ReadRequest{State: state}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	if !resp.State.Raw.IsNull() {
+		t.Fatal("expected a revoked token to be removed from state")
+	}
+}
+
+func TestAPITokenResourceDelete(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		status        int
+		failOnMissing bool
+		wantErr       bool
+	}{
+		"deleted":             {status: http.StatusNoContent},
+		"not found":           {status: http.StatusNotFound},
+		"not found when fail": {status: http.StatusNotFound, failOnMissing: true, wantErr: true},
+		"forbidden":           {status: http.StatusForbidden, wantErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			var deleted atomic.Bool
+			r, s := newTestResource(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodDelete || r.URL.Path != "/terraform/tokens/token-1" {
+					t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+				}
+				deleted.Store(true)
+				w.WriteHeader(tc.status)
+			}, costoryapi.WithFailDeleteOnMissing(tc.failOnMissing))
+
+			state := tfsdk.State{Schema: s}
+			if diags := state.Set(ctx, &apiTokenResourceModel{
+				ID:     types.StringValue("token-1"),
+				Name:   types.StringValue("ci"),
+				Scopes: testScopes(t),
+				Token:  types.StringValue("cst_secret"),
+			}); diags.HasError() {
+				t.Fatalf("unable to build state: %v", diags)
+			}
+
+			resp := resource.DeleteResponse{State: state}
+			r.Delete(ctx, resource.DeleteRequest{State: state}, &resp)
+
+			if !deleted.Load() {
+				t.Fatal("expected a delete request")
+			}
+			if got := resp.Diagnostics.HasError(); got != tc.wantErr {
+				t.Fatalf("unexpected error result: got %t, want %t: %v", got, tc.wantErr, resp.Diagnostics)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/costory-io/costory-terraform/internal/costoryapi"
+	"github.com/costory-io/costory-terraform/internal/provider/versiondiag"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = &billingDatasourceSummaryDataSource{}
+	_ datasource.DataSourceWithConfigure = &billingDatasourceSummaryDataSource{}
+)
+
+type billingDatasourceSummaryDataSource struct {
+	client *costoryapi.Client
+}
+
+type billingDatasourceSummaryDataSourceModel struct {
+	ID        types.String  `tfsdk:"id"`
+	Start     types.String  `tfsdk:"start"`
+	End       types.String  `tfsdk:"end"`
+	TotalCost types.Float64 `tfsdk:"total_cost"`
+	Currency  types.String  `tfsdk:"currency"`
+	RowCount  types.Int64   `tfsdk:"row_count"`
+}
+
+// NewBillingDatasourceSummaryDataSource returns the billing datasource cost summary data source.
+func NewBillingDatasourceSummaryDataSource() datasource.DataSource {
+	return &billingDatasourceSummaryDataSource{}
+}
+
+func (d *billingDatasourceSummaryDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_billing_datasource_summary", req.ProviderTypeName)
+}
+
+func (d *billingDatasourceSummaryDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Returns the total cost and row count a billing datasource ingested over a period.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Billing datasource ID.",
+			},
+			"start": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Period start date (YYYY-MM-DD). When omitted, the period starts with the first ingested data.",
+			},
+			"end": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Period end date (YYYY-MM-DD). When omitted, the period ends with the latest ingested data.",
+			},
+			"total_cost": schema.Float64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Total cost ingested over the period. `0` when the period holds no data.",
+			},
+			"currency": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Currency of `total_cost`. Null when the period holds no data.",
+			},
+			"row_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Number of billing rows ingested over the period.",
+			},
+		},
+	}
+}
+
+func (d *billingDatasourceSummaryDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*costoryapi.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			costoryapi.VersionedSummary("Unexpected data source configure type", req.ProviderData),
+			fmt.Sprintf("Expected *costoryapi.Client, got: %T. This is always a provider implementation bug.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *billingDatasourceSummaryDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.client == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured Costory client",
+			"The provider did not configure the Costory API client for the data source.",
+		)
+		return
+	}
+
+	defer versiondiag.Add(d.client, &resp.Diagnostics)
+
+	var state billingDatasourceSummaryDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	summary, err := d.client.GetBillingDatasourceSummary(ctx, state.ID.ValueString(), state.Start.ValueString(), state.End.ValueString())
+	if errors.Is(err, costoryapi.ErrNotFound) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("id"),
+			"Billing datasource summary not found",
+			fmt.Sprintf("No billing datasource with ID %q was found, or the Costory deployment does not provide datasource summaries.", state.ID.ValueString()),
+		)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read billing datasource summary",
+			err.Error(),
+		)
+		return
+	}
+
+	state.TotalCost = types.Float64Value(summary.TotalCost)
+	state.RowCount = types.Int64Value(summary.RowCount)
+	state.Currency = types.StringNull()
+	if summary.Currency != "" {
+		state.Currency = types.StringValue(summary.Currency)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
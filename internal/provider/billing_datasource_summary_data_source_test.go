@@ -0,0 +1,108 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/costory-io/costory-terraform/internal/costoryapi"
+)
+
+func TestBillingDatasourceSummaryDataSourceRead(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		status       int
+		body         string
+		wantError    string
+		wantCost     float64
+		wantCurrency types.String
+		wantRows     int64
+	}{
+		"period": {
+			status:       http.StatusOK,
+			body:         `{"totalCost":99.5,"currency":"USD","rowCount":12}`,
+			wantCost:     99.5,
+			wantCurrency: types.StringValue("USD"),
+			wantRows:     12,
+		},
+		"empty period": {
+			status:       http.StatusOK,
+			body:         `{"totalCost":0,"currency":"","rowCount":0}`,
+			wantCurrency: types.StringNull(),
+		},
+		"not found": {
+			status:    http.StatusNotFound,
+			wantError: "Billing datasource summary not found",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if got, want := r.URL.Path, "/terraform/billingDatasources/ds-1/summary"; got != want {
+					t.Errorf("unexpected path: got %q, want %q", got, want)
+				}
+				if got, want := r.URL.Query().Get("start"), "2025-01-01"; got != want {
+					t.Errorf("unexpected start: got %q, want %q", got, want)
+				}
+				w.WriteHeader(tc.status)
+				_, _ = w.Write([]byte(tc.body))
+			}))
+			defer server.Close()
+
+			ctx := context.Background()
+			dataSource := &billingDatasourceSummaryDataSource{
+				client: costoryapi.NewClient(server.URL, "test-token", server.Client()),
+			}
+
+			var schemaResp datasource.SchemaResponse
+			dataSource.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+			configState := tfsdk.State{Schema: schemaResp.Schema}
+			if diags := configState.Set(ctx, &billingDatasourceSummaryDataSourceModel{
+				ID:    types.StringValue("ds-1"),
+				Start: types.StringValue("2025-01-01"),
+			}); diags.HasError() {
+				t.Fatalf("unable to build config: %v", diags)
+			}
+			config := tfsdk.Config{Schema: schemaResp.Schema, Raw: configState.Raw}
+
+			resp := datasource.ReadResponse{State: tfsdk.State{
+				Schema: schemaResp.Schema,
+				Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+			}}
+			dataSource.Read(ctx, datasource.ReadRequest{Config: config}, &resp)
+
+			if tc.wantError != "" {
+				if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != tc.wantError {
+					t.Fatalf("expected %q error, got %v", tc.wantError, resp.Diagnostics)
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			var got billingDatasourceSummaryDataSourceModel
+			resp.Diagnostics.Append(resp.State.Get(ctx, &got)...)
+			if got.TotalCost.ValueFloat64() != tc.wantCost {
+				t.Fatalf("unexpected total_cost: got %v, want %v", got.TotalCost.ValueFloat64(), tc.wantCost)
+			}
+			if !got.Currency.Equal(tc.wantCurrency) {
+				t.Fatalf("unexpected currency: got %s, want %s", got.Currency, tc.wantCurrency)
+			}
+			if got.RowCount.ValueInt64() != tc.wantRows {
+				t.Fatalf("unexpected row_count: got %d, want %d", got.RowCount.ValueInt64(), tc.wantRows)
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/costory-io/costory-terraform/internal/costoryapi"
+	"github.com/costory-io/costory-terraform/internal/provider/versiondiag"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = &billingDatasourcesByIDsDataSource{}
+	_ datasource.DataSourceWithConfigure = &billingDatasourcesByIDsDataSource{}
+)
+
+var billingDatasourceByIDAttributeTypes = map[string]attr.Type{
+	"id":             types.StringType,
+	"type":           types.StringType,
+	"cloud_provider": types.StringType,
+	"name":           types.StringType,
+	"status":         types.StringType,
+}
+
+type billingDatasourcesByIDsDataSource struct {
+	client *costoryapi.Client
+}
+
+type billingDatasourcesByIDsDataSourceModel struct {
+	IDs         types.List `tfsdk:"ids"`
+	Datasources types.Map  `tfsdk:"datasources"`
+	NotFoundIDs types.List `tfsdk:"not_found_ids"`
+}
+
+type billingDatasourceByIDModel struct {
+	ID            types.String `tfsdk:"id"`
+	Type          types.String `tfsdk:"type"`
+	CloudProvider types.String `tfsdk:"cloud_provider"`
+	Name          types.String `tfsdk:"name"`
+	Status        types.String `tfsdk:"status"`
+}
+
+// NewBillingDatasourcesByIDsDataSource returns the data source reading billing datasources by ID.
+func NewBillingDatasourcesByIDsDataSource() datasource.DataSource {
+	return &billingDatasourcesByIDsDataSource{}
+}
+
+func (d *billingDatasourcesByIDsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_billing_datasources_by_ids", req.ProviderTypeName)
+}
+
+func (d *billingDatasourcesByIDsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads several billing datasources of any type by ID in one data source. IDs that do not exist are listed in `not_found_ids` instead of failing the read.",
+		Attributes: map[string]schema.Attribute{
+			"ids": schema.ListAttribute{
+				Required:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Billing datasource IDs to read. Duplicates are read once.",
+			},
+			"datasources": schema.MapNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Billing datasources that were found, keyed by ID.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Billing datasource ID.",
+						},
+						"type": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Billing datasource type, for example `AWS` or `GCP`.",
+						},
+						"cloud_provider": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Lowercase name of the provider the datasource ingests from, such as `aws` or `gcp`. Unlike `type`, it does not change with `datasource_type_overrides`. Null when the type is not one this provider knows.",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Billing datasource display name.",
+						},
+						"status": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Billing datasource status, when Costory returns one.",
+						},
+					},
+				},
+			},
+			"not_found_ids": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Requested IDs that Costory does not know, in request order.",
+			},
+		},
+	}
+}
+
+func (d *billingDatasourcesByIDsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*costoryapi.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			costoryapi.VersionedSummary("Unexpected data source configure type", req.ProviderData),
+			fmt.Sprintf("Expected *costoryapi.Client, got: %T. This is always a provider implementation bug.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *billingDatasourcesByIDsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.client == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured Costory client",
+			"The provider did not configure the Costory API client for the data source.",
+		)
+		return
+	}
+
+	defer versiondiag.Add(d.client, &resp.Diagnostics)
+
+	var state billingDatasourcesByIDsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var ids []string
+	resp.Diagnostics.Append(state.IDs.ElementsAs(ctx, &ids, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := d.client.GetBillingDatasourcesByIDs(ctx, ids)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read billing datasources",
+			err.Error(),
+		)
+		return
+	}
+
+	models := make(map[string]billingDatasourceByIDModel, len(result.Datasources))
+	for id, datasource := range result.Datasources {
+		models[id] = billingDatasourceByIDModel{
+			ID:            types.StringValue(datasource.ID),
+			Type:          types.StringValue(datasource.Type),
+			CloudProvider: cloudProviderValue(datasource.CloudProvider),
+			Name:          types.StringValue(datasource.Name),
+			Status:        types.StringPointerValue(datasource.Status),
+		}
+	}
+
+	var diags diag.Diagnostics
+	state.Datasources, diags = types.MapValueFrom(ctx, types.ObjectType{AttrTypes: billingDatasourceByIDAttributeTypes}, models)
+	resp.Diagnostics.Append(diags...)
+	state.NotFoundIDs, diags = types.ListValueFrom(ctx, types.StringType, result.NotFound)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// cloudProviderValue maps an empty cloud provider, for an unknown datasource type, to null.
+func cloudProviderValue(cloudProvider string) types.String {
+	if cloudProvider == "" {
+		return types.StringNull()
+	}
+
+	return types.StringValue(cloudProvider)
+}
@@ -0,0 +1,108 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/costory-io/costory-terraform/internal/costoryapi"
+)
+
+func TestBillingDatasourcesByIDsDataSourceRead(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		missingStatus int
+		wantError     string
+	}{
+		"found and missing": {missingStatus: http.StatusNotFound},
+		"failed read":       {missingStatus: http.StatusForbidden, wantError: "Unable to read billing datasources"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/terraform/billingDatasources/ds-aws":
+					_, _ = w.Write([]byte(`{"id":"ds-aws","type":"AWS","name":"aws","status":"ACTIVE"}`))
+				case "/terraform/billingDatasources/ds-gcp":
+					_, _ = w.Write([]byte(`{"id":"ds-gcp","type":"GCP","name":"gcp"}`))
+				default:
+					w.WriteHeader(tc.missingStatus)
+				}
+			}))
+			defer server.Close()
+
+			ctx := context.Background()
+			dataSource := &billingDatasourcesByIDsDataSource{
+				client: costoryapi.NewClient(server.URL, "test-token", server.Client(), costoryapi.WithBatchReadRate(1000)),
+			}
+
+			var schemaResp datasource.SchemaResponse
+			dataSource.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+			ids, diags := types.ListValueFrom(ctx, types.StringType, []string{"ds-aws", "missing", "ds-gcp"})
+			if diags.HasError() {
+				t.Fatalf("unable to build ids: %v", diags)
+			}
+
+			configState := tfsdk.State{Schema: schemaResp.Schema}
+			if diags := configState.Set(ctx, &billingDatasourcesByIDsDataSourceModel{
+				IDs:         ids,
+				Datasources: types.MapNull(types.ObjectType{AttrTypes: billingDatasourceByIDAttributeTypes}),
+				NotFoundIDs: types.ListNull(types.StringType),
+			}); diags.HasError() {
+				t.Fatalf("unable to build config: %v", diags)
+			}
+			config := tfsdk.Config{Schema: schemaResp.Schema, Raw: configState.Raw}
+
+			resp := datasource.ReadResponse{State: tfsdk.State{
+				Schema: schemaResp.Schema,
+				Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+			}}
+			dataSource.Read(ctx, datasource.ReadRequest{Config: config}, &resp)
+
+			if tc.wantError != "" {
+				if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != tc.wantError {
+					t.Fatalf("expected %q error, got %v", tc.wantError, resp.Diagnostics)
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			var got billingDatasourcesByIDsDataSourceModel
+			resp.Diagnostics.Append(resp.State.Get(ctx, &got)...)
+
+			var notFound []string
+			resp.Diagnostics.Append(got.NotFoundIDs.ElementsAs(ctx, &notFound, false)...)
+			var datasources map[string]billingDatasourceByIDModel
+			resp.Diagnostics.Append(got.Datasources.ElementsAs(ctx, &datasources, false)...)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unable to read state: %v", resp.Diagnostics)
+			}
+
+			if len(notFound) != 1 || notFound[0] != "missing" {
+				t.Fatalf("unexpected not_found_ids: %v", notFound)
+			}
+			if len(datasources) != 2 {
+				t.Fatalf("unexpected datasources: %v", datasources)
+			}
+			if aws := datasources["ds-aws"]; aws.Type.ValueString() != "AWS" || aws.Status.ValueString() != "ACTIVE" {
+				t.Fatalf("unexpected aws datasource: %+v", aws)
+			}
+			if gcp := datasources["ds-gcp"]; gcp.Name.ValueString() != "gcp" || !gcp.Status.IsNull() {
+				t.Fatalf("unexpected gcp datasource: %+v", gcp)
+			}
+		})
+	}
+}
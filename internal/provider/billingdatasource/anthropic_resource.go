@@ -4,37 +4,51 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
-	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/costory-io/costory-terraform/internal/costoryapi"
+	"github.com/costory-io/costory-terraform/internal/provider/retrywarning"
+	"github.com/costory-io/costory-terraform/internal/provider/statediff"
+	"github.com/costory-io/costory-terraform/internal/provider/versiondiag"
 )
 
 var (
-	_ resource.Resource                = &anthropicResource{}
-	_ resource.ResourceWithConfigure   = &anthropicResource{}
-	_ resource.ResourceWithImportState = &anthropicResource{}
+	_ resource.Resource                     = &anthropicResource{}
+	_ resource.ResourceWithConfigure        = &anthropicResource{}
+	_ resource.ResourceWithImportState      = &anthropicResource{}
+	_ resource.ResourceWithModifyPlan       = &anthropicResource{}
+	_ resource.ResourceWithConfigValidators = &anthropicResource{}
 )
 
 type anthropicResource struct {
-	client *costoryapi.Client
+	client costoryapi.BillingDatasourceAPI
 }
 
 type anthropicResourceModel struct {
-	ID          types.String `tfsdk:"id"`
-	Status      types.String `tfsdk:"status"`
-	Name        types.String `tfsdk:"name"`
-	Type        types.String `tfsdk:"type"`
-	AdminAPIKey types.String `tfsdk:"admin_api_key"`
-	BQTableURI  types.String `tfsdk:"bq_table_uri"`
-	StartDate   types.String `tfsdk:"start_date"`
-	EndDate     types.String `tfsdk:"end_date"`
+	ID                types.String `tfsdk:"id"`
+	ImportID          types.String `tfsdk:"import_id"`
+	Status            types.String `tfsdk:"status"`
+	ManagedBy         types.String `tfsdk:"managed_by"`
+	CloudProvider     types.String `tfsdk:"cloud_provider"`
+	Name              types.String `tfsdk:"name"`
+	Type              types.String `tfsdk:"type"`
+	AdminAPIKey       types.String `tfsdk:"admin_api_key"`
+	BQTableURI        types.String `tfsdk:"bq_table_uri"`
+	StartDate         types.String `tfsdk:"start_date"`
+	EndDate           types.String `tfsdk:"end_date"`
+	RecreateOnFailure types.Bool   `tfsdk:"recreate_on_failure"`
+	ConfirmDelete     types.Bool   `tfsdk:"confirm_delete"`
+	ExpectedStatus    types.String `tfsdk:"expected_status"`
+	KnownID           types.String `tfsdk:"known_id"`
+	ExtraHeaders      types.Map    `tfsdk:"extra_headers"`
 }
 
 // NewAnthropicResource returns the Anthropic billing datasource resource.
@@ -54,10 +68,13 @@ func (r *anthropicResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				Computed:            true,
 				MarkdownDescription: "Billing datasource ID returned by Costory.",
 			},
+			"import_id": importIDAttribute(),
 			"status": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "Datasource status returned by Costory.",
 			},
+			"managed_by":     managedByAttribute(),
+			"cloud_provider": cloudProviderAttribute(costoryapi.AnthropicBillingDatasourceRequest{}),
 			"name": schema.StringAttribute{
 				Required:            true,
 				MarkdownDescription: "Billing datasource display name.",
@@ -85,32 +102,52 @@ func (r *anthropicResource) Schema(_ context.Context, _ resource.SchemaRequest,
 			},
 			"start_date": schema.StringAttribute{
 				Optional:            true,
-				MarkdownDescription: "Optional filter start date (ISO-8601).",
+				Computed:            true,
+				MarkdownDescription: "Optional filter start date (ISO-8601). When omitted, Costory may compute it; removing it from configuration keeps the current value. Must not be after `end_date`. Also accepts `today` or `today-<N>d`, resolved in the provider `timezone` (UTC by default) when the request is sent and kept as written in state.",
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
+				Validators: []validator.String{
+					relativeDateValidator{},
+				},
 			},
 			"end_date": schema.StringAttribute{
 				Optional:            true,
-				MarkdownDescription: "Optional filter end date (ISO-8601).",
+				Computed:            true,
+				MarkdownDescription: "Optional filter end date (ISO-8601). When omitted, Costory may compute it; removing it from configuration keeps the current value. Must not be before `start_date`. Also accepts `today` or `today-<N>d`, resolved in the provider `timezone` (UTC by default) when the request is sent and kept as written in state.",
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
+				Validators: []validator.String{
+					relativeDateValidator{},
+					futureEndDateWarningValidator(),
+				},
 			},
+			"recreate_on_failure": recreateOnFailureAttribute(),
+			"confirm_delete":      confirmDeleteAttribute(),
+			"expected_status":     expectedStatusAttribute(),
+			"known_id":            knownIDAttribute(),
+			"extra_headers":       extraHeadersAttribute(),
 		},
 	}
 }
 
+func (r *anthropicResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{dateWindowValidator{}}
+}
+
 func (r *anthropicResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*costoryapi.Client)
+	client, ok := req.ProviderData.(costoryapi.BillingDatasourceAPI)
 	if !ok {
 		resp.Diagnostics.AddError(
-			"Unexpected resource configure type",
-			fmt.Sprintf("Expected *costoryapi.Client, got: %T. This is always a provider implementation bug.", req.ProviderData),
+			costoryapi.VersionedSummary("Unexpected resource configure type", req.ProviderData),
+			fmt.Sprintf("Expected costoryapi.BillingDatasourceAPI, got: %T. This is always a provider implementation bug.", req.ProviderData),
 		)
 		return
 	}
@@ -127,13 +164,31 @@ func (r *anthropicResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
+	ctx, cancel := r.client.OperationContext(ctx)
+	defer cancel()
+	defer retrywarning.Add(ctx, &resp.Diagnostics)
+	defer versiondiag.Add(r.client, &resp.Diagnostics)
+
 	var plan anthropicResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = withExtraHeaders(ctx, plan.ExtraHeaders)
 
-	createRequest := plan.toRequestModel()
+	existing, diags := adoptKnownID(ctx, plan.KnownID, "Anthropic", r.client.GetAnthropicBillingDatasource, plan.knownIDFields)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if existing != nil {
+		plan.ID = plan.KnownID
+		plan.mergeAPIResponse(existing)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+		return
+	}
+
+	createRequest := plan.toRequestModel(r.client.Now)
 
 	if err := r.client.ValidateAnthropicBillingDatasource(ctx, createRequest); err != nil {
 		resp.Diagnostics.AddError(
@@ -143,7 +198,8 @@ func (r *anthropicResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
-	created, err := r.client.CreateAnthropicBillingDatasource(ctx, createRequest)
+	createCtx := costoryapi.WithIdempotencyKey(ctx, costoryapi.NewIdempotencyKey())
+	created, err := r.client.CreateAnthropicBillingDatasource(createCtx, createRequest)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to create Anthropic billing datasource",
@@ -154,6 +210,8 @@ func (r *anthropicResource) Create(ctx context.Context, req resource.CreateReque
 
 	plan.ID = types.StringValue(created.ID)
 	plan.mergeAPIResponse(created)
+	addCreateWarnings(&resp.Diagnostics, r.client, "Anthropic", created.Warnings)
+	addStartDateWarning(&resp.Diagnostics, r.client, "Anthropic", createRequest.StartDate, created.EarliestAvailableDate)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
@@ -167,11 +225,17 @@ func (r *anthropicResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
+	ctx, cancel := r.client.OperationContext(ctx)
+	defer cancel()
+	defer retrywarning.Add(ctx, &resp.Diagnostics)
+	defer versiondiag.Add(r.client, &resp.Diagnostics)
+
 	var state anthropicResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = withExtraHeaders(ctx, state.ExtraHeaders)
 
 	current, err := r.client.GetAnthropicBillingDatasource(ctx, state.ID.ValueString())
 	if err != nil {
@@ -193,13 +257,35 @@ func (r *anthropicResource) Read(ctx context.Context, req resource.ReadRequest,
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	statediff.Log(ctx, "read", req.State, resp.State)
+	checkStrictDrift(ctx, r.client, req, resp)
 }
 
-func (r *anthropicResource) Update(_ context.Context, _ resource.UpdateRequest, resp *resource.UpdateResponse) {
-	resp.Diagnostics.AddError(
-		"Update not supported",
-		"All attributes are immutable for costory_billing_datasource_anthropic. Terraform should replace the resource instead.",
-	)
+func (r *anthropicResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Every API-backed attribute requires replacement, so only provider-side
+	// settings such as recreate_on_failure can change in place.
+	var plan, state anthropicResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.RecreateOnFailure = plan.RecreateOnFailure
+	state.ConfirmDelete = plan.ConfirmDelete
+	state.ExpectedStatus = plan.ExpectedStatus
+	state.KnownID = plan.KnownID
+	state.ExtraHeaders = plan.ExtraHeaders
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	statediff.Log(ctx, "update", req.State, resp.State)
+}
+
+func (r *anthropicResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planRecreateOnFailure(ctx, req, resp)
+	planExpectedStatus(ctx, req, resp)
+	planDatasourceTypeSupported(ctx, r.client, costoryapi.AnthropicBillingDatasourceRequest{}, req, resp)
+	planDateWindow(ctx, r.client, req, resp)
 }
 
 func (r *anthropicResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -211,14 +297,25 @@ func (r *anthropicResource) Delete(ctx context.Context, req resource.DeleteReque
 		return
 	}
 
+	ctx, cancel := r.client.OperationContext(ctx)
+	defer cancel()
+	defer retrywarning.Add(ctx, &resp.Diagnostics)
+	defer versiondiag.Add(r.client, &resp.Diagnostics)
+
 	var state anthropicResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = withExtraHeaders(ctx, state.ExtraHeaders)
+
+	resp.Diagnostics.Append(deleteConfirmationDiagnostics(r.client, state.ConfirmDelete)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	err := r.client.DeleteBillingDatasource(ctx, state.ID.ValueString())
-	if err != nil && !errors.Is(err, costoryapi.ErrNotFound) {
+	if err != nil && (!errors.Is(err, costoryapi.ErrNotFound) || r.client.FailDeleteOnMissing()) {
 		resp.Diagnostics.AddError(
 			"Unable to delete Anthropic billing datasource",
 			err.Error(),
@@ -228,24 +325,18 @@ func (r *anthropicResource) Delete(ctx context.Context, req resource.DeleteReque
 }
 
 func (r *anthropicResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateWithTypePrefix(ctx, importPrefixAnthropic, req, resp)
 }
 
-func (m anthropicResourceModel) toRequestModel() costoryapi.AnthropicBillingDatasourceRequest {
+func (m anthropicResourceModel) toRequestModel(now func() time.Time) costoryapi.AnthropicBillingDatasourceRequest {
 	req := costoryapi.AnthropicBillingDatasourceRequest{
 		Name:        m.Name.ValueString(),
 		AdminAPIKey: m.AdminAPIKey.ValueString(),
 	}
 
-	if !m.StartDate.IsNull() && !m.StartDate.IsUnknown() {
-		value := m.StartDate.ValueString()
-		req.StartDate = &value
-	}
+	req.StartDate = requestDate(m.StartDate, now)
 
-	if !m.EndDate.IsNull() && !m.EndDate.IsUnknown() {
-		value := m.EndDate.ValueString()
-		req.EndDate = &value
-	}
+	req.EndDate = requestDate(m.EndDate, now)
 
 	return req
 }
@@ -259,11 +350,15 @@ func (m *anthropicResourceModel) mergeAPIResponse(apiResponse *costoryapi.Anthro
 		m.ID = types.StringValue(apiResponse.ID)
 	}
 
+	m.CloudProvider = cloudProviderValue(costoryapi.AnthropicBillingDatasourceRequest{})
+
 	m.Status = types.StringNull()
 	if apiResponse.Status != nil {
 		m.Status = types.StringValue(*apiResponse.Status)
 	}
 
+	m.ManagedBy = mergeManagedBy(apiResponse.ManagedBy)
+
 	if apiResponse.Name != "" {
 		m.Name = types.StringValue(apiResponse.Name)
 	}
@@ -276,11 +371,16 @@ func (m *anthropicResourceModel) mergeAPIResponse(apiResponse *costoryapi.Anthro
 		m.BQTableURI = types.StringValue(apiResponse.BQTableURI)
 	}
 
-	if apiResponse.StartDate != nil {
-		m.StartDate = types.StringValue(*apiResponse.StartDate)
-	}
+	m.StartDate = mergeOptionalDate(m.StartDate, apiResponse.StartDate)
+
+	m.EndDate = mergeOptionalDate(m.EndDate, apiResponse.EndDate)
+
+	m.ImportID = importIDValue(importPrefixAnthropic, m.ID)
+}
 
-	if apiResponse.EndDate != nil {
-		m.EndDate = types.StringValue(*apiResponse.EndDate)
+// knownIDFields lists the immutable attributes compared with the datasource known_id names.
+func (m anthropicResourceModel) knownIDFields(existing *costoryapi.AnthropicBillingDatasource) []knownIDField {
+	return []knownIDField{
+		{attribute: "name", configured: m.Name, actual: existing.Name},
 	}
 }
@@ -0,0 +1,82 @@
+package billingdatasource
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/costory-io/costory-terraform/internal/costoryapi"
+)
+
+const redactedAPIRequestValue = "(sensitive value)"
+
+func showAPIRequestAttribute() schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Optional:            true,
+		MarkdownDescription: "When `true`, planning a new datasource shows the JSON body the provider will send to Costory on create, with sensitive values redacted. Useful for debugging field mapping. Defaults to `false`.",
+	}
+}
+
+// apiRequestPreviewer is implemented by resource models whose create request can be shown
+// with show_api_request. redactedFields lists the JSON fields holding sensitive values.
+type apiRequestPreviewer interface {
+	createRequest(now func() time.Time) costoryapi.BillingDatasourceCreateRequest
+	redactedAPIRequestFields() []string
+}
+
+// planShowAPIRequest adds a warning containing the create request JSON when a new datasource
+// is planned with show_api_request. Terraform has no informational severity, so a warning
+// is the only way to surface it in plan output. Values unknown at plan time show as empty.
+func planShowAPIRequest[M apiRequestPreviewer](ctx context.Context, client costoryapi.BillingDatasourceAPI, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if client == nil || !req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var show types.Bool
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("show_api_request"), &show)...)
+	if resp.Diagnostics.HasError() || !show.ValueBool() {
+		return
+	}
+
+	var plan M
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	body, err := redactedAPIRequestJSON(client, plan.createRequest(client.Now), plan.redactedAPIRequestFields())
+	if err != nil {
+		resp.Diagnostics.AddWarning("Unable to show Costory API request", err.Error())
+		return
+	}
+
+	resp.Diagnostics.AddWarning(
+		"Costory API request preview",
+		"Creating this datasource will send the following JSON body to Costory:\n\n"+string(body),
+	)
+}
+
+func redactedAPIRequestJSON(client costoryapi.BillingDatasourceAPI, createRequest costoryapi.BillingDatasourceCreateRequest, redactedFields []string) ([]byte, error) {
+	body, err := client.BillingDatasourceRequestJSON(createRequest)
+	if err != nil || len(redactedFields) == 0 {
+		return body, err
+	}
+
+	var payload map[string]any
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, err
+	}
+
+	for _, field := range redactedFields {
+		if _, ok := payload[field]; ok {
+			payload[field] = redactedAPIRequestValue
+		}
+	}
+
+	return json.MarshalIndent(payload, "", "  ")
+}
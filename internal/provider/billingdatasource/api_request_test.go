@@ -0,0 +1,105 @@
+package billingdatasource
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/costory-io/costory-terraform/internal/costoryapi"
+)
+
+func TestGCPResourceModifyPlanShowAPIRequest(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		show     bool
+		wantKeys []string
+	}{
+		"shown":    {show: true, wantKeys: []string{`"type": "GCP"`, `"name": "GCP Billing"`, `"bqTablePath": "project.dataset.table"`, `"billingAccountId": "01A2B3-C4D5E6-F7A8B9"`}},
+		"disabled": {show: false},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			model := testGCPModel()
+			model.BillingAccountID = types.StringValue("01A2B3-C4D5E6-F7A8B9")
+			model.ShowAPIRequest = types.BoolValue(tc.show)
+
+			resp := modifyNewPlan(t, NewGCPResource(), &model)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error diagnostics: %v", resp.Diagnostics)
+			}
+			if len(tc.wantKeys) == 0 {
+				if resp.Diagnostics.WarningsCount() != 0 {
+					t.Fatalf("unexpected warnings: %v", resp.Diagnostics)
+				}
+				return
+			}
+
+			detail := previewDetail(t, resp)
+			for _, key := range tc.wantKeys {
+				if !strings.Contains(detail, key) {
+					t.Fatalf("expected preview to contain %s, got:\n%s", key, detail)
+				}
+			}
+		})
+	}
+}
+
+func TestAWSResourceModifyPlanShowAPIRequestRedactsSensitiveRoleARN(t *testing.T) {
+	t.Parallel()
+
+	model := testAWSModel()
+	model.RoleARN = types.StringNull()
+	model.SensitiveRoleARN = types.StringValue(testRoleARN)
+	model.ShowAPIRequest = types.BoolValue(true)
+
+	detail := previewDetail(t, modifyNewPlan(t, NewAWSResource(), &model))
+
+	if strings.Contains(detail, testRoleARN) {
+		t.Fatalf("expected role ARN to be redacted, got:\n%s", detail)
+	}
+	for _, key := range []string{`"roleArn": "` + redactedAPIRequestValue + `"`, `"bucketName": "billing-bucket"`, `"prefix": "cur/"`} {
+		if !strings.Contains(detail, key) {
+			t.Fatalf("expected preview to contain %s, got:\n%s", key, detail)
+		}
+	}
+}
+
+// modifyNewPlan runs ModifyPlan for a create of model. The client points at an unroutable
+// host, so the capabilities check fails and is ignored.
+func modifyNewPlan(t *testing.T, r resource.Resource, model any) resource.ModifyPlanResponse {
+	t.Helper()
+
+	f := newResourceFixture(t, r, costoryapi.NewClient("https://example.invalid", "test-token", nil))
+	plan := f.plan(model)
+
+	req := resource.ModifyPlanRequest{
+		Config: tfsdk.Config{Schema: f.schema, Raw: plan.Raw},
+		State:  f.nullState(),
+		Plan:   plan,
+	}
+	resp := resource.ModifyPlanResponse{Plan: plan}
+
+	r.(resource.ResourceWithModifyPlan).ModifyPlan(f.ctx, req, &resp)
+	return resp
+}
+
+func previewDetail(t *testing.T, resp resource.ModifyPlanResponse) string {
+	t.Helper()
+
+	for _, d := range resp.Diagnostics.Warnings() {
+		if d.Summary() == "Costory API request preview" {
+			return d.Detail()
+		}
+	}
+
+	t.Fatalf("expected API request preview warning, got: %v", resp.Diagnostics)
+	return ""
+}
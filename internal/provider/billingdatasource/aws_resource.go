@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -11,32 +13,59 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/costory-io/costory-terraform/internal/costoryapi"
+	"github.com/costory-io/costory-terraform/internal/provider/retrywarning"
+	"github.com/costory-io/costory-terraform/internal/provider/statediff"
+	"github.com/costory-io/costory-terraform/internal/provider/versiondiag"
 )
 
 var (
-	_ resource.Resource                = &awsResource{}
-	_ resource.ResourceWithConfigure   = &awsResource{}
-	_ resource.ResourceWithImportState = &awsResource{}
+	_ resource.Resource                     = &awsResource{}
+	_ resource.ResourceWithConfigure        = &awsResource{}
+	_ resource.ResourceWithImportState      = &awsResource{}
+	_ resource.ResourceWithValidateConfig   = &awsResource{}
+	_ resource.ResourceWithModifyPlan       = &awsResource{}
+	_ resource.ResourceWithConfigValidators = &awsResource{}
 )
 
 type awsResource struct {
-	client *costoryapi.Client
+	client costoryapi.BillingDatasourceAPI
 }
 
 type awsResourceModel struct {
-	ID                  types.String `tfsdk:"id"`
-	Status              types.String `tfsdk:"status"`
-	Name                types.String `tfsdk:"name"`
-	BucketName          types.String `tfsdk:"bucket_name"`
-	RoleARN             types.String `tfsdk:"role_arn"`
-	Prefix              types.String `tfsdk:"prefix"`
-	EKSSplitDataEnabled types.Bool   `tfsdk:"eks_split_data_enabled"`
-	StartDate           types.String `tfsdk:"start_date"`
-	EndDate             types.String `tfsdk:"end_date"`
-	EKSSplit            types.Bool   `tfsdk:"eks_split"`
+	ID                  types.String  `tfsdk:"id"`
+	ImportID            types.String  `tfsdk:"import_id"`
+	Status              types.String  `tfsdk:"status"`
+	ManagedBy           types.String  `tfsdk:"managed_by"`
+	CloudProvider       types.String  `tfsdk:"cloud_provider"`
+	Name                types.String  `tfsdk:"name"`
+	BucketName          types.String  `tfsdk:"bucket_name"`
+	RoleARN             types.String  `tfsdk:"role_arn"`
+	SensitiveRoleARN    types.String  `tfsdk:"sensitive_role_arn"`
+	Prefix              types.String  `tfsdk:"prefix"`
+	EKSSplitDataEnabled types.Bool    `tfsdk:"eks_split_data_enabled"`
+	StartDate           types.String  `tfsdk:"start_date"`
+	EndDate             types.String  `tfsdk:"end_date"`
+	EKSSplit            types.Bool    `tfsdk:"eks_split"`
+	ReportName          types.String  `tfsdk:"report_name"`
+	Summary             types.String  `tfsdk:"summary"`
+	RecreateOnFailure   types.Bool    `tfsdk:"recreate_on_failure"`
+	ConfirmDelete       types.Bool    `tfsdk:"confirm_delete"`
+	ExpectedStatus      types.String  `tfsdk:"expected_status"`
+	KnownID             types.String  `tfsdk:"known_id"`
+	ExtraHeaders        types.Map     `tfsdk:"extra_headers"`
+	ShowAPIRequest      types.Bool    `tfsdk:"show_api_request"`
+	ValidateRoleAccess  types.Bool    `tfsdk:"validate_role_access"`
+	PrefixIsGlob        types.Bool    `tfsdk:"prefix_is_glob"`
+	BackfillProgress    types.Float64 `tfsdk:"backfill_progress"`
+	Owner               types.String  `tfsdk:"owner"`
+	Priority            types.Int64   `tfsdk:"priority"`
+	LastIngestedAt      types.String  `tfsdk:"last_ingested_at"`
+	DetectedColumns     types.List    `tfsdk:"detected_columns"`
+	Tags                types.Map     `tfsdk:"tags"`
 }
 
 // NewAWSResource returns the AWS billing datasource resource.
@@ -56,30 +85,72 @@ func (r *awsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *
 				Computed:            true,
 				MarkdownDescription: "Billing datasource ID returned by Costory.",
 			},
+			"import_id": importIDAttribute(),
 			"status": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "Datasource status returned by Costory (for example ACTIVE or PENDING).",
 			},
+			"managed_by":     managedByAttribute(),
+			"cloud_provider": cloudProviderAttribute(costoryapi.AWSBillingDatasourceRequest{}),
+			"backfill_progress": schema.Float64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Historical backfill progress as a percentage, refreshed on each read. Null when Costory does not report it.",
+			},
+			"last_ingested_at": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Time of the last successful ingestion (RFC3339), refreshed on each read. Null when Costory has not reported one yet.",
+			},
+			"detected_columns": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Export columns Costory detected, refreshed on each read. Use it to confirm the datasource points at the expected export. Null when Costory does not report them.",
+			},
+			"summary": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Human-readable summary of the datasource and its current status, for outputs.",
+			},
 			"name": schema.StringAttribute{
 				Required:            true,
 				MarkdownDescription: "Billing datasource display name.",
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
+				Validators: []validator.String{
+					noSurroundingWhitespaceValidator(),
+				},
 			},
 			"bucket_name": schema.StringAttribute{
 				Required:            true,
 				MarkdownDescription: "S3 bucket containing AWS billing exports.",
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					requiresReplaceUnlessEquivalent(normalizeBucketName),
+				},
+				Validators: []validator.String{
+					noSurroundingWhitespaceValidator(),
 				},
 			},
 			"role_arn": schema.StringAttribute{
-				Required:            true,
-				MarkdownDescription: "IAM role ARN used by Costory to access AWS billing exports.",
+				Optional:            true,
+				MarkdownDescription: "IAM role ARN used by Costory to access AWS billing exports, optionally with a path such as `arn:aws:iam::123456789012:role/team/costory`. A server response naming the same role without its path is not treated as drift, while changing the path in configuration replaces the datasource. Exactly one of `role_arn` or `sensitive_role_arn` must be set.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					noSurroundingWhitespaceValidator(),
+					awsRoleARNValidator(false),
+				},
+			},
+			"sensitive_role_arn": schema.StringAttribute{
+				Optional:            true,
+				Sensitive:           true,
+				MarkdownDescription: "Same as `role_arn`, but masked in plan output for organizations that treat AWS account IDs as sensitive.",
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
+				Validators: []validator.String{
+					noSurroundingWhitespaceValidator(),
+					awsRoleARNValidator(true),
+				},
 			},
 			"prefix": schema.StringAttribute{
 				Required:            true,
@@ -87,6 +158,16 @@ func (r *awsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
+				Validators: []validator.String{
+					noSurroundingWhitespaceValidator(),
+				},
+			},
+			"prefix_is_glob": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Whether `prefix` is a glob pattern (for example `cur/*/year=2025`) rather than a literal prefix. Glob prefixes are sent to Costory as a pattern.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
 			},
 			"eks_split_data_enabled": schema.BoolAttribute{
 				Optional:            true,
@@ -97,17 +178,28 @@ func (r *awsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *
 			},
 			"start_date": schema.StringAttribute{
 				Optional:            true,
-				MarkdownDescription: "Optional filter start date (YYYY-MM-DD).",
+				Computed:            true,
+				MarkdownDescription: "Optional filter start date (YYYY-MM-DD). When omitted, Costory may compute it; removing it from configuration keeps the current value. Must not be after `end_date`. Also accepts `today` or `today-<N>d`, resolved in the provider `timezone` (UTC by default) when the request is sent and kept as written in state.",
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
+				Validators: []validator.String{
+					relativeDateValidator{},
+				},
 			},
 			"end_date": schema.StringAttribute{
 				Optional:            true,
-				MarkdownDescription: "Optional filter end date (YYYY-MM-DD).",
+				Computed:            true,
+				MarkdownDescription: "Optional filter end date (YYYY-MM-DD). When omitted, Costory may compute it; removing it from configuration keeps the current value. Must not be before `start_date`. Also accepts `today` or `today-<N>d`, resolved in the provider `timezone` (UTC by default) when the request is sent and kept as written in state.",
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
+				Validators: []validator.String{
+					relativeDateValidator{},
+					futureEndDateWarningValidator(),
+				},
 			},
 			"eks_split": schema.BoolAttribute{
 				Optional:            true,
@@ -116,20 +208,89 @@ func (r *awsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *
 					boolplanmodifier.RequiresReplace(),
 				},
 			},
+			"report_name": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Optional CUR report name, used by Costory to locate manifests under the prefix. When unset, the report name Costory detects is kept in state, and removing the attribute does not replace the datasource.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					awsReportNameValidator(),
+				},
+			},
+			"priority": priorityAttribute(),
+			"owner": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Owner of the datasource, used for access control and cost attribution. Can be changed in place; removing it from configuration keeps the current owner.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					noSurroundingWhitespaceValidator(),
+					nonEmptyStringValidator(),
+				},
+			},
+			"tags": tagsAttribute(
+				"Free-form tags applied to the datasource. Keys and values are not restricted beyond being strings, unlike GCP datasources where tags are BigQuery labels. Tags are updated in place; removing the attribute keeps the current tags, while `{}` clears them.",
+			),
+			"recreate_on_failure":  recreateOnFailureAttribute(),
+			"confirm_delete":       confirmDeleteAttribute(),
+			"expected_status":      expectedStatusAttribute(),
+			"known_id":             knownIDAttribute(),
+			"extra_headers":        extraHeadersAttribute(),
+			"show_api_request":     showAPIRequestAttribute(),
+			"validate_role_access": validateRoleAccessAttribute(),
 		},
 	}
 }
 
+func (r *awsResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config awsResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.PrefixIsGlob.ValueBool() && !config.Prefix.IsUnknown() {
+		if err := validateGlobPattern(config.Prefix.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("prefix"),
+				"Invalid AWS prefix glob",
+				fmt.Sprintf("prefix %q is not a valid glob pattern: %s.", config.Prefix.ValueString(), err),
+			)
+		}
+	}
+
+	if config.RoleARN.IsUnknown() || config.SensitiveRoleARN.IsUnknown() {
+		return
+	}
+
+	if config.RoleARN.IsNull() == config.SensitiveRoleARN.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("role_arn"),
+			"Invalid AWS role ARN configuration",
+			"Exactly one of role_arn or sensitive_role_arn must be set.",
+		)
+	}
+}
+
+func (r *awsResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{dateWindowValidator{}}
+}
+
 func (r *awsResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*costoryapi.Client)
+	client, ok := req.ProviderData.(costoryapi.BillingDatasourceAPI)
 	if !ok {
 		resp.Diagnostics.AddError(
-			"Unexpected resource configure type",
-			fmt.Sprintf("Expected *costoryapi.Client, got: %T. This is always a provider implementation bug.", req.ProviderData),
+			costoryapi.VersionedSummary("Unexpected resource configure type", req.ProviderData),
+			fmt.Sprintf("Expected costoryapi.BillingDatasourceAPI, got: %T. This is always a provider implementation bug.", req.ProviderData),
 		)
 		return
 	}
@@ -146,39 +307,71 @@ func (r *awsResource) Create(ctx context.Context, req resource.CreateRequest, re
 		return
 	}
 
+	ctx, cancel := r.client.OperationContext(ctx)
+	defer cancel()
+	defer retrywarning.Add(ctx, &resp.Diagnostics)
+	defer versiondiag.Add(r.client, &resp.Diagnostics)
+
 	var plan awsResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = withExtraHeaders(ctx, plan.ExtraHeaders)
+
+	existing, diags := adoptKnownID(ctx, plan.KnownID, "AWS", r.client.GetAWSBillingDatasource, plan.knownIDFields)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if existing != nil {
+		plan.ID = plan.KnownID
+		plan.mergeAPIResponse(existing)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+		return
+	}
 
-	createRequest := plan.toRequestModel()
+	createRequest := plan.toRequestModel(r.client.Now)
+
+	validation, err := r.client.ValidateAWSBillingDatasourceResult(ctx, createRequest)
+	switch {
+	case errors.Is(err, costoryapi.ErrValidationUnavailable):
+		// An API without the validate endpoint may still accept the create, which then
+		// reports any configuration problem itself.
+		summary := "AWS billing datasource validation skipped"
+		detail := fmt.Sprintf("The Costory API does not provide the validate endpoint, so the datasource is created without pre-validation: %s", err)
+		resp.Diagnostics.AddWarning(summary, detail)
+		r.client.RecordWarning(summary, detail)
+	case err != nil:
+		addAPIErrorDiagnostics(&resp.Diagnostics, "Unable to validate AWS billing datasource", err, plan.validationAttributes())
+		return
+	case !validation.Valid:
+		addValidationIssueDiagnostics(&resp.Diagnostics, "Unable to validate AWS billing datasource", validation.Issues, plan.validationAttributes())
+		return
+	}
 
-	created, err := r.client.CreateAWSBillingDatasource(ctx, createRequest)
+	createCtx := costoryapi.WithIdempotencyKey(ctx, costoryapi.NewIdempotencyKey())
+	created, err := r.client.CreateAWSBillingDatasource(createCtx, createRequest)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to create AWS billing datasource",
-			err.Error(),
-		)
+		addAPIErrorDiagnostics(&resp.Diagnostics, "Unable to create AWS billing datasource", err, plan.validationAttributes())
 		return
 	}
 
 	plan.ID = types.StringValue(created.ID)
 	plan.mergeAPIResponse(created)
+	addCreateWarnings(&resp.Diagnostics, r.client, "AWS", created.Warnings)
+	addStartDateWarning(&resp.Diagnostics, r.client, "AWS", createRequest.StartDate, created.EarliestAvailableDate)
 
 	// Refresh after create so state reflects observed backend status (for example PENDING -> ACTIVE lifecycle).
 	current, err := r.client.GetAWSBillingDatasource(ctx, created.ID)
 	if err != nil {
 		if errors.Is(err, costoryapi.ErrNotFound) {
-			resp.Diagnostics.AddWarning(
-				"Created datasource not yet readable",
-				"Costory accepted datasource creation, but the datasource was not immediately readable. The current create response was stored in state and the next refresh will reconcile observed status.",
-			)
+			summary, detail := "Created datasource not yet readable", "Costory accepted datasource creation, but the datasource was not immediately readable. The current create response was stored in state and the next refresh will reconcile observed status."
+			resp.Diagnostics.AddWarning(summary, detail)
+			r.client.RecordWarning(summary, detail)
 		} else {
-			resp.Diagnostics.AddWarning(
-				"Unable to refresh datasource after create",
-				err.Error(),
-			)
+			resp.Diagnostics.AddWarning("Unable to refresh datasource after create", err.Error())
+			r.client.RecordWarning("Unable to refresh datasource after create", err.Error())
 		}
 	} else {
 		plan.mergeAPIResponse(current)
@@ -196,11 +389,17 @@ func (r *awsResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 		return
 	}
 
+	ctx, cancel := r.client.OperationContext(ctx)
+	defer cancel()
+	defer retrywarning.Add(ctx, &resp.Diagnostics)
+	defer versiondiag.Add(r.client, &resp.Diagnostics)
+
 	var state awsResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = withExtraHeaders(ctx, state.ExtraHeaders)
 
 	current, err := r.client.GetAWSBillingDatasource(ctx, state.ID.ValueString())
 	if err != nil {
@@ -222,13 +421,90 @@ func (r *awsResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	statediff.Log(ctx, "read", req.State, resp.State)
+	checkStrictDrift(ctx, r.client, req, resp)
 }
 
-func (r *awsResource) Update(_ context.Context, _ resource.UpdateRequest, resp *resource.UpdateResponse) {
-	resp.Diagnostics.AddError(
-		"Update not supported",
-		"All attributes are immutable for costory_billing_datasource_aws. Terraform should replace the resource instead.",
-	)
+func (r *awsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured Costory client",
+			"The provider did not configure the Costory API client for the resource.",
+		)
+		return
+	}
+
+	ctx, cancel := r.client.OperationContext(ctx)
+	defer cancel()
+	defer retrywarning.Add(ctx, &resp.Diagnostics)
+	defer versiondiag.Add(r.client, &resp.Diagnostics)
+
+	// Every other API-backed attribute requires replacement, so only owner, priority, tags
+	// and provider-side settings such as recreate_on_failure can change in place.
+	var plan, state awsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = withExtraHeaders(ctx, plan.ExtraHeaders)
+
+	if !plan.Owner.IsUnknown() && !plan.Owner.Equal(state.Owner) {
+		if err := r.client.UpdateBillingDatasourceOwner(ctx, state.ID.ValueString(), plan.Owner.ValueStringPointer()); err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to update AWS billing datasource owner",
+				err.Error(),
+			)
+			return
+		}
+		state.Owner = plan.Owner
+	}
+
+	if !plan.Priority.IsUnknown() && !plan.Priority.Equal(state.Priority) {
+		if err := r.client.UpdateBillingDatasourcePriority(ctx, state.ID.ValueString(), plan.Priority.ValueInt64Pointer()); err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to update AWS billing datasource priority",
+				err.Error(),
+			)
+			return
+		}
+		state.Priority = plan.Priority
+	}
+
+	if !plan.Tags.IsUnknown() && !plan.Tags.Equal(state.Tags) {
+		if err := r.client.UpdateBillingDatasourceTags(ctx, state.ID.ValueString(), tagsFromModel(plan.Tags)); err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to update AWS billing datasource tags",
+				err.Error(),
+			)
+			return
+		}
+		state.Tags = plan.Tags
+	}
+
+	// bucket_name only reaches Update when it differs from state by normalization, so the
+	// configured spelling is adopted without an API call.
+	state.BucketName = plan.BucketName
+	state.RecreateOnFailure = plan.RecreateOnFailure
+	state.ConfirmDelete = plan.ConfirmDelete
+	state.ExpectedStatus = plan.ExpectedStatus
+	state.KnownID = plan.KnownID
+	state.ExtraHeaders = plan.ExtraHeaders
+	state.ShowAPIRequest = plan.ShowAPIRequest
+	state.ValidateRoleAccess = plan.ValidateRoleAccess
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	statediff.Log(ctx, "update", req.State, resp.State)
+}
+
+func (r *awsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planRecreateOnFailure(ctx, req, resp)
+	planExpectedStatus(ctx, req, resp)
+	planDatasourceTypeSupported(ctx, r.client, costoryapi.AWSBillingDatasourceRequest{}, req, resp)
+	planDateWindow(ctx, r.client, req, resp)
+	planFeaturesSupported(ctx, r.client, req, resp)
+	planAWSRoleAccess(ctx, r.client, req, resp)
+	planShowAPIRequest[awsResourceModel](ctx, r.client, req, resp)
 }
 
 func (r *awsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -240,14 +516,25 @@ func (r *awsResource) Delete(ctx context.Context, req resource.DeleteRequest, re
 		return
 	}
 
+	ctx, cancel := r.client.OperationContext(ctx)
+	defer cancel()
+	defer retrywarning.Add(ctx, &resp.Diagnostics)
+	defer versiondiag.Add(r.client, &resp.Diagnostics)
+
 	var state awsResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = withExtraHeaders(ctx, state.ExtraHeaders)
+
+	resp.Diagnostics.Append(deleteConfirmationDiagnostics(r.client, state.ConfirmDelete)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	err := r.client.DeleteBillingDatasource(ctx, state.ID.ValueString())
-	if err != nil && !errors.Is(err, costoryapi.ErrNotFound) {
+	if err != nil && (!errors.Is(err, costoryapi.ErrNotFound) || r.client.FailDeleteOnMissing()) {
 		resp.Diagnostics.AddError(
 			"Unable to delete AWS billing datasource",
 			err.Error(),
@@ -257,15 +544,16 @@ func (r *awsResource) Delete(ctx context.Context, req resource.DeleteRequest, re
 }
 
 func (r *awsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateWithTypePrefix(ctx, importPrefixAWS, req, resp)
 }
 
-func (m awsResourceModel) toRequestModel() costoryapi.AWSBillingDatasourceRequest {
+func (m awsResourceModel) toRequestModel(now func() time.Time) costoryapi.AWSBillingDatasourceRequest {
 	req := costoryapi.AWSBillingDatasourceRequest{
-		Name:       m.Name.ValueString(),
-		BucketName: m.BucketName.ValueString(),
-		RoleARN:    m.RoleARN.ValueString(),
-		Prefix:     m.Prefix.ValueString(),
+		Name:         m.Name.ValueString(),
+		BucketName:   m.BucketName.ValueString(),
+		RoleARN:      m.roleARN(),
+		Prefix:       m.Prefix.ValueString(),
+		PrefixIsGlob: m.PrefixIsGlob.ValueBool(),
 	}
 
 	if !m.EKSSplitDataEnabled.IsNull() && !m.EKSSplitDataEnabled.IsUnknown() {
@@ -273,67 +561,110 @@ func (m awsResourceModel) toRequestModel() costoryapi.AWSBillingDatasourceReques
 		req.EKSSplitDataEnabled = &value
 	}
 
-	if !m.StartDate.IsNull() && !m.StartDate.IsUnknown() {
-		value := m.StartDate.ValueString()
-		req.StartDate = &value
-	}
+	req.StartDate = requestDate(m.StartDate, now)
 
-	if !m.EndDate.IsNull() && !m.EndDate.IsUnknown() {
-		value := m.EndDate.ValueString()
-		req.EndDate = &value
-	}
+	req.EndDate = requestDate(m.EndDate, now)
 
 	if !m.EKSSplit.IsNull() && !m.EKSSplit.IsUnknown() {
 		value := m.EKSSplit.ValueBool()
 		req.EKSSplit = &value
 	}
 
-	return req
-}
-
-func (m *awsResourceModel) mergeAPIResponse(apiResponse *costoryapi.AWSBillingDatasource) {
-	if apiResponse == nil {
-		return
+	if !m.ReportName.IsNull() && !m.ReportName.IsUnknown() {
+		value := m.ReportName.ValueString()
+		req.ReportName = &value
 	}
 
-	if apiResponse.ID != "" {
-		m.ID = types.StringValue(apiResponse.ID)
+	if !m.Owner.IsNull() && !m.Owner.IsUnknown() {
+		value := m.Owner.ValueString()
+		req.Owner = &value
 	}
 
-	m.Status = types.StringNull()
-	if apiResponse.Status != nil {
-		m.Status = types.StringValue(*apiResponse.Status)
+	if !m.Priority.IsNull() && !m.Priority.IsUnknown() {
+		value := m.Priority.ValueInt64()
+		req.Priority = &value
 	}
 
-	if apiResponse.Name != "" {
-		m.Name = types.StringValue(apiResponse.Name)
-	}
+	req.Tags = tagsFromModel(m.Tags)
 
-	if apiResponse.BucketName != "" {
-		m.BucketName = types.StringValue(apiResponse.BucketName)
-	}
+	return req
+}
 
-	if apiResponse.RoleARN != "" {
-		m.RoleARN = types.StringValue(apiResponse.RoleARN)
+func (m awsResourceModel) createRequest(now func() time.Time) costoryapi.BillingDatasourceCreateRequest {
+	return m.toRequestModel(now)
+}
+
+// redactedAPIRequestFields hides the role ARN when it is configured through sensitive_role_arn.
+func (m awsResourceModel) redactedAPIRequestFields() []string {
+	if m.usesSensitiveRoleARN() {
+		return []string{"roleArn"}
 	}
+	return nil
+}
 
-	if apiResponse.Prefix != "" {
-		m.Prefix = types.StringValue(apiResponse.Prefix)
+// roleARN returns the configured role ARN from whichever of role_arn or sensitive_role_arn is set.
+func (m awsResourceModel) roleARN() string {
+	if m.usesSensitiveRoleARN() {
+		return m.SensitiveRoleARN.ValueString()
 	}
+	return m.RoleARN.ValueString()
+}
 
-	if apiResponse.EKSSplitDataEnabled != nil {
-		m.EKSSplitDataEnabled = types.BoolValue(*apiResponse.EKSSplitDataEnabled)
+func (m awsResourceModel) usesSensitiveRoleARN() bool {
+	return !m.SensitiveRoleARN.IsNull() && !m.SensitiveRoleARN.IsUnknown()
+}
+
+// validationAttributes maps API field names used in validation issues to schema attributes.
+// roleArn targets whichever of role_arn or sensitive_role_arn is configured.
+func (m awsResourceModel) validationAttributes() map[string]path.Path {
+	roleARN := path.Root("role_arn")
+	if m.usesSensitiveRoleARN() {
+		roleARN = path.Root("sensitive_role_arn")
 	}
 
-	if apiResponse.StartDate != nil {
-		m.StartDate = types.StringValue(*apiResponse.StartDate)
+	return map[string]path.Path{
+		"owner":               path.Root("owner"),
+		"priority":            path.Root("priority"),
+		"name":                path.Root("name"),
+		"bucketName":          path.Root("bucket_name"),
+		"roleArn":             roleARN,
+		"prefix":              path.Root("prefix"),
+		"eksSplitDataEnabled": path.Root("eks_split_data_enabled"),
+		"startDate":           path.Root("start_date"),
+		"endDate":             path.Root("end_date"),
+		"eksSplit":            path.Root("eks_split"),
+		"reportName":          path.Root("report_name"),
+		"prefixPattern":       path.Root("prefix"),
+		"tags":                path.Root("tags"),
 	}
+}
+
+// mergeAPIResponse overwrites state with every field the server returned, so values the
+// server normalized (for example a trimmed prefix) are written back instead of showing as drift.
+func (m *awsResourceModel) mergeAPIResponse(apiResponse *costoryapi.AWSBillingDatasource) {
+	*m = mergeAWSDatasource(*m, apiResponse)
+
+	m.ImportID = importIDValue(importPrefixAWS, m.ID)
+}
+
+func (m awsResourceModel) summary() string {
+	location := "s3://" + m.BucketName.ValueString() + "/" + strings.TrimLeft(m.Prefix.ValueString(), "/")
+	return fmt.Sprintf("AWS billing datasource '%s' (%s) is %s ingesting from %s", m.Name.ValueString(), m.ID.ValueString(), summaryStatus(m.Status), location)
+}
 
-	if apiResponse.EndDate != nil {
-		m.EndDate = types.StringValue(*apiResponse.EndDate)
+// knownIDFields lists the immutable attributes compared with the datasource known_id names.
+func (m awsResourceModel) knownIDFields(existing *costoryapi.AWSBillingDatasource) []knownIDField {
+	prefix := existing.Prefix
+	if existing.PrefixPattern != nil {
+		prefix = existing.PrefixPattern
 	}
 
-	if apiResponse.EKSSplit != nil {
-		m.EKSSplit = types.BoolValue(*apiResponse.EKSSplit)
+	return []knownIDField{
+		{attribute: "name", configured: m.Name, actual: existing.Name},
+		{attribute: "bucket_name", configured: m.BucketName, actual: existing.BucketName, normalize: normalizeBucketName},
+		{attribute: "role_arn", configured: m.RoleARN, actual: existing.RoleARN, normalize: normalizeRoleARN},
+		{attribute: "sensitive_role_arn", configured: m.SensitiveRoleARN, actual: existing.RoleARN, normalize: normalizeRoleARN, sensitive: true},
+		{attribute: "prefix", configured: m.Prefix, actual: stringValue(prefix)},
+		{attribute: "report_name", configured: m.ReportName, actual: stringValue(existing.ReportName)},
 	}
 }
@@ -0,0 +1,147 @@
+package billingdatasource
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/costory-io/costory-terraform/internal/costoryapi"
+)
+
+const testRoleARN = "arn:aws:iam::123456789012:role/costory"
+
+func TestAWSResourceSensitiveRoleARNIsMasked(t *testing.T) {
+	t.Parallel()
+
+	var resp resource.SchemaResponse
+	NewAWSResource().Schema(context.Background(), resource.SchemaRequest{}, &resp)
+
+	attr, ok := resp.Schema.Attributes["sensitive_role_arn"]
+	if !ok {
+		t.Fatal("expected sensitive_role_arn attribute")
+	}
+	if !attr.IsSensitive() {
+		t.Fatal("expected sensitive_role_arn to be sensitive")
+	}
+	if resp.Schema.Attributes["role_arn"].IsSensitive() {
+		t.Fatal("expected role_arn to stay non-sensitive")
+	}
+}
+
+func TestAWSResourceModelSensitiveRoleARN(t *testing.T) {
+	t.Parallel()
+
+	model := awsResourceModel{
+		Name:             types.StringValue("AWS Billing"),
+		BucketName:       types.StringValue("billing-bucket"),
+		RoleARN:          types.StringNull(),
+		SensitiveRoleARN: types.StringValue(testRoleARN),
+		Prefix:           types.StringValue("cur/"),
+	}
+
+	if got := model.toRequestModel(time.Now).RoleARN; got != testRoleARN {
+		t.Fatalf("unexpected request role ARN: got %q, want %q", got, testRoleARN)
+	}
+
+	model.mergeAPIResponse(&costoryapi.AWSBillingDatasource{ID: "aws-ds-1", RoleARN: testRoleARN})
+
+	if !model.RoleARN.IsNull() {
+		t.Fatalf("expected role_arn to stay null, got %s", model.RoleARN)
+	}
+	if got := model.SensitiveRoleARN.ValueString(); got != testRoleARN {
+		t.Fatalf("unexpected sensitive role ARN: got %q", got)
+	}
+}
+
+func TestAWSResourceModelPlainRoleARN(t *testing.T) {
+	t.Parallel()
+
+	model := awsResourceModel{
+		RoleARN:          types.StringValue(testRoleARN),
+		SensitiveRoleARN: types.StringNull(),
+	}
+
+	if got := model.toRequestModel(time.Now).RoleARN; got != testRoleARN {
+		t.Fatalf("unexpected request role ARN: got %q, want %q", got, testRoleARN)
+	}
+
+	model.mergeAPIResponse(&costoryapi.AWSBillingDatasource{ID: "aws-ds-1", RoleARN: testRoleARN})
+
+	if !model.SensitiveRoleARN.IsNull() {
+		t.Fatalf("expected sensitive_role_arn to stay null, got %s", model.SensitiveRoleARN)
+	}
+	if got := model.RoleARN.ValueString(); got != testRoleARN {
+		t.Fatalf("unexpected role ARN: got %q", got)
+	}
+}
+
+func TestAWSResourceModelReadRepairsServerNormalizedValues(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		serverPrefix *string
+		wantPrefix   string
+	}{
+		"normalized": {serverPrefix: stringPointer("cur"), wantPrefix: "cur"},
+		"cleared":    {serverPrefix: stringPointer(""), wantPrefix: ""},
+		"omitted":    {serverPrefix: nil, wantPrefix: "cur/"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			// A case-only bucket difference keeps the configured spelling; see
+			// TestAWSResourceModelReadKeepsEquivalentBucketName.
+			model := awsResourceModel{
+				BucketName: types.StringNull(),
+				RoleARN:    types.StringValue(testRoleARN),
+				Prefix:     types.StringValue("cur/"),
+			}
+
+			model.mergeAPIResponse(&costoryapi.AWSBillingDatasource{
+				ID:         "aws-ds-1",
+				BucketName: "billing-bucket",
+				Prefix:     tc.serverPrefix,
+			})
+
+			if got := model.Prefix.ValueString(); got != tc.wantPrefix {
+				t.Fatalf("unexpected prefix: got %q, want %q", got, tc.wantPrefix)
+			}
+			if got := model.BucketName.ValueString(); got != "billing-bucket" {
+				t.Fatalf("unexpected bucket name: got %q", got)
+			}
+		})
+	}
+}
+
+func TestAWSResourceModelPrefixGlob(t *testing.T) {
+	t.Parallel()
+
+	model := awsResourceModel{
+		RoleARN:      types.StringValue(testRoleARN),
+		Prefix:       types.StringValue("cur/*/year=2025"),
+		PrefixIsGlob: types.BoolValue(true),
+	}
+
+	if req := model.toRequestModel(time.Now); !req.PrefixIsGlob || req.Prefix != "cur/*/year=2025" {
+		t.Fatalf("unexpected request prefix: %#v", req)
+	}
+
+	model.mergeAPIResponse(&costoryapi.AWSBillingDatasource{ID: "aws-ds-1", PrefixPattern: stringPointer("cur/*/year=2025")})
+	if !model.PrefixIsGlob.ValueBool() || model.Prefix.ValueString() != "cur/*/year=2025" {
+		t.Fatalf("unexpected glob state: prefix %s, prefix_is_glob %s", model.Prefix, model.PrefixIsGlob)
+	}
+
+	model.mergeAPIResponse(&costoryapi.AWSBillingDatasource{ID: "aws-ds-1", Prefix: stringPointer("cur/")})
+	if model.PrefixIsGlob.ValueBool() || model.Prefix.ValueString() != "cur/" {
+		t.Fatalf("expected a literal prefix from the server to clear prefix_is_glob: prefix %s, prefix_is_glob %s", model.Prefix, model.PrefixIsGlob)
+	}
+}
+
+func stringPointer(value string) *string {
+	return &value
+}
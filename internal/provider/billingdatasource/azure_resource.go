@@ -5,7 +5,6 @@ import (
 	"errors"
 	"fmt"
 
-	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -13,27 +12,39 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/costory-io/costory-terraform/internal/costoryapi"
+	"github.com/costory-io/costory-terraform/internal/provider/retrywarning"
+	"github.com/costory-io/costory-terraform/internal/provider/statediff"
+	"github.com/costory-io/costory-terraform/internal/provider/versiondiag"
 )
 
 var (
 	_ resource.Resource                = &azureResource{}
 	_ resource.ResourceWithConfigure   = &azureResource{}
 	_ resource.ResourceWithImportState = &azureResource{}
+	_ resource.ResourceWithModifyPlan  = &azureResource{}
 )
 
 type azureResource struct {
-	client *costoryapi.Client
+	client costoryapi.BillingDatasourceAPI
 }
 
 type azureResourceModel struct {
 	ID                 types.String `tfsdk:"id"`
+	ImportID           types.String `tfsdk:"import_id"`
 	Status             types.String `tfsdk:"status"`
+	ManagedBy          types.String `tfsdk:"managed_by"`
+	CloudProvider      types.String `tfsdk:"cloud_provider"`
 	Name               types.String `tfsdk:"name"`
 	SASURL             types.String `tfsdk:"sas_url"`
 	StorageAccountName types.String `tfsdk:"storage_account_name"`
 	ContainerName      types.String `tfsdk:"container_name"`
 	ActualsPath        types.String `tfsdk:"actuals_path"`
 	AmortizedPath      types.String `tfsdk:"amortized_path"`
+	RecreateOnFailure  types.Bool   `tfsdk:"recreate_on_failure"`
+	ConfirmDelete      types.Bool   `tfsdk:"confirm_delete"`
+	ExpectedStatus     types.String `tfsdk:"expected_status"`
+	KnownID            types.String `tfsdk:"known_id"`
+	ExtraHeaders       types.Map    `tfsdk:"extra_headers"`
 }
 
 // NewAzureResource returns the Azure billing datasource resource.
@@ -53,10 +64,13 @@ func (r *azureResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				Computed:            true,
 				MarkdownDescription: "Billing datasource ID returned by Costory.",
 			},
+			"import_id": importIDAttribute(),
 			"status": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "Datasource status returned by Costory.",
 			},
+			"managed_by":     managedByAttribute(),
+			"cloud_provider": cloudProviderAttribute(costoryapi.AzureBillingDatasourceRequest{}),
 			"name": schema.StringAttribute{
 				Required:            true,
 				MarkdownDescription: "Billing datasource display name.",
@@ -100,6 +114,11 @@ func (r *azureResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"recreate_on_failure": recreateOnFailureAttribute(),
+			"confirm_delete":      confirmDeleteAttribute(),
+			"expected_status":     expectedStatusAttribute(),
+			"known_id":            knownIDAttribute(),
+			"extra_headers":       extraHeadersAttribute(),
 		},
 	}
 }
@@ -109,11 +128,11 @@ func (r *azureResource) Configure(_ context.Context, req resource.ConfigureReque
 		return
 	}
 
-	client, ok := req.ProviderData.(*costoryapi.Client)
+	client, ok := req.ProviderData.(costoryapi.BillingDatasourceAPI)
 	if !ok {
 		resp.Diagnostics.AddError(
-			"Unexpected resource configure type",
-			fmt.Sprintf("Expected *costoryapi.Client, got: %T. This is always a provider implementation bug.", req.ProviderData),
+			costoryapi.VersionedSummary("Unexpected resource configure type", req.ProviderData),
+			fmt.Sprintf("Expected costoryapi.BillingDatasourceAPI, got: %T. This is always a provider implementation bug.", req.ProviderData),
 		)
 		return
 	}
@@ -130,11 +149,29 @@ func (r *azureResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	ctx, cancel := r.client.OperationContext(ctx)
+	defer cancel()
+	defer retrywarning.Add(ctx, &resp.Diagnostics)
+	defer versiondiag.Add(r.client, &resp.Diagnostics)
+
 	var plan azureResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = withExtraHeaders(ctx, plan.ExtraHeaders)
+
+	existing, diags := adoptKnownID(ctx, plan.KnownID, "Azure", r.client.GetAzureBillingDatasource, plan.knownIDFields)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if existing != nil {
+		plan.ID = plan.KnownID
+		plan.mergeAPIResponse(existing)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+		return
+	}
 
 	createRequest := plan.toRequestModel()
 
@@ -146,7 +183,8 @@ func (r *azureResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	created, err := r.client.CreateAzureBillingDatasource(ctx, createRequest)
+	createCtx := costoryapi.WithIdempotencyKey(ctx, costoryapi.NewIdempotencyKey())
+	created, err := r.client.CreateAzureBillingDatasource(createCtx, createRequest)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to create Azure billing datasource",
@@ -157,6 +195,7 @@ func (r *azureResource) Create(ctx context.Context, req resource.CreateRequest,
 
 	plan.ID = types.StringValue(created.ID)
 	plan.mergeAPIResponse(created)
+	addCreateWarnings(&resp.Diagnostics, r.client, "Azure", created.Warnings)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
@@ -170,11 +209,17 @@ func (r *azureResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
+	ctx, cancel := r.client.OperationContext(ctx)
+	defer cancel()
+	defer retrywarning.Add(ctx, &resp.Diagnostics)
+	defer versiondiag.Add(r.client, &resp.Diagnostics)
+
 	var state azureResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = withExtraHeaders(ctx, state.ExtraHeaders)
 
 	current, err := r.client.GetAzureBillingDatasource(ctx, state.ID.ValueString())
 	if err != nil {
@@ -196,13 +241,34 @@ func (r *azureResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	statediff.Log(ctx, "read", req.State, resp.State)
+	checkStrictDrift(ctx, r.client, req, resp)
 }
 
-func (r *azureResource) Update(_ context.Context, _ resource.UpdateRequest, resp *resource.UpdateResponse) {
-	resp.Diagnostics.AddError(
-		"Update not supported",
-		"All attributes are immutable for costory_billing_datasource_azure. Terraform should replace the resource instead.",
-	)
+func (r *azureResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Every API-backed attribute requires replacement, so only provider-side
+	// settings such as recreate_on_failure can change in place.
+	var plan, state azureResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.RecreateOnFailure = plan.RecreateOnFailure
+	state.ConfirmDelete = plan.ConfirmDelete
+	state.ExpectedStatus = plan.ExpectedStatus
+	state.KnownID = plan.KnownID
+	state.ExtraHeaders = plan.ExtraHeaders
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	statediff.Log(ctx, "update", req.State, resp.State)
+}
+
+func (r *azureResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planRecreateOnFailure(ctx, req, resp)
+	planExpectedStatus(ctx, req, resp)
+	planDatasourceTypeSupported(ctx, r.client, costoryapi.AzureBillingDatasourceRequest{}, req, resp)
 }
 
 func (r *azureResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -214,14 +280,25 @@ func (r *azureResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	ctx, cancel := r.client.OperationContext(ctx)
+	defer cancel()
+	defer retrywarning.Add(ctx, &resp.Diagnostics)
+	defer versiondiag.Add(r.client, &resp.Diagnostics)
+
 	var state azureResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = withExtraHeaders(ctx, state.ExtraHeaders)
+
+	resp.Diagnostics.Append(deleteConfirmationDiagnostics(r.client, state.ConfirmDelete)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	err := r.client.DeleteBillingDatasource(ctx, state.ID.ValueString())
-	if err != nil && !errors.Is(err, costoryapi.ErrNotFound) {
+	if err != nil && (!errors.Is(err, costoryapi.ErrNotFound) || r.client.FailDeleteOnMissing()) {
 		resp.Diagnostics.AddError(
 			"Unable to delete Azure billing datasource",
 			err.Error(),
@@ -231,7 +308,7 @@ func (r *azureResource) Delete(ctx context.Context, req resource.DeleteRequest,
 }
 
 func (r *azureResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateWithTypePrefix(ctx, importPrefixAzure, req, resp)
 }
 
 func (m azureResourceModel) toRequestModel() costoryapi.AzureBillingDatasourceRequest {
@@ -254,11 +331,15 @@ func (m *azureResourceModel) mergeAPIResponse(apiResponse *costoryapi.AzureBilli
 		m.ID = types.StringValue(apiResponse.ID)
 	}
 
+	m.CloudProvider = cloudProviderValue(costoryapi.AzureBillingDatasourceRequest{})
+
 	m.Status = types.StringNull()
 	if apiResponse.Status != nil {
 		m.Status = types.StringValue(*apiResponse.Status)
 	}
 
+	m.ManagedBy = mergeManagedBy(apiResponse.ManagedBy)
+
 	if apiResponse.Name != "" {
 		m.Name = types.StringValue(apiResponse.Name)
 	}
@@ -278,4 +359,17 @@ func (m *azureResourceModel) mergeAPIResponse(apiResponse *costoryapi.AzureBilli
 	if apiResponse.AmortizedPath != "" {
 		m.AmortizedPath = types.StringValue(apiResponse.AmortizedPath)
 	}
+
+	m.ImportID = importIDValue(importPrefixAzure, m.ID)
+}
+
+// knownIDFields lists the immutable attributes compared with the datasource known_id names.
+func (m azureResourceModel) knownIDFields(existing *costoryapi.AzureBillingDatasource) []knownIDField {
+	return []knownIDField{
+		{attribute: "name", configured: m.Name, actual: existing.Name},
+		{attribute: "storage_account_name", configured: m.StorageAccountName, actual: existing.StorageAccountName},
+		{attribute: "container_name", configured: m.ContainerName, actual: existing.ContainerName},
+		{attribute: "actuals_path", configured: m.ActualsPath, actual: existing.ActualsPath},
+		{attribute: "amortized_path", configured: m.AmortizedPath, actual: existing.AmortizedPath},
+	}
 }
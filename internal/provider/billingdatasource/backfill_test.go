@@ -0,0 +1,66 @@
+package billingdatasource
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/costory-io/costory-terraform/internal/costoryapi"
+)
+
+func TestMergeBackfillProgress(t *testing.T) {
+	t.Parallel()
+
+	progress := 42.0
+
+	gcp := gcpResourceModel{BackfillProgress: types.Float64Unknown()}
+	gcp.mergeAPIResponse(&costoryapi.GCPBillingDatasource{ID: "gcp-ds-1", BackfillProgress: &progress})
+	if got := gcp.BackfillProgress.ValueFloat64(); got != 42 {
+		t.Fatalf("unexpected GCP backfill progress: got %v, want 42", got)
+	}
+
+	gcp.mergeAPIResponse(&costoryapi.GCPBillingDatasource{ID: "gcp-ds-1"})
+	if !gcp.BackfillProgress.IsNull() {
+		t.Fatalf("expected GCP backfill progress to be null when absent, got %s", gcp.BackfillProgress)
+	}
+
+	aws := awsResourceModel{RoleARN: types.StringValue(testRoleARN), BackfillProgress: types.Float64Unknown()}
+	aws.mergeAPIResponse(&costoryapi.AWSBillingDatasource{ID: "aws-ds-1", BackfillProgress: &progress})
+	if got := aws.BackfillProgress.ValueFloat64(); got != 42 {
+		t.Fatalf("unexpected AWS backfill progress: got %v, want 42", got)
+	}
+
+	aws.mergeAPIResponse(&costoryapi.AWSBillingDatasource{ID: "aws-ds-1"})
+	if !aws.BackfillProgress.IsNull() {
+		t.Fatalf("expected AWS backfill progress to be null when absent, got %s", aws.BackfillProgress)
+	}
+}
+
+func TestMergeLastIngestedAt(t *testing.T) {
+	t.Parallel()
+
+	ingestedAt := time.Date(2025, 3, 4, 6, 6, 7, 0, time.FixedZone("CET", 3600))
+
+	gcp := gcpResourceModel{LastIngestedAt: types.StringUnknown()}
+	gcp.mergeAPIResponse(&costoryapi.GCPBillingDatasource{ID: "gcp-ds-1", LastIngestedAt: &ingestedAt})
+	if got := gcp.LastIngestedAt.ValueString(); got != "2025-03-04T05:06:07Z" {
+		t.Fatalf("unexpected GCP last ingested time: got %q", got)
+	}
+
+	gcp.mergeAPIResponse(&costoryapi.GCPBillingDatasource{ID: "gcp-ds-1"})
+	if !gcp.LastIngestedAt.IsNull() {
+		t.Fatalf("expected GCP last ingested time to be null when absent, got %s", gcp.LastIngestedAt)
+	}
+
+	aws := awsResourceModel{RoleARN: types.StringValue(testRoleARN), LastIngestedAt: types.StringUnknown()}
+	aws.mergeAPIResponse(&costoryapi.AWSBillingDatasource{ID: "aws-ds-1", LastIngestedAt: &ingestedAt})
+	if got := aws.LastIngestedAt.ValueString(); got != "2025-03-04T05:06:07Z" {
+		t.Fatalf("unexpected AWS last ingested time: got %q", got)
+	}
+
+	aws.mergeAPIResponse(&costoryapi.AWSBillingDatasource{ID: "aws-ds-1"})
+	if !aws.LastIngestedAt.IsNull() {
+		t.Fatalf("expected AWS last ingested time to be null when absent, got %s", aws.LastIngestedAt)
+	}
+}
@@ -0,0 +1,25 @@
+package billingdatasource
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+
+	"github.com/costory-io/costory-terraform/internal/costoryapi"
+)
+
+// planDatasourceTypeSupported rejects planning a new datasource whose type the Costory
+// deployment does not support. kind is a zero-value request identifying the type. The client
+// fetches capabilities on the first call and caches them; a failed lookup is ignored, leaving
+// the API to reject an unsupported type on apply.
+func planDatasourceTypeSupported(ctx context.Context, client costoryapi.BillingDatasourceAPI, kind costoryapi.BillingDatasourceCreateRequest, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if client == nil || !req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var unsupported *costoryapi.UnsupportedDatasourceTypeError
+	if err := client.CheckBillingDatasourceSupported(ctx, kind); errors.As(err, &unsupported) {
+		resp.Diagnostics.AddError("Unsupported billing datasource type", err.Error())
+	}
+}
@@ -0,0 +1,57 @@
+package billingdatasource
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/costory-io/costory-terraform/internal/costoryapi"
+)
+
+func TestPlanDatasourceTypeSupported(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		status    int
+		body      string
+		kind      costoryapi.BillingDatasourceCreateRequest
+		wantError bool
+	}{
+		"unsupported":        {status: http.StatusOK, body: `{"datasourceTypes":["GCP"]}`, kind: costoryapi.AzureBillingDatasourceRequest{}, wantError: true},
+		"supported":          {status: http.StatusOK, body: `{"datasourceTypes":["GCP"]}`, kind: costoryapi.GCPBillingDatasourceRequest{}},
+		"older backend":      {status: http.StatusNotFound, kind: costoryapi.AzureBillingDatasourceRequest{}},
+		"lookup unavailable": {status: http.StatusForbidden, kind: costoryapi.AzureBillingDatasourceRequest{}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tc.status)
+				_, _ = w.Write([]byte(tc.body))
+			}))
+			defer server.Close()
+
+			client := costoryapi.NewClient(server.URL, "test-token", server.Client())
+
+			objectType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{}}
+			req := resource.ModifyPlanRequest{
+				State: tfsdk.State{Raw: tftypes.NewValue(objectType, nil)},
+				Plan:  tfsdk.Plan{Raw: tftypes.NewValue(objectType, map[string]tftypes.Value{})},
+			}
+			var resp resource.ModifyPlanResponse
+
+			planDatasourceTypeSupported(context.Background(), client, tc.kind, req, &resp)
+
+			if got := resp.Diagnostics.HasError(); got != tc.wantError {
+				t.Fatalf("unexpected error diagnostics: got %v, want %v (%v)", got, tc.wantError, resp.Diagnostics)
+			}
+		})
+	}
+}
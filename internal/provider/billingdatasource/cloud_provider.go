@@ -0,0 +1,24 @@
+package billingdatasource
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/costory-io/costory-terraform/internal/costoryapi"
+)
+
+// cloudProviderAttribute returns the computed cloud_provider attribute for the datasource type
+// identified by kind, a zero-value request. The value is known at plan time.
+func cloudProviderAttribute(kind costoryapi.BillingDatasourceCreateRequest) schema.StringAttribute {
+	return schema.StringAttribute{
+		Computed:            true,
+		Default:             stringdefault.StaticString(costoryapi.CloudProvider(kind)),
+		MarkdownDescription: "Lowercase name of the provider the datasource ingests from, such as `aws`, `gcp` or `azure`. Unlike the API type identifier, it does not change with `datasource_type_overrides`.",
+	}
+}
+
+// cloudProviderValue returns the cloud_provider value for the datasource type identified by kind.
+func cloudProviderValue(kind costoryapi.BillingDatasourceCreateRequest) types.String {
+	return types.StringValue(costoryapi.CloudProvider(kind))
+}
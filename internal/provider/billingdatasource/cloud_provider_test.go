@@ -0,0 +1,69 @@
+package billingdatasource
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/defaults"
+
+	"github.com/costory-io/costory-terraform/internal/costoryapi"
+)
+
+func TestCloudProviderAttribute(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		resource resource.Resource
+		want     string
+	}{
+		"gcp":           {resource: NewGCPResource(), want: "gcp"},
+		"aws":           {resource: NewAWSResource(), want: "aws"},
+		"azure":         {resource: NewAzureResource(), want: "azure"},
+		"cursor":        {resource: NewCursorResource(), want: "cursor"},
+		"anthropic":     {resource: NewAnthropicResource(), want: "anthropic"},
+		"elastic cloud": {resource: NewElasticCloudResource(), want: "elastic_cloud"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			var resp resource.SchemaResponse
+			tc.resource.Schema(ctx, resource.SchemaRequest{}, &resp)
+
+			attribute, ok := resp.Schema.Attributes["cloud_provider"].(schema.StringAttribute)
+			if !ok {
+				t.Fatal("expected cloud_provider to be a string attribute")
+			}
+			if !attribute.Computed || attribute.Optional || attribute.Required {
+				t.Fatal("expected cloud_provider to be computed only")
+			}
+
+			var planned defaults.StringResponse
+			attribute.Default.DefaultString(ctx, defaults.StringRequest{}, &planned)
+			got := planned.PlanValue.ValueString()
+			if got != tc.want || got != strings.ToLower(got) {
+				t.Fatalf("unexpected cloud_provider: got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCloudProviderIgnoresTypeOverrides(t *testing.T) {
+	t.Parallel()
+
+	// The API reports an overridden type identifier; cloud_provider stays the normalized name.
+	model := mergeAWSDatasource(awsResourceModel{}, &costoryapi.AWSBillingDatasource{ID: "aws-ds-1", Type: "aws-cur"})
+	if got := model.CloudProvider.ValueString(); got != "aws" {
+		t.Fatalf("unexpected cloud_provider: got %q, want %q", got, "aws")
+	}
+
+	again := mergeAWSDatasource(model, &costoryapi.AWSBillingDatasource{ID: "aws-ds-1", Type: "AWS"})
+	if !again.CloudProvider.Equal(model.CloudProvider) {
+		t.Fatalf("expected cloud_provider to be stable, got %s then %s", model.CloudProvider, again.CloudProvider)
+	}
+}
@@ -0,0 +1,50 @@
+package billingdatasource
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+
+	"github.com/costory-io/costory-terraform/internal/costoryapi"
+)
+
+// addCreateWarnings surfaces the non-fatal warnings returned by a create, such as a prefix
+// that holds no data yet, as warning diagnostics without failing the create. They are also
+// recorded on the client so the costory_diagnostics data source reports them.
+func addCreateWarnings(diags *diag.Diagnostics, client costoryapi.BillingDatasourceAPI, kind string, warnings []string) {
+	summary := kind + " billing datasource created with a warning"
+	for _, warning := range warnings {
+		warning = strings.TrimSpace(warning)
+		if warning == "" {
+			continue
+		}
+
+		diags.AddWarning(summary, warning)
+		client.RecordWarning(summary, warning)
+	}
+}
+
+// addStartDateWarning warns on start_date when the create requested a window starting before
+// the earliest date the API reports data for, since the days before it have nothing to ingest.
+// It is a no-op when either date is missing or does not parse.
+func addStartDateWarning(diags *diag.Diagnostics, client costoryapi.BillingDatasourceAPI, kind string, startDate, earliestAvailable *string) {
+	if startDate == nil || earliestAvailable == nil {
+		return
+	}
+
+	start, ok := parseDate(*startDate)
+	if !ok {
+		return
+	}
+	earliest, ok := parseDate(*earliestAvailable)
+	if !ok || !start.Before(earliest) {
+		return
+	}
+
+	summary := kind + " billing datasource start date predates available data"
+	detail := fmt.Sprintf("start_date %q is before %q, the earliest date Costory reports data for, so the days before it are ingested as an empty range. Set start_date to %q or later to skip them.", *startDate, *earliestAvailable, *earliestAvailable)
+	diags.AddAttributeWarning(path.Root("start_date"), summary, detail)
+	client.RecordWarning(summary, detail)
+}
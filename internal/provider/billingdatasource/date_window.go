@@ -0,0 +1,106 @@
+package billingdatasource
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/costory-io/costory-terraform/internal/costoryapi"
+)
+
+var _ resource.ConfigValidator = dateWindowValidator{}
+
+// dateWindowValidator rejects a configured start_date that falls after end_date. Either bound
+// may be omitted, in which case Costory computes a default for it, so the check only applies
+// when both are configured and known. Values that do not parse are left to the API, which
+// owns the accepted date formats. Relative dates depend on the provider time zone, which is
+// not known during config validation, so windows using them are checked by planDateWindow.
+type dateWindowValidator struct{}
+
+func (v dateWindowValidator) Description(_ context.Context) string {
+	return "start_date must not be after end_date"
+}
+
+func (v dateWindowValidator) MarkdownDescription(_ context.Context) string {
+	return "`start_date` must not be after `end_date`"
+}
+
+func (v dateWindowValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	checkDateWindow(ctx, req.Config, false, time.Time{}, &resp.Diagnostics)
+}
+
+// planDateWindow rejects a start_date after end_date when either is a relative date, resolving
+// them in the provider time zone as the create or update request will.
+func planDateWindow(ctx context.Context, client costoryapi.BillingDatasourceAPI, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if client == nil || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	checkDateWindow(ctx, req.Config, true, client.Now(), &resp.Diagnostics)
+}
+
+// checkDateWindow reports a configured start_date after end_date. It only checks windows
+// that use a relative date when relative is true, and only windows of absolute dates
+// otherwise, so each window is checked exactly once.
+func checkDateWindow(ctx context.Context, config tfsdk.Config, relative bool, now time.Time, diags *diag.Diagnostics) {
+	var startDate, endDate types.String
+	diags.Append(config.GetAttribute(ctx, path.Root("start_date"), &startDate)...)
+	diags.Append(config.GetAttribute(ctx, path.Root("end_date"), &endDate)...)
+	if diags.HasError() {
+		return
+	}
+
+	if usesRelative := isRelativeDate(startDate.ValueString()) || isRelativeDate(endDate.ValueString()); usesRelative != relative {
+		return
+	}
+
+	start, ok := parseConfigDate(startDate, now)
+	if !ok {
+		return
+	}
+	end, ok := parseConfigDate(endDate, now)
+	if !ok {
+		return
+	}
+
+	if start.After(end) {
+		diags.AddAttributeError(
+			path.Root("start_date"),
+			"Invalid billing datasource date window",
+			fmt.Sprintf("start_date %q is after end_date %q. Swap the dates, or omit one to let Costory compute it.", startDate.ValueString(), endDate.ValueString()),
+		)
+	}
+}
+
+// parseConfigDate parses a configured date as YYYY-MM-DD, RFC3339, or a relative expression
+// resolved against now. It reports false for null, unknown, or unparsable values.
+func parseConfigDate(value types.String, now time.Time) (time.Time, bool) {
+	if value.IsNull() || value.IsUnknown() {
+		return time.Time{}, false
+	}
+
+	raw, err := resolveRelativeDate(value.ValueString(), now, now.Location())
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return parseDate(raw)
+}
+
+// parseDate parses an absolute date as YYYY-MM-DD or RFC3339, reporting false when it matches
+// neither layout.
+func parseDate(raw string) (time.Time, bool) {
+	for _, layout := range []string{time.DateOnly, time.RFC3339} {
+		if parsed, err := time.Parse(layout, raw); err == nil {
+			return parsed, true
+		}
+	}
+
+	return time.Time{}, false
+}
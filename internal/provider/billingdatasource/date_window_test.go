@@ -0,0 +1,122 @@
+package billingdatasource
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/costory-io/costory-terraform/internal/costoryapi"
+)
+
+func TestDateWindowValidator(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		startDate types.String
+		endDate   types.String
+		wantError bool
+	}{
+		"start after end":       {startDate: types.StringValue("2025-06-01"), endDate: types.StringValue("2025-01-01"), wantError: true},
+		"rfc3339 start after":   {startDate: types.StringValue("2025-01-02T00:00:00Z"), endDate: types.StringValue("2025-01-01T12:00:00Z"), wantError: true},
+		"ordered window":        {startDate: types.StringValue("2025-01-01"), endDate: types.StringValue("2025-06-01")},
+		"single day":            {startDate: types.StringValue("2025-01-01"), endDate: types.StringValue("2025-01-01")},
+		"start only":            {startDate: types.StringValue("2025-01-01"), endDate: types.StringNull()},
+		"end only":              {startDate: types.StringNull(), endDate: types.StringValue("2025-01-01")},
+		"neither":               {startDate: types.StringNull(), endDate: types.StringNull()},
+		"unknown end":           {startDate: types.StringValue("2025-06-01"), endDate: types.StringUnknown()},
+		"unparsable left alone": {startDate: types.StringValue("June 2025"), endDate: types.StringValue("2025-01-01")},
+		"relative left to plan": {startDate: types.StringValue("today"), endDate: types.StringValue("today-7d")},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			f := newResourceFixture(t, NewGCPResource(), nil)
+
+			model := testGCPModel()
+			model.StartDate = tc.startDate
+			model.EndDate = tc.endDate
+
+			var resp resource.ValidateConfigResponse
+			dateWindowValidator{}.ValidateResource(f.ctx, resource.ValidateConfigRequest{Config: f.config(&model)}, &resp)
+
+			if got := resp.Diagnostics.HasError(); got != tc.wantError {
+				t.Fatalf("unexpected validation result: got error=%t, want %t: %v", got, tc.wantError, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestPlanDateWindow(t *testing.T) {
+	t.Parallel()
+
+	behindUTC := time.FixedZone("UTC-12", -12*60*60)
+	aheadOfUTC := time.FixedZone("UTC+14", 14*60*60)
+	// The local date in UTC-12 is always at least a day behind the one in UTC+14.
+	localToday := time.Now().In(behindUTC).Format(time.DateOnly)
+
+	tests := map[string]struct {
+		startDate types.String
+		endDate   types.String
+		timezone  *time.Location
+		wantError bool
+	}{
+		"relative window":         {startDate: types.StringValue("today-30d"), endDate: types.StringValue("today"), timezone: time.UTC},
+		"relative start after":    {startDate: types.StringValue("today"), endDate: types.StringValue("today-7d"), timezone: time.UTC, wantError: true},
+		"today in provider zone":  {startDate: types.StringValue("today"), endDate: types.StringValue(localToday), timezone: behindUTC},
+		"today ahead of end":      {startDate: types.StringValue("today"), endDate: types.StringValue(localToday), timezone: aheadOfUTC, wantError: true},
+		"absolute left to config": {startDate: types.StringValue("2025-06-01"), endDate: types.StringValue("2025-01-01"), timezone: time.UTC},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			f := newResourceFixture(t, NewGCPResource(), nil)
+
+			model := testGCPModel()
+			model.StartDate = tc.startDate
+			model.EndDate = tc.endDate
+			plan := f.plan(&model)
+
+			req := resource.ModifyPlanRequest{Config: tfsdk.Config{Schema: f.schema, Raw: plan.Raw}, Plan: plan}
+			resp := resource.ModifyPlanResponse{Plan: plan}
+			planDateWindow(f.ctx, costoryapi.NewFakeClient(costoryapi.WithTimezone(tc.timezone)), req, &resp)
+
+			if got := resp.Diagnostics.HasError(); got != tc.wantError {
+				t.Fatalf("unexpected plan result: got error=%t, want %t: %v", got, tc.wantError, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestDatedResourcesValidateDateWindow(t *testing.T) {
+	t.Parallel()
+
+	for name, newResource := range map[string]func() resource.Resource{
+		"gcp":       NewGCPResource,
+		"aws":       NewAWSResource,
+		"cursor":    NewCursorResource,
+		"anthropic": NewAnthropicResource,
+	} {
+		withValidators, ok := newResource().(resource.ResourceWithConfigValidators)
+		if !ok {
+			t.Fatalf("expected %s resource to declare config validators", name)
+		}
+
+		var found bool
+		for _, v := range withValidators.ConfigValidators(context.Background()) {
+			if _, ok := v.(dateWindowValidator); ok {
+				found = true
+			}
+		}
+		if !found {
+			t.Fatalf("expected %s resource to validate the date window", name)
+		}
+	}
+}
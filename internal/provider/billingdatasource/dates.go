@@ -0,0 +1,16 @@
+package billingdatasource
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+// mergeOptionalDate merges an optional+computed date returned by the API into state.
+// A configured relative expression such as "today" is kept as written, since the API only
+// ever returns the date it resolved to on the last apply. Otherwise a returned date always
+// wins; failing that the configured or prior value is kept, and an unknown value (omitted
+// in config during create) resolves to null.
+func mergeOptionalDate(current types.String, apiValue *string) types.String {
+	if !current.IsNull() && !current.IsUnknown() && isRelativeDate(current.ValueString()) {
+		return current
+	}
+
+	return mergeOptionalString(current, apiValue)
+}
@@ -0,0 +1,91 @@
+package billingdatasource
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestOptionalDatesKeepStateWhenRemovedFromConfig(t *testing.T) {
+	t.Parallel()
+
+	resources := map[string]func() resource.Resource{
+		"gcp":           NewGCPResource,
+		"aws":           NewAWSResource,
+		"cursor":        NewCursorResource,
+		"anthropic":     NewAnthropicResource,
+		"elastic_cloud": NewElasticCloudResource,
+	}
+
+	for name, newResource := range resources {
+		var resp resource.SchemaResponse
+		newResource().Schema(context.Background(), resource.SchemaRequest{}, &resp)
+
+		for _, attrName := range []string{"start_date", "end_date"} {
+			attr, ok := resp.Schema.Attributes[attrName].(schema.StringAttribute)
+			if !ok {
+				continue
+			}
+
+			t.Run(name+"/"+attrName, func(t *testing.T) {
+				t.Parallel()
+
+				if !attr.Optional || !attr.Computed {
+					t.Fatalf("expected %s to be optional and computed", attrName)
+				}
+
+				removed := planDate(attr.PlanModifiers, types.StringNull(), types.StringUnknown(), types.StringValue("2025-01-01"))
+				if removed.RequiresReplace || removed.PlanValue.ValueString() != "2025-01-01" {
+					t.Fatalf("unexpected plan after removing the date: %#v", removed)
+				}
+
+				changed := planDate(attr.PlanModifiers, types.StringValue("2025-02-01"), types.StringValue("2025-02-01"), types.StringValue("2025-01-01"))
+				if !changed.RequiresReplace {
+					t.Fatal("expected a changed date to require replacement")
+				}
+			})
+		}
+	}
+}
+
+func TestMergeOptionalDate(t *testing.T) {
+	t.Parallel()
+
+	if got := mergeOptionalDate(types.StringUnknown(), nil); !got.IsNull() {
+		t.Fatalf("expected unknown date without API value to resolve to null, got %s", got)
+	}
+
+	if got := mergeOptionalDate(types.StringUnknown(), stringPointer("2025-01-01")); got.ValueString() != "2025-01-01" {
+		t.Fatalf("expected API computed date, got %s", got)
+	}
+
+	if got := mergeOptionalDate(types.StringValue("2025-01-01"), nil); got.ValueString() != "2025-01-01" {
+		t.Fatalf("expected prior date to be kept, got %s", got)
+	}
+}
+
+// planDate runs string plan modifiers in order, the way the framework does during plan for an update.
+func planDate(modifiers []planmodifier.String, config, plan, state types.String) planmodifier.StringResponse {
+	existing := tftypes.NewValue(tftypes.String, "existing")
+	req := planmodifier.StringRequest{
+		ConfigValue: config,
+		PlanValue:   plan,
+		StateValue:  state,
+		State:       tfsdk.State{Raw: existing},
+		Plan:        tfsdk.Plan{Raw: existing},
+	}
+
+	resp := planmodifier.StringResponse{PlanValue: plan}
+	for _, modifier := range modifiers {
+		modifier.PlanModifyString(context.Background(), req, &resp)
+		req.PlanValue = resp.PlanValue
+	}
+
+	return resp
+}
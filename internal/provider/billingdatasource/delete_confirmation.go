@@ -0,0 +1,36 @@
+package billingdatasource
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/costory-io/costory-terraform/internal/costoryapi"
+)
+
+func confirmDeleteAttribute() schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Optional:            true,
+		MarkdownDescription: "Acknowledges that deleting the datasource discards its ingested history. Required to be `true`, and applied before destroying or replacing the datasource, when the provider sets `require_delete_confirmation`.",
+	}
+}
+
+// deleteConfirmationDiagnostics blocks Delete when the provider requires delete confirmation
+// and the prior state does not carry confirm_delete = true. Replacements go through Delete too,
+// so they are blocked the same way.
+func deleteConfirmationDiagnostics(client costoryapi.BillingDatasourceAPI, confirmDelete types.Bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !client.RequireDeleteConfirmation() || confirmDelete.ValueBool() {
+		return diags
+	}
+
+	diags.AddAttributeError(
+		path.Root("confirm_delete"),
+		"Billing datasource delete not confirmed",
+		"The provider is configured with require_delete_confirmation, and deleting this datasource would discard its ingested cost history. Set confirm_delete = true on the resource and apply it, then destroy or replace the datasource.",
+	)
+
+	return diags
+}
@@ -0,0 +1,89 @@
+package billingdatasource
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/costory-io/costory-terraform/internal/costoryapi"
+)
+
+func TestCursorResourceDeleteConfirmation(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		require       bool
+		confirmDelete types.Bool
+		wantBlocked   bool
+	}{
+		"blocked without confirmation":       {require: true, confirmDelete: types.BoolNull(), wantBlocked: true},
+		"blocked when confirmation is false": {require: true, confirmDelete: types.BoolValue(false), wantBlocked: true},
+		"confirmed":                          {require: true, confirmDelete: types.BoolValue(true)},
+		"confirmation not required":          {require: false, confirmDelete: types.BoolNull()},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			client := costoryapi.NewFakeClient(costoryapi.WithRequireDeleteConfirmation(tc.require))
+			if err := client.PutDatasource(&costoryapi.CursorBillingDatasource{ID: "cursor-ds-1", Type: "Cursor", Name: "Cursor Billing"}); err != nil {
+				t.Fatalf("unable to store datasource: %v", err)
+			}
+
+			model := testCursorModel()
+			model.ID = types.StringValue("cursor-ds-1")
+			model.Type = types.StringValue("Cursor")
+			model.Status = types.StringValue("ACTIVE")
+			model.ConfirmDelete = tc.confirmDelete
+			resp := newResourceFixture(t, NewCursorResource(), client).delete(&model)
+
+			if tc.wantBlocked {
+				if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != "Billing datasource delete not confirmed" {
+					t.Fatalf("expected delete to be blocked, got %v", resp.Diagnostics)
+				}
+				if client.Datasource("cursor-ds-1") == nil {
+					t.Fatal("expected the datasource not to be deleted")
+				}
+				return
+			}
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+			if got := client.Datasource("cursor-ds-1"); got != nil {
+				t.Fatalf("expected the datasource to be deleted, got %#v", got)
+			}
+		})
+	}
+}
+
+func TestCursorResourceDeleteMissing(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		failOnMissing bool
+		wantError     bool
+	}{
+		"missing is success by default": {},
+		"missing fails when configured": {failOnMissing: true, wantError: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			client := costoryapi.NewFakeClient(costoryapi.WithFailDeleteOnMissing(tc.failOnMissing))
+
+			model := testCursorModel()
+			model.ID = types.StringValue("cursor-ds-1")
+			model.Type = types.StringValue("Cursor")
+			model.Status = types.StringValue("ACTIVE")
+			resp := newResourceFixture(t, NewCursorResource(), client).delete(&model)
+
+			if got := resp.Diagnostics.HasError(); got != tc.wantError {
+				t.Fatalf("unexpected error result: got %t, want %t: %v", got, tc.wantError, resp.Diagnostics)
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package billingdatasource
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/costory-io/costory-terraform/internal/costoryapi"
+)
+
+// planDefaultDetailedBilling plans the provider's default_detailed_billing for a new GCP
+// datasource that leaves is_detailed_billing unset, so the effective value is visible in the
+// plan and sent by toRequestModel. Existing datasources keep their value, which avoids
+// replacing them when the provider default changes.
+func planDefaultDetailedBilling(ctx context.Context, client costoryapi.BillingDatasourceAPI, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if client == nil || client.DefaultDetailedBilling() == nil || !req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var configured types.Bool
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("is_detailed_billing"), &configured)...)
+	if resp.Diagnostics.HasError() || !configured.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("is_detailed_billing"), types.BoolValue(*client.DefaultDetailedBilling()))...)
+}
@@ -0,0 +1,82 @@
+package billingdatasource
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/costory-io/costory-terraform/internal/costoryapi"
+)
+
+func TestPlanDefaultDetailedBilling(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		providerDefault *bool
+		configured      types.Bool
+		want            types.Bool
+		wantRequest     *bool
+	}{
+		"default applied":       {providerDefault: boolPointer(true), configured: types.BoolNull(), want: types.BoolValue(true), wantRequest: boolPointer(true)},
+		"explicitly overridden": {providerDefault: boolPointer(true), configured: types.BoolValue(false), want: types.BoolValue(false), wantRequest: boolPointer(false)},
+		"no default":            {configured: types.BoolNull(), want: types.BoolUnknown()},
+		"no default explicit":   {configured: types.BoolValue(true), want: types.BoolValue(true), wantRequest: boolPointer(true)},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			client := costoryapi.NewClient("https://example.invalid", "test-token", nil, costoryapi.WithDefaultDetailedBilling(tc.providerDefault))
+
+			f := newResourceFixture(t, NewGCPResource(), nil)
+
+			model := testGCPModel()
+			model.IsDetailedBilling = tc.configured
+			config := f.config(&model)
+
+			// Terraform plans an unset optional+computed attribute as unknown.
+			if tc.configured.IsNull() {
+				model.IsDetailedBilling = types.BoolUnknown()
+			}
+			plan := f.plan(&model)
+
+			req := resource.ModifyPlanRequest{
+				Config: config,
+				State:  f.nullState(),
+				Plan:   plan,
+			}
+			resp := resource.ModifyPlanResponse{Plan: plan}
+
+			planDefaultDetailedBilling(ctx, client, req, &resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			var planned gcpResourceModel
+			resp.Diagnostics.Append(resp.Plan.Get(ctx, &planned)...)
+			if !planned.IsDetailedBilling.Equal(tc.want) {
+				t.Fatalf("unexpected planned is_detailed_billing: got %s, want %s", planned.IsDetailedBilling, tc.want)
+			}
+
+			got := planned.toRequestModel(time.Now).IsDetailedBilling
+			if (got == nil) != (tc.wantRequest == nil) || (got != nil && *got != *tc.wantRequest) {
+				t.Fatalf("unexpected request is_detailed_billing: got %v, want %v", got, tc.wantRequest)
+			}
+
+			// The effective value lands in state, and an unknown value the API does not report resolves to null.
+			planned.mergeAPIResponse(&costoryapi.GCPBillingDatasource{ID: "gcp-ds-1"})
+			if tc.want.IsUnknown() {
+				if !planned.IsDetailedBilling.IsNull() {
+					t.Fatalf("expected unreported is_detailed_billing to resolve to null, got %s", planned.IsDetailedBilling)
+				}
+			} else if !planned.IsDetailedBilling.Equal(tc.want) {
+				t.Fatalf("unexpected state is_detailed_billing: got %s, want %s", planned.IsDetailedBilling, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package billingdatasource
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/costory-io/costory-terraform/internal/costoryapi"
+)
+
+// checkStrictDrift fails Read when the provider is configured with strict_drift and the
+// refreshed state differs from prior state. Computed-only attributes such as status are
+// expected to change server-side, and attributes without a prior value (for example right
+// after import) have nothing to drift from, so both are ignored.
+func checkStrictDrift(ctx context.Context, client costoryapi.BillingDatasourceAPI, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if client == nil || !client.StrictDrift() || resp.Diagnostics.HasError() || resp.State.Raw.IsNull() {
+		return
+	}
+
+	var drifted []string
+	for name, attribute := range req.State.Schema.GetAttributes() {
+		if attribute.IsComputed() && !attribute.IsOptional() && !attribute.IsRequired() {
+			continue
+		}
+
+		attributePath := tftypes.NewAttributePath().WithAttributeName(name)
+		prior, _, err := tftypes.WalkAttributePath(req.State.Raw, attributePath)
+		if err != nil {
+			continue
+		}
+		current, _, err := tftypes.WalkAttributePath(resp.State.Raw, attributePath)
+		if err != nil {
+			continue
+		}
+
+		priorValue, ok := prior.(tftypes.Value)
+		if !ok || priorValue.IsNull() || !priorValue.IsKnown() {
+			continue
+		}
+		if currentValue, ok := current.(tftypes.Value); !ok || !priorValue.Equal(currentValue) {
+			drifted = append(drifted, name)
+		}
+	}
+
+	if len(drifted) == 0 {
+		return
+	}
+
+	sort.Strings(drifted)
+	resp.Diagnostics.AddError(
+		"Costory datasource drifted",
+		fmt.Sprintf(
+			"The provider is configured with strict_drift and the following attributes were changed outside Terraform: %s. Reconcile the datasource in Costory or update the configuration, or disable strict_drift to accept server-side changes.",
+			strings.Join(drifted, ", "),
+		),
+	)
+}
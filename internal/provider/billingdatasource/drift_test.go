@@ -0,0 +1,122 @@
+package billingdatasource
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+
+	"github.com/costory-io/costory-terraform/internal/costoryapi"
+)
+
+func TestAWSResourceReadStrictDrift(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		strict       bool
+		serverPrefix string
+		serverStatus string
+		wantDrift    bool
+		wantPrefix   string
+	}{
+		"strict changed prefix":     {strict: true, serverPrefix: "other/", serverStatus: "ACTIVE", wantDrift: true},
+		"strict changed status":     {strict: true, serverPrefix: "cur/", serverStatus: "FAILED", wantPrefix: "cur/"},
+		"non-strict changed prefix": {strict: false, serverPrefix: "other/", serverStatus: "ACTIVE", wantPrefix: "other/"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			client := costoryapi.NewFakeClient(costoryapi.WithStrictDrift(tc.strict))
+			if err := client.PutDatasource(&costoryapi.AWSBillingDatasource{
+				ID:         "aws-ds-1",
+				Type:       "AWS",
+				Status:     stringPointer(tc.serverStatus),
+				Name:       "AWS Billing",
+				BucketName: "billing-bucket",
+				RoleARN:    testRoleARN,
+				Prefix:     stringPointer(tc.serverPrefix),
+			}); err != nil {
+				t.Fatalf("unable to store datasource: %v", err)
+			}
+
+			f := newResourceFixture(t, NewAWSResource(), client)
+
+			prior := testAWSModel()
+			prior.ID = types.StringValue("aws-ds-1")
+			prior.Status = types.StringValue("ACTIVE")
+			resp := f.read(&prior)
+
+			if !tc.wantDrift {
+				if resp.Diagnostics.HasError() {
+					t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+				}
+
+				var refreshed awsResourceModel
+				f.decode(resp.State, &refreshed)
+				if got := refreshed.Prefix.ValueString(); got != tc.wantPrefix {
+					t.Fatalf("unexpected prefix: got %q, want %q", got, tc.wantPrefix)
+				}
+				return
+			}
+
+			if !resp.Diagnostics.HasError() {
+				t.Fatal("expected drift error, got none")
+			}
+
+			detail := resp.Diagnostics.Errors()[0].Detail()
+			if !strings.Contains(detail, "changed outside Terraform: prefix.") {
+				t.Fatalf("expected drift detail to list only prefix, got %q", detail)
+			}
+		})
+	}
+}
+
+func TestAWSResourceReadLogsChangedAttributes(t *testing.T) {
+	t.Parallel()
+
+	client := costoryapi.NewFakeClient()
+	if err := client.PutDatasource(&costoryapi.AWSBillingDatasource{
+		ID:         "aws-ds-1",
+		Type:       "AWS",
+		Status:     stringPointer("ACTIVE"),
+		Name:       "AWS Billing",
+		BucketName: "billing-bucket",
+		RoleARN:    testRoleARN,
+		Prefix:     stringPointer("cur/"),
+	}); err != nil {
+		t.Fatalf("unable to store datasource: %v", err)
+	}
+
+	var output bytes.Buffer
+	f := newResourceFixture(t, NewAWSResource(), client)
+	f.ctx = tflogtest.RootLogger(context.Background(), &output)
+
+	prior := testAWSModel()
+	prior.ID = types.StringValue("aws-ds-1")
+	prior.Status = types.StringValue("PENDING")
+	if resp := f.read(&prior); resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatalf("unable to decode log output: %v", err)
+	}
+	for _, entry := range entries {
+		if entry["@message"] != "Costory resource state changed" {
+			continue
+		}
+		changed, _ := entry["changed_attributes"].([]any)
+		if !slices.Contains(changed, any("status")) {
+			t.Fatalf("expected status among changed attributes, got %v", entry["changed_attributes"])
+		}
+		return
+	}
+	t.Fatalf("expected a state change log entry, got %v", entries)
+}
@@ -4,37 +4,50 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
-	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/costory-io/costory-terraform/internal/costoryapi"
+	"github.com/costory-io/costory-terraform/internal/provider/retrywarning"
+	"github.com/costory-io/costory-terraform/internal/provider/statediff"
+	"github.com/costory-io/costory-terraform/internal/provider/versiondiag"
 )
 
 var (
 	_ resource.Resource                = &elasticCloudResource{}
 	_ resource.ResourceWithConfigure   = &elasticCloudResource{}
 	_ resource.ResourceWithImportState = &elasticCloudResource{}
+	_ resource.ResourceWithModifyPlan  = &elasticCloudResource{}
 )
 
 type elasticCloudResource struct {
-	client *costoryapi.Client
+	client costoryapi.BillingDatasourceAPI
 }
 
 type elasticCloudResourceModel struct {
-	ID             types.String `tfsdk:"id"`
-	Status         types.String `tfsdk:"status"`
-	Name           types.String `tfsdk:"name"`
-	Type           types.String `tfsdk:"type"`
-	APIKey         types.String `tfsdk:"api_key"`
-	OrganizationID types.String `tfsdk:"organization_id"`
-	BQTableURI     types.String `tfsdk:"bq_table_uri"`
-	StartDate      types.String `tfsdk:"start_date"`
+	ID                types.String `tfsdk:"id"`
+	ImportID          types.String `tfsdk:"import_id"`
+	Status            types.String `tfsdk:"status"`
+	ManagedBy         types.String `tfsdk:"managed_by"`
+	CloudProvider     types.String `tfsdk:"cloud_provider"`
+	Name              types.String `tfsdk:"name"`
+	Type              types.String `tfsdk:"type"`
+	APIKey            types.String `tfsdk:"api_key"`
+	OrganizationID    types.String `tfsdk:"organization_id"`
+	BQTableURI        types.String `tfsdk:"bq_table_uri"`
+	StartDate         types.String `tfsdk:"start_date"`
+	RecreateOnFailure types.Bool   `tfsdk:"recreate_on_failure"`
+	ConfirmDelete     types.Bool   `tfsdk:"confirm_delete"`
+	ExpectedStatus    types.String `tfsdk:"expected_status"`
+	KnownID           types.String `tfsdk:"known_id"`
+	ExtraHeaders      types.Map    `tfsdk:"extra_headers"`
 }
 
 // NewElasticCloudResource returns the Elastic Cloud billing datasource resource.
@@ -54,10 +67,13 @@ func (r *elasticCloudResource) Schema(_ context.Context, _ resource.SchemaReques
 				Computed:            true,
 				MarkdownDescription: "Billing datasource ID returned by Costory.",
 			},
+			"import_id": importIDAttribute(),
 			"status": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "Datasource status returned by Costory.",
 			},
+			"managed_by":     managedByAttribute(),
+			"cloud_provider": cloudProviderAttribute(costoryapi.ElasticCloudBillingDatasourceRequest{}),
 			"name": schema.StringAttribute{
 				Required:            true,
 				MarkdownDescription: "Billing datasource display name.",
@@ -92,11 +108,21 @@ func (r *elasticCloudResource) Schema(_ context.Context, _ resource.SchemaReques
 			},
 			"start_date": schema.StringAttribute{
 				Optional:            true,
-				MarkdownDescription: "Optional filter start date (ISO-8601).",
+				Computed:            true,
+				MarkdownDescription: "Optional filter start date (ISO-8601). When omitted, Costory may compute it; removing it from configuration keeps the current value. Also accepts `today` or `today-<N>d`, resolved in the provider `timezone` (UTC by default) when the request is sent and kept as written in state.",
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
+				Validators: []validator.String{
+					relativeDateValidator{},
+				},
 			},
+			"recreate_on_failure": recreateOnFailureAttribute(),
+			"confirm_delete":      confirmDeleteAttribute(),
+			"expected_status":     expectedStatusAttribute(),
+			"known_id":            knownIDAttribute(),
+			"extra_headers":       extraHeadersAttribute(),
 		},
 	}
 }
@@ -106,11 +132,11 @@ func (r *elasticCloudResource) Configure(_ context.Context, req resource.Configu
 		return
 	}
 
-	client, ok := req.ProviderData.(*costoryapi.Client)
+	client, ok := req.ProviderData.(costoryapi.BillingDatasourceAPI)
 	if !ok {
 		resp.Diagnostics.AddError(
-			"Unexpected resource configure type",
-			fmt.Sprintf("Expected *costoryapi.Client, got: %T. This is always a provider implementation bug.", req.ProviderData),
+			costoryapi.VersionedSummary("Unexpected resource configure type", req.ProviderData),
+			fmt.Sprintf("Expected costoryapi.BillingDatasourceAPI, got: %T. This is always a provider implementation bug.", req.ProviderData),
 		)
 		return
 	}
@@ -127,13 +153,31 @@ func (r *elasticCloudResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 
+	ctx, cancel := r.client.OperationContext(ctx)
+	defer cancel()
+	defer retrywarning.Add(ctx, &resp.Diagnostics)
+	defer versiondiag.Add(r.client, &resp.Diagnostics)
+
 	var plan elasticCloudResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = withExtraHeaders(ctx, plan.ExtraHeaders)
+
+	existing, diags := adoptKnownID(ctx, plan.KnownID, "Elastic Cloud", r.client.GetElasticCloudBillingDatasource, plan.knownIDFields)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if existing != nil {
+		plan.ID = plan.KnownID
+		plan.mergeAPIResponse(existing)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+		return
+	}
 
-	createRequest := plan.toRequestModel()
+	createRequest := plan.toRequestModel(r.client.Now)
 
 	if err := r.client.ValidateElasticCloudBillingDatasource(ctx, createRequest); err != nil {
 		resp.Diagnostics.AddError(
@@ -143,7 +187,8 @@ func (r *elasticCloudResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 
-	created, err := r.client.CreateElasticCloudBillingDatasource(ctx, createRequest)
+	createCtx := costoryapi.WithIdempotencyKey(ctx, costoryapi.NewIdempotencyKey())
+	created, err := r.client.CreateElasticCloudBillingDatasource(createCtx, createRequest)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to create Elastic Cloud billing datasource",
@@ -154,6 +199,8 @@ func (r *elasticCloudResource) Create(ctx context.Context, req resource.CreateRe
 
 	plan.ID = types.StringValue(created.ID)
 	plan.mergeAPIResponse(created)
+	addCreateWarnings(&resp.Diagnostics, r.client, "Elastic Cloud", created.Warnings)
+	addStartDateWarning(&resp.Diagnostics, r.client, "Elastic Cloud", createRequest.StartDate, created.EarliestAvailableDate)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
@@ -167,11 +214,17 @@ func (r *elasticCloudResource) Read(ctx context.Context, req resource.ReadReques
 		return
 	}
 
+	ctx, cancel := r.client.OperationContext(ctx)
+	defer cancel()
+	defer retrywarning.Add(ctx, &resp.Diagnostics)
+	defer versiondiag.Add(r.client, &resp.Diagnostics)
+
 	var state elasticCloudResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = withExtraHeaders(ctx, state.ExtraHeaders)
 
 	current, err := r.client.GetElasticCloudBillingDatasource(ctx, state.ID.ValueString())
 	if err != nil {
@@ -193,13 +246,34 @@ func (r *elasticCloudResource) Read(ctx context.Context, req resource.ReadReques
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	statediff.Log(ctx, "read", req.State, resp.State)
+	checkStrictDrift(ctx, r.client, req, resp)
 }
 
-func (r *elasticCloudResource) Update(_ context.Context, _ resource.UpdateRequest, resp *resource.UpdateResponse) {
-	resp.Diagnostics.AddError(
-		"Update not supported",
-		"All attributes are immutable for costory_billing_datasource_elastic_cloud. Terraform should replace the resource instead.",
-	)
+func (r *elasticCloudResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Every API-backed attribute requires replacement, so only provider-side
+	// settings such as recreate_on_failure can change in place.
+	var plan, state elasticCloudResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.RecreateOnFailure = plan.RecreateOnFailure
+	state.ConfirmDelete = plan.ConfirmDelete
+	state.ExpectedStatus = plan.ExpectedStatus
+	state.KnownID = plan.KnownID
+	state.ExtraHeaders = plan.ExtraHeaders
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	statediff.Log(ctx, "update", req.State, resp.State)
+}
+
+func (r *elasticCloudResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planRecreateOnFailure(ctx, req, resp)
+	planExpectedStatus(ctx, req, resp)
+	planDatasourceTypeSupported(ctx, r.client, costoryapi.ElasticCloudBillingDatasourceRequest{}, req, resp)
 }
 
 func (r *elasticCloudResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -211,14 +285,25 @@ func (r *elasticCloudResource) Delete(ctx context.Context, req resource.DeleteRe
 		return
 	}
 
+	ctx, cancel := r.client.OperationContext(ctx)
+	defer cancel()
+	defer retrywarning.Add(ctx, &resp.Diagnostics)
+	defer versiondiag.Add(r.client, &resp.Diagnostics)
+
 	var state elasticCloudResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = withExtraHeaders(ctx, state.ExtraHeaders)
+
+	resp.Diagnostics.Append(deleteConfirmationDiagnostics(r.client, state.ConfirmDelete)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	err := r.client.DeleteBillingDatasource(ctx, state.ID.ValueString())
-	if err != nil && !errors.Is(err, costoryapi.ErrNotFound) {
+	if err != nil && (!errors.Is(err, costoryapi.ErrNotFound) || r.client.FailDeleteOnMissing()) {
 		resp.Diagnostics.AddError(
 			"Unable to delete Elastic Cloud billing datasource",
 			err.Error(),
@@ -228,20 +313,17 @@ func (r *elasticCloudResource) Delete(ctx context.Context, req resource.DeleteRe
 }
 
 func (r *elasticCloudResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateWithTypePrefix(ctx, importPrefixElasticCloud, req, resp)
 }
 
-func (m elasticCloudResourceModel) toRequestModel() costoryapi.ElasticCloudBillingDatasourceRequest {
+func (m elasticCloudResourceModel) toRequestModel(now func() time.Time) costoryapi.ElasticCloudBillingDatasourceRequest {
 	req := costoryapi.ElasticCloudBillingDatasourceRequest{
 		Name:           m.Name.ValueString(),
 		APIKey:         m.APIKey.ValueString(),
 		OrganizationID: m.OrganizationID.ValueString(),
 	}
 
-	if !m.StartDate.IsNull() && !m.StartDate.IsUnknown() {
-		value := m.StartDate.ValueString()
-		req.StartDate = &value
-	}
+	req.StartDate = requestDate(m.StartDate, now)
 
 	return req
 }
@@ -255,11 +337,15 @@ func (m *elasticCloudResourceModel) mergeAPIResponse(apiResponse *costoryapi.Ela
 		m.ID = types.StringValue(apiResponse.ID)
 	}
 
+	m.CloudProvider = cloudProviderValue(costoryapi.ElasticCloudBillingDatasourceRequest{})
+
 	m.Status = types.StringNull()
 	if apiResponse.Status != nil {
 		m.Status = types.StringValue(*apiResponse.Status)
 	}
 
+	m.ManagedBy = mergeManagedBy(apiResponse.ManagedBy)
+
 	if apiResponse.Name != "" {
 		m.Name = types.StringValue(apiResponse.Name)
 	}
@@ -276,7 +362,15 @@ func (m *elasticCloudResourceModel) mergeAPIResponse(apiResponse *costoryapi.Ela
 		m.OrganizationID = types.StringValue(apiResponse.OrganizationID)
 	}
 
-	if apiResponse.StartDate != nil {
-		m.StartDate = types.StringValue(*apiResponse.StartDate)
+	m.StartDate = mergeOptionalDate(m.StartDate, apiResponse.StartDate)
+
+	m.ImportID = importIDValue(importPrefixElasticCloud, m.ID)
+}
+
+// knownIDFields lists the immutable attributes compared with the datasource known_id names.
+func (m elasticCloudResourceModel) knownIDFields(existing *costoryapi.ElasticCloudBillingDatasource) []knownIDField {
+	return []knownIDField{
+		{attribute: "name", configured: m.Name, actual: existing.Name},
+		{attribute: "organization_id", configured: m.OrganizationID, actual: existing.OrganizationID},
 	}
 }
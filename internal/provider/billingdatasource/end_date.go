@@ -0,0 +1,54 @@
+package billingdatasource
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var _ validator.String = futureEndDateValidator{}
+
+// maxFutureEndDateDays is how many days past the current UTC date end_date may fall before
+// it is reported. Tomorrow is tolerated so time zones ahead of UTC can use their local date.
+const maxFutureEndDateDays = 1
+
+// futureEndDateValidator warns when end_date lies well in the future, where no cost data
+// exists yet. It is a warning rather than an error so a deliberately open-ended window
+// still applies. Values that do not parse are left to the API.
+type futureEndDateValidator struct {
+	now func() time.Time
+}
+
+func futureEndDateWarningValidator() validator.String {
+	return futureEndDateValidator{now: time.Now}
+}
+
+func (v futureEndDateValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("should not be more than %d day after the current UTC date", maxFutureEndDateDays)
+}
+
+func (v futureEndDateValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v futureEndDateValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	now := v.now().UTC()
+	end, ok := parseConfigDate(req.ConfigValue, now)
+	if !ok {
+		return
+	}
+
+	limit := time.Date(now.Year(), now.Month(), now.Day()+maxFutureEndDateDays, 0, 0, 0, 0, time.UTC)
+	end = end.UTC()
+	if !time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, time.UTC).After(limit) {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeWarning(
+		req.Path,
+		"Billing datasource end_date is in the future",
+		fmt.Sprintf("end_date %q is after %s, but Costory has no cost data beyond the current date yet. Set end_date to today or earlier, or omit it to let Costory compute it.", req.ConfigValue.ValueString(), limit.Format(time.DateOnly)),
+	)
+}
@@ -0,0 +1,50 @@
+package billingdatasource
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestFutureEndDateValidator(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, time.June, 15, 22, 30, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		endDate     types.String
+		wantWarning bool
+	}{
+		"far future":         {endDate: types.StringValue("2026-01-01"), wantWarning: true},
+		"two days ahead":     {endDate: types.StringValue("2025-06-17"), wantWarning: true},
+		"rfc3339 far future": {endDate: types.StringValue("2025-07-01T00:00:00Z"), wantWarning: true},
+		"tomorrow":           {endDate: types.StringValue("2025-06-16")},
+		"today":              {endDate: types.StringValue("2025-06-15")},
+		"past":               {endDate: types.StringValue("2025-01-31")},
+		"rfc3339 tomorrow":   {endDate: types.StringValue("2025-06-16T23:59:59Z")},
+		"null":               {endDate: types.StringNull()},
+		"unknown":            {endDate: types.StringUnknown()},
+		"unparsable":         {endDate: types.StringValue("next year")},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			req := validator.StringRequest{Path: path.Root("end_date"), ConfigValue: tc.endDate}
+			var resp validator.StringResponse
+			futureEndDateValidator{now: func() time.Time { return now }}.ValidateString(context.Background(), req, &resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("expected no errors, got %v", resp.Diagnostics)
+			}
+			if got := resp.Diagnostics.WarningsCount() > 0; got != tc.wantWarning {
+				t.Fatalf("unexpected warning result: got %t, want %t: %v", got, tc.wantWarning, resp.Diagnostics)
+			}
+		})
+	}
+}
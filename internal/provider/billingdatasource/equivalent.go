@@ -0,0 +1,53 @@
+package billingdatasource
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// normalizeBucketName returns the form Costory stores an S3 bucket name in. S3 bucket names
+// are lowercase, and the API lowercases whatever it is sent.
+func normalizeBucketName(name string) string {
+	return strings.ToLower(name)
+}
+
+// normalizeRoleARN returns an IAM role ARN without its path, as in
+// arn:aws:iam::123456789012:role/costory for arn:aws:iam::123456789012:role/team/costory.
+// Role names are unique within an account whatever their path, so the two name the same
+// role, and the API may echo either form. Values that are not role ARNs are returned as is.
+func normalizeRoleARN(arn string) string {
+	prefix, resource, ok := strings.Cut(arn, ":role/")
+	if !ok || !awsRoleARNPattern.MatchString(arn) {
+		return arn
+	}
+
+	return prefix + ":role/" + resource[strings.LastIndex(resource, "/")+1:]
+}
+
+// requiresReplaceUnlessEquivalent requires replacement only when the planned value differs
+// from state after normalization, so an immutable attribute the server normalized on read
+// does not replace the datasource while other attributes change.
+func requiresReplaceUnlessEquivalent(normalize func(string) string) planmodifier.String {
+	return stringplanmodifier.RequiresReplaceIf(
+		func(_ context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+			resp.RequiresReplace = req.PlanValue.IsUnknown() ||
+				normalize(req.PlanValue.ValueString()) != normalize(req.StateValue.ValueString())
+		},
+		"Changing this value requires replacement, unless it only differs from state by server normalization.",
+		"Changing this value requires replacement, unless it only differs from state by server normalization.",
+	)
+}
+
+// mergeEquivalentString works like mergeNonEmptyString but keeps current when the API value
+// is the same after normalization, so the configured spelling stays in state.
+func mergeEquivalentString(current types.String, apiValue string, normalize func(string) string) types.String {
+	if !current.IsNull() && !current.IsUnknown() && normalize(current.ValueString()) == normalize(apiValue) {
+		return current
+	}
+
+	return mergeNonEmptyString(current, apiValue)
+}
@@ -0,0 +1,197 @@
+package billingdatasource
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/costory-io/costory-terraform/internal/costoryapi"
+)
+
+func TestRequiresReplaceUnlessEquivalentBucketName(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		state       types.String
+		plan        types.String
+		wantReplace bool
+	}{
+		"unchanged":             {state: types.StringValue("billing-bucket"), plan: types.StringValue("billing-bucket")},
+		"server normalized":     {state: types.StringValue("billing-bucket"), plan: types.StringValue("Billing-Bucket")},
+		"configured spelling":   {state: types.StringValue("Billing-Bucket"), plan: types.StringValue("billing-bucket")},
+		"moved to a new bucket": {state: types.StringValue("billing-bucket"), plan: types.StringValue("new-billing-bucket"), wantReplace: true},
+		"unknown":               {state: types.StringValue("billing-bucket"), plan: types.StringUnknown(), wantReplace: true},
+	}
+
+	// RequiresReplaceIf only inspects values when both state and plan exist.
+	existing := tftypes.NewValue(tftypes.Object{}, map[string]tftypes.Value{})
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			req := planmodifier.StringRequest{
+				Path:        path.Root("bucket_name"),
+				State:       tfsdk.State{Raw: existing},
+				Plan:        tfsdk.Plan{Raw: existing},
+				StateValue:  tc.state,
+				PlanValue:   tc.plan,
+				ConfigValue: tc.plan,
+			}
+			resp := planmodifier.StringResponse{PlanValue: tc.plan}
+
+			requiresReplaceUnlessEquivalent(normalizeBucketName).PlanModifyString(context.Background(), req, &resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+			if resp.RequiresReplace != tc.wantReplace {
+				t.Fatalf("unexpected replacement: got %t, want %t", resp.RequiresReplace, tc.wantReplace)
+			}
+		})
+	}
+}
+
+func TestAWSResourceModelReadKeepsEquivalentBucketName(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		current    types.String
+		serverName string
+		want       string
+	}{
+		"server lowercased":   {current: types.StringValue("Billing-Bucket"), serverName: "billing-bucket", want: "Billing-Bucket"},
+		"bucket moved":        {current: types.StringValue("Billing-Bucket"), serverName: "other-bucket", want: "other-bucket"},
+		"imported":            {current: types.StringNull(), serverName: "billing-bucket", want: "billing-bucket"},
+		"omitted by response": {current: types.StringValue("Billing-Bucket"), serverName: "", want: "Billing-Bucket"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			model := awsResourceModel{
+				BucketName: tc.current,
+				RoleARN:    types.StringValue(testRoleARN),
+				Prefix:     types.StringValue("cur/"),
+			}
+			model.mergeAPIResponse(&costoryapi.AWSBillingDatasource{ID: "aws-ds-1", BucketName: tc.serverName})
+
+			if got := model.BucketName.ValueString(); got != tc.want {
+				t.Fatalf("unexpected bucket name: got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeRoleARN(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		arn  string
+		want string
+	}{
+		"no path":     {arn: "arn:aws:iam::123456789012:role/costory", want: "arn:aws:iam::123456789012:role/costory"},
+		"path":        {arn: "arn:aws:iam::123456789012:role/team/costory", want: "arn:aws:iam::123456789012:role/costory"},
+		"nested path": {arn: "arn:aws:iam::123456789012:role/org/team/costory", want: "arn:aws:iam::123456789012:role/costory"},
+		"not a role":  {arn: "arn:aws:iam::123456789012:user/team/costory", want: "arn:aws:iam::123456789012:user/team/costory"},
+		"empty":       {arn: "", want: ""},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := normalizeRoleARN(tc.arn); got != tc.want {
+				t.Fatalf("unexpected normalized ARN: got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestAWSResourceModelReadKeepsPathedRoleARN(t *testing.T) {
+	t.Parallel()
+
+	const pathedRoleARN = "arn:aws:iam::123456789012:role/team/costory"
+
+	tests := map[string]struct {
+		sensitive bool
+		serverARN string
+		want      string
+	}{
+		"echoed with path":       {serverARN: pathedRoleARN, want: pathedRoleARN},
+		"echoed without path":    {serverARN: testRoleARN, want: pathedRoleARN},
+		"sensitive without path": {sensitive: true, serverARN: testRoleARN, want: pathedRoleARN},
+		"role changed":           {serverARN: "arn:aws:iam::123456789012:role/team/other", want: "arn:aws:iam::123456789012:role/team/other"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			model := awsResourceModel{
+				BucketName:       types.StringValue("billing-bucket"),
+				RoleARN:          types.StringValue(pathedRoleARN),
+				SensitiveRoleARN: types.StringNull(),
+			}
+			if tc.sensitive {
+				model.RoleARN, model.SensitiveRoleARN = types.StringNull(), types.StringValue(pathedRoleARN)
+			}
+			model.mergeAPIResponse(&costoryapi.AWSBillingDatasource{ID: "aws-ds-1", RoleARN: tc.serverARN})
+
+			if got := model.roleARN(); got != tc.want {
+				t.Fatalf("unexpected role ARN: got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestAWSResourceRoleARNChangeRequiresReplace(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		state types.String
+		plan  types.String
+	}{
+		"path added":   {state: types.StringValue(testRoleARN), plan: types.StringValue("arn:aws:iam::123456789012:role/team/costory")},
+		"path moved":   {state: types.StringValue("arn:aws:iam::123456789012:role/team/costory"), plan: types.StringValue("arn:aws:iam::123456789012:role/ops/costory")},
+		"role changed": {state: types.StringValue("arn:aws:iam::123456789012:role/team/costory"), plan: types.StringValue("arn:aws:iam::123456789012:role/team/other")},
+	}
+
+	var schemaResp resource.SchemaResponse
+	(&awsResource{}).Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+	existing := tftypes.NewValue(tftypes.Object{}, map[string]tftypes.Value{})
+
+	for _, attribute := range []string{"role_arn", "sensitive_role_arn"} {
+		for name, tc := range tests {
+			t.Run(attribute+"/"+name, func(t *testing.T) {
+				t.Parallel()
+
+				req := planmodifier.StringRequest{
+					Path:        path.Root(attribute),
+					State:       tfsdk.State{Raw: existing},
+					Plan:        tfsdk.Plan{Raw: existing},
+					StateValue:  tc.state,
+					PlanValue:   tc.plan,
+					ConfigValue: tc.plan,
+				}
+				resp := planmodifier.StringResponse{PlanValue: tc.plan}
+
+				// Update never sends the role ARN, so even a path-only move must replace the
+				// datasource for Costory to use the configured role.
+				for _, modifier := range schemaResp.Schema.Attributes[attribute].(schema.StringAttribute).PlanModifiers {
+					modifier.PlanModifyString(context.Background(), req, &resp)
+				}
+				if !resp.RequiresReplace {
+					t.Fatal("expected a role ARN change to require replacement")
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package billingdatasource
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func expectedStatusAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional:            true,
+		MarkdownDescription: "Status the datasource is expected to report, for example `ACTIVE`. When set, planning fails if the refreshed `status` differs, so a plan can gate deploys on ingestion health. Destroy plans skip the check, and removing the attribute disables it. Unset by default.",
+		Validators: []validator.String{
+			noSurroundingWhitespaceValidator(),
+			nonEmptyStringValidator(),
+		},
+	}
+}
+
+// planExpectedStatus fails the plan when the configured expected_status does not match the
+// refreshed status. Destroy plans and configurations that no longer set expected_status
+// skip the check, so removing the attribute is enough to apply or destroy a datasource
+// that does not match.
+func planExpectedStatus(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var expected, status types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("expected_status"), &expected)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("status"), &status)...)
+	if resp.Diagnostics.HasError() || expected.IsNull() || expected.IsUnknown() {
+		return
+	}
+
+	if strings.EqualFold(status.ValueString(), expected.ValueString()) {
+		return
+	}
+
+	observed := "no status"
+	if !status.IsNull() {
+		observed = fmt.Sprintf("status %q", status.ValueString())
+	}
+	resp.Diagnostics.AddAttributeError(
+		path.Root("expected_status"),
+		"Unexpected billing datasource status",
+		fmt.Sprintf("The datasource reports %s, but expected_status is %q.", observed, expected.ValueString()),
+	)
+}
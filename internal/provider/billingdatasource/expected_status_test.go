@@ -0,0 +1,105 @@
+package billingdatasource
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/costory-io/costory-terraform/internal/costoryapi"
+)
+
+func TestPlanExpectedStatus(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		expected  types.String
+		status    string
+		destroy   bool
+		wantError bool
+	}{
+		"unset":      {expected: types.StringNull(), status: "FAILED"},
+		"matches":    {expected: types.StringValue("ACTIVE"), status: "ACTIVE"},
+		"case":       {expected: types.StringValue("active"), status: "ACTIVE"},
+		"mismatches": {expected: types.StringValue("ACTIVE"), status: "PENDING", wantError: true},
+		"destroy":    {expected: types.StringValue("ACTIVE"), status: "PENDING", destroy: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			f := newResourceFixture(t, NewAWSResource(), nil)
+
+			model := testAWSModel()
+			model.ID = types.StringValue("aws-ds-1")
+			model.Status = types.StringValue(tc.status)
+			model.ExpectedStatus = types.StringValue("ACTIVE")
+			state := f.state(&model)
+
+			model.ExpectedStatus = tc.expected
+			plan := f.plan(&model)
+			if tc.destroy {
+				plan.Raw = f.nullState().Raw
+			}
+
+			req := resource.ModifyPlanRequest{
+				Config: tfsdk.Config{Schema: f.schema, Raw: plan.Raw},
+				State:  state,
+				Plan:   plan,
+			}
+			resp := resource.ModifyPlanResponse{Plan: plan}
+			planExpectedStatus(f.ctx, req, &resp)
+
+			if !resp.Plan.Raw.Equal(plan.Raw) {
+				t.Fatalf("expected the plan to be left unchanged, got %v", resp.Plan.Raw)
+			}
+
+			if !tc.wantError {
+				if resp.Diagnostics.HasError() {
+					t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+				}
+				return
+			}
+
+			if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != "Unexpected billing datasource status" {
+				t.Fatalf("expected a status mismatch error, got %v", resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestAWSResourceReadIgnoresExpectedStatus(t *testing.T) {
+	t.Parallel()
+
+	client := costoryapi.NewFakeClient()
+	if err := client.PutDatasource(&costoryapi.AWSBillingDatasource{
+		ID:         "aws-ds-1",
+		Type:       "AWS",
+		Status:     stringPointer("PENDING"),
+		Name:       "AWS Billing",
+		BucketName: "billing-bucket",
+		RoleARN:    testRoleARN,
+		Prefix:     stringPointer("cur/"),
+	}); err != nil {
+		t.Fatalf("unable to store datasource: %v", err)
+	}
+
+	f := newResourceFixture(t, NewAWSResource(), client)
+
+	prior := testAWSModel()
+	prior.ID = types.StringValue("aws-ds-1")
+	prior.Status = types.StringValue("ACTIVE")
+	prior.ExpectedStatus = types.StringValue("ACTIVE")
+	resp := f.read(&prior)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var refreshed awsResourceModel
+	f.decode(resp.State, &refreshed)
+	if got := refreshed.Status.ValueString(); got != "PENDING" {
+		t.Fatalf("expected the refreshed status to be recorded, got %q", got)
+	}
+}
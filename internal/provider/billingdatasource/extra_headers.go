@@ -0,0 +1,90 @@
+package billingdatasource
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/costory-io/costory-terraform/internal/costoryapi"
+)
+
+var (
+	_ validator.Map = extraHeadersValidator{}
+
+	// headerNamePattern matches an RFC 9110 field name token.
+	headerNamePattern = regexp.MustCompile("^[!#$%&'*+.^_`|~0-9A-Za-z-]+$")
+)
+
+func extraHeadersAttribute() schema.MapAttribute {
+	return schema.MapAttribute{
+		Optional:            true,
+		ElementType:         types.StringType,
+		MarkdownDescription: "Additional HTTP headers sent on this resource's Costory API calls only, for example to route through a gateway path. Headers the provider sets itself, such as `Authorization` and `X-Costory-Slug`, cannot be overridden.",
+		Validators: []validator.Map{
+			extraHeadersValidator{},
+		},
+	}
+}
+
+// extraHeadersValidator rejects header names that are not valid HTTP tokens or that the
+// client reserves for credentials and tenant routing.
+type extraHeadersValidator struct{}
+
+func (v extraHeadersValidator) Description(_ context.Context) string {
+	return "must be valid HTTP header names that the provider does not set itself"
+}
+
+func (v extraHeadersValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v extraHeadersValidator) ValidateMap(_ context.Context, req validator.MapRequest, resp *validator.MapResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	names := make([]string, 0, len(req.ConfigValue.Elements()))
+	for name := range req.ConfigValue.Elements() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		switch {
+		case !headerNamePattern.MatchString(name):
+			resp.Diagnostics.AddAttributeError(
+				req.Path.AtMapKey(name),
+				"Invalid extra header",
+				fmt.Sprintf("%q is not a valid HTTP header name.", name),
+			)
+		case costoryapi.IsReservedRequestHeader(name):
+			resp.Diagnostics.AddAttributeError(
+				req.Path.AtMapKey(name),
+				"Reserved extra header",
+				fmt.Sprintf("The %q header is set by the provider and cannot be overridden.", name),
+			)
+		}
+	}
+}
+
+// withExtraHeaders scopes a resource's extra_headers to the API calls made with the returned
+// context, so they never reach calls made for other resources.
+func withExtraHeaders(ctx context.Context, extraHeaders types.Map) context.Context {
+	if extraHeaders.IsNull() || extraHeaders.IsUnknown() {
+		return ctx
+	}
+
+	headers := make(map[string]string, len(extraHeaders.Elements()))
+	for name, element := range extraHeaders.Elements() {
+		if value, ok := element.(types.String); ok && !value.IsNull() && !value.IsUnknown() {
+			headers[name] = value.ValueString()
+		}
+	}
+
+	return costoryapi.WithRequestHeaders(ctx, headers)
+}
@@ -0,0 +1,100 @@
+package billingdatasource
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/costory-io/costory-terraform/internal/costoryapi"
+)
+
+func TestCursorResourceCreateSendsExtraHeaders(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	gatewayHeaders := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		gatewayHeaders[r.Method+" "+r.URL.Path] = r.Header.Get("X-Gateway-Route")
+		mu.Unlock()
+
+		switch r.URL.Path {
+		case "/terraform/billingDatasources/validate":
+			w.WriteHeader(http.StatusNoContent)
+		case "/terraform/":
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"service_account":"sa-test","sub_ids":[]}`))
+		default:
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":"cursor-ds-1","type":"Cursor","status":"PENDING","name":"Cursor Billing"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := costoryapi.NewClient(server.URL, "test-token", server.Client())
+	f := newResourceFixture(t, NewCursorResource(), client)
+
+	model := testCursorModel()
+	model.ExtraHeaders = types.MapValueMust(types.StringType, map[string]attr.Value{"X-Gateway-Route": types.StringValue("billing")})
+	if resp := f.create(&model); resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	if _, err := client.GetServiceAccount(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	for request, want := range map[string]string{
+		"POST /terraform/billingDatasources/validate": "billing",
+		"POST /terraform/billingDatasources":          "billing",
+		"GET /terraform/":                             "",
+	} {
+		got, ok := gatewayHeaders[request]
+		if !ok {
+			t.Fatalf("expected a %s request, got %v", request, gatewayHeaders)
+		}
+		if got != want {
+			t.Fatalf("unexpected X-Gateway-Route header on %s: got %q, want %q", request, got, want)
+		}
+	}
+}
+
+func TestExtraHeadersValidator(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		name    string
+		wantErr bool
+	}{
+		"custom header":        {name: "X-Gateway-Route"},
+		"reserved header":      {name: "Authorization", wantErr: true},
+		"reserved differently": {name: "x-costory-slug", wantErr: true},
+		"invalid name":         {name: "X Gateway", wantErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			req := validator.MapRequest{
+				Path:        path.Root("extra_headers"),
+				ConfigValue: types.MapValueMust(types.StringType, map[string]attr.Value{tc.name: types.StringValue("value")}),
+			}
+			var resp validator.MapResponse
+			extraHeadersValidator{}.ValidateMap(context.Background(), req, &resp)
+
+			if got := resp.Diagnostics.HasError(); got != tc.wantErr {
+				t.Fatalf("unexpected error state: got %t, want %t: %v", got, tc.wantErr, resp.Diagnostics)
+			}
+		})
+	}
+}
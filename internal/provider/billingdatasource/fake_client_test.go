@@ -0,0 +1,160 @@
+package billingdatasource
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/costory-io/costory-terraform/internal/costoryapi"
+)
+
+func TestCursorResourceLifecycleWithFakeClient(t *testing.T) {
+	t.Parallel()
+
+	client := costoryapi.NewFakeClient()
+	f := newResourceFixture(t, NewCursorResource(), client)
+
+	model := testCursorModel()
+	model.StartDate = types.StringValue("2025-01-01")
+	createResp := f.create(&model)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected create diagnostics: %v", createResp.Diagnostics)
+	}
+
+	var created cursorResourceModel
+	f.decode(createResp.State, &created)
+	if created.ID.ValueString() == "" {
+		t.Fatal("expected create to set an id")
+	}
+	if client.Datasource(created.ID.ValueString()) == nil {
+		t.Fatalf("expected datasource %q to be stored", created.ID.ValueString())
+	}
+
+	readResp := f.read(&created)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected read diagnostics: %v", readResp.Diagnostics)
+	}
+
+	var refreshed cursorResourceModel
+	f.decode(readResp.State, &refreshed)
+	if got := refreshed.StartDate.ValueString(); got != "2025-01-01" {
+		t.Fatalf("unexpected start date: got %q, want %q", got, "2025-01-01")
+	}
+
+	deleteResp := f.delete(&refreshed)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected delete diagnostics: %v", deleteResp.Diagnostics)
+	}
+	if client.Datasource(created.ID.ValueString()) != nil {
+		t.Fatalf("expected datasource %q to be deleted", created.ID.ValueString())
+	}
+
+	goneResp := f.read(&refreshed)
+	if goneResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected read diagnostics after delete: %v", goneResp.Diagnostics)
+	}
+	if !goneResp.State.Raw.IsNull() {
+		t.Fatal("expected a deleted datasource to be removed from state")
+	}
+}
+
+func TestCursorResourceCreateValidationIssuesWithFakeClient(t *testing.T) {
+	t.Parallel()
+
+	client := costoryapi.NewFakeClient()
+	client.ValidationIssues = []costoryapi.ValidationIssue{{Field: "adminApiKey", Message: "key is revoked"}}
+
+	model := testCursorModel()
+	resp := newResourceFixture(t, NewCursorResource(), client).create(&model)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected create to fail validation")
+	}
+	if detail := resp.Diagnostics.Errors()[0].Detail(); !strings.Contains(detail, "key is revoked") {
+		t.Fatalf("expected the validation issue in the error, got %q", detail)
+	}
+	if got := client.Datasource("fake-ds-1"); got != nil {
+		t.Fatalf("expected nothing to be created, got %#v", got)
+	}
+}
+
+func TestGCPResourceReadStrictDriftWithFakeClient(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client := costoryapi.NewFakeClient(costoryapi.WithStrictDrift(true))
+	created, err := client.CreateGCPBillingDatasource(ctx, costoryapi.GCPBillingDatasourceRequest{
+		Name:  "GCP Billing",
+		BQURI: "project.dataset.table",
+	})
+	if err != nil {
+		t.Fatalf("unexpected create error: %v", err)
+	}
+
+	changed := *created
+	changed.Name = "Renamed outside Terraform"
+	if err := client.PutDatasource(&changed); err != nil {
+		t.Fatalf("unable to store changed datasource: %v", err)
+	}
+
+	prior := testGCPModel()
+	prior.ID = types.StringValue(created.ID)
+	resp := newResourceFixture(t, NewGCPResource(), client).read(&prior)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected drift error, got none")
+	}
+	if detail := resp.Diagnostics.Errors()[0].Detail(); !strings.Contains(detail, "changed outside Terraform: name.") {
+		t.Fatalf("expected drift detail to list only name, got %q", detail)
+	}
+}
+
+func TestAWSResourcePathedRoleARNRoundTripWithFakeClient(t *testing.T) {
+	t.Parallel()
+
+	const pathedRoleARN = "arn:aws:iam::123456789012:role/team/costory"
+
+	ctx := context.Background()
+	client := costoryapi.NewFakeClient(costoryapi.WithStrictDrift(true))
+	f := newResourceFixture(t, NewAWSResource(), client)
+
+	model := testAWSModel()
+	model.RoleARN = types.StringValue(pathedRoleARN)
+	createResp := f.create(&model)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected create diagnostics: %v", createResp.Diagnostics)
+	}
+
+	var created awsResourceModel
+	f.decode(createResp.State, &created)
+	if got := created.RoleARN.ValueString(); got != pathedRoleARN {
+		t.Fatalf("unexpected role ARN after create: got %q, want %q", got, pathedRoleARN)
+	}
+
+	// The server echoes the role without its path from now on.
+	stored, err := client.GetAWSBillingDatasource(ctx, created.ID.ValueString())
+	if err != nil {
+		t.Fatalf("unexpected get error: %v", err)
+	}
+	normalized := *stored
+	normalized.RoleARN = testRoleARN
+	if err := client.PutDatasource(&normalized); err != nil {
+		t.Fatalf("unable to store normalized datasource: %v", err)
+	}
+
+	readResp := f.read(&created)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected read diagnostics: %v", readResp.Diagnostics)
+	}
+
+	var refreshed awsResourceModel
+	f.decode(readResp.State, &refreshed)
+	if got := refreshed.RoleARN.ValueString(); got != pathedRoleARN {
+		t.Fatalf("unexpected role ARN after read: got %q, want %q", got, pathedRoleARN)
+	}
+	if !readResp.State.Raw.Equal(createResp.State.Raw) {
+		t.Fatal("expected read to leave state unchanged")
+	}
+}
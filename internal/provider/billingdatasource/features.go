@@ -0,0 +1,49 @@
+package billingdatasource
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+
+	"github.com/costory-io/costory-terraform/internal/costoryapi"
+)
+
+// featureAttributes maps the billing datasource attributes gated by a capabilities feature
+// flag to that flag.
+var featureAttributes = []struct{ name, feature string }{
+	{name: "owner", feature: costoryapi.FeatureOwner},
+	{name: "tags", feature: costoryapi.FeatureTags},
+	{name: "priority", feature: costoryapi.FeaturePriority},
+}
+
+// planFeaturesSupported rejects setting or changing an attribute whose feature the Costory
+// deployment does not enable. Such a value could not be sent, so applying it would record a
+// value in state that Costory never stored.
+func planFeaturesSupported(ctx context.Context, client costoryapi.BillingDatasourceAPI, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if client == nil || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	for _, attribute := range featureAttributes {
+		name := attribute.name
+		var config, state attr.Value
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(name), &config)...)
+		if !req.State.Raw.IsNull() {
+			resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root(name), &state)...)
+		}
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if config.IsNull() || config.IsUnknown() || (state != nil && config.Equal(state)) {
+			continue
+		}
+
+		var unsupported *costoryapi.UnsupportedFeatureError
+		if err := client.CheckFeatureSupported(ctx, attribute.feature, name); errors.As(err, &unsupported) {
+			resp.Diagnostics.AddAttributeError(path.Root(name), "Unsupported Costory feature", err.Error())
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package billingdatasource
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/costory-io/costory-terraform/internal/costoryapi"
+)
+
+func TestPlanFeaturesSupported(t *testing.T) {
+	t.Parallel()
+
+	finops := stringMap(map[string]string{"team": "finops"})
+
+	tests := map[string]struct {
+		stateTags types.Map
+		planTags  types.Map
+		create    bool
+		wantError bool
+	}{
+		"create with tags":         {planTags: finops, create: true, wantError: true},
+		"create without tags":      {planTags: types.MapNull(types.StringType), create: true},
+		"change tags":              {stateTags: stringMap(map[string]string{"team": "platform"}), planTags: finops, wantError: true},
+		"unchanged tags":           {stateTags: finops, planTags: finops},
+		"tags removed from config": {stateTags: finops, planTags: types.MapNull(types.StringType)},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			client := costoryapi.NewFakeClient()
+			client.DisabledFeatures = []string{costoryapi.FeatureTags}
+			f := newResourceFixture(t, NewGCPResource(), nil)
+
+			model := testGCPModel()
+			model.Tags = tc.planTags
+			plan := f.plan(&model)
+
+			state := f.nullState()
+			if !tc.create {
+				model.Tags = tc.stateTags
+				state = f.state(&model)
+			}
+
+			req := resource.ModifyPlanRequest{
+				Config: tfsdk.Config{Schema: f.schema, Raw: plan.Raw},
+				Plan:   plan,
+				State:  state,
+			}
+			resp := resource.ModifyPlanResponse{Plan: plan}
+			planFeaturesSupported(f.ctx, client, req, &resp)
+
+			if got := resp.Diagnostics.HasError(); got != tc.wantError {
+				t.Fatalf("unexpected error diagnostics: got %v, want %v (%v)", got, tc.wantError, resp.Diagnostics)
+			}
+			if tc.wantError {
+				withPath, ok := resp.Diagnostics.Errors()[0].(diag.DiagnosticWithPath)
+				if !ok || !withPath.Path().Equal(path.Root("tags")) {
+					t.Fatalf("expected the error to target tags, got %#v", resp.Diagnostics.Errors()[0])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package billingdatasource
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/costory-io/costory-terraform/internal/costoryapi"
+)
+
+// The model constructors below return a minimal configuration with every collection null,
+// so that adding a model field only touches this file. Tests override what they exercise.
+
+func testGCPModel() gcpResourceModel {
+	return gcpResourceModel{
+		Name:            types.StringValue("GCP Billing"),
+		BQURI:           types.StringValue("project.dataset.table"),
+		Tags:            types.MapNull(types.StringType),
+		ExtraHeaders:    types.MapNull(types.StringType),
+		DetectedColumns: types.ListNull(types.StringType),
+	}
+}
+
+func testAWSModel() awsResourceModel {
+	return awsResourceModel{
+		Name:             types.StringValue("AWS Billing"),
+		BucketName:       types.StringValue("billing-bucket"),
+		RoleARN:          types.StringValue(testRoleARN),
+		SensitiveRoleARN: types.StringNull(),
+		Prefix:           types.StringValue("cur/"),
+		Tags:             types.MapNull(types.StringType),
+		ExtraHeaders:     types.MapNull(types.StringType),
+		DetectedColumns:  types.ListNull(types.StringType),
+	}
+}
+
+func testCursorModel() cursorResourceModel {
+	return cursorResourceModel{
+		Name:         types.StringValue("Cursor Billing"),
+		AdminAPIKey:  types.StringValue("sk_cursor_admin_123"),
+		ExtraHeaders: types.MapNull(types.StringType),
+	}
+}
+
+// resourceFixture runs resource operations against models, hiding the schema, plan and
+// state plumbing. ctx may be replaced before use, for example to capture logs.
+type resourceFixture struct {
+	t      *testing.T
+	ctx    context.Context
+	r      resource.Resource
+	schema schema.Schema
+}
+
+// newResourceFixture returns a fixture for r, configured with client unless it is nil.
+func newResourceFixture(t *testing.T, r resource.Resource, client costoryapi.BillingDatasourceAPI) *resourceFixture {
+	t.Helper()
+
+	ctx := context.Background()
+	if client != nil {
+		var resp resource.ConfigureResponse
+		r.(resource.ResourceWithConfigure).Configure(ctx, resource.ConfigureRequest{ProviderData: client}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unable to configure resource: %v", resp.Diagnostics)
+		}
+	}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	return &resourceFixture{t: t, ctx: ctx, r: r, schema: schemaResp.Schema}
+}
+
+func (f *resourceFixture) plan(model any) tfsdk.Plan {
+	f.t.Helper()
+
+	plan := tfsdk.Plan{Schema: f.schema}
+	if diags := plan.Set(f.ctx, model); diags.HasError() {
+		f.t.Fatalf("unable to build plan: %v", diags)
+	}
+	return plan
+}
+
+func (f *resourceFixture) state(model any) tfsdk.State {
+	f.t.Helper()
+
+	state := tfsdk.State{Schema: f.schema}
+	if diags := state.Set(f.ctx, model); diags.HasError() {
+		f.t.Fatalf("unable to build state: %v", diags)
+	}
+	return state
+}
+
+func (f *resourceFixture) config(model any) tfsdk.Config {
+	f.t.Helper()
+
+	return tfsdk.Config{Schema: f.schema, Raw: f.state(model).Raw}
+}
+
+// nullState returns the state of a resource that does not exist yet.
+func (f *resourceFixture) nullState() tfsdk.State {
+	return tfsdk.State{Schema: f.schema, Raw: tftypes.NewValue(f.schema.Type().TerraformType(f.ctx), nil)}
+}
+
+func (f *resourceFixture) create(model any) resource.CreateResponse {
+	f.t.Helper()
+
+	resp := resource.CreateResponse{State: f.nullState()}
+	f.r.Create(f.ctx, resource.CreateRequest{Plan: f.plan(model)}, &resp)
+	return resp
+}
+
+func (f *resourceFixture) read(model any) resource.ReadResponse {
+	f.t.Helper()
+
+	state := f.state(model)
+	resp := resource.ReadResponse{State: state}
+	f.r.Read(f.ctx, resource.ReadRequest{State: state}, &resp)
+	return resp
+}
+
+func (f *resourceFixture) update(prior, planned any) resource.UpdateResponse {
+	f.t.Helper()
+
+	state := f.state(prior)
+	resp := resource.UpdateResponse{State: state}
+	f.r.Update(f.ctx, resource.UpdateRequest{Plan: f.plan(planned), State: state}, &resp)
+	return resp
+}
+
+func (f *resourceFixture) delete(model any) resource.DeleteResponse {
+	f.t.Helper()
+
+	state := f.state(model)
+	resp := resource.DeleteResponse{State: state}
+	f.r.Delete(f.ctx, resource.DeleteRequest{State: state}, &resp)
+	return resp
+}
+
+// decode reads state into target, failing the test when it does not fit the model.
+func (f *resourceFixture) decode(state tfsdk.State, target any) {
+	f.t.Helper()
+
+	if diags := state.Get(f.ctx, target); diags.HasError() {
+		f.t.Fatalf("unable to read state: %v", diags)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -11,29 +12,53 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/costory-io/costory-terraform/internal/costoryapi"
+	"github.com/costory-io/costory-terraform/internal/provider/retrywarning"
+	"github.com/costory-io/costory-terraform/internal/provider/statediff"
+	"github.com/costory-io/costory-terraform/internal/provider/versiondiag"
 )
 
 var (
-	_ resource.Resource                = &gcpResource{}
-	_ resource.ResourceWithConfigure   = &gcpResource{}
-	_ resource.ResourceWithImportState = &gcpResource{}
+	_ resource.Resource                     = &gcpResource{}
+	_ resource.ResourceWithConfigure        = &gcpResource{}
+	_ resource.ResourceWithImportState      = &gcpResource{}
+	_ resource.ResourceWithModifyPlan       = &gcpResource{}
+	_ resource.ResourceWithConfigValidators = &gcpResource{}
 )
 
 type gcpResource struct {
-	client *costoryapi.Client
+	client costoryapi.BillingDatasourceAPI
 }
 
 type gcpResourceModel struct {
-	ID                types.String `tfsdk:"id"`
-	Status            types.String `tfsdk:"status"`
-	Name              types.String `tfsdk:"name"`
-	BQURI             types.String `tfsdk:"bq_uri"`
-	IsDetailedBilling types.Bool   `tfsdk:"is_detailed_billing"`
-	StartDate         types.String `tfsdk:"start_date"`
-	EndDate           types.String `tfsdk:"end_date"`
+	ID                types.String  `tfsdk:"id"`
+	ImportID          types.String  `tfsdk:"import_id"`
+	Status            types.String  `tfsdk:"status"`
+	ManagedBy         types.String  `tfsdk:"managed_by"`
+	CloudProvider     types.String  `tfsdk:"cloud_provider"`
+	Name              types.String  `tfsdk:"name"`
+	BQURI             types.String  `tfsdk:"bq_uri"`
+	IsDetailedBilling types.Bool    `tfsdk:"is_detailed_billing"`
+	StartDate         types.String  `tfsdk:"start_date"`
+	EndDate           types.String  `tfsdk:"end_date"`
+	Summary           types.String  `tfsdk:"summary"`
+	RecreateOnFailure types.Bool    `tfsdk:"recreate_on_failure"`
+	ConfirmDelete     types.Bool    `tfsdk:"confirm_delete"`
+	ExpectedStatus    types.String  `tfsdk:"expected_status"`
+	KnownID           types.String  `tfsdk:"known_id"`
+	ExtraHeaders      types.Map     `tfsdk:"extra_headers"`
+	ShowAPIRequest    types.Bool    `tfsdk:"show_api_request"`
+	BackfillProgress  types.Float64 `tfsdk:"backfill_progress"`
+	Owner             types.String  `tfsdk:"owner"`
+	Priority          types.Int64   `tfsdk:"priority"`
+	BillingAccountID  types.String  `tfsdk:"billing_account_id"`
+	ExportProjectID   types.String  `tfsdk:"export_project_id"`
+	LastIngestedAt    types.String  `tfsdk:"last_ingested_at"`
+	DetectedColumns   types.List    `tfsdk:"detected_columns"`
+	Tags              types.Map     `tfsdk:"tags"`
 }
 
 // NewGCPResource returns the GCP billing datasource resource.
@@ -53,16 +78,39 @@ func (r *gcpResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *
 				Computed:            true,
 				MarkdownDescription: "Billing datasource ID returned by Costory.",
 			},
+			"import_id": importIDAttribute(),
 			"status": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "Datasource status returned by Costory.",
 			},
+			"managed_by":     managedByAttribute(),
+			"cloud_provider": cloudProviderAttribute(costoryapi.GCPBillingDatasourceRequest{}),
+			"backfill_progress": schema.Float64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Historical backfill progress as a percentage, refreshed on each read. Null when Costory does not report it.",
+			},
+			"last_ingested_at": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Time of the last successful ingestion (RFC3339), refreshed on each read. Null when Costory has not reported one yet.",
+			},
+			"detected_columns": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Export columns Costory detected, refreshed on each read. Use it to confirm the datasource points at the expected export. Null when Costory does not report them.",
+			},
+			"summary": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Human-readable summary of the datasource and its current status, for outputs.",
+			},
 			"name": schema.StringAttribute{
 				Required:            true,
 				MarkdownDescription: "Billing datasource display name.",
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
+				Validators: []validator.String{
+					noSurroundingWhitespaceValidator(),
+				},
 			},
 			"bq_uri": schema.StringAttribute{
 				Required:            true,
@@ -70,42 +118,110 @@ func (r *gcpResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
+				Validators: []validator.String{
+					noSurroundingWhitespaceValidator(),
+				},
 			},
 			"is_detailed_billing": schema.BoolAttribute{
 				Optional:            true,
-				MarkdownDescription: "Whether Costory should use detailed billing rows.",
+				Computed:            true,
+				MarkdownDescription: "Whether Costory should use detailed billing rows. When omitted, the provider's `default_detailed_billing` applies if set, otherwise Costory may compute it; removing it from configuration keeps the current value.",
 				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
 					boolplanmodifier.RequiresReplace(),
 				},
 			},
+			"billing_account_id": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Optional Cloud Billing account ID (`XXXXXX-XXXXXX-XXXXXX`) exported to `bq_uri`, when it differs from the account billing the BigQuery project. When unset, the value Costory reports is kept in state, and removing the attribute does not replace the datasource.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					gcpBillingAccountIDValidator(),
+				},
+			},
+			"export_project_id": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Optional ID of the project that owns the billing export dataset, when it differs from the project in `bq_uri`. When unset, the value Costory reports is kept in state, and removing the attribute does not replace the datasource.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					noSurroundingWhitespaceValidator(),
+					nonEmptyStringValidator(),
+				},
+			},
 			"start_date": schema.StringAttribute{
 				Optional:            true,
-				MarkdownDescription: "Optional filter start date (YYYY-MM-DD).",
+				Computed:            true,
+				MarkdownDescription: "Optional filter start date (YYYY-MM-DD). When omitted, Costory may compute it; removing it from configuration keeps the current value. Must not be after `end_date`. Also accepts `today` or `today-<N>d`, resolved in the provider `timezone` (UTC by default) when the request is sent and kept as written in state.",
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
+				Validators: []validator.String{
+					relativeDateValidator{},
+				},
 			},
 			"end_date": schema.StringAttribute{
 				Optional:            true,
-				MarkdownDescription: "Optional filter end date (YYYY-MM-DD).",
+				Computed:            true,
+				MarkdownDescription: "Optional filter end date (YYYY-MM-DD). When omitted, Costory may compute it; removing it from configuration keeps the current value. Must not be before `start_date`. Also accepts `today` or `today-<N>d`, resolved in the provider `timezone` (UTC by default) when the request is sent and kept as written in state.",
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
+				Validators: []validator.String{
+					relativeDateValidator{},
+					futureEndDateWarningValidator(),
+				},
+			},
+			"priority": priorityAttribute(),
+			"owner": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Owner of the datasource, used for access control and cost attribution. Can be changed in place; removing it from configuration keeps the current owner.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					noSurroundingWhitespaceValidator(),
+					nonEmptyStringValidator(),
+				},
 			},
+			"tags": tagsAttribute(
+				"Tags applied to the datasource, stored as BigQuery labels. Keys must be 1-63 characters, start with a lowercase letter, and contain only lowercase letters, digits, underscores, and dashes; values follow the same character rules and may be empty. At most 64 tags. Tags are updated in place; removing the attribute keeps the current tags, while `{}` clears them.",
+				gcpLabelsValidator{},
+			),
+			"recreate_on_failure": recreateOnFailureAttribute(),
+			"confirm_delete":      confirmDeleteAttribute(),
+			"expected_status":     expectedStatusAttribute(),
+			"known_id":            knownIDAttribute(),
+			"extra_headers":       extraHeadersAttribute(),
+			"show_api_request":    showAPIRequestAttribute(),
 		},
 	}
 }
 
+func (r *gcpResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{dateWindowValidator{}}
+}
+
 func (r *gcpResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*costoryapi.Client)
+	client, ok := req.ProviderData.(costoryapi.BillingDatasourceAPI)
 	if !ok {
 		resp.Diagnostics.AddError(
-			"Unexpected resource configure type",
-			fmt.Sprintf("Expected *costoryapi.Client, got: %T. This is always a provider implementation bug.", req.ProviderData),
+			costoryapi.VersionedSummary("Unexpected resource configure type", req.ProviderData),
+			fmt.Sprintf("Expected costoryapi.BillingDatasourceAPI, got: %T. This is always a provider implementation bug.", req.ProviderData),
 		)
 		return
 	}
@@ -122,33 +238,60 @@ func (r *gcpResource) Create(ctx context.Context, req resource.CreateRequest, re
 		return
 	}
 
+	ctx, cancel := r.client.OperationContext(ctx)
+	defer cancel()
+	defer retrywarning.Add(ctx, &resp.Diagnostics)
+	defer versiondiag.Add(r.client, &resp.Diagnostics)
+
 	var plan gcpResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = withExtraHeaders(ctx, plan.ExtraHeaders)
 
-	createRequest := plan.toRequestModel()
+	existing, diags := adoptKnownID(ctx, plan.KnownID, "GCP", r.client.GetGCPBillingDatasource, plan.knownIDFields)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if existing != nil {
+		plan.ID = plan.KnownID
+		plan.mergeAPIResponse(existing)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+		return
+	}
 
-	if err := r.client.ValidateGCPBillingDatasource(ctx, createRequest); err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to validate GCP billing datasource",
-			err.Error(),
-		)
+	createRequest := plan.toRequestModel(r.client.Now)
+
+	validation, err := r.client.ValidateGCPBillingDatasourceResult(ctx, createRequest)
+	switch {
+	case errors.Is(err, costoryapi.ErrValidationUnavailable):
+		// An API without the validate endpoint may still accept the create, which then
+		// reports any configuration problem itself.
+		summary := "GCP billing datasource validation skipped"
+		detail := fmt.Sprintf("The Costory API does not provide the validate endpoint, so the datasource is created without pre-validation: %s", err)
+		resp.Diagnostics.AddWarning(summary, detail)
+		r.client.RecordWarning(summary, detail)
+	case err != nil:
+		addAPIErrorDiagnostics(&resp.Diagnostics, "Unable to validate GCP billing datasource", err, plan.validationAttributes())
+		return
+	case !validation.Valid:
+		addValidationIssueDiagnostics(&resp.Diagnostics, "Unable to validate GCP billing datasource", validation.Issues, plan.validationAttributes())
 		return
 	}
 
-	created, err := r.client.CreateGCPBillingDatasource(ctx, createRequest)
+	createCtx := costoryapi.WithIdempotencyKey(ctx, costoryapi.NewIdempotencyKey())
+	created, err := r.client.CreateGCPBillingDatasource(createCtx, createRequest)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to create GCP billing datasource",
-			err.Error(),
-		)
+		addAPIErrorDiagnostics(&resp.Diagnostics, "Unable to create GCP billing datasource", err, plan.validationAttributes())
 		return
 	}
 
 	plan.ID = types.StringValue(created.ID)
 	plan.mergeAPIResponse(created)
+	addCreateWarnings(&resp.Diagnostics, r.client, "GCP", created.Warnings)
+	addStartDateWarning(&resp.Diagnostics, r.client, "GCP", createRequest.StartDate, created.EarliestAvailableDate)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
@@ -162,11 +305,17 @@ func (r *gcpResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 		return
 	}
 
+	ctx, cancel := r.client.OperationContext(ctx)
+	defer cancel()
+	defer retrywarning.Add(ctx, &resp.Diagnostics)
+	defer versiondiag.Add(r.client, &resp.Diagnostics)
+
 	var state gcpResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = withExtraHeaders(ctx, state.ExtraHeaders)
 
 	current, err := r.client.GetGCPBillingDatasource(ctx, state.ID.ValueString())
 	if err != nil {
@@ -188,13 +337,86 @@ func (r *gcpResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	statediff.Log(ctx, "read", req.State, resp.State)
+	checkStrictDrift(ctx, r.client, req, resp)
+}
+
+func (r *gcpResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured Costory client",
+			"The provider did not configure the Costory API client for the resource.",
+		)
+		return
+	}
+
+	ctx, cancel := r.client.OperationContext(ctx)
+	defer cancel()
+	defer retrywarning.Add(ctx, &resp.Diagnostics)
+	defer versiondiag.Add(r.client, &resp.Diagnostics)
+
+	// Every other API-backed attribute requires replacement, so only owner, priority, tags
+	// and provider-side settings such as recreate_on_failure can change in place.
+	var plan, state gcpResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = withExtraHeaders(ctx, plan.ExtraHeaders)
+
+	if !plan.Owner.IsUnknown() && !plan.Owner.Equal(state.Owner) {
+		if err := r.client.UpdateBillingDatasourceOwner(ctx, state.ID.ValueString(), plan.Owner.ValueStringPointer()); err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to update GCP billing datasource owner",
+				err.Error(),
+			)
+			return
+		}
+		state.Owner = plan.Owner
+	}
+
+	if !plan.Priority.IsUnknown() && !plan.Priority.Equal(state.Priority) {
+		if err := r.client.UpdateBillingDatasourcePriority(ctx, state.ID.ValueString(), plan.Priority.ValueInt64Pointer()); err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to update GCP billing datasource priority",
+				err.Error(),
+			)
+			return
+		}
+		state.Priority = plan.Priority
+	}
+
+	if !plan.Tags.IsUnknown() && !plan.Tags.Equal(state.Tags) {
+		if err := r.client.UpdateBillingDatasourceTags(ctx, state.ID.ValueString(), tagsFromModel(plan.Tags)); err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to update GCP billing datasource tags",
+				err.Error(),
+			)
+			return
+		}
+		state.Tags = plan.Tags
+	}
+
+	state.RecreateOnFailure = plan.RecreateOnFailure
+	state.ConfirmDelete = plan.ConfirmDelete
+	state.ExpectedStatus = plan.ExpectedStatus
+	state.KnownID = plan.KnownID
+	state.ExtraHeaders = plan.ExtraHeaders
+	state.ShowAPIRequest = plan.ShowAPIRequest
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	statediff.Log(ctx, "update", req.State, resp.State)
 }
 
-func (r *gcpResource) Update(_ context.Context, _ resource.UpdateRequest, resp *resource.UpdateResponse) {
-	resp.Diagnostics.AddError(
-		"Update not supported",
-		"All attributes are immutable for costory_billing_datasource_gcp. Terraform should replace the resource instead.",
-	)
+func (r *gcpResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planRecreateOnFailure(ctx, req, resp)
+	planExpectedStatus(ctx, req, resp)
+	planDatasourceTypeSupported(ctx, r.client, costoryapi.GCPBillingDatasourceRequest{}, req, resp)
+	planDateWindow(ctx, r.client, req, resp)
+	planFeaturesSupported(ctx, r.client, req, resp)
+	planDefaultDetailedBilling(ctx, r.client, req, resp)
+	planShowAPIRequest[gcpResourceModel](ctx, r.client, req, resp)
 }
 
 func (r *gcpResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -206,14 +428,25 @@ func (r *gcpResource) Delete(ctx context.Context, req resource.DeleteRequest, re
 		return
 	}
 
+	ctx, cancel := r.client.OperationContext(ctx)
+	defer cancel()
+	defer retrywarning.Add(ctx, &resp.Diagnostics)
+	defer versiondiag.Add(r.client, &resp.Diagnostics)
+
 	var state gcpResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = withExtraHeaders(ctx, state.ExtraHeaders)
+
+	resp.Diagnostics.Append(deleteConfirmationDiagnostics(r.client, state.ConfirmDelete)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	err := r.client.DeleteBillingDatasource(ctx, state.ID.ValueString())
-	if err != nil && !errors.Is(err, costoryapi.ErrNotFound) {
+	if err != nil && (!errors.Is(err, costoryapi.ErrNotFound) || r.client.FailDeleteOnMissing()) {
 		resp.Diagnostics.AddError(
 			"Unable to delete GCP billing datasource",
 			err.Error(),
@@ -223,10 +456,10 @@ func (r *gcpResource) Delete(ctx context.Context, req resource.DeleteRequest, re
 }
 
 func (r *gcpResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateWithTypePrefix(ctx, importPrefixGCP, req, resp)
 }
 
-func (m gcpResourceModel) toRequestModel() costoryapi.GCPBillingDatasourceRequest {
+func (m gcpResourceModel) toRequestModel(now func() time.Time) costoryapi.GCPBillingDatasourceRequest {
 	req := costoryapi.GCPBillingDatasourceRequest{
 		Name:  m.Name.ValueString(),
 		BQURI: m.BQURI.ValueString(),
@@ -237,50 +470,76 @@ func (m gcpResourceModel) toRequestModel() costoryapi.GCPBillingDatasourceReques
 		req.IsDetailedBilling = &value
 	}
 
-	if !m.StartDate.IsNull() && !m.StartDate.IsUnknown() {
-		value := m.StartDate.ValueString()
-		req.StartDate = &value
-	}
-
-	if !m.EndDate.IsNull() && !m.EndDate.IsUnknown() {
-		value := m.EndDate.ValueString()
-		req.EndDate = &value
-	}
+	req.StartDate = requestDate(m.StartDate, now)
 
-	return req
-}
+	req.EndDate = requestDate(m.EndDate, now)
 
-func (m *gcpResourceModel) mergeAPIResponse(apiResponse *costoryapi.GCPBillingDatasource) {
-	if apiResponse == nil {
-		return
+	if !m.Owner.IsNull() && !m.Owner.IsUnknown() {
+		value := m.Owner.ValueString()
+		req.Owner = &value
 	}
 
-	if apiResponse.ID != "" {
-		m.ID = types.StringValue(apiResponse.ID)
+	if !m.Priority.IsNull() && !m.Priority.IsUnknown() {
+		value := m.Priority.ValueInt64()
+		req.Priority = &value
 	}
 
-	m.Status = types.StringNull()
-	if apiResponse.Status != nil {
-		m.Status = types.StringValue(*apiResponse.Status)
+	if !m.BillingAccountID.IsNull() && !m.BillingAccountID.IsUnknown() {
+		value := m.BillingAccountID.ValueString()
+		req.BillingAccountID = &value
 	}
 
-	if apiResponse.Name != "" {
-		m.Name = types.StringValue(apiResponse.Name)
+	if !m.ExportProjectID.IsNull() && !m.ExportProjectID.IsUnknown() {
+		value := m.ExportProjectID.ValueString()
+		req.ExportProjectID = &value
 	}
 
-	if apiResponse.BQURI != "" {
-		m.BQURI = types.StringValue(apiResponse.BQURI)
-	}
+	req.Tags = tagsFromModel(m.Tags)
 
-	if apiResponse.IsDetailedBilling != nil {
-		m.IsDetailedBilling = types.BoolValue(*apiResponse.IsDetailedBilling)
-	}
+	return req
+}
+
+func (m gcpResourceModel) createRequest(now func() time.Time) costoryapi.BillingDatasourceCreateRequest {
+	return m.toRequestModel(now)
+}
 
-	if apiResponse.StartDate != nil {
-		m.StartDate = types.StringValue(*apiResponse.StartDate)
+func (m gcpResourceModel) redactedAPIRequestFields() []string {
+	return nil
+}
+
+// validationAttributes maps API field names used in validation issues to schema attributes.
+func (m gcpResourceModel) validationAttributes() map[string]path.Path {
+	return map[string]path.Path{
+		"owner":             path.Root("owner"),
+		"priority":          path.Root("priority"),
+		"name":              path.Root("name"),
+		"bqTablePath":       path.Root("bq_uri"),
+		"bqUri":             path.Root("bq_uri"),
+		"isDetailedBilling": path.Root("is_detailed_billing"),
+		"startDate":         path.Root("start_date"),
+		"endDate":           path.Root("end_date"),
+		"billingAccountId":  path.Root("billing_account_id"),
+		"exportProjectId":   path.Root("export_project_id"),
+		"tags":              path.Root("tags"),
 	}
+}
+
+func (m *gcpResourceModel) mergeAPIResponse(apiResponse *costoryapi.GCPBillingDatasource) {
+	*m = mergeGCPDatasource(*m, apiResponse)
+
+	m.ImportID = importIDValue(importPrefixGCP, m.ID)
+}
+
+func (m gcpResourceModel) summary() string {
+	return fmt.Sprintf("GCP billing datasource '%s' (%s) is %s ingesting from %s", m.Name.ValueString(), m.ID.ValueString(), summaryStatus(m.Status), m.BQURI.ValueString())
+}
 
-	if apiResponse.EndDate != nil {
-		m.EndDate = types.StringValue(*apiResponse.EndDate)
+// knownIDFields lists the immutable attributes compared with the datasource known_id names.
+func (m gcpResourceModel) knownIDFields(existing *costoryapi.GCPBillingDatasource) []knownIDField {
+	return []knownIDField{
+		{attribute: "name", configured: m.Name, actual: existing.Name},
+		{attribute: "bq_uri", configured: m.BQURI, actual: existing.BQURI},
+		{attribute: "billing_account_id", configured: m.BillingAccountID, actual: stringValue(existing.BillingAccountID)},
+		{attribute: "export_project_id", configured: m.ExportProjectID, actual: stringValue(existing.ExportProjectID)},
 	}
 }
@@ -0,0 +1,78 @@
+package billingdatasource
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Import ID prefixes, one per billing datasource resource, accepted as "<prefix>:<id>".
+const (
+	importPrefixGCP          = "gcp"
+	importPrefixAWS          = "aws"
+	importPrefixCursor       = "cursor"
+	importPrefixAnthropic    = "anthropic"
+	importPrefixElasticCloud = "elastic_cloud"
+	importPrefixAzure        = "azure"
+)
+
+var importPrefixes = []string{
+	importPrefixGCP,
+	importPrefixAWS,
+	importPrefixCursor,
+	importPrefixAnthropic,
+	importPrefixElasticCloud,
+	importPrefixAzure,
+}
+
+func importIDAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Computed:            true,
+		MarkdownDescription: "Value to pass to `terraform import` for this datasource, in the form `<type>:<id>`.",
+	}
+}
+
+// importIDValue returns the prefixed import ID for a datasource, null until its ID is known.
+func importIDValue(prefix string, id types.String) types.String {
+	if id.IsNull() || id.IsUnknown() || id.ValueString() == "" {
+		return types.StringNull()
+	}
+
+	return types.StringValue(prefix + ":" + id.ValueString())
+}
+
+// importStateWithTypePrefix imports a datasource by bare ID or by "<prefix>:<id>". A known
+// prefix that belongs to another resource type is rejected, so importing for example a GCP
+// datasource into an AWS resource fails up front instead of producing confusing state. IDs
+// whose part before ":" is not a known prefix are passed through unchanged.
+func importStateWithTypePrefix(ctx context.Context, prefix string, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id := req.ID
+
+	if idPrefix, rest, ok := strings.Cut(req.ID, ":"); ok && slices.Contains(importPrefixes, idPrefix) {
+		if idPrefix != prefix {
+			resp.Diagnostics.AddError(
+				"Mismatched import ID type",
+				fmt.Sprintf("Import ID %q is for a %s billing datasource, but this resource imports %s billing datasources. Use %q or a bare datasource ID.", req.ID, idPrefix, prefix, prefix+":<id>"),
+			)
+			return
+		}
+
+		id = rest
+	}
+
+	if strings.TrimSpace(id) == "" {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			fmt.Sprintf("Import ID %q does not include a datasource ID. Use %q or a bare datasource ID.", req.ID, prefix+":<id>"),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
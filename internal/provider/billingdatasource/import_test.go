@@ -0,0 +1,181 @@
+package billingdatasource
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/costory-io/costory-terraform/internal/costoryapi"
+)
+
+func TestImportStateWithTypePrefix(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		newResource func() resource.Resource
+		importID    string
+		wantID      string
+		wantError   string
+	}{
+		"aws prefixed":        {newResource: NewAWSResource, importID: "aws:aws-ds-1", wantID: "aws-ds-1"},
+		"gcp prefixed":        {newResource: NewGCPResource, importID: "gcp:gcp-ds-1", wantID: "gcp-ds-1"},
+		"bare":                {newResource: NewAWSResource, importID: "aws-ds-1", wantID: "aws-ds-1"},
+		"unknown prefix kept": {newResource: NewGCPResource, importID: "tenant:gcp-ds-1", wantID: "tenant:gcp-ds-1"},
+		"mismatched prefix":   {newResource: NewAWSResource, importID: "gcp:gcp-ds-1", wantError: "Mismatched import ID type"},
+		"missing id":          {newResource: NewGCPResource, importID: "gcp:", wantError: "Invalid import ID"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			r := tc.newResource().(resource.ResourceWithImportState)
+
+			var schemaResp resource.SchemaResponse
+			r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+			resp := resource.ImportStateResponse{
+				State: tfsdk.State{
+					Schema: schemaResp.Schema,
+					Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+				},
+			}
+			r.ImportState(ctx, resource.ImportStateRequest{ID: tc.importID}, &resp)
+
+			if tc.wantError != "" {
+				if !resp.Diagnostics.HasError() || !strings.Contains(resp.Diagnostics.Errors()[0].Summary(), tc.wantError) {
+					t.Fatalf("expected %q error, got %v", tc.wantError, resp.Diagnostics)
+				}
+				return
+			}
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			var id types.String
+			resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("id"), &id)...)
+			if got := id.ValueString(); got != tc.wantID {
+				t.Fatalf("unexpected imported id: got %q, want %q", got, tc.wantID)
+			}
+		})
+	}
+}
+
+func TestImportIDMatchesImportFormat(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		newResource func() resource.Resource
+		importID    func() types.String
+		want        string
+	}{
+		"gcp": {
+			newResource: NewGCPResource,
+			importID: func() types.String {
+				var model gcpResourceModel
+				model.mergeAPIResponse(&costoryapi.GCPBillingDatasource{ID: "ds-1"})
+				return model.ImportID
+			},
+			want: "gcp:ds-1",
+		},
+		"aws": {
+			newResource: NewAWSResource,
+			importID: func() types.String {
+				var model awsResourceModel
+				model.mergeAPIResponse(&costoryapi.AWSBillingDatasource{ID: "ds-1"})
+				return model.ImportID
+			},
+			want: "aws:ds-1",
+		},
+		"cursor": {
+			newResource: NewCursorResource,
+			importID: func() types.String {
+				var model cursorResourceModel
+				model.mergeAPIResponse(&costoryapi.CursorBillingDatasource{ID: "ds-1"})
+				return model.ImportID
+			},
+			want: "cursor:ds-1",
+		},
+		"anthropic": {
+			newResource: NewAnthropicResource,
+			importID: func() types.String {
+				var model anthropicResourceModel
+				model.mergeAPIResponse(&costoryapi.AnthropicBillingDatasource{ID: "ds-1"})
+				return model.ImportID
+			},
+			want: "anthropic:ds-1",
+		},
+		"elastic cloud": {
+			newResource: NewElasticCloudResource,
+			importID: func() types.String {
+				var model elasticCloudResourceModel
+				model.mergeAPIResponse(&costoryapi.ElasticCloudBillingDatasource{ID: "ds-1"})
+				return model.ImportID
+			},
+			want: "elastic_cloud:ds-1",
+		},
+		"azure": {
+			newResource: NewAzureResource,
+			importID: func() types.String {
+				var model azureResourceModel
+				model.mergeAPIResponse(&costoryapi.AzureBillingDatasource{ID: "ds-1"})
+				return model.ImportID
+			},
+			want: "azure:ds-1",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			importID := tc.importID()
+			if got := importID.ValueString(); got != tc.want {
+				t.Fatalf("unexpected import_id: got %q, want %q", got, tc.want)
+			}
+
+			ctx := context.Background()
+			r := tc.newResource()
+
+			var schemaResp resource.SchemaResponse
+			r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+			resp := resource.ImportStateResponse{State: tfsdk.State{
+				Schema: schemaResp.Schema,
+				Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+			}}
+			r.(resource.ResourceWithImportState).ImportState(ctx, resource.ImportStateRequest{ID: importID.ValueString()}, &resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("expected import_id to be importable: %v", resp.Diagnostics)
+			}
+
+			var id types.String
+			resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("id"), &id)...)
+			if got := id.ValueString(); got != "ds-1" {
+				t.Fatalf("unexpected imported id: got %q, want %q", got, "ds-1")
+			}
+		})
+	}
+}
+
+func TestImportIDValueNullWithoutID(t *testing.T) {
+	t.Parallel()
+
+	for name, id := range map[string]types.String{
+		"null":    types.StringNull(),
+		"unknown": types.StringUnknown(),
+		"empty":   types.StringValue(""),
+	} {
+		if got := importIDValue(importPrefixGCP, id); !got.IsNull() {
+			t.Fatalf("%s: expected null import_id, got %s", name, got)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package billingdatasource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/costory-io/costory-terraform/internal/costoryapi"
+)
+
+func knownIDAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional:            true,
+		MarkdownDescription: "ID of a datasource that already exists in Costory, for example one created by an apply that was interrupted before Terraform saved state. On create, the resource adopts that datasource instead of creating a duplicate, and fails if it does not exist or its immutable attributes, such as `name`, differ from the configuration. Ignored once the resource is created.",
+		Validators: []validator.String{
+			noSurroundingWhitespaceValidator(),
+			nonEmptyStringValidator(),
+		},
+	}
+}
+
+// knownIDField pairs an immutable attribute from configuration with the value reported by the
+// datasource known_id names. normalize, when set, is applied to both before comparing.
+type knownIDField struct {
+	attribute  string
+	configured types.String
+	actual     string
+	normalize  func(string) string
+	sensitive  bool
+}
+
+// adoptKnownID fetches the datasource named by known_id so Create can adopt it instead of
+// creating a duplicate. It returns nil without diagnostics when known_id is not set. The
+// datasource is only adopted when the immutable attributes returned by fields match it, since
+// its values cannot be updated in place and the configuration would otherwise be recorded in
+// state while Costory keeps the existing ones.
+func adoptKnownID[T any](ctx context.Context, knownID types.String, kind string, get func(context.Context, string) (*T, error), fields func(*T) []knownIDField) (*T, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	id := strings.TrimSpace(knownID.ValueString())
+	if knownID.IsNull() || knownID.IsUnknown() || id == "" {
+		return nil, diags
+	}
+
+	existing, err := get(ctx, id)
+	if err != nil {
+		if errors.Is(err, costoryapi.ErrNotFound) {
+			diags.AddAttributeError(
+				path.Root("known_id"),
+				fmt.Sprintf("%s billing datasource not found", kind),
+				fmt.Sprintf("known_id is set to %q, but no %s billing datasource with that ID exists in Costory. Remove known_id to create a new datasource.", id, kind),
+			)
+			return nil, diags
+		}
+
+		diags.AddError(
+			fmt.Sprintf("Unable to read %s billing datasource", kind),
+			err.Error(),
+		)
+		return nil, diags
+	}
+
+	for _, field := range fields(existing) {
+		configured := field.configured.ValueString()
+		if field.configured.IsNull() || field.configured.IsUnknown() || field.actual == "" {
+			continue
+		}
+
+		actual := field.actual
+		if field.normalize != nil {
+			configured, actual = field.normalize(configured), field.normalize(actual)
+		}
+		if configured == actual {
+			continue
+		}
+
+		detail := fmt.Sprintf("known_id is set to %q, but that %s billing datasource has a different %s", id, kind, field.attribute)
+		if !field.sensitive {
+			detail += fmt.Sprintf(" (%q, configured %q)", field.actual, field.configured.ValueString())
+		}
+		diags.AddAttributeError(
+			path.Root(field.attribute),
+			fmt.Sprintf("%s billing datasource does not match known_id", kind),
+			detail+". It cannot be changed in place, so update the configuration to match the existing datasource or remove known_id to create a new one.",
+		)
+	}
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	return existing, diags
+}
+
+// stringValue returns the value of an optional string field, or "" when the API omitted it.
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+
+	return *value
+}
@@ -0,0 +1,85 @@
+package billingdatasource
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/costory-io/costory-terraform/internal/costoryapi"
+)
+
+func TestAWSResourceCreateKnownID(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		exists          bool
+		bucketName      string
+		wantErrorOnAttr string
+	}{
+		"adopts existing datasource":    {exists: true, bucketName: "billing-bucket"},
+		"adopts equivalent bucket name": {exists: true, bucketName: "Billing-Bucket"},
+		"known id not found":            {bucketName: "billing-bucket", wantErrorOnAttr: "known_id"},
+		"immutable attribute mismatch":  {exists: true, bucketName: "other-bucket", wantErrorOnAttr: "bucket_name"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			client := costoryapi.NewFakeClient()
+			if tc.exists {
+				if err := client.PutDatasource(&costoryapi.AWSBillingDatasource{
+					ID:         "aws-ds-orphan",
+					Type:       "AWS",
+					Status:     stringPointer("ACTIVE"),
+					Name:       "AWS Billing",
+					BucketName: "billing-bucket",
+					RoleARN:    testRoleARN,
+					Prefix:     stringPointer("cur/"),
+				}); err != nil {
+					t.Fatalf("unable to store datasource: %v", err)
+				}
+			}
+
+			f := newResourceFixture(t, NewAWSResource(), client)
+
+			model := testAWSModel()
+			model.BucketName = types.StringValue(tc.bucketName)
+			model.KnownID = types.StringValue("aws-ds-orphan")
+			resp := f.create(&model)
+
+			if got := client.Datasource("fake-ds-1"); got != nil {
+				t.Fatalf("expected no datasource to be created, got %#v", got)
+			}
+
+			if tc.wantErrorOnAttr != "" {
+				if resp.Diagnostics.ErrorsCount() != 1 {
+					t.Fatalf("expected a single error, got %v", resp.Diagnostics)
+				}
+				withPath, ok := resp.Diagnostics.Errors()[0].(diag.DiagnosticWithPath)
+				if !ok || !withPath.Path().Equal(path.Root(tc.wantErrorOnAttr)) {
+					t.Fatalf("expected the error to target %s, got %#v", tc.wantErrorOnAttr, resp.Diagnostics.Errors()[0])
+				}
+				if !resp.State.Raw.IsNull() {
+					t.Fatal("expected no state to be written")
+				}
+				return
+			}
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			var state awsResourceModel
+			f.decode(resp.State, &state)
+			if got := state.ID.ValueString(); got != "aws-ds-orphan" {
+				t.Fatalf("unexpected id: got %q, want %q", got, "aws-ds-orphan")
+			}
+			if got := state.Status.ValueString(); got != "ACTIVE" {
+				t.Fatalf("unexpected status: got %q, want %q", got, "ACTIVE")
+			}
+		})
+	}
+}
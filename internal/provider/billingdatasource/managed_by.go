@@ -0,0 +1,25 @@
+package billingdatasource
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// managedByAttribute returns the computed managed_by attribute. A datasource's origin does not
+// change after creation, so the prior value is kept in plans.
+func managedByAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Computed:            true,
+		MarkdownDescription: "Origin Costory reports for the datasource, such as `terraform` for one created through the API or `ui` for one created in the Costory app, so audits can tell IaC-managed datasources apart, including adopted or imported ones. Null when Costory does not report it.",
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.UseStateForUnknown(),
+		},
+	}
+}
+
+// mergeManagedBy maps the computed managed_by value, which is null whenever the API omits it.
+func mergeManagedBy(apiValue *string) types.String {
+	return types.StringPointerValue(apiValue)
+}
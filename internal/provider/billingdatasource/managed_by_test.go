@@ -0,0 +1,82 @@
+package billingdatasource
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/costory-io/costory-terraform/internal/costoryapi"
+)
+
+func TestCursorResourceReadManagedBy(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		managedBy *string
+		want      types.String
+	}{
+		"created in the app":   {managedBy: stringPointer("ui"), want: types.StringValue("ui")},
+		"created by terraform": {managedBy: stringPointer("terraform"), want: types.StringValue("terraform")},
+		"not reported":         {want: types.StringNull()},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			client := costoryapi.NewFakeClient()
+			if err := client.PutDatasource(&costoryapi.CursorBillingDatasource{
+				ID:        "cursor-ds-1",
+				Type:      "Cursor",
+				Name:      "Cursor Billing",
+				ManagedBy: tc.managedBy,
+			}); err != nil {
+				t.Fatalf("unable to store datasource: %v", err)
+			}
+
+			f := newResourceFixture(t, NewCursorResource(), client)
+
+			// State as left by an import: only the ID is known.
+			resp := f.read(&cursorResourceModel{
+				ID:           types.StringValue("cursor-ds-1"),
+				ExtraHeaders: types.MapNull(types.StringType),
+			})
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected read diagnostics: %v", resp.Diagnostics)
+			}
+
+			var refreshed cursorResourceModel
+			f.decode(resp.State, &refreshed)
+			if !refreshed.ManagedBy.Equal(tc.want) {
+				t.Fatalf("unexpected managed_by: got %s, want %s", refreshed.ManagedBy, tc.want)
+			}
+		})
+	}
+}
+
+func TestGCPResourceModelMergeManagedBy(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		current   types.String
+		managedBy *string
+		want      types.String
+	}{
+		"created in the app":   {current: types.StringUnknown(), managedBy: stringPointer("ui"), want: types.StringValue("ui")},
+		"created by terraform": {current: types.StringUnknown(), managedBy: stringPointer("terraform"), want: types.StringValue("terraform")},
+		"not reported":         {current: types.StringValue("terraform"), want: types.StringNull()},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			model := gcpResourceModel{ManagedBy: tc.current}
+			model.mergeAPIResponse(&costoryapi.GCPBillingDatasource{ID: "gcp-ds-1", ManagedBy: tc.managedBy})
+
+			if !model.ManagedBy.Equal(tc.want) {
+				t.Fatalf("unexpected managed_by: got %s, want %s", model.ManagedBy, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,201 @@
+package billingdatasource
+
+import (
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/costory-io/costory-terraform/internal/costoryapi"
+)
+
+// The merge functions below map API responses onto resource models. They are pure so
+// that every optional field can be table-tested without a Terraform plan or state.
+
+// mergeNonEmptyString replaces current with a value the API always returns, keeping
+// current when the API left the field empty.
+func mergeNonEmptyString(current types.String, apiValue string) types.String {
+	if apiValue == "" {
+		return current
+	}
+
+	return types.StringValue(apiValue)
+}
+
+// mergeBoolPointer replaces current with an optional bool returned by the API, keeping
+// current when the API omitted the field. A returned false is kept as false, not null.
+func mergeBoolPointer(current types.Bool, apiValue *bool) types.Bool {
+	if apiValue == nil {
+		return current
+	}
+
+	return types.BoolValue(*apiValue)
+}
+
+// mergeOptionalBool merges an optional+computed bool returned by the API into state, with
+// the same rules as mergeOptionalString.
+func mergeOptionalBool(current types.Bool, apiValue *bool) types.Bool {
+	if apiValue != nil {
+		return types.BoolValue(*apiValue)
+	}
+
+	if current.IsUnknown() {
+		return types.BoolNull()
+	}
+
+	return current
+}
+
+// mergeOptionalString merges an optional+computed string returned by the API into state.
+// A returned value always wins; otherwise the configured or prior value is kept, and an
+// unknown value (omitted in config during create) resolves to null.
+func mergeOptionalString(current types.String, apiValue *string) types.String {
+	if apiValue != nil {
+		return types.StringValue(*apiValue)
+	}
+
+	if current.IsUnknown() {
+		return types.StringNull()
+	}
+
+	return current
+}
+
+// mergeOptionalInt64 merges an optional+computed integer returned by the API into state,
+// like mergeOptionalString.
+func mergeOptionalInt64(current types.Int64, apiValue *int64) types.Int64 {
+	if apiValue != nil {
+		return types.Int64Value(*apiValue)
+	}
+
+	if current.IsUnknown() {
+		return types.Int64Null()
+	}
+
+	return current
+}
+
+// mergeTags replaces current with the tags returned by the API, keeping current when the
+// API omitted them or returned none for a resource that never configured any. Tags are
+// optional+computed, so an unknown current resolves to null when the API reports none.
+func mergeTags(current types.Map, apiValue map[string]string) types.Map {
+	if len(apiValue) == 0 && current.IsUnknown() {
+		return types.MapNull(types.StringType)
+	}
+	if apiValue == nil || (len(apiValue) == 0 && current.IsNull()) {
+		return current
+	}
+
+	elements := make(map[string]attr.Value, len(apiValue))
+	for key, value := range apiValue {
+		elements[key] = types.StringValue(value)
+	}
+
+	return types.MapValueMust(types.StringType, elements)
+}
+
+// mergeTimestamp maps a computed timestamp to RFC3339 in UTC, null when the API omits it.
+func mergeTimestamp(apiValue *time.Time) types.String {
+	if apiValue == nil {
+		return types.StringNull()
+	}
+
+	return types.StringValue(apiValue.UTC().Format(time.RFC3339))
+}
+
+// mergeDetectedColumns maps the computed detected columns, which are null whenever the API
+// omits them.
+func mergeDetectedColumns(apiValue []string) types.List {
+	if apiValue == nil {
+		return types.ListNull(types.StringType)
+	}
+
+	columns := make([]attr.Value, 0, len(apiValue))
+	for _, column := range apiValue {
+		columns = append(columns, types.StringValue(column))
+	}
+
+	return types.ListValueMust(types.StringType, columns)
+}
+
+// mergeStatus maps the computed status, which is null whenever the API omits it.
+func mergeStatus(apiValue *string) types.String {
+	return types.StringPointerValue(apiValue)
+}
+
+// mergeGCPDatasource returns model updated with the values returned by the API.
+func mergeGCPDatasource(model gcpResourceModel, apiResponse *costoryapi.GCPBillingDatasource) gcpResourceModel {
+	if apiResponse == nil {
+		return model
+	}
+
+	model.ID = mergeNonEmptyString(model.ID, apiResponse.ID)
+	model.CloudProvider = cloudProviderValue(costoryapi.GCPBillingDatasourceRequest{})
+	model.Status = mergeStatus(apiResponse.Status)
+	model.ManagedBy = mergeManagedBy(apiResponse.ManagedBy)
+	model.Name = mergeNonEmptyString(model.Name, apiResponse.Name)
+	model.BQURI = mergeNonEmptyString(model.BQURI, apiResponse.BQURI)
+	model.IsDetailedBilling = mergeOptionalBool(model.IsDetailedBilling, apiResponse.IsDetailedBilling)
+	model.StartDate = mergeOptionalDate(model.StartDate, apiResponse.StartDate)
+	model.EndDate = mergeOptionalDate(model.EndDate, apiResponse.EndDate)
+	model.BackfillProgress = types.Float64PointerValue(apiResponse.BackfillProgress)
+	model.LastIngestedAt = mergeTimestamp(apiResponse.LastIngestedAt)
+	model.DetectedColumns = mergeDetectedColumns(apiResponse.DetectedColumns)
+	model.Owner = mergeOptionalString(model.Owner, apiResponse.Owner)
+	model.Priority = mergeOptionalInt64(model.Priority, apiResponse.Priority)
+	model.BillingAccountID = mergeOptionalString(model.BillingAccountID, apiResponse.BillingAccountID)
+	model.ExportProjectID = mergeOptionalString(model.ExportProjectID, apiResponse.ExportProjectID)
+	model.Tags = mergeTags(model.Tags, apiResponse.Tags)
+	model.Summary = types.StringValue(model.summary())
+
+	return model
+}
+
+// mergeAWSDatasource returns model updated with the values returned by the API. The
+// role ARN is written to whichever of role_arn or sensitive_role_arn is in use, keeping the
+// configured path when the API echoes the role without it, and a returned prefix pattern
+// sets prefix_is_glob while a literal prefix clears it.
+func mergeAWSDatasource(model awsResourceModel, apiResponse *costoryapi.AWSBillingDatasource) awsResourceModel {
+	if apiResponse == nil {
+		return model
+	}
+
+	model.ID = mergeNonEmptyString(model.ID, apiResponse.ID)
+	model.CloudProvider = cloudProviderValue(costoryapi.AWSBillingDatasourceRequest{})
+	model.Status = mergeStatus(apiResponse.Status)
+	model.ManagedBy = mergeManagedBy(apiResponse.ManagedBy)
+	model.Name = mergeNonEmptyString(model.Name, apiResponse.Name)
+	model.BucketName = mergeEquivalentString(model.BucketName, apiResponse.BucketName, normalizeBucketName)
+
+	if model.usesSensitiveRoleARN() {
+		model.SensitiveRoleARN = mergeEquivalentString(model.SensitiveRoleARN, apiResponse.RoleARN, normalizeRoleARN)
+	} else {
+		model.RoleARN = mergeEquivalentString(model.RoleARN, apiResponse.RoleARN, normalizeRoleARN)
+	}
+
+	switch {
+	case apiResponse.PrefixPattern != nil:
+		model.Prefix = types.StringValue(*apiResponse.PrefixPattern)
+		model.PrefixIsGlob = types.BoolValue(true)
+	case apiResponse.Prefix != nil:
+		model.Prefix = types.StringValue(*apiResponse.Prefix)
+		if model.PrefixIsGlob.ValueBool() {
+			model.PrefixIsGlob = types.BoolValue(false)
+		}
+	}
+
+	model.EKSSplitDataEnabled = mergeBoolPointer(model.EKSSplitDataEnabled, apiResponse.EKSSplitDataEnabled)
+	model.StartDate = mergeOptionalDate(model.StartDate, apiResponse.StartDate)
+	model.EndDate = mergeOptionalDate(model.EndDate, apiResponse.EndDate)
+	model.EKSSplit = mergeBoolPointer(model.EKSSplit, apiResponse.EKSSplit)
+	model.ReportName = mergeOptionalString(model.ReportName, apiResponse.ReportName)
+	model.BackfillProgress = types.Float64PointerValue(apiResponse.BackfillProgress)
+	model.LastIngestedAt = mergeTimestamp(apiResponse.LastIngestedAt)
+	model.DetectedColumns = mergeDetectedColumns(apiResponse.DetectedColumns)
+	model.Owner = mergeOptionalString(model.Owner, apiResponse.Owner)
+	model.Priority = mergeOptionalInt64(model.Priority, apiResponse.Priority)
+	model.Tags = mergeTags(model.Tags, apiResponse.Tags)
+	model.Summary = types.StringValue(model.summary())
+
+	return model
+}
@@ -0,0 +1,438 @@
+package billingdatasource
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/costory-io/costory-terraform/internal/costoryapi"
+)
+
+func boolPointer(value bool) *bool {
+	return &value
+}
+
+func float64Pointer(value float64) *float64 {
+	return &value
+}
+
+// assertModelsEqual compares two resource models field by field using attr.Value
+// equality, since framework values such as Float64 are not comparable with ==.
+func assertModelsEqual(t *testing.T, got, want any) {
+	t.Helper()
+
+	gotValue, wantValue := reflect.ValueOf(got), reflect.ValueOf(want)
+	for i := range gotValue.NumField() {
+		gotField := gotValue.Field(i).Interface().(attr.Value)
+		wantField := wantValue.Field(i).Interface().(attr.Value)
+		if !gotField.Equal(wantField) {
+			t.Errorf("unexpected %s: got %s, want %s", gotValue.Type().Field(i).Name, gotField, wantField)
+		}
+	}
+}
+
+func TestMergeBoolPointer(t *testing.T) {
+	t.Parallel()
+
+	currents := map[string]types.Bool{
+		"null":    types.BoolNull(),
+		"unknown": types.BoolUnknown(),
+		"false":   types.BoolValue(false),
+		"true":    types.BoolValue(true),
+	}
+	apiValues := map[string]*bool{
+		"nil":   nil,
+		"false": boolPointer(false),
+		"true":  boolPointer(true),
+	}
+
+	for currentName, current := range currents {
+		for apiName, apiValue := range apiValues {
+			t.Run(currentName+"/"+apiName, func(t *testing.T) {
+				t.Parallel()
+
+				want := current
+				if apiValue != nil {
+					want = types.BoolValue(*apiValue)
+				}
+
+				if got := mergeBoolPointer(current, apiValue); !got.Equal(want) {
+					t.Fatalf("unexpected merged value: got %s, want %s", got, want)
+				}
+			})
+		}
+	}
+}
+
+func TestMergeStringHelpers(t *testing.T) {
+	t.Parallel()
+
+	currents := map[string]types.String{
+		"null":    types.StringNull(),
+		"unknown": types.StringUnknown(),
+		"empty":   types.StringValue(""),
+		"value":   types.StringValue("prior"),
+	}
+	apiValues := map[string]*string{
+		"nil":   nil,
+		"empty": stringPointer(""),
+		"value": stringPointer("returned"),
+	}
+
+	for currentName, current := range currents {
+		for apiName, apiValue := range apiValues {
+			t.Run(currentName+"/"+apiName, func(t *testing.T) {
+				t.Parallel()
+
+				wantOptional := current
+				if current.IsUnknown() {
+					wantOptional = types.StringNull()
+				}
+				if apiValue != nil {
+					wantOptional = types.StringValue(*apiValue)
+				}
+
+				if got := mergeOptionalString(current, apiValue); !got.Equal(wantOptional) {
+					t.Fatalf("unexpected mergeOptionalString value: got %s, want %s", got, wantOptional)
+				}
+
+				wantNonEmpty := current
+				if apiValue != nil && *apiValue != "" {
+					wantNonEmpty = types.StringValue(*apiValue)
+				}
+				var nonEmpty string
+				if apiValue != nil {
+					nonEmpty = *apiValue
+				}
+				if got := mergeNonEmptyString(current, nonEmpty); !got.Equal(wantNonEmpty) {
+					t.Fatalf("unexpected mergeNonEmptyString value: got %s, want %s", got, wantNonEmpty)
+				}
+			})
+		}
+	}
+}
+
+func TestMergeStatus(t *testing.T) {
+	t.Parallel()
+
+	if got := mergeStatus(nil); !got.IsNull() {
+		t.Fatalf("expected a missing status to be null, got %s", got)
+	}
+	if got := mergeStatus(stringPointer("ACTIVE")); got.ValueString() != "ACTIVE" {
+		t.Fatalf("unexpected status: got %s", got)
+	}
+}
+
+func TestMergeDetectedColumns(t *testing.T) {
+	t.Parallel()
+
+	if got := mergeDetectedColumns(nil); !got.IsNull() {
+		t.Fatalf("expected missing detected columns to be null, got %s", got)
+	}
+
+	want := types.ListValueMust(types.StringType, []attr.Value{types.StringValue("cost"), types.StringValue("currency")})
+	if got := mergeDetectedColumns([]string{"cost", "currency"}); !got.Equal(want) {
+		t.Fatalf("unexpected detected columns: got %s, want %s", got, want)
+	}
+
+	aws := awsResourceModel{RoleARN: types.StringValue(testRoleARN), DetectedColumns: want}
+	aws.mergeAPIResponse(&costoryapi.AWSBillingDatasource{ID: "aws-ds-1"})
+	if !aws.DetectedColumns.IsNull() {
+		t.Fatalf("expected AWS detected columns to be null when absent, got %s", aws.DetectedColumns)
+	}
+}
+
+func TestMergeGCPDatasource(t *testing.T) {
+	t.Parallel()
+
+	prior := gcpResourceModel{
+		ID:                types.StringValue("gcp-ds-1"),
+		Status:            types.StringValue("ACTIVE"),
+		Name:              types.StringValue("GCP Billing"),
+		BQURI:             types.StringValue("project.dataset.table"),
+		IsDetailedBilling: types.BoolValue(true),
+		StartDate:         types.StringValue("2025-01-01"),
+		EndDate:           types.StringUnknown(),
+		BackfillProgress:  types.Float64Value(0.5),
+		Owner:             types.StringValue("finops"),
+		BillingAccountID:  types.StringValue("01A2B3-C4D5E6-F7A8B9"),
+		ExportProjectID:   types.StringNull(),
+		Tags:              types.MapNull(types.StringType),
+		ExtraHeaders:      types.MapNull(types.StringType),
+		DetectedColumns:   types.ListNull(types.StringType),
+	}
+
+	tests := map[string]struct {
+		apiResponse *costoryapi.GCPBillingDatasource
+		check       func(t *testing.T, got gcpResourceModel)
+	}{
+		"nil response keeps model": {
+			apiResponse: nil,
+			check: func(t *testing.T, got gcpResourceModel) {
+				if !got.EndDate.IsUnknown() || !got.Status.Equal(prior.Status) {
+					t.Fatalf("expected model to be unchanged, got %#v", got)
+				}
+			},
+		},
+		"empty response keeps configured values": {
+			apiResponse: &costoryapi.GCPBillingDatasource{},
+			check: func(t *testing.T, got gcpResourceModel) {
+				if got.ID.ValueString() != "gcp-ds-1" || got.Name.ValueString() != "GCP Billing" || got.BQURI.ValueString() != "project.dataset.table" {
+					t.Fatalf("unexpected required values: %#v", got)
+				}
+				if !got.Status.IsNull() {
+					t.Fatalf("expected status to be null, got %s", got.Status)
+				}
+				if !got.IsDetailedBilling.ValueBool() {
+					t.Fatalf("expected is_detailed_billing to be kept, got %s", got.IsDetailedBilling)
+				}
+				if got.StartDate.ValueString() != "2025-01-01" || !got.EndDate.IsNull() {
+					t.Fatalf("unexpected dates: start %s, end %s", got.StartDate, got.EndDate)
+				}
+				if !got.BackfillProgress.IsNull() {
+					t.Fatalf("expected backfill_progress to be null, got %s", got.BackfillProgress)
+				}
+				if got.Owner.ValueString() != "finops" {
+					t.Fatalf("expected owner to be kept, got %s", got.Owner)
+				}
+				if got.BillingAccountID.ValueString() != "01A2B3-C4D5E6-F7A8B9" || !got.ExportProjectID.IsNull() {
+					t.Fatalf("expected billing export location to be kept: %s, %s", got.BillingAccountID, got.ExportProjectID)
+				}
+			},
+		},
+		"false bool is not null": {
+			apiResponse: &costoryapi.GCPBillingDatasource{IsDetailedBilling: boolPointer(false)},
+			check: func(t *testing.T, got gcpResourceModel) {
+				if got.IsDetailedBilling.IsNull() || got.IsDetailedBilling.ValueBool() {
+					t.Fatalf("expected is_detailed_billing false, got %s", got.IsDetailedBilling)
+				}
+			},
+		},
+		"returned values win": {
+			apiResponse: &costoryapi.GCPBillingDatasource{
+				ID:                "gcp-ds-2",
+				Status:            stringPointer("FAILED"),
+				Name:              "Renamed",
+				BQURI:             "other.dataset.table",
+				IsDetailedBilling: boolPointer(true),
+				StartDate:         stringPointer("2024-06-01"),
+				EndDate:           stringPointer("2025-06-01"),
+				BackfillProgress:  float64Pointer(1),
+				Owner:             stringPointer("platform"),
+				BillingAccountID:  stringPointer("01A2B3-C4D5E6-F7A8B9"),
+				ExportProjectID:   stringPointer("billing-export"),
+				Tags:              map[string]string{"team": "finops"},
+			},
+			check: func(t *testing.T, got gcpResourceModel) {
+				want := gcpResourceModel{
+					ID:                types.StringValue("gcp-ds-2"),
+					CloudProvider:     types.StringValue("gcp"),
+					Status:            types.StringValue("FAILED"),
+					Name:              types.StringValue("Renamed"),
+					BQURI:             types.StringValue("other.dataset.table"),
+					IsDetailedBilling: types.BoolValue(true),
+					StartDate:         types.StringValue("2024-06-01"),
+					EndDate:           types.StringValue("2025-06-01"),
+					BackfillProgress:  types.Float64Value(1),
+					Owner:             types.StringValue("platform"),
+					BillingAccountID:  types.StringValue("01A2B3-C4D5E6-F7A8B9"),
+					ExportProjectID:   types.StringValue("billing-export"),
+					Tags:              types.MapValueMust(types.StringType, map[string]attr.Value{"team": types.StringValue("finops")}),
+					ExtraHeaders:      types.MapNull(types.StringType),
+					DetectedColumns:   types.ListNull(types.StringType),
+				}
+				want.Summary = types.StringValue(want.summary())
+
+				assertModelsEqual(t, got, want)
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			tc.check(t, mergeGCPDatasource(prior, tc.apiResponse))
+		})
+	}
+}
+
+func TestMergeGCPDatasourceResolvesUnsetExportLocation(t *testing.T) {
+	t.Parallel()
+
+	planned := gcpResourceModel{
+		ID:               types.StringValue("gcp-ds-1"),
+		BillingAccountID: types.StringUnknown(),
+		ExportProjectID:  types.StringUnknown(),
+		Tags:             types.MapNull(types.StringType),
+		ExtraHeaders:     types.MapNull(types.StringType),
+		DetectedColumns:  types.ListNull(types.StringType),
+	}
+
+	got := mergeGCPDatasource(planned, &costoryapi.GCPBillingDatasource{ExportProjectID: stringPointer("billing-export")})
+	if !got.BillingAccountID.IsNull() {
+		t.Fatalf("expected an unreported billing_account_id to be null, got %s", got.BillingAccountID)
+	}
+	if got.ExportProjectID.ValueString() != "billing-export" {
+		t.Fatalf("expected the reported export_project_id to be kept, got %s", got.ExportProjectID)
+	}
+}
+
+func TestMergeAWSDatasource(t *testing.T) {
+	t.Parallel()
+
+	prior := awsResourceModel{
+		ID:                  types.StringValue("aws-ds-1"),
+		Status:              types.StringValue("ACTIVE"),
+		Name:                types.StringValue("AWS Billing"),
+		BucketName:          types.StringValue("billing-bucket"),
+		RoleARN:             types.StringValue(testRoleARN),
+		SensitiveRoleARN:    types.StringNull(),
+		Prefix:              types.StringValue("cur/"),
+		EKSSplitDataEnabled: types.BoolValue(true),
+		StartDate:           types.StringUnknown(),
+		EndDate:             types.StringValue("2025-12-31"),
+		EKSSplit:            types.BoolNull(),
+		ReportName:          types.StringValue("report"),
+		PrefixIsGlob:        types.BoolNull(),
+		Owner:               types.StringUnknown(),
+		Tags:                types.MapNull(types.StringType),
+		ExtraHeaders:        types.MapNull(types.StringType),
+		DetectedColumns:     types.ListNull(types.StringType),
+	}
+
+	tests := map[string]struct {
+		apiResponse *costoryapi.AWSBillingDatasource
+		check       func(t *testing.T, got awsResourceModel)
+	}{
+		"nil response keeps model": {
+			apiResponse: nil,
+			check: func(t *testing.T, got awsResourceModel) {
+				if !got.StartDate.IsUnknown() || !got.Owner.IsUnknown() {
+					t.Fatalf("expected model to be unchanged, got %#v", got)
+				}
+			},
+		},
+		"empty response keeps configured values": {
+			apiResponse: &costoryapi.AWSBillingDatasource{},
+			check: func(t *testing.T, got awsResourceModel) {
+				if got.ID.ValueString() != "aws-ds-1" || got.BucketName.ValueString() != "billing-bucket" || got.RoleARN.ValueString() != testRoleARN {
+					t.Fatalf("unexpected required values: %#v", got)
+				}
+				if !got.Status.IsNull() || !got.StartDate.IsNull() || !got.Owner.IsNull() {
+					t.Fatalf("expected status, start_date and owner to be null: %s, %s, %s", got.Status, got.StartDate, got.Owner)
+				}
+				if got.Prefix.ValueString() != "cur/" || !got.PrefixIsGlob.IsNull() {
+					t.Fatalf("unexpected prefix: %s, prefix_is_glob %s", got.Prefix, got.PrefixIsGlob)
+				}
+				if !got.EKSSplitDataEnabled.ValueBool() || !got.EKSSplit.IsNull() || got.ReportName.ValueString() != "report" {
+					t.Fatalf("expected optional values to be kept: %s, %s, %s", got.EKSSplitDataEnabled, got.EKSSplit, got.ReportName)
+				}
+			},
+		},
+		"false bools are not null": {
+			apiResponse: &costoryapi.AWSBillingDatasource{EKSSplitDataEnabled: boolPointer(false), EKSSplit: boolPointer(false)},
+			check: func(t *testing.T, got awsResourceModel) {
+				for name, value := range map[string]types.Bool{"eks_split_data_enabled": got.EKSSplitDataEnabled, "eks_split": got.EKSSplit} {
+					if value.IsNull() || value.ValueBool() {
+						t.Fatalf("expected %s false, got %s", name, value)
+					}
+				}
+			},
+		},
+		"empty prefix and report name are values": {
+			apiResponse: &costoryapi.AWSBillingDatasource{Prefix: stringPointer(""), ReportName: stringPointer("")},
+			check: func(t *testing.T, got awsResourceModel) {
+				if got.Prefix.IsNull() || got.Prefix.ValueString() != "" || got.ReportName.IsNull() || got.ReportName.ValueString() != "" {
+					t.Fatalf("expected empty strings, got prefix %s, report_name %s", got.Prefix, got.ReportName)
+				}
+				if !got.PrefixIsGlob.IsNull() {
+					t.Fatalf("expected prefix_is_glob to stay null, got %s", got.PrefixIsGlob)
+				}
+			},
+		},
+		"prefix pattern wins over prefix": {
+			apiResponse: &costoryapi.AWSBillingDatasource{Prefix: stringPointer("cur/"), PrefixPattern: stringPointer("cur/*")},
+			check: func(t *testing.T, got awsResourceModel) {
+				if got.Prefix.ValueString() != "cur/*" || !got.PrefixIsGlob.ValueBool() {
+					t.Fatalf("unexpected prefix: %s, prefix_is_glob %s", got.Prefix, got.PrefixIsGlob)
+				}
+			},
+		},
+		"returned values win": {
+			apiResponse: &costoryapi.AWSBillingDatasource{
+				ID:                  "aws-ds-2",
+				Status:              stringPointer("PENDING"),
+				Name:                "Renamed",
+				BucketName:          "other-bucket",
+				RoleARN:             "arn:aws:iam::123456789012:role/other",
+				Prefix:              stringPointer("other/"),
+				EKSSplitDataEnabled: boolPointer(true),
+				StartDate:           stringPointer("2025-01-01"),
+				EndDate:             stringPointer("2026-01-01"),
+				EKSSplit:            boolPointer(true),
+				ReportName:          stringPointer("other-report"),
+				BackfillProgress:    float64Pointer(0.25),
+				Owner:               stringPointer("platform"),
+				Tags:                map[string]string{"CostCenter": "Finance Ops"},
+			},
+			check: func(t *testing.T, got awsResourceModel) {
+				want := awsResourceModel{
+					ID:                  types.StringValue("aws-ds-2"),
+					CloudProvider:       types.StringValue("aws"),
+					Status:              types.StringValue("PENDING"),
+					Name:                types.StringValue("Renamed"),
+					BucketName:          types.StringValue("other-bucket"),
+					RoleARN:             types.StringValue("arn:aws:iam::123456789012:role/other"),
+					SensitiveRoleARN:    types.StringNull(),
+					Prefix:              types.StringValue("other/"),
+					EKSSplitDataEnabled: types.BoolValue(true),
+					StartDate:           types.StringValue("2025-01-01"),
+					EndDate:             types.StringValue("2026-01-01"),
+					EKSSplit:            types.BoolValue(true),
+					ReportName:          types.StringValue("other-report"),
+					PrefixIsGlob:        types.BoolNull(),
+					BackfillProgress:    types.Float64Value(0.25),
+					Owner:               types.StringValue("platform"),
+					Tags:                types.MapValueMust(types.StringType, map[string]attr.Value{"CostCenter": types.StringValue("Finance Ops")}),
+					ExtraHeaders:        types.MapNull(types.StringType),
+					DetectedColumns:     types.ListNull(types.StringType),
+				}
+				want.Summary = types.StringValue(want.summary())
+
+				assertModelsEqual(t, got, want)
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			tc.check(t, mergeAWSDatasource(prior, tc.apiResponse))
+		})
+	}
+}
+
+func TestMergeAWSDatasourceResolvesUnsetReportName(t *testing.T) {
+	t.Parallel()
+
+	planned := awsResourceModel{
+		ID:               types.StringValue("aws-ds-1"),
+		RoleARN:          types.StringValue(testRoleARN),
+		SensitiveRoleARN: types.StringNull(),
+		ReportName:       types.StringUnknown(),
+		Tags:             types.MapNull(types.StringType),
+		ExtraHeaders:     types.MapNull(types.StringType),
+		DetectedColumns:  types.ListNull(types.StringType),
+	}
+
+	if got := mergeAWSDatasource(planned, &costoryapi.AWSBillingDatasource{}).ReportName; !got.IsNull() {
+		t.Fatalf("expected an unreported report_name to be null, got %s", got)
+	}
+	if got := mergeAWSDatasource(planned, &costoryapi.AWSBillingDatasource{ReportName: stringPointer("cur-report")}).ReportName; got.ValueString() != "cur-report" {
+		t.Fatalf("expected the detected report_name to be kept, got %s", got)
+	}
+}
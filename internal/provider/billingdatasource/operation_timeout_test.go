@@ -0,0 +1,38 @@
+package billingdatasource
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/costory-io/costory-terraform/internal/costoryapi"
+)
+
+func TestGCPResourceCreateOperationTimeout(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := costoryapi.NewClient(server.URL, "test-token", server.Client(), costoryapi.WithOperationTimeout(50*time.Millisecond))
+	model := testGCPModel()
+
+	started := time.Now()
+	resp := newResourceFixture(t, NewGCPResource(), client).create(&model)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected create to fail once the operation timeout elapsed")
+	}
+	if elapsed := time.Since(started); elapsed > 5*time.Second {
+		t.Fatalf("expected create to abort promptly, took %s", elapsed)
+	}
+}
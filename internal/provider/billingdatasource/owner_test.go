@@ -0,0 +1,93 @@
+package billingdatasource
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/costory-io/costory-terraform/internal/costoryapi"
+)
+
+func TestOwnerIsUpdatableInPlace(t *testing.T) {
+	t.Parallel()
+
+	for name, newResource := range map[string]func() resource.Resource{"gcp": NewGCPResource, "aws": NewAWSResource} {
+		var resp resource.SchemaResponse
+		newResource().Schema(context.Background(), resource.SchemaRequest{}, &resp)
+
+		attr, ok := resp.Schema.Attributes["owner"].(schema.StringAttribute)
+		if !ok {
+			t.Fatalf("%s: expected owner string attribute", name)
+		}
+
+		replaced := planDate(attr.PlanModifiers, types.StringValue("platform"), types.StringValue("platform"), types.StringValue("finops"))
+		if replaced.RequiresReplace {
+			t.Fatalf("%s: expected owner changes not to require replacement", name)
+		}
+	}
+}
+
+func TestGCPResourceUpdateOwner(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client := costoryapi.NewFakeClient()
+	created, err := client.CreateGCPBillingDatasource(ctx, costoryapi.GCPBillingDatasourceRequest{
+		Name:  "GCP Billing",
+		BQURI: "project.dataset.table",
+		Owner: stringPointer("finops"),
+	})
+	if err != nil {
+		t.Fatalf("unexpected create error: %v", err)
+	}
+
+	f := newResourceFixture(t, NewGCPResource(), client)
+
+	prior := testGCPModel()
+	prior.ID = types.StringValue(created.ID)
+	prior.Owner = types.StringValue("finops")
+	planned := prior
+	planned.Owner = types.StringValue("platform")
+
+	resp := f.update(&prior, &planned)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var updated gcpResourceModel
+	f.decode(resp.State, &updated)
+	if updated.ID.ValueString() != created.ID || updated.Owner.ValueString() != "platform" {
+		t.Fatalf("unexpected state after update: id %s, owner %s", updated.ID, updated.Owner)
+	}
+
+	current, err := client.GetGCPBillingDatasource(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("unexpected get error: %v", err)
+	}
+	if current.Owner == nil || *current.Owner != "platform" {
+		t.Fatalf("unexpected stored owner: got %v, want %q", current.Owner, "platform")
+	}
+}
+
+func TestGCPResourceModelOwner(t *testing.T) {
+	t.Parallel()
+
+	withOwner := gcpResourceModel{Owner: types.StringValue("finops")}
+	if req := withOwner.toRequestModel(time.Now); req.Owner == nil || *req.Owner != "finops" {
+		t.Fatalf("unexpected request owner: %#v", req.Owner)
+	}
+
+	withoutOwner := gcpResourceModel{Owner: types.StringUnknown()}
+	if req := withoutOwner.toRequestModel(time.Now); req.Owner != nil {
+		t.Fatalf("expected owner to be omitted, got %q", *req.Owner)
+	}
+
+	withoutOwner.mergeAPIResponse(&costoryapi.GCPBillingDatasource{ID: "gcp-ds-1"})
+	if !withoutOwner.Owner.IsNull() {
+		t.Fatalf("expected owner to be null when neither configured nor returned, got %s", withoutOwner.Owner)
+	}
+}
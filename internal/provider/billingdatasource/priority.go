@@ -0,0 +1,56 @@
+package billingdatasource
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// Bounds of the ingestion priority accepted by the API.
+const (
+	minPriority = 1
+	maxPriority = 10
+)
+
+func priorityAttribute() schema.Int64Attribute {
+	return schema.Int64Attribute{
+		Optional:            true,
+		Computed:            true,
+		MarkdownDescription: fmt.Sprintf("Ingestion priority of the datasource, from `%d` to `%d`. Costory ingests datasources with a higher priority first, which helps order large onboarding batches. Can be changed in place without re-ingesting; removing it from configuration keeps the current priority.", minPriority, maxPriority),
+		PlanModifiers: []planmodifier.Int64{
+			int64planmodifier.UseStateForUnknown(),
+		},
+		Validators: []validator.Int64{
+			priorityValidator{},
+		},
+	}
+}
+
+// priorityValidator rejects a priority outside the range the API accepts.
+type priorityValidator struct{}
+
+func (v priorityValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("must be between %d and %d", minPriority, maxPriority)
+}
+
+func (v priorityValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v priorityValidator) ValidateInt64(ctx context.Context, req validator.Int64Request, resp *validator.Int64Response) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if value := req.ConfigValue.ValueInt64(); value < minPriority || value > maxPriority {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid attribute value",
+			fmt.Sprintf("Priority %d is invalid: %s.", value, v.Description(ctx)),
+		)
+	}
+}
@@ -0,0 +1,117 @@
+package billingdatasource
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/costory-io/costory-terraform/internal/costoryapi"
+)
+
+func TestPriorityValidator(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		value     types.Int64
+		wantError bool
+	}{
+		"minimum":   {value: types.Int64Value(1)},
+		"maximum":   {value: types.Int64Value(10)},
+		"null":      {value: types.Int64Null()},
+		"unknown":   {value: types.Int64Unknown()},
+		"zero":      {value: types.Int64Value(0), wantError: true},
+		"negative":  {value: types.Int64Value(-1), wantError: true},
+		"too large": {value: types.Int64Value(11), wantError: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			req := validator.Int64Request{Path: path.Root("priority"), ConfigValue: tc.value}
+			var resp validator.Int64Response
+			priorityValidator{}.ValidateInt64(context.Background(), req, &resp)
+
+			if got := resp.Diagnostics.HasError(); got != tc.wantError {
+				t.Fatalf("unexpected validation result: got error=%t, want %t: %v", got, tc.wantError, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestGCPResourceToRequestModelPriority(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		priority types.Int64
+		want     *int64
+	}{
+		"set":     {priority: types.Int64Value(4), want: int64Pointer(4)},
+		"null":    {priority: types.Int64Null()},
+		"unknown": {priority: types.Int64Unknown()},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			req := gcpResourceModel{Priority: tc.priority}.toRequestModel(time.Now)
+			if (req.Priority == nil) != (tc.want == nil) || (req.Priority != nil && *req.Priority != *tc.want) {
+				t.Fatalf("unexpected priority: got %v, want %v", req.Priority, tc.want)
+			}
+		})
+	}
+}
+
+func TestGCPResourceUpdatePriorityInPlace(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client := costoryapi.NewFakeClient()
+	created, err := client.CreateGCPBillingDatasource(ctx, costoryapi.GCPBillingDatasourceRequest{
+		Name:     "GCP Billing",
+		BQURI:    "project.dataset.table",
+		Priority: int64Pointer(2),
+	})
+	if err != nil {
+		t.Fatalf("unexpected create error: %v", err)
+	}
+
+	f := newResourceFixture(t, NewGCPResource(), client)
+
+	prior := testGCPModel()
+	prior.ID = types.StringValue(created.ID)
+	prior.Priority = types.Int64Value(2)
+	planned := prior
+	planned.Priority = types.Int64Value(9)
+
+	resp := f.update(&prior, &planned)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected update diagnostics: %v", resp.Diagnostics)
+	}
+
+	var updated gcpResourceModel
+	f.decode(resp.State, &updated)
+	if got := updated.Priority.ValueInt64(); got != 9 {
+		t.Fatalf("unexpected priority in state: got %d, want 9", got)
+	}
+	if got := updated.ID.ValueString(); got != created.ID {
+		t.Fatalf("expected the datasource to be updated in place, got id %q, want %q", got, created.ID)
+	}
+
+	current, err := client.GetGCPBillingDatasource(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("unexpected get error: %v", err)
+	}
+	if current.Priority == nil || *current.Priority != 9 {
+		t.Fatalf("unexpected stored priority: got %v, want 9", current.Priority)
+	}
+}
+
+func int64Pointer(value int64) *int64 {
+	return &value
+}
@@ -0,0 +1,57 @@
+package billingdatasource
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+const statusFailed = "FAILED"
+
+func recreateOnFailureAttribute() schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Optional:            true,
+		MarkdownDescription: "When `true`, a datasource whose status is `FAILED` is replaced on the next apply, even if its configuration is unchanged, to retry ingestion. Defaults to `false`.",
+	}
+}
+
+// planRecreateOnFailure plans a replacement when the resource opted in with
+// recreate_on_failure and the prior state reports a FAILED status. Computed-only
+// attributes are marked unknown so the values returned by the new datasource apply cleanly.
+func planRecreateOnFailure(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var recreate types.Bool
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("recreate_on_failure"), &recreate)...)
+	if resp.Diagnostics.HasError() || !recreate.ValueBool() {
+		return
+	}
+
+	var status types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("status"), &status)...)
+	if resp.Diagnostics.HasError() || status.ValueString() != statusFailed {
+		return
+	}
+
+	for name, attribute := range resp.Plan.Schema.GetAttributes() {
+		if !attribute.IsComputed() || attribute.IsOptional() {
+			continue
+		}
+
+		unknown, err := attribute.GetType().ValueFromTerraform(ctx, tftypes.NewValue(attribute.GetType().TerraformType(ctx), tftypes.UnknownValue))
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root(name), "Unable to plan datasource replacement", err.Error())
+			return
+		}
+
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(name), unknown)...)
+	}
+
+	resp.RequiresReplace = append(resp.RequiresReplace, path.Root("status"))
+}
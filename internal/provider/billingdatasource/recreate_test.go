@@ -0,0 +1,94 @@
+package billingdatasource
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestGCPResourceModifyPlanRecreateOnFailure(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		status          string
+		recreate        types.Bool
+		wantReplacement bool
+	}{
+		"failed and opted in": {status: statusFailed, recreate: types.BoolValue(true), wantReplacement: true},
+		"failed not opted in": {status: statusFailed, recreate: types.BoolNull(), wantReplacement: false},
+		"failed opted out":    {status: statusFailed, recreate: types.BoolValue(false), wantReplacement: false},
+		"active and opted in": {status: "ACTIVE", recreate: types.BoolValue(true), wantReplacement: false},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			model := testGCPModel()
+			model.ID = types.StringValue("gcp-ds-1")
+			model.Status = types.StringValue(tc.status)
+			model.StartDate = types.StringValue("2025-01-01")
+			model.Summary = types.StringValue("summary")
+			model.RecreateOnFailure = tc.recreate
+
+			resp := modifyPlan(t, NewGCPResource(), &model)
+
+			if got := len(resp.RequiresReplace) > 0; got != tc.wantReplacement {
+				t.Fatalf("unexpected replacement: got %v, want %v", got, tc.wantReplacement)
+			}
+
+			var planned gcpResourceModel
+			resp.Diagnostics.Append(resp.Plan.Get(context.Background(), &planned)...)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			if planned.Status.IsUnknown() != tc.wantReplacement || planned.ID.IsUnknown() != tc.wantReplacement {
+				t.Fatalf("unexpected planned computed values: status %s, id %s", planned.Status, planned.ID)
+			}
+			if planned.BQURI.ValueString() != "project.dataset.table" {
+				t.Fatalf("unexpected planned bq_uri: got %s", planned.BQURI)
+			}
+		})
+	}
+}
+
+func TestAWSResourceModifyPlanRecreateOnFailure(t *testing.T) {
+	t.Parallel()
+
+	model := testAWSModel()
+	model.ID = types.StringValue("aws-ds-1")
+	model.Status = types.StringValue(statusFailed)
+	model.Summary = types.StringValue("summary")
+	model.RecreateOnFailure = types.BoolValue(true)
+
+	resp := modifyPlan(t, NewAWSResource(), &model)
+
+	if len(resp.RequiresReplace) != 1 || !resp.RequiresReplace[0].Equal(path.Root("status")) {
+		t.Fatalf("unexpected replacement paths: %v", resp.RequiresReplace)
+	}
+}
+
+// modifyPlan runs ModifyPlan for an unchanged configuration, using model as both the
+// prior state and the proposed plan.
+func modifyPlan(t *testing.T, r resource.Resource, model any) *resource.ModifyPlanResponse {
+	t.Helper()
+
+	f := newResourceFixture(t, r, nil)
+	state := f.state(model)
+
+	plan := tfsdk.Plan{Schema: f.schema, Raw: state.Raw.Copy()}
+	req := resource.ModifyPlanRequest{Config: tfsdk.Config{Schema: f.schema, Raw: state.Raw.Copy()}, State: state, Plan: plan}
+	resp := &resource.ModifyPlanResponse{Plan: tfsdk.Plan{Schema: f.schema, Raw: state.Raw.Copy()}}
+
+	r.(resource.ResourceWithModifyPlan).ModifyPlan(f.ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	return resp
+}
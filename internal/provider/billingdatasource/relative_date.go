@@ -0,0 +1,97 @@
+package billingdatasource
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ validator.String = relativeDateValidator{}
+
+// relativeDateKeyword prefixes every relative date expression.
+const relativeDateKeyword = "today"
+
+// relativeDatePattern matches "today" or "today-<N>d", where N is a number of days.
+var relativeDatePattern = regexp.MustCompile(`^today(?:-([0-9]{1,5})d)?$`)
+
+// isRelativeDate reports whether value is written as a relative date expression, valid or
+// not. Anything else is an absolute date and is left to the API.
+func isRelativeDate(value string) bool {
+	return strings.HasPrefix(value, relativeDateKeyword)
+}
+
+// resolveRelativeDate resolves a relative date expression against now, returning the
+// concrete date in loc as YYYY-MM-DD. Absolute dates are returned unchanged.
+func resolveRelativeDate(value string, now time.Time, loc *time.Location) (string, error) {
+	if !isRelativeDate(value) {
+		return value, nil
+	}
+
+	match := relativeDatePattern.FindStringSubmatch(value)
+	if match == nil {
+		return "", fmt.Errorf("relative date %q must be %q or %q-<N>d, for example %q", value, relativeDateKeyword, relativeDateKeyword, "today-30d")
+	}
+
+	days := 0
+	if match[1] != "" {
+		parsed, err := strconv.Atoi(match[1])
+		if err != nil {
+			return "", fmt.Errorf("relative date %q: %w", value, err)
+		}
+		days = parsed
+	}
+
+	now = now.In(loc)
+	return time.Date(now.Year(), now.Month(), now.Day()-days, 0, 0, 0, 0, loc).Format(time.DateOnly), nil
+}
+
+// requestDate returns the date to send to the API for a configured start_date or end_date,
+// resolving relative expressions against now, which carries the provider time zone. Null and
+// unknown values are omitted.
+func requestDate(value types.String, now func() time.Time) *string {
+	if value.IsNull() || value.IsUnknown() {
+		return nil
+	}
+
+	at := now()
+	resolved, err := resolveRelativeDate(value.ValueString(), at, at.Location())
+	if err != nil {
+		// Rejected by relativeDateValidator before any request is built; send the raw
+		// value so the API reports it rather than silently dropping the filter.
+		resolved = value.ValueString()
+	}
+
+	return &resolved
+}
+
+// relativeDateValidator rejects values that start with "today" but are not a supported
+// relative date expression. Absolute dates are left to the API.
+type relativeDateValidator struct{}
+
+func (v relativeDateValidator) Description(_ context.Context) string {
+	return `relative dates must be "today" or "today-<N>d"`
+}
+
+func (v relativeDateValidator) MarkdownDescription(_ context.Context) string {
+	return "relative dates must be `today` or `today-<N>d`"
+}
+
+func (v relativeDateValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := resolveRelativeDate(req.ConfigValue.ValueString(), time.Now(), time.UTC); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid relative date",
+			err.Error()+".",
+		)
+	}
+}
@@ -0,0 +1,151 @@
+package billingdatasource
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestResolveRelativeDate(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, time.March, 3, 23, 30, 0, 0, time.FixedZone("UTC-2", -2*60*60))
+
+	tests := map[string]struct {
+		value   string
+		want    string
+		wantErr bool
+	}{
+		"today":            {value: "today", want: "2025-03-04"},
+		"today-7d":         {value: "today-7d", want: "2025-02-25"},
+		"today-0d":         {value: "today-0d", want: "2025-03-04"},
+		"today-30d":        {value: "today-30d", want: "2025-02-02"},
+		"absolute":         {value: "2025-01-01", want: "2025-01-01"},
+		"rfc3339":          {value: "2025-01-01T00:00:00Z", want: "2025-01-01T00:00:00Z"},
+		"future offset":    {value: "today+1d", wantErr: true},
+		"missing days":     {value: "today-d", wantErr: true},
+		"unsupported unit": {value: "today-1w", wantErr: true},
+		"trailing text":    {value: "todayish", wantErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := resolveRelativeDate(tc.value, now, time.UTC)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Fatalf("unexpected date: got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestResolveRelativeDateTimezone(t *testing.T) {
+	t.Parallel()
+
+	losAngeles, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		t.Fatalf("unable to load time zone: %v", err)
+	}
+
+	// 06:30 UTC on March 4 is still 22:30 on March 3 in Los Angeles.
+	instant := time.Date(2025, time.March, 4, 6, 30, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		loc  *time.Location
+		want string
+	}{
+		"utc":         {loc: time.UTC, want: "2025-03-04"},
+		"los angeles": {loc: losAngeles, want: "2025-03-03"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got, err := resolveRelativeDate("today", instant, tc.loc); err != nil || got != tc.want {
+				t.Fatalf("unexpected resolved date: got %q (%v), want %q", got, err, tc.want)
+			}
+
+			now := func() time.Time { return instant.In(tc.loc) }
+			if got := requestDate(types.StringValue("today"), now); got == nil || *got != tc.want {
+				t.Fatalf("unexpected request date: got %v, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRequestDateResolvesRelativeExpressions(t *testing.T) {
+	t.Parallel()
+
+	now := func() time.Time { return time.Date(2025, time.June, 15, 10, 0, 0, 0, time.UTC) }
+
+	if got := requestDate(types.StringValue("today-7d"), now); got == nil || *got != "2025-06-08" {
+		t.Fatalf("unexpected request date: %v", got)
+	}
+	if got := requestDate(types.StringValue("2025-01-01"), now); got == nil || *got != "2025-01-01" {
+		t.Fatalf("unexpected request date: %v", got)
+	}
+	if got := requestDate(types.StringNull(), now); got != nil {
+		t.Fatalf("expected nil for null date, got %q", *got)
+	}
+	if got := requestDate(types.StringUnknown(), now); got != nil {
+		t.Fatalf("expected nil for unknown date, got %q", *got)
+	}
+}
+
+func TestRelativeDateValidator(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		value   types.String
+		wantErr bool
+	}{
+		"today":      {value: types.StringValue("today")},
+		"today-7d":   {value: types.StringValue("today-7d")},
+		"absolute":   {value: types.StringValue("2025-01-01")},
+		"unparsable": {value: types.StringValue("next year")},
+		"null":       {value: types.StringNull()},
+		"unknown":    {value: types.StringUnknown()},
+		"invalid":    {value: types.StringValue("today-7"), wantErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			req := validator.StringRequest{Path: path.Root("end_date"), ConfigValue: tc.value}
+			var resp validator.StringResponse
+			relativeDateValidator{}.ValidateString(context.Background(), req, &resp)
+
+			if got := resp.Diagnostics.HasError(); got != tc.wantErr {
+				t.Fatalf("unexpected error result: got %t, want %t: %v", got, tc.wantErr, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestMergeOptionalDateKeepsRelativeExpression(t *testing.T) {
+	t.Parallel()
+
+	resolved := "2025-06-08"
+	if got := mergeOptionalDate(types.StringValue("today-7d"), &resolved); got.ValueString() != "today-7d" {
+		t.Fatalf("expected relative expression to be kept, got %s", got)
+	}
+	if got := mergeOptionalDate(types.StringValue("2025-01-01"), &resolved); got.ValueString() != resolved {
+		t.Fatalf("expected API date to win for absolute dates, got %s", got)
+	}
+}
@@ -0,0 +1,73 @@
+package billingdatasource
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+
+	"github.com/costory-io/costory-terraform/internal/costoryapi"
+)
+
+func validateRoleAccessAttribute() schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Optional:            true,
+		MarkdownDescription: "When `true`, plan asks Costory whether the AWS account owning the role is permitted for the tenant and fails early if it is not. Opt-in because it adds an API request whenever the role changes. Defaults to `false`.",
+	}
+}
+
+// planAWSRoleAccess checks the planned role's account against the tenant when the resource
+// opted in with validate_role_access. The check only runs when the role is new or changed,
+// and is skipped on deployments without the role access endpoint. Lookup failures only
+// warn so an unreachable check never blocks a plan the API would accept.
+func planAWSRoleAccess(ctx context.Context, client costoryapi.BillingDatasourceAPI, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if client == nil || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan awsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || !plan.ValidateRoleAccess.ValueBool() {
+		return
+	}
+
+	if plan.RoleARN.IsUnknown() || plan.SensitiveRoleARN.IsUnknown() || plan.roleARN() == "" {
+		return
+	}
+
+	if !req.State.Raw.IsNull() {
+		var state awsResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() || state.roleARN() == plan.roleARN() {
+			return
+		}
+	}
+
+	access, err := client.CheckAWSRoleAccess(ctx, plan.roleARN())
+	if err != nil {
+		summary, detail := "Unable to check AWS role access", "Costory could not confirm that the role's AWS account is permitted for the tenant, so any mismatch will only be reported on apply: "+err.Error()
+		resp.Diagnostics.AddWarning(summary, detail)
+		client.RecordWarning(summary, detail)
+		return
+	}
+
+	if !access.Checked || access.Allowed {
+		return
+	}
+
+	detail := fmt.Sprintf("AWS account %s, which owns the configured role, is not permitted for this Costory tenant.", access.AccountID)
+	reason := access.Reason
+	if plan.usesSensitiveRoleARN() {
+		// The account ID is part of the role ARN, so it stays hidden along with it.
+		detail = "The AWS account that owns the configured role is not permitted for this Costory tenant."
+		if access.AccountID != "" {
+			reason = strings.ReplaceAll(reason, access.AccountID, redactedAPIRequestValue)
+		}
+	}
+	if reason != "" {
+		detail += " " + reason
+	}
+	resp.Diagnostics.AddAttributeError(plan.validationAttributes()["roleArn"], "AWS role account not permitted", detail)
+}
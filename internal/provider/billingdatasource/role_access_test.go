@@ -0,0 +1,108 @@
+package billingdatasource
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/costory-io/costory-terraform/internal/costoryapi"
+)
+
+func TestPlanAWSRoleAccess(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		validate    bool
+		sensitive   bool
+		status      int
+		body        string
+		wantCalls   int
+		wantError   bool
+		wantWarning bool
+	}{
+		"allowed account":      {validate: true, status: http.StatusOK, body: `{"allowed":true,"accountId":"123456789012"}`, wantCalls: 1},
+		"disallowed account":   {validate: true, status: http.StatusOK, body: `{"allowed":false,"accountId":"123456789012","reason":"Link the account in Costory first."}`, wantCalls: 1, wantError: true},
+		"disallowed sensitive": {validate: true, sensitive: true, status: http.StatusOK, body: `{"allowed":false,"accountId":"123456789012","reason":"Link account 123456789012 in Costory first."}`, wantCalls: 1, wantError: true},
+		"older backend":        {validate: true, status: http.StatusNotFound, wantCalls: 1},
+		"lookup unavailable":   {validate: true, status: http.StatusForbidden, wantCalls: 1, wantWarning: true},
+		"not opted in":         {validate: false, status: http.StatusOK, body: `{"allowed":false}`},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls int
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				calls++
+				w.WriteHeader(tc.status)
+				_, _ = w.Write([]byte(tc.body))
+			}))
+			defer server.Close()
+
+			client := costoryapi.NewClient(server.URL, "test-token", server.Client())
+			f := newResourceFixture(t, NewAWSResource(), nil)
+
+			model := testAWSModel()
+			model.ValidateRoleAccess = types.BoolValue(tc.validate)
+			wantPath := path.Root("role_arn")
+			if tc.sensitive {
+				model.RoleARN = types.StringNull()
+				model.SensitiveRoleARN = types.StringValue(testRoleARN)
+				wantPath = path.Root("sensitive_role_arn")
+			}
+
+			plan := f.plan(&model)
+			resp := resource.ModifyPlanResponse{Plan: plan}
+			planAWSRoleAccess(f.ctx, client, resource.ModifyPlanRequest{State: f.nullState(), Plan: plan}, &resp)
+
+			if calls != tc.wantCalls {
+				t.Fatalf("unexpected role access calls: got %d, want %d", calls, tc.wantCalls)
+			}
+			if got := resp.Diagnostics.HasError(); got != tc.wantError {
+				t.Fatalf("unexpected error diagnostics: got %v, want %v (%v)", got, tc.wantError, resp.Diagnostics)
+			}
+			if got := resp.Diagnostics.WarningsCount() > 0; got != tc.wantWarning {
+				t.Fatalf("unexpected warning diagnostics: got %v, want %v (%v)", got, tc.wantWarning, resp.Diagnostics)
+			}
+			if tc.wantError {
+				withPath, ok := resp.Diagnostics.Errors()[0].(interface{ Path() path.Path })
+				if !ok || !withPath.Path().Equal(wantPath) {
+					t.Fatalf("expected error on %s, got %v", wantPath, resp.Diagnostics.Errors()[0])
+				}
+				if got := strings.Contains(resp.Diagnostics.Errors()[0].Detail(), "123456789012"); got == tc.sensitive {
+					t.Fatalf("unexpected account ID disclosure: got %v, want %v (%q)", got, !tc.sensitive, resp.Diagnostics.Errors()[0].Detail())
+				}
+			}
+		})
+	}
+}
+
+func TestPlanAWSRoleAccessSkipsUnchangedRole(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+	}))
+	defer server.Close()
+
+	client := costoryapi.NewClient(server.URL, "test-token", server.Client())
+	f := newResourceFixture(t, NewAWSResource(), nil)
+
+	model := testAWSModel()
+	model.ID = types.StringValue("aws-ds-1")
+	model.ValidateRoleAccess = types.BoolValue(true)
+
+	plan := f.plan(&model)
+	resp := resource.ModifyPlanResponse{Plan: plan}
+	planAWSRoleAccess(f.ctx, client, resource.ModifyPlanRequest{State: f.state(&model), Plan: plan}, &resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+}
@@ -0,0 +1,11 @@
+package billingdatasource
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+// summaryStatus renders a datasource status for the computed summary attribute.
+func summaryStatus(status types.String) string {
+	if status.IsNull() || status.IsUnknown() || status.ValueString() == "" {
+		return "in an unknown status"
+	}
+	return status.ValueString()
+}
@@ -0,0 +1,48 @@
+package billingdatasource
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/costory-io/costory-terraform/internal/costoryapi"
+)
+
+func TestAWSResourceModelSummary(t *testing.T) {
+	t.Parallel()
+
+	model := awsResourceModel{Name: types.StringValue("AWS CUR")}
+
+	model.mergeAPIResponse(&costoryapi.AWSBillingDatasource{
+		ID:         "aws-ds-1",
+		Status:     stringPointer("PENDING"),
+		BucketName: "billing-bucket",
+		Prefix:     stringPointer("/cur"),
+	})
+
+	if got, want := model.Summary.ValueString(), "AWS billing datasource 'AWS CUR' (aws-ds-1) is PENDING ingesting from s3://billing-bucket/cur"; got != want {
+		t.Fatalf("unexpected summary: got %q, want %q", got, want)
+	}
+
+	model.mergeAPIResponse(&costoryapi.AWSBillingDatasource{ID: "aws-ds-1", Status: stringPointer("ACTIVE")})
+
+	if got, want := model.Summary.ValueString(), "AWS billing datasource 'AWS CUR' (aws-ds-1) is ACTIVE ingesting from s3://billing-bucket/cur"; got != want {
+		t.Fatalf("unexpected summary after refresh: got %q, want %q", got, want)
+	}
+}
+
+func TestGCPResourceModelSummary(t *testing.T) {
+	t.Parallel()
+
+	model := gcpResourceModel{}
+
+	model.mergeAPIResponse(&costoryapi.GCPBillingDatasource{
+		ID:    "gcp-ds-1",
+		Name:  "GCP Billing",
+		BQURI: "project.dataset.table",
+	})
+
+	if got, want := model.Summary.ValueString(), "GCP billing datasource 'GCP Billing' (gcp-ds-1) is in an unknown status ingesting from project.dataset.table"; got != want {
+		t.Fatalf("unexpected summary: got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,114 @@
+package billingdatasource
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const gcpMaxLabels = 64
+
+var (
+	_ validator.Map = gcpLabelsValidator{}
+
+	gcpLabelKeyPattern   = regexp.MustCompile(`^[a-z][a-z0-9_-]{0,62}$`)
+	gcpLabelValuePattern = regexp.MustCompile(`^[a-z0-9_-]{0,63}$`)
+)
+
+// tagsAttribute returns the tags attribute shared by billing datasources. Each datasource
+// type documents and validates its own constraints, since tags map to provider-native
+// metadata such as BigQuery labels. Tags are updated in place, and are computed so tags
+// reported by the API for a datasource that leaves them unset do not conflict with config.
+func tagsAttribute(description string, validators ...validator.Map) schema.MapAttribute {
+	return schema.MapAttribute{
+		Optional:            true,
+		Computed:            true,
+		ElementType:         types.StringType,
+		MarkdownDescription: description,
+		PlanModifiers: []planmodifier.Map{
+			mapplanmodifier.UseStateForUnknown(),
+		},
+		Validators: validators,
+	}
+}
+
+// gcpLabelsValidator enforces BigQuery label constraints on GCP tags: at most 64 labels,
+// keys of 1-63 characters starting with a lowercase letter, and values of at most 63
+// characters, both limited to lowercase letters, digits, underscores and dashes.
+type gcpLabelsValidator struct{}
+
+func (v gcpLabelsValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("must be at most %d BigQuery labels with lowercase keys and values", gcpMaxLabels)
+}
+
+func (v gcpLabelsValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v gcpLabelsValidator) ValidateMap(_ context.Context, req validator.MapRequest, resp *validator.MapResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	elements := req.ConfigValue.Elements()
+	if len(elements) > gcpMaxLabels {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid GCP labels",
+			fmt.Sprintf("GCP datasources support at most %d tags, got %d.", gcpMaxLabels, len(elements)),
+		)
+	}
+
+	keys := make([]string, 0, len(elements))
+	for key := range elements {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		keyPath := req.Path.AtMapKey(key)
+		if !gcpLabelKeyPattern.MatchString(key) {
+			resp.Diagnostics.AddAttributeError(
+				keyPath,
+				"Invalid GCP label key",
+				fmt.Sprintf("Tag key %q is not a valid BigQuery label key: it must be 1-63 characters, start with a lowercase letter, and contain only lowercase letters, digits, underscores, and dashes.", key),
+			)
+		}
+
+		value, ok := elements[key].(types.String)
+		if !ok || value.IsNull() || value.IsUnknown() {
+			continue
+		}
+		if !gcpLabelValuePattern.MatchString(value.ValueString()) {
+			resp.Diagnostics.AddAttributeError(
+				keyPath,
+				"Invalid GCP label value",
+				fmt.Sprintf("Tag %q has value %q, which is not a valid BigQuery label value: it must be at most 63 characters of lowercase letters, digits, underscores, and dashes.", key, value.ValueString()),
+			)
+		}
+	}
+}
+
+// tagsFromModel converts a configured tags map into the request representation, nil when
+// tags are unset or not yet known.
+func tagsFromModel(tags types.Map) map[string]string {
+	if tags.IsNull() || tags.IsUnknown() {
+		return nil
+	}
+
+	result := make(map[string]string, len(tags.Elements()))
+	for key, element := range tags.Elements() {
+		if value, ok := element.(types.String); ok && !value.IsNull() && !value.IsUnknown() {
+			result[key] = value.ValueString()
+		}
+	}
+
+	return result
+}
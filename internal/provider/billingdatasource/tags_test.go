@@ -0,0 +1,205 @@
+package billingdatasource
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/costory-io/costory-terraform/internal/costoryapi"
+)
+
+func stringMap(values map[string]string) types.Map {
+	elements := make(map[string]attr.Value, len(values))
+	for key, value := range values {
+		elements[key] = types.StringValue(value)
+	}
+
+	return types.MapValueMust(types.StringType, elements)
+}
+
+func TestGCPLabelsValidator(t *testing.T) {
+	t.Parallel()
+
+	tooMany := make(map[string]string, gcpMaxLabels+1)
+	for i := range gcpMaxLabels + 1 {
+		tooMany[fmt.Sprintf("label_%d", i)] = "value"
+	}
+
+	tests := map[string]struct {
+		value     types.Map
+		wantError bool
+	}{
+		"valid":            {value: stringMap(map[string]string{"team": "finops", "env": "prod-1", "empty_value": ""})},
+		"null":             {value: types.MapNull(types.StringType)},
+		"unknown":          {value: types.MapUnknown(types.StringType)},
+		"unknown value":    {value: types.MapValueMust(types.StringType, map[string]attr.Value{"team": types.StringUnknown()})},
+		"uppercase key":    {value: stringMap(map[string]string{"Team": "finops"}), wantError: true},
+		"digit first key":  {value: stringMap(map[string]string{"1team": "finops"}), wantError: true},
+		"empty key":        {value: stringMap(map[string]string{"": "finops"}), wantError: true},
+		"long key":         {value: stringMap(map[string]string{strings.Repeat("k", 64): "finops"}), wantError: true},
+		"uppercase value":  {value: stringMap(map[string]string{"team": "FinOps"}), wantError: true},
+		"value with space": {value: stringMap(map[string]string{"team": "fin ops"}), wantError: true},
+		"long value":       {value: stringMap(map[string]string{"team": strings.Repeat("v", 64)}), wantError: true},
+		"too many labels":  {value: stringMap(tooMany), wantError: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			req := validator.MapRequest{Path: path.Root("tags"), ConfigValue: tc.value}
+			var resp validator.MapResponse
+			gcpLabelsValidator{}.ValidateMap(context.Background(), req, &resp)
+
+			if got := resp.Diagnostics.HasError(); got != tc.wantError {
+				t.Fatalf("unexpected validation result: got error=%t, want %t: %v", got, tc.wantError, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestTagsValidatedPerDatasourceType(t *testing.T) {
+	t.Parallel()
+
+	// Valid AWS tags that are not valid BigQuery labels.
+	awsStyleTags := map[string]map[string]string{
+		"uppercase key":    {"CostCenter": "finops"},
+		"value with space": {"team": "Finance Ops"},
+		"colon key":        {"aws:team": "finops"},
+	}
+
+	tests := map[string]struct {
+		resource  resource.Resource
+		wantError bool
+	}{
+		"gcp rejects": {resource: NewGCPResource(), wantError: true},
+		"aws accepts": {resource: NewAWSResource()},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var resp resource.SchemaResponse
+			tc.resource.Schema(context.Background(), resource.SchemaRequest{}, &resp)
+
+			attribute, ok := resp.Schema.Attributes["tags"].(schema.MapAttribute)
+			if !ok {
+				t.Fatal("expected tags to be a map attribute")
+			}
+
+			for tagsName, tags := range awsStyleTags {
+				var validation validator.MapResponse
+				for _, v := range attribute.Validators {
+					v.ValidateMap(context.Background(), validator.MapRequest{
+						Path:        path.Root("tags"),
+						ConfigValue: stringMap(tags),
+					}, &validation)
+				}
+				if got := validation.Diagnostics.HasError(); got != tc.wantError {
+					t.Fatalf("unexpected validation result for %s: got error=%t, want %t: %v", tagsName, got, tc.wantError, validation.Diagnostics)
+				}
+			}
+		})
+	}
+}
+
+func TestTagsFromModel(t *testing.T) {
+	t.Parallel()
+
+	if got := tagsFromModel(types.MapNull(types.StringType)); got != nil {
+		t.Fatalf("expected null tags to be omitted, got %v", got)
+	}
+	if got := tagsFromModel(types.MapUnknown(types.StringType)); got != nil {
+		t.Fatalf("expected unknown tags to be omitted, got %v", got)
+	}
+
+	model := awsResourceModel{RoleARN: types.StringValue(testRoleARN), Tags: stringMap(map[string]string{"CostCenter": "Finance Ops"})}
+	if got := model.toRequestModel(time.Now).Tags["CostCenter"]; got != "Finance Ops" {
+		t.Fatalf("unexpected request tag: got %q, want %q", got, "Finance Ops")
+	}
+}
+
+func TestMergeTags(t *testing.T) {
+	t.Parallel()
+
+	configured := stringMap(map[string]string{"team": "finops"})
+
+	tests := map[string]struct {
+		current  types.Map
+		apiValue map[string]string
+		want     types.Map
+	}{
+		"omitted keeps configured":   {current: configured, want: configured},
+		"empty keeps unset":          {current: types.MapNull(types.StringType), apiValue: map[string]string{}, want: types.MapNull(types.StringType)},
+		"empty clears configured":    {current: configured, apiValue: map[string]string{}, want: stringMap(map[string]string{})},
+		"returned values win":        {current: configured, apiValue: map[string]string{"team": "platform"}, want: stringMap(map[string]string{"team": "platform"})},
+		"returned values fill unset": {current: types.MapNull(types.StringType), apiValue: map[string]string{"team": "finops"}, want: configured},
+		"unknown resolves to null":   {current: types.MapUnknown(types.StringType), want: types.MapNull(types.StringType)},
+		"unknown takes returned":     {current: types.MapUnknown(types.StringType), apiValue: map[string]string{"team": "finops"}, want: configured},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := mergeTags(tc.current, tc.apiValue); !got.Equal(tc.want) {
+				t.Fatalf("unexpected merged tags: got %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestGCPResourceUpdateTagsInPlace(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client := costoryapi.NewFakeClient()
+	created, err := client.CreateGCPBillingDatasource(ctx, costoryapi.GCPBillingDatasourceRequest{
+		Name:  "GCP Billing",
+		BQURI: "project.dataset.table",
+		Tags:  map[string]string{"team": "finops"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected create error: %v", err)
+	}
+
+	f := newResourceFixture(t, NewGCPResource(), client)
+
+	prior := testGCPModel()
+	prior.ID = types.StringValue(created.ID)
+	prior.Tags = stringMap(map[string]string{"team": "finops"})
+	planned := prior
+	planned.Tags = stringMap(map[string]string{"team": "platform"})
+
+	resp := f.update(&prior, &planned)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected update diagnostics: %v", resp.Diagnostics)
+	}
+
+	var updated gcpResourceModel
+	f.decode(resp.State, &updated)
+	if !updated.Tags.Equal(planned.Tags) {
+		t.Fatalf("unexpected tags in state: got %s, want %s", updated.Tags, planned.Tags)
+	}
+	if got := updated.ID.ValueString(); got != created.ID {
+		t.Fatalf("expected the datasource to be updated in place, got id %q, want %q", got, created.ID)
+	}
+
+	current, err := client.GetGCPBillingDatasource(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("unexpected get error: %v", err)
+	}
+	if got := current.Tags["team"]; got != "platform" {
+		t.Fatalf("unexpected stored tag: got %q, want %q", got, "platform")
+	}
+}
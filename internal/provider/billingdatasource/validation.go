@@ -0,0 +1,42 @@
+package billingdatasource
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+
+	"github.com/costory-io/costory-terraform/internal/costoryapi"
+)
+
+// addAPIErrorDiagnostics reports err under summary. A *costoryapi.ValidationError is split
+// into one diagnostic per issue, attached to the attribute mapped from the issue's API
+// field when attributes knows it.
+func addAPIErrorDiagnostics(diags *diag.Diagnostics, summary string, err error, attributes map[string]path.Path) {
+	var validationErr *costoryapi.ValidationError
+	if !errors.As(err, &validationErr) || len(validationErr.Issues) == 0 {
+		diags.AddError(summary, err.Error())
+		return
+	}
+
+	addValidationIssueDiagnostics(diags, summary, validationErr.Issues, attributes)
+}
+
+// addValidationIssueDiagnostics reports one error per issue, attached to the attribute mapped
+// from the issue's API field when attributes knows it.
+func addValidationIssueDiagnostics(diags *diag.Diagnostics, summary string, issues []costoryapi.ValidationIssue, attributes map[string]path.Path) {
+	for _, issue := range issues {
+		if attribute, ok := attributes[issue.Field]; ok {
+			diags.AddAttributeError(attribute, summary, issue.Message)
+			continue
+		}
+
+		if issue.Field != "" {
+			diags.AddError(summary, fmt.Sprintf("%s: %s", issue.Field, issue.Message))
+			continue
+		}
+
+		diags.AddError(summary, issue.Message)
+	}
+}
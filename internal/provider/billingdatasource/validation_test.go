@@ -0,0 +1,258 @@
+package billingdatasource
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/costory-io/costory-terraform/internal/costoryapi"
+)
+
+func TestAddAPIErrorDiagnosticsMultipleIssues(t *testing.T) {
+	t.Parallel()
+
+	model := awsResourceModel{
+		RoleARN:          types.StringNull(),
+		SensitiveRoleARN: types.StringValue(testRoleARN),
+	}
+
+	var diags diag.Diagnostics
+	addAPIErrorDiagnostics(&diags, "Unable to create AWS billing datasource", &costoryapi.ValidationError{
+		StatusCode: 400,
+		Issues: []costoryapi.ValidationIssue{
+			{Field: "bucketName", Message: "bucket not found"},
+			{Field: "roleArn", Message: "role cannot be assumed"},
+			{Field: "unknownField", Message: "unexpected value"},
+			{Message: "account is suspended"},
+		},
+	}, model.validationAttributes())
+
+	if len(diags) != 4 || diags.ErrorsCount() != 4 {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	wantPaths := []path.Path{path.Root("bucket_name"), path.Root("sensitive_role_arn")}
+	for i, want := range wantPaths {
+		withPath, ok := diags[i].(diag.DiagnosticWithPath)
+		if !ok || !withPath.Path().Equal(want) {
+			t.Fatalf("unexpected diagnostic %d: %#v", i, diags[i])
+		}
+	}
+
+	for i, wantDetail := range []string{"unknownField: unexpected value", "account is suspended"} {
+		if _, ok := diags[i+2].(diag.DiagnosticWithPath); ok {
+			t.Fatalf("expected diagnostic %d to have no attribute path", i+2)
+		}
+		if got := diags[i+2].Detail(); got != wantDetail {
+			t.Fatalf("unexpected diagnostic detail: got %q, want %q", got, wantDetail)
+		}
+	}
+}
+
+func TestAddAPIErrorDiagnosticsPlainError(t *testing.T) {
+	t.Parallel()
+
+	var diags diag.Diagnostics
+	addAPIErrorDiagnostics(&diags, "Unable to validate GCP billing datasource", errors.New("unexpected status code 500"), gcpResourceModel{}.validationAttributes())
+
+	if len(diags) != 1 || diags[0].Detail() != "unexpected status code 500" {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+}
+
+func TestAWSResourceCreateReportsMissingManifestsOnPrefix(t *testing.T) {
+	t.Parallel()
+
+	var created atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/validate") {
+			created.Store(true)
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"error":"No manifests found under s3://billing-bucket/cur/","reason":"NO_MANIFESTS_FOUND"}`))
+	}))
+	defer server.Close()
+
+	model := testAWSModel()
+	resp := newResourceFixture(t, NewAWSResource(), costoryapi.NewClient(server.URL, "test-token", server.Client())).create(&model)
+
+	if resp.Diagnostics.ErrorsCount() != 1 {
+		t.Fatalf("expected a single error, got %v", resp.Diagnostics)
+	}
+	withPath, ok := resp.Diagnostics.Errors()[0].(diag.DiagnosticWithPath)
+	if !ok || !withPath.Path().Equal(path.Root("prefix")) {
+		t.Fatalf("expected the error to target prefix, got %#v", resp.Diagnostics.Errors()[0])
+	}
+	if created.Load() {
+		t.Fatal("expected create to be skipped after validation failed")
+	}
+}
+
+func TestGCPResourceCreateReportsValidationIssueOnAttribute(t *testing.T) {
+	t.Parallel()
+
+	var created atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/validate") {
+			created.Store(true)
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"issues":[{"field":"bqTablePath","message":"table not found","code":"TABLE_NOT_FOUND"}]}`))
+	}))
+	defer server.Close()
+
+	model := testGCPModel()
+	resp := newResourceFixture(t, NewGCPResource(), costoryapi.NewClient(server.URL, "test-token", server.Client())).create(&model)
+
+	if resp.Diagnostics.ErrorsCount() != 1 {
+		t.Fatalf("expected a single error, got %v", resp.Diagnostics)
+	}
+	withPath, ok := resp.Diagnostics.Errors()[0].(diag.DiagnosticWithPath)
+	if !ok || !withPath.Path().Equal(path.Root("bq_uri")) {
+		t.Fatalf("expected the error to target bq_uri, got %#v", resp.Diagnostics.Errors()[0])
+	}
+	if got := resp.Diagnostics.Errors()[0].Detail(); got != "table not found" {
+		t.Fatalf("unexpected diagnostic detail: %q", got)
+	}
+	if created.Load() {
+		t.Fatal("expected create to be skipped after validation failed")
+	}
+}
+
+func TestGCPResourceCreateReportsDetailedExportMismatchOnBothAttributes(t *testing.T) {
+	t.Parallel()
+
+	var created atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/validate") {
+			created.Store(true)
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"error":"Detailed billing requires detailed export","reason":"DETAILED_BILLING_REQUIRES_DETAILED_EXPORT"}`))
+	}))
+	defer server.Close()
+
+	model := testGCPModel()
+	model.BQURI = types.StringValue("project.dataset.gcp_billing_export_v1")
+	model.IsDetailedBilling = types.BoolValue(true)
+	resp := newResourceFixture(t, NewGCPResource(), costoryapi.NewClient(server.URL, "test-token", server.Client())).create(&model)
+
+	wantPaths := []path.Path{path.Root("bq_uri"), path.Root("is_detailed_billing")}
+	errs := resp.Diagnostics.Errors()
+	if len(errs) != len(wantPaths) {
+		t.Fatalf("expected an error on each attribute, got %v", resp.Diagnostics)
+	}
+	for i, want := range wantPaths {
+		withPath, ok := errs[i].(diag.DiagnosticWithPath)
+		if !ok || !withPath.Path().Equal(want) {
+			t.Fatalf("expected error %d to target %s, got %#v", i, want, errs[i])
+		}
+		if got := errs[i].Detail(); got != "Detailed billing requires detailed export" {
+			t.Fatalf("unexpected diagnostic detail: %q", got)
+		}
+	}
+	if created.Load() {
+		t.Fatal("expected create to be skipped after validation failed")
+	}
+}
+
+func TestGCPResourceCreateValidateUnavailable(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		validateStatus int
+		wantCreated    bool
+	}{
+		"not found":          {validateStatus: http.StatusNotFound, wantCreated: true},
+		"method not allowed": {validateStatus: http.StatusMethodNotAllowed, wantCreated: true},
+		"bad request":        {validateStatus: http.StatusBadRequest},
+		"forbidden":          {validateStatus: http.StatusForbidden},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var created atomic.Bool
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if strings.HasSuffix(r.URL.Path, "/validate") {
+					w.WriteHeader(tc.validateStatus)
+					_, _ = w.Write([]byte(`{"error":"validation failed"}`))
+					return
+				}
+				created.Store(true)
+				w.WriteHeader(http.StatusCreated)
+				_, _ = w.Write([]byte(`{"id":"gcp-ds-1","type":"GCP","status":"PENDING","name":"GCP Billing","bqTablePath":"project.dataset.table"}`))
+			}))
+			defer server.Close()
+
+			model := testGCPModel()
+			resp := newResourceFixture(t, NewGCPResource(), costoryapi.NewClient(server.URL, "test-token", server.Client())).create(&model)
+
+			if got := created.Load(); got != tc.wantCreated {
+				t.Fatalf("unexpected create call: got %t, want %t", got, tc.wantCreated)
+			}
+			if !tc.wantCreated {
+				if resp.Diagnostics.ErrorsCount() != 1 {
+					t.Fatalf("expected a single validation error, got %v", resp.Diagnostics)
+				}
+				return
+			}
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+			if resp.Diagnostics.WarningsCount() != 1 || resp.Diagnostics.Warnings()[0].Summary() != "GCP billing datasource validation skipped" {
+				t.Fatalf("expected a skipped validation warning, got %v", resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestAWSResourceCreateValidateUnavailable(t *testing.T) {
+	t.Parallel()
+
+	for _, validateStatus := range []int{http.StatusNotFound, http.StatusMethodNotAllowed} {
+		t.Run(http.StatusText(validateStatus), func(t *testing.T) {
+			t.Parallel()
+
+			var created atomic.Bool
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if strings.HasSuffix(r.URL.Path, "/validate") {
+					w.WriteHeader(validateStatus)
+					return
+				}
+				created.Store(true)
+				w.WriteHeader(http.StatusCreated)
+				_, _ = w.Write([]byte(`{"id":"aws-ds-1","type":"AWS","status":"PENDING","name":"AWS Billing","bucketName":"billing-bucket"}`))
+			}))
+			defer server.Close()
+
+			model := testAWSModel()
+			resp := newResourceFixture(t, NewAWSResource(), costoryapi.NewClient(server.URL, "test-token", server.Client())).create(&model)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+			if !created.Load() {
+				t.Fatal("expected create to proceed without pre-validation")
+			}
+			if resp.Diagnostics.WarningsCount() != 1 || resp.Diagnostics.Warnings()[0].Summary() != "AWS billing datasource validation skipped" {
+				t.Fatalf("expected a skipped validation warning, got %v", resp.Diagnostics)
+			}
+		})
+	}
+}
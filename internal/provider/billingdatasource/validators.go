@@ -0,0 +1,124 @@
+package billingdatasource
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var (
+	_ validator.String = stringPatternValidator{}
+	_ validator.String = trimmedStringValidator{}
+)
+
+// stringPatternValidator checks that a configured string matches a regular expression.
+// Sensitive values are not echoed in the error.
+type stringPatternValidator struct {
+	pattern     *regexp.Regexp
+	description string
+	sensitive   bool
+}
+
+func (v stringPatternValidator) Description(_ context.Context) string {
+	return v.description
+}
+
+func (v stringPatternValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v stringPatternValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if v.pattern.MatchString(req.ConfigValue.ValueString()) {
+		return
+	}
+
+	detail := fmt.Sprintf("Value %q is invalid: %s.", req.ConfigValue.ValueString(), v.description)
+	if v.sensitive {
+		detail = fmt.Sprintf("Value is invalid: %s.", v.description)
+	}
+	resp.Diagnostics.AddAttributeError(req.Path, "Invalid attribute value", detail)
+}
+
+// awsReportNameValidator enforces the character set and length AWS allows for CUR report names.
+func awsReportNameValidator() validator.String {
+	return stringPatternValidator{
+		pattern:     regexp.MustCompile(`^[0-9A-Za-z!\-_.*'()]{1,256}$`),
+		description: "must be 1-256 characters from letters, digits, and ! - _ . * ' ( )",
+	}
+}
+
+// awsRoleARNPattern matches an IAM role ARN in any partition, with or without a path such
+// as /team/ before the role name.
+var awsRoleARNPattern = regexp.MustCompile(`^arn:aws[a-z-]*:iam::[0-9]{12}:role/([!-~]*/)?[\w+=,.@-]{1,64}$`)
+
+// awsRoleARNValidator enforces the IAM role ARN format, accepting roles under a path.
+func awsRoleARNValidator(sensitive bool) validator.String {
+	return stringPatternValidator{
+		pattern:     awsRoleARNPattern,
+		description: "must be an IAM role ARN such as arn:aws:iam::123456789012:role/costory or, with a path, arn:aws:iam::123456789012:role/team/costory",
+		sensitive:   sensitive,
+	}
+}
+
+// gcpBillingAccountIDValidator enforces the Cloud Billing account ID format.
+func gcpBillingAccountIDValidator() validator.String {
+	return stringPatternValidator{
+		pattern:     regexp.MustCompile(`^[0-9A-F]{6}-[0-9A-F]{6}-[0-9A-F]{6}$`),
+		description: "must be a billing account ID in the form XXXXXX-XXXXXX-XXXXXX (uppercase hexadecimal)",
+	}
+}
+
+// nonEmptyStringValidator rejects empty or whitespace-only values.
+func nonEmptyStringValidator() validator.String {
+	return stringPatternValidator{
+		pattern:     regexp.MustCompile(`\S`),
+		description: "must be a non-empty string",
+	}
+}
+
+// trimmedStringValidator rejects values with leading or trailing whitespace, such as a
+// copy-pasted value ending in a newline. Trimming silently would leave configuration and
+// state permanently different, so the value is rejected instead. The value itself is not
+// echoed because it may be sensitive.
+type trimmedStringValidator struct{}
+
+func (v trimmedStringValidator) Description(_ context.Context) string {
+	return "must not have leading or trailing whitespace"
+}
+
+func (v trimmedStringValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v trimmedStringValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if value := req.ConfigValue.ValueString(); value != strings.TrimSpace(value) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid attribute value",
+			"Value must not have leading or trailing whitespace, including spaces, tabs, or newlines. Remove it, for example with trimspace().",
+		)
+	}
+}
+
+// noSurroundingWhitespaceValidator rejects values with leading or trailing whitespace.
+func noSurroundingWhitespaceValidator() validator.String {
+	return trimmedStringValidator{}
+}
+
+// validateGlobPattern reports malformed glob syntax, such as an unterminated character class.
+func validateGlobPattern(pattern string) error {
+	_, err := path.Match(pattern, "")
+	return err
+}
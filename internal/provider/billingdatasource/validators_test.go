@@ -0,0 +1,217 @@
+package billingdatasource
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestAWSReportNameValidator(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		value     types.String
+		wantError bool
+	}{
+		"valid":    {value: types.StringValue("costory-cur_v1.(daily)")},
+		"null":     {value: types.StringNull()},
+		"unknown":  {value: types.StringUnknown()},
+		"slash":    {value: types.StringValue("cur/report"), wantError: true},
+		"space":    {value: types.StringValue("cur report"), wantError: true},
+		"empty":    {value: types.StringValue(""), wantError: true},
+		"too long": {value: types.StringValue(strings.Repeat("a", 257)), wantError: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			req := validator.StringRequest{Path: path.Root("report_name"), ConfigValue: tc.value}
+			var resp validator.StringResponse
+			awsReportNameValidator().ValidateString(context.Background(), req, &resp)
+
+			if got := resp.Diagnostics.HasError(); got != tc.wantError {
+				t.Fatalf("unexpected validation result: got error=%t, want %t: %v", got, tc.wantError, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestGCPBillingAccountIDValidator(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		value     types.String
+		wantError bool
+	}{
+		"valid":         {value: types.StringValue("01A2B3-C4D5E6-F7A8B9")},
+		"null":          {value: types.StringNull()},
+		"unknown":       {value: types.StringUnknown()},
+		"lowercase":     {value: types.StringValue("01a2b3-c4d5e6-f7a8b9"), wantError: true},
+		"no dashes":     {value: types.StringValue("01A2B3C4D5E6F7A8B9"), wantError: true},
+		"short segment": {value: types.StringValue("01A2B-C4D5E6-F7A8B9"), wantError: true},
+		"non hex":       {value: types.StringValue("01A2B3-C4D5E6-F7A8BZ"), wantError: true},
+		"empty":         {value: types.StringValue(""), wantError: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			req := validator.StringRequest{Path: path.Root("billing_account_id"), ConfigValue: tc.value}
+			var resp validator.StringResponse
+			gcpBillingAccountIDValidator().ValidateString(context.Background(), req, &resp)
+
+			if got := resp.Diagnostics.HasError(); got != tc.wantError {
+				t.Fatalf("unexpected validation result: got error=%t, want %t: %v", got, tc.wantError, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestValidateGlobPattern(t *testing.T) {
+	t.Parallel()
+
+	for _, pattern := range []string{"cur/", "cur/*/year=2025", "cur/?/[0-9]*"} {
+		if err := validateGlobPattern(pattern); err != nil {
+			t.Fatalf("unexpected error for %q: %v", pattern, err)
+		}
+	}
+
+	for _, pattern := range []string{"cur/[0-9", "cur/\\"} {
+		if err := validateGlobPattern(pattern); err == nil {
+			t.Fatalf("expected error for %q", pattern)
+		}
+	}
+}
+
+func TestNoSurroundingWhitespaceValidator(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		value     types.String
+		wantError bool
+	}{
+		"trimmed":          {value: types.StringValue("billing-bucket")},
+		"inner space":      {value: types.StringValue("AWS Billing")},
+		"null":             {value: types.StringNull()},
+		"unknown":          {value: types.StringUnknown()},
+		"leading space":    {value: types.StringValue(" billing-bucket"), wantError: true},
+		"trailing space":   {value: types.StringValue("billing-bucket "), wantError: true},
+		"trailing newline": {value: types.StringValue(testRoleARN + "\n"), wantError: true},
+		"leading tab":      {value: types.StringValue("\tcur/"), wantError: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			req := validator.StringRequest{Path: path.Root("bucket_name"), ConfigValue: tc.value}
+			var resp validator.StringResponse
+			noSurroundingWhitespaceValidator().ValidateString(context.Background(), req, &resp)
+
+			if got := resp.Diagnostics.HasError(); got != tc.wantError {
+				t.Fatalf("unexpected validation result: got error=%t, want %t: %v", got, tc.wantError, resp.Diagnostics)
+			}
+			if tc.wantError && strings.Contains(resp.Diagnostics.Errors()[0].Detail(), strings.TrimSpace(tc.value.ValueString())) {
+				t.Fatalf("expected diagnostic not to echo the value, got %q", resp.Diagnostics.Errors()[0].Detail())
+			}
+		})
+	}
+}
+
+func TestStringInputsRejectSurroundingWhitespace(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		resource   resource.Resource
+		attributes []string
+	}{
+		"gcp": {resource: NewGCPResource(), attributes: []string{"name", "bq_uri", "export_project_id", "owner"}},
+		"aws": {resource: NewAWSResource(), attributes: []string{"name", "bucket_name", "role_arn", "sensitive_role_arn", "prefix", "owner"}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var resp resource.SchemaResponse
+			tc.resource.Schema(context.Background(), resource.SchemaRequest{}, &resp)
+
+			for _, attributeName := range tc.attributes {
+				attribute, ok := resp.Schema.Attributes[attributeName].(schema.StringAttribute)
+				if !ok {
+					t.Fatalf("expected %s to be a string attribute", attributeName)
+				}
+
+				var validation validator.StringResponse
+				for _, v := range attribute.Validators {
+					v.ValidateString(context.Background(), validator.StringRequest{
+						Path:        path.Root(attributeName),
+						ConfigValue: types.StringValue("value\n"),
+					}, &validation)
+				}
+				if !validation.Diagnostics.HasError() {
+					t.Fatalf("expected %s to reject a trailing newline", attributeName)
+				}
+			}
+		})
+	}
+}
+
+func TestAWSRoleARNValidator(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		value     types.String
+		wantError bool
+	}{
+		"valid":           {value: types.StringValue("arn:aws:iam::123456789012:role/costory")},
+		"pathed":          {value: types.StringValue("arn:aws:iam::123456789012:role/team/costory")},
+		"nested path":     {value: types.StringValue("arn:aws:iam::123456789012:role/org/team/costory-reader")},
+		"gov partition":   {value: types.StringValue("arn:aws-us-gov:iam::123456789012:role/costory")},
+		"null":            {value: types.StringNull()},
+		"unknown":         {value: types.StringUnknown()},
+		"user":            {value: types.StringValue("arn:aws:iam::123456789012:user/costory"), wantError: true},
+		"short account":   {value: types.StringValue("arn:aws:iam::12345:role/costory"), wantError: true},
+		"trailing slash":  {value: types.StringValue("arn:aws:iam::123456789012:role/team/"), wantError: true},
+		"role name space": {value: types.StringValue("arn:aws:iam::123456789012:role/cost ory"), wantError: true},
+		"empty":           {value: types.StringValue(""), wantError: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			req := validator.StringRequest{Path: path.Root("role_arn"), ConfigValue: tc.value}
+			var resp validator.StringResponse
+			awsRoleARNValidator(false).ValidateString(context.Background(), req, &resp)
+
+			if got := resp.Diagnostics.HasError(); got != tc.wantError {
+				t.Fatalf("unexpected validation result: got error=%t, want %t: %v", got, tc.wantError, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestAWSRoleARNValidatorSensitiveOmitsValue(t *testing.T) {
+	t.Parallel()
+
+	const value = "arn:aws:iam::210987654321:user/costory"
+	req := validator.StringRequest{Path: path.Root("sensitive_role_arn"), ConfigValue: types.StringValue(value)}
+	var resp validator.StringResponse
+	awsRoleARNValidator(true).ValidateString(context.Background(), req, &resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected an invalid role ARN error")
+	}
+	if detail := resp.Diagnostics.Errors()[0].Detail(); strings.Contains(detail, "210987654321") {
+		t.Fatalf("expected the sensitive value to be omitted, got %q", detail)
+	}
+}
@@ -0,0 +1,128 @@
+package billingdatasource
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/costory-io/costory-terraform/internal/costoryapi"
+)
+
+func TestAWSResourceCreateRecordsNotYetReadableWarning(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":"aws-ds-1","type":"AWS","status":"PENDING","name":"AWS Billing","bucketName":"billing-bucket","roleArn":"` + testRoleARN + `","prefix":"cur/"}`))
+	}))
+	defer server.Close()
+
+	client := costoryapi.NewClient(server.URL, "test-token", server.Client())
+	model := testAWSModel()
+	resp := newResourceFixture(t, NewAWSResource(), client).create(&model)
+
+	if resp.Diagnostics.HasError() || resp.Diagnostics.WarningsCount() != 1 {
+		t.Fatalf("expected a single warning, got %v", resp.Diagnostics)
+	}
+
+	warnings := client.Warnings()
+	if len(warnings) != 1 || warnings[0].Summary != "Created datasource not yet readable" {
+		t.Fatalf("unexpected recorded warnings: %#v", warnings)
+	}
+}
+
+func TestCursorResourceCreateSurfacesResponseWarnings(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/terraform/billingDatasources/validate" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":"cursor-ds-1","type":"Cursor","status":"PENDING","name":"Cursor Billing","warnings":["No usage data found yet, ingestion will start when data appears."," ","Start date is before the organization was created."]}`))
+	}))
+	defer server.Close()
+
+	client := costoryapi.NewClient(server.URL, "test-token", server.Client())
+	f := newResourceFixture(t, NewCursorResource(), client)
+	model := testCursorModel()
+	resp := f.create(&model)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("expected create to succeed, got %v", resp.Diagnostics)
+	}
+
+	warnings := resp.Diagnostics.Warnings()
+	want := []string{
+		"No usage data found yet, ingestion will start when data appears.",
+		"Start date is before the organization was created.",
+	}
+	if len(warnings) != len(want) {
+		t.Fatalf("unexpected warnings: got %v, want %d", warnings, len(want))
+	}
+	for i, detail := range want {
+		if warnings[i].Summary() != "Cursor billing datasource created with a warning" || warnings[i].Detail() != detail {
+			t.Fatalf("unexpected warning %d: got %q / %q, want detail %q", i, warnings[i].Summary(), warnings[i].Detail(), detail)
+		}
+	}
+	if got := len(client.Warnings()); got != len(want) {
+		t.Fatalf("unexpected recorded warning count: got %d, want %d", got, len(want))
+	}
+
+	var state cursorResourceModel
+	f.decode(resp.State, &state)
+	if got := state.ID.ValueString(); got != "cursor-ds-1" {
+		t.Fatalf("unexpected id: got %q, want %q", got, "cursor-ds-1")
+	}
+}
+
+func TestCursorResourceCreateWarnsStartDateBeforeAvailableData(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/terraform/billingDatasources/validate" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":"cursor-ds-1","type":"Cursor","status":"PENDING","name":"Cursor Billing","startDate":"2024-01-01","earliestAvailableDate":"2024-06-01"}`))
+	}))
+	defer server.Close()
+
+	client := costoryapi.NewClient(server.URL, "test-token", server.Client())
+	model := testCursorModel()
+	model.StartDate = types.StringValue("2024-01-01")
+	resp := newResourceFixture(t, NewCursorResource(), client).create(&model)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("expected create to succeed, got %v", resp.Diagnostics)
+	}
+
+	warnings := resp.Diagnostics.Warnings()
+	if len(warnings) != 1 {
+		t.Fatalf("expected a single warning, got %v", warnings)
+	}
+	withPath, ok := warnings[0].(diag.DiagnosticWithPath)
+	if !ok || !withPath.Path().Equal(path.Root("start_date")) {
+		t.Fatalf("expected warning on start_date, got %#v", warnings[0])
+	}
+	if got, want := warnings[0].Summary(), "Cursor billing datasource start date predates available data"; got != want {
+		t.Fatalf("unexpected warning summary: got %q, want %q", got, want)
+	}
+	if !strings.Contains(warnings[0].Detail(), `"2024-06-01"`) {
+		t.Fatalf("expected warning detail to name the earliest available date, got %q", warnings[0].Detail())
+	}
+	if got := len(client.Warnings()); got != 1 {
+		t.Fatalf("unexpected recorded warning count: got %d, want 1", got)
+	}
+}
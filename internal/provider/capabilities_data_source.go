@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/costory-io/costory-terraform/internal/costoryapi"
+	"github.com/costory-io/costory-terraform/internal/provider/versiondiag"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = &capabilitiesDataSource{}
+	_ datasource.DataSourceWithConfigure = &capabilitiesDataSource{}
+)
+
+type capabilitiesDataSource struct {
+	client *costoryapi.Client
+}
+
+type capabilitiesDataSourceModel struct {
+	AllSupported    types.Bool `tfsdk:"all_supported"`
+	DatasourceTypes types.List `tfsdk:"datasource_types"`
+	Features        types.Map  `tfsdk:"features"`
+}
+
+// NewCapabilitiesDataSource returns the Costory capabilities data source.
+func NewCapabilitiesDataSource() datasource.DataSource {
+	return &capabilitiesDataSource{}
+}
+
+func (d *capabilitiesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_capabilities", req.ProviderTypeName)
+}
+
+func (d *capabilitiesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Returns the datasource types and feature flags supported by the Costory deployment.",
+		Attributes: map[string]schema.Attribute{
+			"all_supported": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the deployment predates capability reporting, in which case every datasource type and feature is assumed to be supported.",
+			},
+			"datasource_types": schema.ListAttribute{
+				Computed:            true,
+				MarkdownDescription: "Datasource types supported by the deployment. Empty when `all_supported` is `true`.",
+				ElementType:         types.StringType,
+			},
+			"features": schema.MapAttribute{
+				Computed:            true,
+				MarkdownDescription: "Feature flags reported by the deployment.",
+				ElementType:         types.BoolType,
+			},
+		},
+	}
+}
+
+func (d *capabilitiesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*costoryapi.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			costoryapi.VersionedSummary("Unexpected data source configure type", req.ProviderData),
+			fmt.Sprintf("Expected *costoryapi.Client, got: %T. This is always a provider implementation bug.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *capabilitiesDataSource) Read(ctx context.Context, _ datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.client == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured Costory client",
+			"The provider did not configure the Costory API client for the data source.",
+		)
+		return
+	}
+
+	defer versiondiag.Add(d.client, &resp.Diagnostics)
+
+	capabilities, err := d.client.GetCapabilities(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read Costory capabilities",
+			err.Error(),
+		)
+		return
+	}
+
+	var state capabilitiesDataSourceModel
+	var diags diag.Diagnostics
+
+	state.AllSupported = types.BoolValue(capabilities.AllSupported)
+	state.DatasourceTypes, diags = types.ListValueFrom(ctx, types.StringType, capabilities.DatasourceTypes)
+	resp.Diagnostics.Append(diags...)
+	state.Features, diags = types.MapValueFrom(ctx, types.BoolType, capabilities.Features)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -2,9 +2,12 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/costory-io/costory-terraform/internal/costoryapi"
+	"github.com/costory-io/costory-terraform/internal/provider/versiondiag"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
@@ -23,6 +26,7 @@ type serviceAccountDataSource struct {
 
 type serviceAccountDataSourceModel struct {
 	ServiceAccount types.String `tfsdk:"service_account"`
+	IAMMember      types.String `tfsdk:"iam_member"`
 	SubIDs         types.List   `tfsdk:"sub_ids"`
 }
 
@@ -43,6 +47,10 @@ func (d *serviceAccountDataSource) Schema(_ context.Context, _ datasource.Schema
 				Computed:            true,
 				MarkdownDescription: "Service account name returned by Costory.",
 			},
+			"iam_member": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Service account formatted as `serviceAccount:<email>`, ready for the `member` argument of `google_project_iam_member`. Null when the returned service account is not an email address.",
+			},
 			"sub_ids": schema.ListAttribute{
 				Computed:            true,
 				MarkdownDescription: "Subscription IDs returned by Costory.",
@@ -60,7 +68,7 @@ func (d *serviceAccountDataSource) Configure(_ context.Context, req datasource.C
 	client, ok := req.ProviderData.(*costoryapi.Client)
 	if !ok {
 		resp.Diagnostics.AddError(
-			"Unexpected data source configure type",
+			costoryapi.VersionedSummary("Unexpected data source configure type", req.ProviderData),
 			fmt.Sprintf("Expected *costoryapi.Client, got: %T. This is always a provider implementation bug.", req.ProviderData),
 		)
 		return
@@ -78,7 +86,16 @@ func (d *serviceAccountDataSource) Read(ctx context.Context, _ datasource.ReadRe
 		return
 	}
 
+	defer versiondiag.Add(d.client, &resp.Diagnostics)
+
 	serviceAccountResponse, err := d.client.GetServiceAccount(ctx)
+	if errors.Is(err, costoryapi.ErrNotFound) {
+		resp.Diagnostics.AddError(
+			"Costory tenant not found",
+			tenantNotFoundDetail(d.client.Slug()),
+		)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to read Costory service account",
@@ -91,6 +108,15 @@ func (d *serviceAccountDataSource) Read(ctx context.Context, _ datasource.ReadRe
 	var diags diag.Diagnostics
 
 	state.ServiceAccount = types.StringValue(serviceAccountResponse.ServiceAccount)
+	state.IAMMember = types.StringNull()
+	if isServiceAccountEmail(serviceAccountResponse.ServiceAccount) {
+		state.IAMMember = types.StringValue("serviceAccount:" + serviceAccountResponse.ServiceAccount)
+	} else {
+		resp.Diagnostics.AddWarning(
+			"Costory service account is not an email address",
+			fmt.Sprintf("The service account %q returned by Costory is not an email address, so iam_member is left unset.", serviceAccountResponse.ServiceAccount),
+		)
+	}
 	state.SubIDs, diags = types.ListValueFrom(ctx, types.StringType, serviceAccountResponse.SubIDs)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -99,3 +125,18 @@ func (d *serviceAccountDataSource) Read(ctx context.Context, _ datasource.ReadRe
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
+
+// isServiceAccountEmail reports whether value looks like a GCP service account email, which
+// is the only form IAM accepts after the serviceAccount: prefix.
+func isServiceAccountEmail(value string) bool {
+	local, domain, ok := strings.Cut(value, "@")
+	return ok && local != "" && domain != "" && !strings.ContainsAny(value, " \t\n") && !strings.Contains(domain, "@")
+}
+
+func tenantNotFoundDetail(slug string) string {
+	if slug == "" {
+		return "The Costory tenant for this token was not found or is not yet provisioned. Set the provider `slug` attribute if the token can access several tenants."
+	}
+
+	return fmt.Sprintf("Tenant slug %q not found or not yet provisioned. Check the provider `slug` attribute.", slug)
+}
@@ -0,0 +1,113 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/costory-io/costory-terraform/internal/costoryapi"
+)
+
+func TestServiceAccountDataSourceTenantNotFound(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	dataSource := &serviceAccountDataSource{
+		client: costoryapi.NewClient(server.URL, "test-token", server.Client(), costoryapi.WithSlug("acme")),
+	}
+
+	var schemaResp datasource.SchemaResponse
+	dataSource.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+	resp := datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	dataSource.Read(ctx, datasource.ReadRequest{}, &resp)
+
+	if resp.Diagnostics.ErrorsCount() != 1 {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	diagnostic := resp.Diagnostics.Errors()[0]
+	if got, want := diagnostic.Summary(), "Costory tenant not found"; got != want {
+		t.Fatalf("unexpected summary: got %q, want %q", got, want)
+	}
+	if !strings.Contains(diagnostic.Detail(), `Tenant slug "acme" not found or not yet provisioned`) {
+		t.Fatalf("unexpected detail: %q", diagnostic.Detail())
+	}
+}
+
+func TestServiceAccountDataSourceIAMMember(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		serviceAccount string
+		wantIAMMember  string
+		wantNull       bool
+		wantWarnings   int
+	}{
+		"email": {
+			serviceAccount: "costory@acme-prod.iam.gserviceaccount.com",
+			wantIAMMember:  "serviceAccount:costory@acme-prod.iam.gserviceaccount.com",
+		},
+		"not an email": {
+			serviceAccount: "costory-reader",
+			wantNull:       true,
+			wantWarnings:   1,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte(`{"service_account":"` + tc.serviceAccount + `","sub_ids":[]}`))
+			}))
+			defer server.Close()
+
+			ctx := context.Background()
+			dataSource := &serviceAccountDataSource{
+				client: costoryapi.NewClient(server.URL, "test-token", server.Client()),
+			}
+
+			var schemaResp datasource.SchemaResponse
+			dataSource.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+			resp := datasource.ReadResponse{State: tfsdk.State{
+				Schema: schemaResp.Schema,
+				Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+			}}
+			dataSource.Read(ctx, datasource.ReadRequest{}, &resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+			if got := resp.Diagnostics.WarningsCount(); got != tc.wantWarnings {
+				t.Fatalf("unexpected warning count: got %d, want %d", got, tc.wantWarnings)
+			}
+
+			var state serviceAccountDataSourceModel
+			resp.Diagnostics.Append(resp.State.Get(ctx, &state)...)
+			if tc.wantNull {
+				if !state.IAMMember.IsNull() {
+					t.Fatalf("expected iam_member to be null, got %s", state.IAMMember)
+				}
+				return
+			}
+			if got := state.IAMMember.ValueString(); got != tc.wantIAMMember {
+				t.Fatalf("unexpected iam_member: got %q, want %q", got, tc.wantIAMMember)
+			}
+		})
+	}
+}
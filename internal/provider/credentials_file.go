@@ -0,0 +1,93 @@
+package provider
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// credentialsFile is the JSON document referenced by credentials_file.
+type credentialsFile struct {
+	Slug    string `json:"slug"`
+	Token   string `json:"token"`
+	BaseURL string `json:"base_url"`
+}
+
+// applyCredentialsFile loads credentials_file and fills slug, token and base_url from it
+// where the configuration leaves them unset, so explicit attributes always win. The token is
+// not taken from the file when token_command is set, nor base_url when environment is set. Diagnostics never include file contents,
+// since the file holds the token.
+func applyCredentialsFile(config *costoryProviderModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if config.CredentialsFile.IsNull() || config.CredentialsFile.IsUnknown() {
+		return diags
+	}
+
+	filename := strings.TrimSpace(config.CredentialsFile.ValueString())
+	credentials, err := readCredentialsFile(filename)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("credentials_file"),
+			"Invalid Costory credentials file",
+			fmt.Sprintf("The provider cannot read credentials from %q: %s.", filename, err),
+		)
+		return diags
+	}
+
+	if config.Slug.IsNull() {
+		config.Slug = types.StringValue(credentials.Slug)
+	}
+	if config.Token.IsNull() && config.TokenCommand.IsNull() {
+		config.Token = types.StringValue(credentials.Token)
+	}
+	if config.BaseURL.IsNull() && config.Environment.IsNull() && credentials.BaseURL != "" {
+		config.BaseURL = types.StringValue(credentials.BaseURL)
+	}
+
+	return diags
+}
+
+func readCredentialsFile(filename string) (credentialsFile, error) {
+	var credentials credentialsFile
+
+	if filename == "" {
+		return credentials, errors.New("the path is empty")
+	}
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return credentials, err
+	}
+
+	if err := json.Unmarshal(data, &credentials); err != nil {
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			return credentials, fmt.Errorf("the file is not valid JSON (syntax error at byte %d)", syntaxErr.Offset)
+		}
+		return credentials, errors.New("the file must be a JSON object with string slug, token and base_url fields")
+	}
+
+	credentials.Slug = strings.TrimSpace(credentials.Slug)
+	credentials.Token = strings.TrimSpace(credentials.Token)
+	credentials.BaseURL = strings.TrimSpace(credentials.BaseURL)
+
+	var missing []string
+	if credentials.Slug == "" {
+		missing = append(missing, "slug")
+	}
+	if credentials.Token == "" {
+		missing = append(missing, "token")
+	}
+	if len(missing) > 0 {
+		return credentials, fmt.Errorf("the file is missing %s", strings.Join(missing, " and "))
+	}
+
+	return credentials, nil
+}
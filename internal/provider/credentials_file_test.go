@@ -0,0 +1,131 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const credentialsFileToken = "file-secret-token"
+
+func TestApplyCredentialsFile(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		contents    string
+		config      costoryProviderModel
+		wantSlug    string
+		wantToken   string
+		wantBaseURL string
+		wantError   string
+	}{
+		"file only": {
+			contents:    `{"slug":"acme","token":"` + credentialsFileToken + `","base_url":"https://costory.example"}`,
+			wantSlug:    "acme",
+			wantToken:   credentialsFileToken,
+			wantBaseURL: "https://costory.example",
+		},
+		"explicit attributes win": {
+			contents: `{"slug":"acme","token":"` + credentialsFileToken + `","base_url":"https://costory.example"}`,
+			config: costoryProviderModel{
+				Slug:    types.StringValue("globex"),
+				Token:   types.StringValue("explicit-token"),
+				BaseURL: types.StringValue("https://explicit.example"),
+			},
+			wantSlug:    "globex",
+			wantToken:   "explicit-token",
+			wantBaseURL: "https://explicit.example",
+		},
+		"environment wins over file base url": {
+			contents: `{"slug":"acme","token":"` + credentialsFileToken + `","base_url":"https://costory.example"}`,
+			config: costoryProviderModel{
+				Environment: types.StringValue("production"),
+			},
+			wantSlug:  "acme",
+			wantToken: credentialsFileToken,
+		},
+		"token command wins": {
+			contents: `{"slug":"acme","token":"` + credentialsFileToken + `"}`,
+			config: costoryProviderModel{
+				TokenCommand: types.ListValueMust(types.StringType, []attr.Value{types.StringValue("costory-sso")}),
+			},
+			wantSlug: "acme",
+		},
+		"base url optional": {
+			contents:  `{"slug":"acme","token":"` + credentialsFileToken + `"}`,
+			wantSlug:  "acme",
+			wantToken: credentialsFileToken,
+		},
+		"missing token": {
+			contents:  `{"slug":"acme"}`,
+			wantError: "missing token",
+		},
+		"missing slug and token": {
+			contents:  `{"base_url":"https://costory.example"}`,
+			wantError: "missing slug and token",
+		},
+		"invalid json": {
+			contents:  `{"slug":"acme","token":"` + credentialsFileToken + `"`,
+			wantError: "not valid JSON",
+		},
+		"wrong field type": {
+			contents:  `{"slug":"acme","token":["` + credentialsFileToken + `"]}`,
+			wantError: "must be a JSON object",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			filename := filepath.Join(t.TempDir(), "credentials.json")
+			if err := os.WriteFile(filename, []byte(tc.contents), 0o600); err != nil {
+				t.Fatalf("unable to write credentials file: %v", err)
+			}
+
+			config := tc.config
+			config.CredentialsFile = types.StringValue(filename)
+
+			diags := applyCredentialsFile(&config)
+			if tc.wantError != "" {
+				if !diags.HasError() {
+					t.Fatalf("expected an error containing %q, got none", tc.wantError)
+				}
+				detail := diags.Errors()[0].Detail()
+				if !strings.Contains(detail, tc.wantError) {
+					t.Fatalf("expected error detail to contain %q, got %q", tc.wantError, detail)
+				}
+				if strings.Contains(detail, credentialsFileToken) {
+					t.Fatalf("expected the token to be redacted, got %q", detail)
+				}
+				return
+			}
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			if got := config.Slug.ValueString(); got != tc.wantSlug {
+				t.Fatalf("unexpected slug: got %q, want %q", got, tc.wantSlug)
+			}
+			if got := config.Token.ValueString(); got != tc.wantToken {
+				t.Fatalf("unexpected token: got %q, want %q", got, tc.wantToken)
+			}
+			if got := config.BaseURL.ValueString(); got != tc.wantBaseURL {
+				t.Fatalf("unexpected base URL: got %q, want %q", got, tc.wantBaseURL)
+			}
+		})
+	}
+}
+
+func TestApplyCredentialsFileMissingFile(t *testing.T) {
+	t.Parallel()
+
+	config := costoryProviderModel{CredentialsFile: types.StringValue(filepath.Join(t.TempDir(), "absent.json"))}
+	if diags := applyCredentialsFile(&config); !diags.HasError() {
+		t.Fatal("expected an error for a missing credentials file")
+	}
+}
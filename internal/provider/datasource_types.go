@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/costory-io/costory-terraform/internal/costoryapi"
+)
+
+// validateDatasourceTypeOverrides checks datasource_type_overrides. Keys must be default
+// datasource types; values are free-form because self-hosted deployments may use any
+// identifier, but a value that is not a case variant of its key is surfaced as a warning.
+func validateDatasourceTypeOverrides(ctx context.Context, config costoryProviderModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if config.DatasourceTypeOverrides.IsNull() || config.DatasourceTypeOverrides.IsUnknown() {
+		return diags
+	}
+
+	overrides := make(map[string]types.String, len(config.DatasourceTypeOverrides.Elements()))
+	diags.Append(config.DatasourceTypeOverrides.ElementsAs(ctx, &overrides, false)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	knownTypes := costoryapi.BillingDatasourceTypes()
+	for defaultType, override := range overrides {
+		attributePath := path.Root("datasource_type_overrides").AtMapKey(defaultType)
+
+		if !slices.Contains(knownTypes, defaultType) {
+			diags.AddAttributeError(
+				attributePath,
+				"Unknown Costory datasource type",
+				fmt.Sprintf("Datasource type %q cannot be overridden. Valid keys are: %s.", defaultType, strings.Join(knownTypes, ", ")),
+			)
+			continue
+		}
+
+		if override.IsNull() || override.IsUnknown() {
+			continue
+		}
+
+		value := strings.TrimSpace(override.ValueString())
+		if value == "" {
+			diags.AddAttributeError(
+				attributePath,
+				"Invalid Costory datasource type override",
+				fmt.Sprintf("The override for datasource type %q is empty. Remove the entry to use the default identifier.", defaultType),
+			)
+			continue
+		}
+
+		if !strings.EqualFold(value, defaultType) {
+			diags.AddAttributeWarning(
+				attributePath,
+				"Custom Costory datasource type",
+				fmt.Sprintf("Datasource type %q is sent to the API as %q. Make sure your Costory deployment uses this identifier, otherwise creates will be rejected and reads will fail the type check.", defaultType, value),
+			)
+		}
+	}
+
+	return diags
+}
+
+// datasourceTypeOverrides returns the configured overrides with surrounding whitespace trimmed.
+func datasourceTypeOverrides(ctx context.Context, config costoryProviderModel) (map[string]string, diag.Diagnostics) {
+	if config.DatasourceTypeOverrides.IsNull() {
+		return nil, nil
+	}
+
+	var overrides map[string]string
+	diags := config.DatasourceTypeOverrides.ElementsAs(ctx, &overrides, false)
+	for defaultType, override := range overrides {
+		overrides[defaultType] = strings.TrimSpace(override)
+	}
+
+	return overrides, diags
+}
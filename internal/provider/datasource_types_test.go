@@ -0,0 +1,55 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestValidateDatasourceTypeOverrides(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		overrides   map[string]attr.Value
+		wantWarning bool
+		wantError   bool
+	}{
+		"case variant": {
+			overrides: map[string]attr.Value{"AWS": types.StringValue("aws")},
+		},
+		"free-form value": {
+			overrides:   map[string]attr.Value{"GCP": types.StringValue("google-cloud")},
+			wantWarning: true,
+		},
+		"unknown key": {
+			overrides: map[string]attr.Value{"Oracle": types.StringValue("oracle")},
+			wantError: true,
+		},
+		"empty value": {
+			overrides: map[string]attr.Value{"AWS": types.StringValue(" ")},
+			wantError: true,
+		},
+		"unknown value": {
+			overrides: map[string]attr.Value{"AWS": types.StringUnknown()},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			diags := validateDatasourceTypeOverrides(context.Background(), costoryProviderModel{
+				DatasourceTypeOverrides: types.MapValueMust(types.StringType, tc.overrides),
+			})
+
+			if got := diags.HasError(); got != tc.wantError {
+				t.Fatalf("unexpected error result: got %t, want %t: %v", got, tc.wantError, diags)
+			}
+			if got := diags.WarningsCount() > 0; got != tc.wantWarning {
+				t.Fatalf("unexpected warning result: got %t, want %t: %v", got, tc.wantWarning, diags)
+			}
+		})
+	}
+}
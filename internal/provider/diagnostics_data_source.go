@@ -0,0 +1,134 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/costory-io/costory-terraform/internal/costoryapi"
+	"github.com/costory-io/costory-terraform/internal/provider/versiondiag"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = &diagnosticsDataSource{}
+	_ datasource.DataSourceWithConfigure = &diagnosticsDataSource{}
+)
+
+var diagnosticsWarningAttributeTypes = map[string]attr.Type{
+	"summary":     types.StringType,
+	"detail":      types.StringType,
+	"recorded_at": types.StringType,
+}
+
+type diagnosticsDataSource struct {
+	client *costoryapi.Client
+}
+
+type diagnosticsDataSourceModel struct {
+	WarningCount types.Int64 `tfsdk:"warning_count"`
+	Warnings     types.List  `tfsdk:"warnings"`
+}
+
+type diagnosticsWarningModel struct {
+	Summary    types.String `tfsdk:"summary"`
+	Detail     types.String `tfsdk:"detail"`
+	RecordedAt types.String `tfsdk:"recorded_at"`
+}
+
+// NewDiagnosticsDataSource returns the Costory provider diagnostics data source.
+func NewDiagnosticsDataSource() datasource.DataSource {
+	return &diagnosticsDataSource{}
+}
+
+func (d *diagnosticsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_diagnostics", req.ProviderTypeName)
+}
+
+func (d *diagnosticsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Returns the most recent warnings collected by this provider instance, for example so a CI step can assert that none were raised. Collection is best-effort: only warnings raised earlier in the same Terraform operation by the same provider configuration are reported, and older warnings are dropped once the buffer is full.",
+		Attributes: map[string]schema.Attribute{
+			"warning_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Number of warnings in `warnings`.",
+			},
+			"warnings": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Collected warnings, oldest first.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"summary": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Warning summary.",
+						},
+						"detail": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Warning detail.",
+						},
+						"recorded_at": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Time the warning was collected (RFC3339).",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *diagnosticsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*costoryapi.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			costoryapi.VersionedSummary("Unexpected data source configure type", req.ProviderData),
+			fmt.Sprintf("Expected *costoryapi.Client, got: %T. This is always a provider implementation bug.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *diagnosticsDataSource) Read(ctx context.Context, _ datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.client == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured Costory client",
+			"The provider did not configure the Costory API client for the data source.",
+		)
+		return
+	}
+
+	defer versiondiag.Add(d.client, &resp.Diagnostics)
+
+	warnings := d.client.Warnings()
+	models := make([]diagnosticsWarningModel, 0, len(warnings))
+	for _, warning := range warnings {
+		models = append(models, diagnosticsWarningModel{
+			Summary:    types.StringValue(warning.Summary),
+			Detail:     types.StringValue(warning.Detail),
+			RecordedAt: types.StringValue(warning.RecordedAt.Format(time.RFC3339)),
+		})
+	}
+
+	list, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: diagnosticsWarningAttributeTypes}, models)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state := diagnosticsDataSourceModel{
+		WarningCount: types.Int64Value(int64(len(models))),
+		Warnings:     list,
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+
+	"github.com/costory-io/costory-terraform/internal/costoryapi"
+)
+
+func TestDiagnosticsDataSourceReportsCollectedWarnings(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Deprecation", "true")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"datasourceTypes":["GCP"]}`))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client := costoryapi.NewClient(server.URL, "test-token", server.Client())
+	dataSource := &diagnosticsDataSource{client: client}
+
+	if got := readDiagnostics(t, dataSource); len(got.Warnings) != 0 || got.WarningCount != 0 {
+		t.Fatalf("expected no warnings before any operation, got %#v", got)
+	}
+
+	if _, err := client.GetCapabilities(ctx); err != nil {
+		t.Fatalf("unexpected capabilities error: %v", err)
+	}
+
+	got := readDiagnostics(t, dataSource)
+	if got.WarningCount != 1 || len(got.Warnings) != 1 {
+		t.Fatalf("expected one warning, got %#v", got)
+	}
+	if want := "Costory API endpoint is deprecated"; got.Warnings[0].Summary != want {
+		t.Fatalf("unexpected warning summary: got %q, want %q", got.Warnings[0].Summary, want)
+	}
+	if got.Warnings[0].RecordedAt == "" {
+		t.Fatal("expected recorded_at to be set")
+	}
+}
+
+type diagnosticsReadResult struct {
+	WarningCount int64
+	Warnings     []struct {
+		Summary    string `tfsdk:"summary"`
+		Detail     string `tfsdk:"detail"`
+		RecordedAt string `tfsdk:"recorded_at"`
+	}
+}
+
+func readDiagnostics(t *testing.T, dataSource *diagnosticsDataSource) diagnosticsReadResult {
+	t.Helper()
+
+	ctx := context.Background()
+
+	var schemaResp datasource.SchemaResponse
+	dataSource.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+	resp := datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	dataSource.Read(ctx, datasource.ReadRequest{}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var state diagnosticsDataSourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &state)...)
+
+	result := diagnosticsReadResult{WarningCount: state.WarningCount.ValueInt64()}
+	resp.Diagnostics.Append(state.Warnings.ElementsAs(ctx, &result.Warnings, false)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unable to decode state: %v", resp.Diagnostics)
+	}
+
+	return result
+}
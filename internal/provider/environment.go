@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// environmentBaseURLs maps the environment presets accepted by the provider to API base URLs.
+var environmentBaseURLs = map[string]string{
+	"production": defaultBaseURL,
+}
+
+func environmentNames() []string {
+	names := make([]string, 0, len(environmentBaseURLs))
+	for name := range environmentBaseURLs {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// validateEndpointConfig checks how base_url and environment combine. An explicit base_url
+// takes precedence, which is surfaced as a warning when both are set; an unrecognized
+// environment is only an error when base_url does not override it.
+func validateEndpointConfig(config costoryProviderModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if config.BaseURL.IsUnknown() || config.Environment.IsUnknown() {
+		return diags
+	}
+
+	baseURL := strings.TrimSpace(config.BaseURL.ValueString())
+	environment := strings.TrimSpace(config.Environment.ValueString())
+	if environment == "" {
+		return diags
+	}
+
+	if baseURL != "" {
+		diags.AddAttributeWarning(
+			path.Root("base_url"),
+			"Both base_url and environment are set",
+			fmt.Sprintf("base_url %q takes precedence over environment %q. Remove one of them to silence this warning.", baseURL, environment),
+		)
+		return diags
+	}
+
+	if _, ok := environmentBaseURLs[environment]; !ok {
+		diags.AddAttributeError(
+			path.Root("environment"),
+			"Unknown Costory environment",
+			fmt.Sprintf("Environment %q is not recognized. Valid environments are: %s. Set base_url to target another deployment.", environment, strings.Join(environmentNames(), ", ")),
+		)
+	}
+
+	return diags
+}
+
+// resolveBaseURL returns the API base URL for the provider configuration: base_url when set,
+// otherwise the environment preset, otherwise the production default.
+func resolveBaseURL(baseURL, environment string) string {
+	if baseURL != "" {
+		return baseURL
+	}
+
+	if resolved, ok := environmentBaseURLs[environment]; ok {
+		return resolved
+	}
+
+	return defaultBaseURL
+}
@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestValidateEndpointConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		baseURL     types.String
+		environment types.String
+		wantBaseURL string
+		wantWarning bool
+		wantError   bool
+	}{
+		"both set": {
+			baseURL:     types.StringValue("https://costory.example.com"),
+			environment: types.StringValue("production"),
+			wantBaseURL: "https://costory.example.com",
+			wantWarning: true,
+		},
+		"environment only": {
+			baseURL:     types.StringNull(),
+			environment: types.StringValue("production"),
+			wantBaseURL: defaultBaseURL,
+		},
+		"neither": {
+			baseURL:     types.StringNull(),
+			environment: types.StringNull(),
+			wantBaseURL: defaultBaseURL,
+		},
+		"unknown environment without base_url": {
+			baseURL:     types.StringNull(),
+			environment: types.StringValue("moon"),
+			wantError:   true,
+		},
+		"unknown environment overridden by base_url": {
+			baseURL:     types.StringValue("https://costory.example.com"),
+			environment: types.StringValue("moon"),
+			wantBaseURL: "https://costory.example.com",
+			wantWarning: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			diags := validateEndpointConfig(costoryProviderModel{BaseURL: tc.baseURL, Environment: tc.environment})
+
+			if got := diags.HasError(); got != tc.wantError {
+				t.Fatalf("unexpected error diagnostics: got %v, want %v (%v)", got, tc.wantError, diags)
+			}
+			if got := len(diags.Warnings()) > 0; got != tc.wantWarning {
+				t.Fatalf("unexpected warning diagnostics: got %v, want %v (%v)", got, tc.wantWarning, diags)
+			}
+			if tc.wantError {
+				return
+			}
+
+			if got := resolveBaseURL(tc.baseURL.ValueString(), tc.environment.ValueString()); got != tc.wantBaseURL {
+				t.Fatalf("unexpected base URL: got %q, want %q", got, tc.wantBaseURL)
+			}
+		})
+	}
+}
+
+func TestValidateEndpointConfigUnknownValues(t *testing.T) {
+	t.Parallel()
+
+	diags := validateEndpointConfig(costoryProviderModel{BaseURL: types.StringNull(), Environment: types.StringUnknown()})
+	if len(diags) != 0 {
+		t.Fatalf("expected no diagnostics for an unknown environment value, got %v", diags)
+	}
+
+	if diags := validateEndpointConfig(costoryProviderModel{BaseURL: types.StringUnknown(), Environment: types.StringValue("moon")}); len(diags) != 0 {
+		t.Fatalf("expected no diagnostics for an unknown base_url value, got %v", diags)
+	}
+}
@@ -15,6 +15,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/costory-io/costory-terraform/internal/costoryapi"
+	"github.com/costory-io/costory-terraform/internal/provider/retrywarning"
+	"github.com/costory-io/costory-terraform/internal/provider/statediff"
+	"github.com/costory-io/costory-terraform/internal/provider/versiondiag"
 )
 
 var (
@@ -29,6 +32,7 @@ type metricsDatasourceResource struct {
 
 type metricsDatasourceResourceModel struct {
 	ID                types.String `tfsdk:"id"`
+	ImportID          types.String `tfsdk:"import_id"`
 	Status            types.String `tfsdk:"status"`
 	Name              types.String `tfsdk:"name"`
 	Type              types.String `tfsdk:"type"`
@@ -65,6 +69,10 @@ func (r *metricsDatasourceResource) Schema(_ context.Context, _ resource.SchemaR
 				Computed:            true,
 				MarkdownDescription: "Datasource ID returned by Costory.",
 			},
+			"import_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Value to pass to `terraform import` for this datasource. Always the datasource ID.",
+			},
 			"status": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "Datasource status returned by Costory.",
@@ -155,7 +163,7 @@ func (r *metricsDatasourceResource) Configure(_ context.Context, req resource.Co
 	client, ok := req.ProviderData.(*costoryapi.Client)
 	if !ok {
 		resp.Diagnostics.AddError(
-			"Unexpected resource configure type",
+			costoryapi.VersionedSummary("Unexpected resource configure type", req.ProviderData),
 			fmt.Sprintf("Expected *costoryapi.Client, got: %T. This is always a provider implementation bug.", req.ProviderData),
 		)
 		return
@@ -173,6 +181,11 @@ func (r *metricsDatasourceResource) Create(ctx context.Context, req resource.Cre
 		return
 	}
 
+	ctx, cancel := r.client.OperationContext(ctx)
+	defer cancel()
+	defer retrywarning.Add(ctx, &resp.Diagnostics)
+	defer versiondiag.Add(r.client, &resp.Diagnostics)
+
 	var plan metricsDatasourceResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -189,7 +202,8 @@ func (r *metricsDatasourceResource) Create(ctx context.Context, req resource.Cre
 		return
 	}
 
-	created, err := r.client.CreateMetricsDatasource(ctx, createRequest)
+	createCtx := costoryapi.WithIdempotencyKey(ctx, costoryapi.NewIdempotencyKey())
+	created, err := r.client.CreateMetricsDatasource(createCtx, createRequest)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to create metrics datasource",
@@ -213,6 +227,11 @@ func (r *metricsDatasourceResource) Read(ctx context.Context, req resource.ReadR
 		return
 	}
 
+	ctx, cancel := r.client.OperationContext(ctx)
+	defer cancel()
+	defer retrywarning.Add(ctx, &resp.Diagnostics)
+	defer versiondiag.Add(r.client, &resp.Diagnostics)
+
 	var state metricsDatasourceResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -239,6 +258,7 @@ func (r *metricsDatasourceResource) Read(ctx context.Context, req resource.ReadR
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	statediff.Log(ctx, "read", req.State, resp.State)
 }
 
 func (r *metricsDatasourceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -250,6 +270,11 @@ func (r *metricsDatasourceResource) Update(ctx context.Context, req resource.Upd
 		return
 	}
 
+	ctx, cancel := r.client.OperationContext(ctx)
+	defer cancel()
+	defer retrywarning.Add(ctx, &resp.Diagnostics)
+	defer versiondiag.Add(r.client, &resp.Diagnostics)
+
 	var plan metricsDatasourceResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -283,17 +308,17 @@ func (r *metricsDatasourceResource) Update(ctx context.Context, req resource.Upd
 
 	current, err := r.client.GetMetricsDatasource(ctx, state.ID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddWarning(
-			"Unable to refresh metrics datasource after update",
-			err.Error(),
-		)
+		resp.Diagnostics.AddWarning("Unable to refresh metrics datasource after update", err.Error())
+		r.client.RecordWarning("Unable to refresh metrics datasource after update", err.Error())
 		plan.ID = state.ID
+		plan.ImportID = state.ImportID
 		plan.Status = state.Status
 	} else {
 		plan.mergeAPIResponse(current)
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	statediff.Log(ctx, "update", req.State, resp.State)
 }
 
 func (r *metricsDatasourceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -305,6 +330,11 @@ func (r *metricsDatasourceResource) Delete(ctx context.Context, req resource.Del
 		return
 	}
 
+	ctx, cancel := r.client.OperationContext(ctx)
+	defer cancel()
+	defer retrywarning.Add(ctx, &resp.Diagnostics)
+	defer versiondiag.Add(r.client, &resp.Diagnostics)
+
 	var state metricsDatasourceResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -312,7 +342,7 @@ func (r *metricsDatasourceResource) Delete(ctx context.Context, req resource.Del
 	}
 
 	err := r.client.DeleteMetricsDatasource(ctx, state.ID.ValueString())
-	if err != nil && !errors.Is(err, costoryapi.ErrNotFound) {
+	if err != nil && (!errors.Is(err, costoryapi.ErrNotFound) || r.client.FailDeleteOnMissing()) {
 		resp.Diagnostics.AddError(
 			"Unable to delete metrics datasource",
 			err.Error(),
@@ -431,4 +461,6 @@ func (m *metricsDatasourceResourceModel) mergeAPIResponse(apiResponse *costoryap
 		}
 		m.MetricsDefinition = types.ListValueMust(objType, objs)
 	}
+
+	m.ImportID = m.ID
 }
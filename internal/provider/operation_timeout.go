@@ -0,0 +1,33 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// parseOperationTimeout parses operation_timeout as a Go duration. A null or unknown value
+// yields zero, which leaves operations bounded only by Terraform's own timeouts.
+func parseOperationTimeout(value types.String) (time.Duration, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if value.IsNull() || value.IsUnknown() {
+		return 0, diags
+	}
+
+	timeout, err := time.ParseDuration(strings.TrimSpace(value.ValueString()))
+	if err != nil || timeout <= 0 {
+		diags.AddAttributeError(
+			path.Root("operation_timeout"),
+			"Invalid Costory operation timeout",
+			fmt.Sprintf("operation_timeout must be a positive duration such as \"10m\" or \"90s\", got %q.", value.ValueString()),
+		)
+		return 0, diags
+	}
+
+	return timeout, diags
+}
@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestParseOperationTimeout(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		value     types.String
+		want      time.Duration
+		wantError bool
+	}{
+		"unset":    {value: types.StringNull()},
+		"unknown":  {value: types.StringUnknown()},
+		"minutes":  {value: types.StringValue("10m"), want: 10 * time.Minute},
+		"mixed":    {value: types.StringValue(" 1h30s "), want: time.Hour + 30*time.Second},
+		"zero":     {value: types.StringValue("0s"), wantError: true},
+		"negative": {value: types.StringValue("-1m"), wantError: true},
+		"no unit":  {value: types.StringValue("30"), wantError: true},
+		"empty":    {value: types.StringValue(""), wantError: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, diags := parseOperationTimeout(tc.value)
+			if diags.HasError() != tc.wantError {
+				t.Fatalf("unexpected error result: got %t, want %t: %v", diags.HasError(), tc.wantError, diags)
+			}
+			if got != tc.want {
+				t.Fatalf("unexpected timeout: got %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
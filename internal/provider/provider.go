@@ -2,9 +2,8 @@ package provider
 
 import (
 	"context"
-	"net/http"
+	"fmt"
 	"strings"
-	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -14,37 +13,89 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/costory-io/costory-terraform/internal/costoryapi"
+	"github.com/costory-io/costory-terraform/internal/provider/allocationrule"
+	"github.com/costory-io/costory-terraform/internal/provider/apitoken"
 	"github.com/costory-io/costory-terraform/internal/provider/billingdatasource"
 	"github.com/costory-io/costory-terraform/internal/provider/metricsdatasource"
 	"github.com/costory-io/costory-terraform/internal/provider/team"
 )
 
-const defaultBaseURL = "https://app-api.costory.io"
+const (
+	defaultBaseURL  = "https://app-api.costory.io"
+	defaultTypeName = "costory"
+)
 
 var (
-	_ provider.Provider = &costoryProvider{}
+	_ provider.Provider                   = &costoryProvider{}
+	_ provider.ProviderWithValidateConfig = &costoryProvider{}
 )
 
 type costoryProvider struct {
-	version string
+	version  string
+	typeName string
 }
 
 type costoryProviderModel struct {
-	Token   types.String `tfsdk:"token"`
-	BaseURL types.String `tfsdk:"base_url"`
+	Token       types.String `tfsdk:"token"`
+	BaseURL     types.String `tfsdk:"base_url"`
+	Environment types.String `tfsdk:"environment"`
+	Slug        types.String `tfsdk:"slug"`
+	WorkspaceID types.String `tfsdk:"workspace_id"`
+	StrictDrift types.Bool   `tfsdk:"strict_drift"`
+
+	MaxIdleConns      types.Int64  `tfsdk:"max_idle_conns"`
+	MaxConnsPerHost   types.Int64  `tfsdk:"max_conns_per_host"`
+	IdleConnTimeout   types.String `tfsdk:"idle_conn_timeout"`
+	DisableKeepAlives types.Bool   `tfsdk:"disable_keep_alives"`
+
+	RetryableStatusCodes types.Set `tfsdk:"retryable_status_codes"`
+
+	DatasourceTypeOverrides types.Map    `tfsdk:"datasource_type_overrides"`
+	OperationTimeout        types.String `tfsdk:"operation_timeout"`
+	Timezone                types.String `tfsdk:"timezone"`
+	APIPathPrefix           types.String `tfsdk:"api_path_prefix"`
+
+	RequireDeleteConfirmation types.Bool `tfsdk:"require_delete_confirmation"`
+	FailDeleteOnMissing       types.Bool `tfsdk:"fail_delete_on_missing"`
+	DefaultDetailedBilling    types.Bool `tfsdk:"default_detailed_billing"`
+	VerboseRetries            types.Bool `tfsdk:"verbose_retries"`
+	PreflightChecks           types.Bool `tfsdk:"preflight_checks"`
+
+	TokenCommand    types.List   `tfsdk:"token_command"`
+	CredentialsFile types.String `tfsdk:"credentials_file"`
+}
+
+// Option customizes the provider returned by New.
+type Option func(*costoryProvider)
+
+// WithTypeName sets the provider type name, which prefixes every resource and data source
+// type name. It defaults to "costory"; embedders serving the provider under another brand
+// set it to match their registry address.
+func WithTypeName(name string) Option {
+	return func(p *costoryProvider) {
+		if name = strings.TrimSpace(name); name != "" {
+			p.typeName = name
+		}
+	}
 }
 
 // New returns a constructor for the Costory Terraform provider implementation.
-func New(version string) func() provider.Provider {
+func New(version string, opts ...Option) func() provider.Provider {
 	return func() provider.Provider {
-		return &costoryProvider{
-			version: version,
+		p := &costoryProvider{
+			version:  version,
+			typeName: defaultTypeName,
 		}
+		for _, opt := range opts {
+			opt(p)
+		}
+
+		return p
 	}
 }
 
 func (p *costoryProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
-	resp.TypeName = "costory"
+	resp.TypeName = p.typeName
 	resp.Version = p.version
 }
 
@@ -53,18 +104,131 @@ func (p *costoryProvider) Schema(_ context.Context, _ provider.SchemaRequest, re
 		MarkdownDescription: "The Costory provider forwards API calls to the Costory app.",
 		Attributes: map[string]schema.Attribute{
 			"token": schema.StringAttribute{
-				MarkdownDescription: "Costory API token.",
-				Required:            true,
+				MarkdownDescription: "Costory API token. Exactly one of `token` and `token_command` must be set, unless `credentials_file` provides the token.",
+				Optional:            true,
 				Sensitive:           true,
 			},
+			"token_command": schema.ListAttribute{
+				MarkdownDescription: "Command that prints a Costory API token to standard output, as the executable followed by its arguments, for example `[\"costory-sso\", \"token\"]`. It runs without a shell on the first request, and again whenever Costory rejects the cached token with `401`, so short-lived SSO or OIDC-backed tokens are refreshed during long applies. The printed token is never logged. Conflicts with `token`.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"credentials_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a JSON file with `slug`, `token` and optionally `base_url` keys, for credentials kept in a shared file. Both `slug` and `token` must be present. Explicitly configured `slug`, `token`, `token_command`, `base_url` and `environment` attributes take precedence over the file.",
+				Optional:            true,
+			},
 			"base_url": schema.StringAttribute{
-				MarkdownDescription: "Costory API base URL",
+				MarkdownDescription: "Costory API base URL. Takes precedence over `environment` when both are set.",
+				Optional:            true,
+			},
+			"environment": schema.StringAttribute{
+				MarkdownDescription: "Costory environment preset used to derive the API base URL when `base_url` is not set. Valid values: `production`.",
+				Optional:            true,
+			},
+			"slug": schema.StringAttribute{
+				MarkdownDescription: "Costory tenant slug, sent as the `X-Costory-Slug` header. Must contain only ASCII letters, digits, dots, underscores, and dashes, and start with a letter or digit.",
+				Optional:            true,
+			},
+			"workspace_id": schema.StringAttribute{
+				MarkdownDescription: "Costory workspace ID, sent as the `X-Costory-Workspace` header to scope resources to one workspace of the tenant.",
+				Optional:            true,
+			},
+			"max_idle_conns": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Maximum number of idle HTTP connections kept open to the Costory API. Must be positive. Defaults to `%d`.", defaultMaxIdleConns),
+				Optional:            true,
+			},
+			"max_conns_per_host": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Maximum number of concurrent HTTP connections to the Costory API host, including idle ones. Tune it against your gateway for large concurrent applies. Must be positive. Defaults to `%d`.", defaultMaxConnsPerHost),
+				Optional:            true,
+			},
+			"idle_conn_timeout": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("How long an idle HTTP connection to the Costory API is kept open for reuse, as a duration such as `30s`. Set it below the idle timeout of any load balancer in front of the API to avoid connection resets during long applies. Defaults to `%s`.", defaultIdleConnTimeout),
+				Optional:            true,
+			},
+			"disable_keep_alives": schema.BoolAttribute{
+				MarkdownDescription: "When `true`, every request to the Costory API opens a new HTTP connection instead of reusing an idle one, for gateways that reset idle connections unpredictably. Defaults to `false`.",
+				Optional:            true,
+			},
+			"retryable_status_codes": schema.SetAttribute{
+				MarkdownDescription: "HTTP response statuses treated as transient and retried, for deployments behind gateways with their own status conventions. Must only contain 4xx or 5xx codes. Retrying any status but `408`, `425` and `429` still requires an idempotent method, a read-only request such as validation, or an idempotency key, which every create sends. Defaults to `408`, `425`, `429` and every 5xx status.",
+				ElementType:         types.Int64Type,
+				Optional:            true,
+			},
+			"datasource_type_overrides": schema.MapAttribute{
+				MarkdownDescription: "Datasource type identifiers sent to and expected from the API, keyed by the default identifier (`" + strings.Join(costoryapi.BillingDatasourceTypes(), "`, `") + "`). Only needed for self-hosted deployments that use other identifiers, for example `{ AWS = \"aws\" }`.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"default_detailed_billing": schema.BoolAttribute{
+				MarkdownDescription: "Value applied to `is_detailed_billing` on GCP billing datasources that leave it unset. Explicit resource values take precedence. Unset by default, leaving the choice to Costory.",
+				Optional:            true,
+			},
+			"operation_timeout": schema.StringAttribute{
+				MarkdownDescription: "Upper bound for any single resource operation (create, read, update or delete) against Costory, as a duration such as `10m`, including retries and polling. Terraform's own timeouts still apply when shorter. Unbounded by default.",
+				Optional:            true,
+			},
+			"api_path_prefix": schema.StringAttribute{
+				MarkdownDescription: "URL path every Costory API route is mounted under, including the service account route, for self-hosted deployments that serve the API elsewhere, such as `/api/v1/terraform`. Appended to the base URL. Defaults to `/terraform`.",
+				Optional:            true,
+			},
+			"timezone": schema.StringAttribute{
+				MarkdownDescription: "IANA time zone name, such as `America/Los_Angeles`, in which relative dates like `today` in `start_date` and `end_date` are resolved, so they mean the local day. Defaults to `UTC`.",
+				Optional:            true,
+			},
+			"require_delete_confirmation": schema.BoolAttribute{
+				MarkdownDescription: "When `true`, deleting or replacing a billing datasource fails unless the resource has `confirm_delete = true` applied, guarding against accidental loss of ingested cost history. Defaults to `false`.",
+				Optional:            true,
+			},
+			"fail_delete_on_missing": schema.BoolAttribute{
+				MarkdownDescription: "When `true`, destroying a resource that Costory reports as already deleted (`404`) fails instead of succeeding silently, surfacing state drift. Defaults to `false`.",
+				Optional:            true,
+			},
+			"strict_drift": schema.BoolAttribute{
+				MarkdownDescription: "When `true`, refreshing a managed datasource fails with an error listing every attribute changed outside Terraform, instead of reconciling the change into state. Computed-only attributes such as `status` are ignored. Defaults to `false`.",
+				Optional:            true,
+			},
+			"preflight_checks": schema.BoolAttribute{
+				MarkdownDescription: "When `true`, configuring the provider checks the token's scopes and, when `slug` is set, that the token belongs to that tenant, so a read-only or mismatched token is reported before any resource is touched. This costs up to two extra API requests per run. Defaults to `false`.",
+				Optional:            true,
+			},
+			"verbose_retries": schema.BoolAttribute{
+				MarkdownDescription: "When `true`, a resource operation that only succeeded after retrying Costory API requests adds a warning summarizing the retries, such as `succeeded after 2 retries due to 503`, so backend flakiness is visible without `TF_LOG`. Defaults to `false`.",
 				Optional:            true,
 			},
 		},
 	}
 }
 
+func (p *costoryProvider) ValidateConfig(ctx context.Context, req provider.ValidateConfigRequest, resp *provider.ValidateConfigResponse) {
+	var config costoryProviderModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateTokenConfig(ctx, config)...)
+	resp.Diagnostics.Append(validateEndpointConfig(config)...)
+	resp.Diagnostics.Append(validateSlugConfig(config)...)
+	resp.Diagnostics.Append(validateConnectionPoolConfig(config)...)
+	resp.Diagnostics.Append(validateDatasourceTypeOverrides(ctx, config)...)
+
+	_, diags := retryableStatusCodes(ctx, config)
+	resp.Diagnostics.Append(diags...)
+
+	_, diags = parseOperationTimeout(config.OperationTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	_, diags = parseTimezone(config.Timezone)
+	resp.Diagnostics.Append(diags...)
+
+	_, diags = parseAPIPathPrefix(config.APIPathPrefix)
+	resp.Diagnostics.Append(diags...)
+
+	_, diags = parseIdleConnTimeout(config.IdleConnTimeout)
+	resp.Diagnostics.Append(diags...)
+}
+
 func (p *costoryProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
 	var config costoryProviderModel
 
@@ -81,6 +245,22 @@ func (p *costoryProvider) Configure(ctx context.Context, req provider.ConfigureR
 		)
 	}
 
+	if config.TokenCommand.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("token_command"),
+			"Unknown Costory token command",
+			"The provider cannot create the Costory client because token_command is unknown.",
+		)
+	}
+
+	if config.CredentialsFile.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("credentials_file"),
+			"Unknown Costory credentials file",
+			"The provider cannot create the Costory client because credentials_file is unknown.",
+		)
+	}
+
 	if config.BaseURL.IsUnknown() {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("base_url"),
@@ -89,14 +269,160 @@ func (p *costoryProvider) Configure(ctx context.Context, req provider.ConfigureR
 		)
 	}
 
+	if config.Environment.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("environment"),
+			"Unknown Costory environment",
+			"The provider cannot create the Costory client because the environment is unknown.",
+		)
+	}
+
+	if config.Slug.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("slug"),
+			"Unknown Costory slug",
+			"The provider cannot create the Costory client because the slug is unknown.",
+		)
+	}
+
+	if config.WorkspaceID.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("workspace_id"),
+			"Unknown Costory workspace ID",
+			"The provider cannot create the Costory client because the workspace ID is unknown.",
+		)
+	}
+
+	if config.StrictDrift.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("strict_drift"),
+			"Unknown Costory strict drift setting",
+			"The provider cannot create the Costory client because strict_drift is unknown.",
+		)
+	}
+
+	if config.MaxIdleConns.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_idle_conns"),
+			"Unknown Costory connection pool size",
+			"The provider cannot create the Costory client because max_idle_conns is unknown.",
+		)
+	}
+
+	if config.MaxConnsPerHost.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_conns_per_host"),
+			"Unknown Costory connection pool size",
+			"The provider cannot create the Costory client because max_conns_per_host is unknown.",
+		)
+	}
+
+	if config.IdleConnTimeout.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("idle_conn_timeout"),
+			"Unknown Costory idle connection timeout",
+			"The provider cannot create the Costory client because idle_conn_timeout is unknown.",
+		)
+	}
+
+	if config.DisableKeepAlives.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("disable_keep_alives"),
+			"Unknown Costory keep-alive setting",
+			"The provider cannot create the Costory client because disable_keep_alives is unknown.",
+		)
+	}
+
+	if config.RetryableStatusCodes.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("retryable_status_codes"),
+			"Unknown Costory retryable status codes",
+			"The provider cannot create the Costory client because retryable_status_codes is unknown.",
+		)
+	}
+
+	if config.DatasourceTypeOverrides.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("datasource_type_overrides"),
+			"Unknown Costory datasource type overrides",
+			"The provider cannot create the Costory client because datasource_type_overrides is unknown.",
+		)
+	}
+
+	if config.RequireDeleteConfirmation.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("require_delete_confirmation"),
+			"Unknown Costory delete confirmation setting",
+			"The provider cannot create the Costory client because require_delete_confirmation is unknown.",
+		)
+	}
+
+	if config.FailDeleteOnMissing.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("fail_delete_on_missing"),
+			"Unknown Costory delete behavior",
+			"The provider cannot create the Costory client because fail_delete_on_missing is unknown.",
+		)
+	}
+
+	if config.DefaultDetailedBilling.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("default_detailed_billing"),
+			"Unknown Costory detailed billing default",
+			"The provider cannot create the Costory client because default_detailed_billing is unknown.",
+		)
+	}
+
+	if config.VerboseRetries.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("verbose_retries"),
+			"Unknown Costory retry reporting setting",
+			"The provider cannot create the Costory client because verbose_retries is unknown.",
+		)
+	}
+
+	if config.OperationTimeout.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("operation_timeout"),
+			"Unknown Costory operation timeout",
+			"The provider cannot create the Costory client because operation_timeout is unknown.",
+		)
+	}
+
+	if config.Timezone.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("timezone"),
+			"Unknown Costory time zone",
+			"The provider cannot create the Costory client because timezone is unknown.",
+		)
+	}
+
+	if config.APIPathPrefix.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("api_path_prefix"),
+			"Unknown Costory API path prefix",
+			"The provider cannot create the Costory client because api_path_prefix is unknown.",
+		)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(applyCredentialsFile(&config)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	token := strings.TrimSpace(config.Token.ValueString())
 	baseURL := strings.TrimSpace(config.BaseURL.ValueString())
+	environment := strings.TrimSpace(config.Environment.ValueString())
+	slug := strings.TrimSpace(config.Slug.ValueString())
+	workspaceID := strings.TrimSpace(config.WorkspaceID.ValueString())
+
+	resp.Diagnostics.Append(validateTokenConfig(ctx, config)...)
 
-	if token == "" {
+	if !config.Token.IsNull() && token == "" {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("token"),
 			"Invalid Costory token",
@@ -104,17 +430,81 @@ func (p *costoryProvider) Configure(ctx context.Context, req provider.ConfigureR
 		)
 	}
 
+	if !config.WorkspaceID.IsNull() && workspaceID == "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("workspace_id"),
+			"Invalid Costory workspace ID",
+			"The provider cannot create the Costory client because the workspace ID is empty. Remove the attribute to use the whole tenant.",
+		)
+	}
+
+	resp.Diagnostics.Append(validateSlugConfig(config)...)
+	resp.Diagnostics.Append(validateConnectionPoolConfig(config)...)
+
+	typeOverrides, diags := datasourceTypeOverrides(ctx, config)
+	resp.Diagnostics.Append(diags...)
+
+	operationTimeout, diags := parseOperationTimeout(config.OperationTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	retryableStatuses, diags := retryableStatusCodes(ctx, config)
+	resp.Diagnostics.Append(diags...)
+
+	timezone, diags := parseTimezone(config.Timezone)
+	resp.Diagnostics.Append(diags...)
+
+	apiPathPrefix, diags := parseAPIPathPrefix(config.APIPathPrefix)
+	resp.Diagnostics.Append(diags...)
+
+	idleConnTimeout, diags := parseIdleConnTimeout(config.IdleConnTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	command, diags := tokenCommand(ctx, config)
+	resp.Diagnostics.Append(diags...)
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if baseURL == "" {
-		baseURL = defaultBaseURL
+	baseURL = resolveBaseURL(baseURL, environment)
+
+	httpClient := newHTTPClient(
+		int64OrDefault(config.MaxIdleConns, defaultMaxIdleConns),
+		int64OrDefault(config.MaxConnsPerHost, defaultMaxConnsPerHost),
+		idleConnTimeout,
+		config.DisableKeepAlives.ValueBool(),
+	)
+
+	client := costoryapi.NewClient(baseURL, token, httpClient,
+		costoryapi.WithSlug(slug),
+		costoryapi.WithWorkspaceID(workspaceID),
+		costoryapi.WithStrictDrift(config.StrictDrift.ValueBool()),
+		costoryapi.WithDatasourceTypeOverrides(typeOverrides),
+		costoryapi.WithOperationTimeout(operationTimeout),
+		costoryapi.WithRequireDeleteConfirmation(config.RequireDeleteConfirmation.ValueBool()),
+		costoryapi.WithFailDeleteOnMissing(config.FailDeleteOnMissing.ValueBool()),
+		costoryapi.WithDefaultDetailedBilling(config.DefaultDetailedBilling.ValueBoolPointer()),
+		costoryapi.WithTokenCommand(command),
+		costoryapi.WithRetryableStatusCodes(retryableStatuses...),
+		costoryapi.WithTimezone(timezone),
+		costoryapi.WithAPIPathPrefix(apiPathPrefix),
+		costoryapi.WithVerboseRetries(config.VerboseRetries.ValueBool()),
+		costoryapi.WithProviderVersion(p.version),
+	)
+
+	// Capabilities are not fetched here: the client caches them on the first plan that needs
+	// them, so runs that never plan a new datasource make no extra request.
+	if config.PreflightChecks.ValueBool() {
+		resp.Diagnostics.Append(preflightDiagnostics(ctx, client, slug)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
 	}
 
-	client := costoryapi.NewClient(baseURL, token, &http.Client{
-		Timeout: 45 * time.Second,
-	})
+	// Retain configure-time warnings so the costory_diagnostics data source can report them.
+	for _, warning := range resp.Diagnostics.Warnings() {
+		client.RecordWarning(warning.Summary(), warning.Detail())
+	}
 
 	resp.DataSourceData = client
 	resp.ResourceData = client
@@ -123,6 +513,11 @@ func (p *costoryProvider) Configure(ctx context.Context, req provider.ConfigureR
 func (p *costoryProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewServiceAccountDataSource,
+		NewCapabilitiesDataSource,
+		NewDiagnosticsDataSource,
+		NewProviderConfigDataSource,
+		NewBillingDatasourceSummaryDataSource,
+		NewBillingDatasourcesByIDsDataSource,
 	}
 }
 
@@ -137,5 +532,7 @@ func (p *costoryProvider) Resources(_ context.Context) []func() resource.Resourc
 		metricsdatasource.NewResource,
 		team.NewResource,
 		team.NewMemberResource,
+		allocationrule.NewResource,
+		apitoken.NewResource,
 	}
 }
@@ -0,0 +1,128 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/costory-io/costory-terraform/internal/costoryapi"
+	"github.com/costory-io/costory-terraform/internal/provider/versiondiag"
+)
+
+var (
+	_ datasource.DataSource              = &providerConfigDataSource{}
+	_ datasource.DataSourceWithConfigure = &providerConfigDataSource{}
+)
+
+type providerConfigDataSource struct {
+	client *costoryapi.Client
+}
+
+type providerConfigDataSourceModel struct {
+	BaseURL          types.String `tfsdk:"base_url"`
+	Slug             types.String `tfsdk:"slug"`
+	WorkspaceID      types.String `tfsdk:"workspace_id"`
+	MaxRetries       types.Int64  `tfsdk:"max_retries"`
+	RequestTimeout   types.String `tfsdk:"request_timeout"`
+	OperationTimeout types.String `tfsdk:"operation_timeout"`
+	TokenSet         types.Bool   `tfsdk:"token_set"`
+}
+
+// NewProviderConfigDataSource returns the Costory provider configuration data source.
+func NewProviderConfigDataSource() datasource.DataSource {
+	return &providerConfigDataSource{}
+}
+
+func (d *providerConfigDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_provider_config", req.ProviderTypeName)
+}
+
+func (d *providerConfigDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Returns the non-sensitive provider configuration in effect after environment variables and defaults are resolved. The token is never exposed.",
+		Attributes: map[string]schema.Attribute{
+			"base_url": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Costory API base URL the provider sends requests to.",
+			},
+			"slug": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Costory tenant slug sent with every request. Empty when unset.",
+			},
+			"workspace_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Costory workspace requests are scoped to. Empty when unset.",
+			},
+			"max_retries": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Number of times a transient API failure is retried after the first attempt.",
+			},
+			"request_timeout": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Timeout for a single HTTP request, as a duration. Null when unbounded.",
+			},
+			"operation_timeout": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Timeout for a whole resource operation, as a duration. Null when unbounded.",
+			},
+			"token_set": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether a token or token command is configured. The token value is never exposed.",
+			},
+		},
+	}
+}
+
+func (d *providerConfigDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*costoryapi.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			costoryapi.VersionedSummary("Unexpected data source configure type", req.ProviderData),
+			fmt.Sprintf("Expected *costoryapi.Client, got: %T. This is always a provider implementation bug.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *providerConfigDataSource) Read(ctx context.Context, _ datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.client == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured Costory client",
+			"The provider did not configure the Costory API client for the data source.",
+		)
+		return
+	}
+
+	defer versiondiag.Add(d.client, &resp.Diagnostics)
+
+	config := d.client.EffectiveConfig()
+	state := providerConfigDataSourceModel{
+		BaseURL:          types.StringValue(config.BaseURL),
+		Slug:             types.StringValue(config.Slug),
+		WorkspaceID:      types.StringValue(config.WorkspaceID),
+		MaxRetries:       types.Int64Value(int64(config.MaxRetries)),
+		RequestTimeout:   durationValue(config.RequestTimeout),
+		OperationTimeout: durationValue(config.OperationTimeout),
+		TokenSet:         types.BoolValue(config.TokenSet),
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// durationValue renders a timeout as a duration string, or null when it is unbounded.
+func durationValue(timeout time.Duration) types.String {
+	if timeout <= 0 {
+		return types.StringNull()
+	}
+	return types.StringValue(timeout.String())
+}
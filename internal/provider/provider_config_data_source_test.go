@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+
+	"github.com/costory-io/costory-terraform/internal/costoryapi"
+)
+
+func TestProviderConfigDataSourceReportsResolvedConfig(t *testing.T) {
+	t.Parallel()
+
+	const token = "super-secret-token"
+
+	tests := map[string]struct {
+		client                                       *costoryapi.Client
+		baseURL, slug, workspaceID, operationTimeout string
+	}{
+		"resolved defaults": {
+			client:  costoryapi.NewClient(resolveBaseURL("", ""), token, newHTTPClient(defaultMaxIdleConns, defaultMaxConnsPerHost, defaultIdleConnTimeout, false)),
+			baseURL: defaultBaseURL,
+		},
+		"explicit settings": {
+			client: costoryapi.NewClient("https://costory.example", token, newHTTPClient(defaultMaxIdleConns, defaultMaxConnsPerHost, defaultIdleConnTimeout, false),
+				costoryapi.WithSlug("acme"),
+				costoryapi.WithWorkspaceID("ws-1"),
+				costoryapi.WithOperationTimeout(10*time.Minute),
+			),
+			baseURL:          "https://costory.example",
+			slug:             "acme",
+			workspaceID:      "ws-1",
+			operationTimeout: "10m0s",
+		},
+		"token command": {
+			client: costoryapi.NewClient(defaultBaseURL, "", newHTTPClient(defaultMaxIdleConns, defaultMaxConnsPerHost, defaultIdleConnTimeout, false),
+				costoryapi.WithTokenCommand([]string{"print-token"}),
+			),
+			baseURL: defaultBaseURL,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			dataSource := &providerConfigDataSource{client: tc.client}
+
+			var schemaResp datasource.SchemaResponse
+			dataSource.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+			for attribute := range schemaResp.Schema.Attributes {
+				if attribute == "token" || attribute == "token_command" {
+					t.Fatalf("expected no %s attribute on the provider config data source", attribute)
+				}
+			}
+
+			resp := datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+			dataSource.Read(ctx, datasource.ReadRequest{}, &resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+			if strings.Contains(resp.State.Raw.String(), token) {
+				t.Fatalf("expected the token to never appear in state, got %s", resp.State.Raw)
+			}
+
+			var got providerConfigDataSourceModel
+			resp.Diagnostics.Append(resp.State.Get(ctx, &got)...)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unable to decode state: %v", resp.Diagnostics)
+			}
+
+			if got.BaseURL.ValueString() != tc.baseURL {
+				t.Fatalf("unexpected base_url: got %q, want %q", got.BaseURL.ValueString(), tc.baseURL)
+			}
+			if got.Slug.ValueString() != tc.slug {
+				t.Fatalf("unexpected slug: got %q, want %q", got.Slug.ValueString(), tc.slug)
+			}
+			if got.WorkspaceID.ValueString() != tc.workspaceID {
+				t.Fatalf("unexpected workspace_id: got %q, want %q", got.WorkspaceID.ValueString(), tc.workspaceID)
+			}
+			if got.OperationTimeout.ValueString() != tc.operationTimeout {
+				t.Fatalf("unexpected operation_timeout: got %q, want %q", got.OperationTimeout.ValueString(), tc.operationTimeout)
+			}
+			if got.MaxRetries.ValueInt64() != 3 {
+				t.Fatalf("unexpected max_retries: got %d, want 3", got.MaxRetries.ValueInt64())
+			}
+			if got.RequestTimeout.ValueString() != httpClientTimeout.String() {
+				t.Fatalf("unexpected request_timeout: got %q, want %q", got.RequestTimeout.ValueString(), httpClientTimeout.String())
+			}
+			if !got.TokenSet.ValueBool() {
+				t.Fatal("expected token_set to be true")
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// retryableStatusCodes returns the configured retryable status codes, nil when
+// retryable_status_codes is not set. Codes outside the 4xx and 5xx ranges are rejected.
+// Unknown values are left to Configure.
+func retryableStatusCodes(ctx context.Context, config costoryProviderModel) ([]int, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if config.RetryableStatusCodes.IsNull() || config.RetryableStatusCodes.IsUnknown() {
+		return nil, diags
+	}
+
+	var values []types.Int64
+	diags.Append(config.RetryableStatusCodes.ElementsAs(ctx, &values, false)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	codes := make([]int, 0, len(values))
+	for _, value := range values {
+		if value.IsUnknown() {
+			continue
+		}
+
+		code := value.ValueInt64()
+		if code < 400 || code > 599 {
+			diags.AddAttributeError(
+				path.Root("retryable_status_codes"),
+				"Invalid retryable status code",
+				fmt.Sprintf("retryable_status_codes must only contain 4xx or 5xx status codes, got %d.", code),
+			)
+			continue
+		}
+
+		codes = append(codes, int(code))
+	}
+
+	if len(values) == 0 {
+		diags.AddAttributeError(
+			path.Root("retryable_status_codes"),
+			"Invalid retryable status codes",
+			"retryable_status_codes must not be empty. Remove the attribute to use the default set.",
+		)
+	}
+
+	return codes, diags
+}
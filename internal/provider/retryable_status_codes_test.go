@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestRetryableStatusCodes(t *testing.T) {
+	t.Parallel()
+
+	codes := func(values ...int64) types.Set {
+		elements := make([]attr.Value, 0, len(values))
+		for _, value := range values {
+			elements = append(elements, types.Int64Value(value))
+		}
+		return types.SetValueMust(types.Int64Type, elements)
+	}
+
+	tests := map[string]struct {
+		codes     types.Set
+		want      []int
+		wantError bool
+	}{
+		"null":         {codes: types.SetNull(types.Int64Type)},
+		"unknown":      {codes: types.SetUnknown(types.Int64Type)},
+		"custom":       {codes: codes(418, 503), want: []int{418, 503}},
+		"empty":        {codes: codes(), wantError: true},
+		"success code": {codes: codes(200), wantError: true},
+		"too large":    {codes: codes(503, 600), wantError: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, diags := retryableStatusCodes(context.Background(), costoryProviderModel{RetryableStatusCodes: tc.codes})
+			if diags.HasError() != tc.wantError {
+				t.Fatalf("unexpected error result: got %t, want %t: %v", diags.HasError(), tc.wantError, diags)
+			}
+			if tc.wantError {
+				return
+			}
+
+			slices.Sort(got)
+			if !slices.Equal(got, tc.want) {
+				t.Fatalf("unexpected codes: got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,3 @@
+// Package retrywarning reports resource operations that only succeeded after retrying
+// Costory API requests, along with warnings raised while retrying them.
+package retrywarning
@@ -0,0 +1,53 @@
+package retrywarning
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+
+	"github.com/costory-io/costory-terraform/internal/costoryapi"
+)
+
+// Add appends the warnings raised by the API calls of the operation ctx was derived for, such
+// as a likely maintenance window, to diags. It also adds a warning summarizing the operation's
+// retries, as in "succeeded after 2 retries due to 503", unless the operation failed, since
+// its error already explains the outcome, it made no retries, or the client was not
+// configured with costoryapi.WithVerboseRetries. Call it deferred, right after
+// OperationContext.
+func Add(ctx context.Context, diags *diag.Diagnostics) {
+	for _, warning := range costoryapi.OperationWarnings(ctx) {
+		diags.AddWarning(warning.Summary, warning.Detail)
+	}
+
+	retries := costoryapi.Retries(ctx)
+	if len(retries) == 0 || diags.HasError() {
+		return
+	}
+
+	var statuses []string
+	requests := make([]string, 0, len(retries))
+	for _, retry := range retries {
+		status := strconv.Itoa(retry.StatusCode)
+		if !slices.Contains(statuses, status) {
+			statuses = append(statuses, status)
+		}
+		requests = append(requests, fmt.Sprintf("%s %s: %s", retry.Method, retry.Path, status))
+	}
+
+	noun := "retries"
+	if len(retries) == 1 {
+		noun = "retry"
+	}
+
+	diags.AddWarning(
+		"Costory API request retried",
+		fmt.Sprintf(
+			"The operation succeeded after %d %s due to %s, which may indicate a flaky Costory backend. Retried requests:\n\n%s",
+			len(retries), noun, strings.Join(statuses, ", "), strings.Join(requests, "\n"),
+		),
+	)
+}
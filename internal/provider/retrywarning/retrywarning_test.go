@@ -0,0 +1,113 @@
+package retrywarning
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+
+	"github.com/costory-io/costory-terraform/internal/costoryapi"
+)
+
+func TestAdd(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		verbose     bool
+		priorError  bool
+		wantWarning string
+	}{
+		"verbose":          {verbose: true, wantWarning: "The operation succeeded after 2 retries due to 503, which may indicate a flaky Costory backend."},
+		"not verbose":      {},
+		"failed operation": {verbose: true, priorError: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var attempts atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				if attempts.Add(1) <= 2 {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"id":"team-1","name":"Platform"}`))
+			}))
+			defer server.Close()
+
+			client := costoryapi.NewClient(server.URL, "test-token", server.Client(), costoryapi.WithVerboseRetries(tc.verbose))
+
+			var diags diag.Diagnostics
+			if tc.priorError {
+				diags.AddError("Unable to update team", "boom")
+			}
+
+			func() {
+				ctx, cancel := client.OperationContext(context.Background())
+				defer cancel()
+				defer Add(ctx, &diags)
+
+				if _, err := client.GetTeam(ctx, "team-1"); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}()
+
+			warnings := diags.Warnings()
+			if tc.wantWarning == "" {
+				if len(warnings) != 0 {
+					t.Fatalf("expected no warning, got %v", warnings)
+				}
+				return
+			}
+
+			if len(warnings) != 1 {
+				t.Fatalf("expected one warning, got %v", diags)
+			}
+			detail := warnings[0].Detail()
+			if !strings.HasPrefix(detail, tc.wantWarning) {
+				t.Fatalf("unexpected warning detail: got %q, want prefix %q", detail, tc.wantWarning)
+			}
+			if !strings.Contains(detail, "GET /terraform/teams/team-1: 503") {
+				t.Fatalf("expected the retried request in the warning, got %q", detail)
+			}
+		})
+	}
+}
+
+func TestAddReportsMaintenance(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Retry-After", "600")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := costoryapi.NewClient(server.URL, "test-token", server.Client(), costoryapi.WithOperationTimeout(5*time.Second))
+
+	var diags diag.Diagnostics
+	func() {
+		ctx, cancel := client.OperationContext(context.Background())
+		defer cancel()
+		defer Add(ctx, &diags)
+
+		if _, err := client.GetTeam(ctx, "team-1"); err != nil {
+			diags.AddError("Unable to read team", err.Error())
+		}
+	}()
+
+	warnings := diags.Warnings()
+	if len(warnings) != 1 || warnings[0].Summary() != "Costory API is likely in maintenance" {
+		t.Fatalf("expected a maintenance warning, got %v", diags)
+	}
+	if !diags.HasError() {
+		t.Fatal("expected the operation to fail rather than wait past its timeout")
+	}
+}
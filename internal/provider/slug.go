@@ -0,0 +1,35 @@
+package provider
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// slugPattern restricts slugs to characters that are safe in an HTTP header value as-is, so
+// the X-Costory-Slug header is sent verbatim and never needs encoding.
+var slugPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// validateSlugConfig rejects slugs that cannot be sent verbatim in the X-Costory-Slug header,
+// such as ones containing spaces, control characters or non-ASCII letters. An unset or blank
+// slug is left to the default tenant.
+func validateSlugConfig(config costoryProviderModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if config.Slug.IsNull() || config.Slug.IsUnknown() {
+		return diags
+	}
+
+	slug := strings.TrimSpace(config.Slug.ValueString())
+	if slug != "" && !slugPattern.MatchString(slug) {
+		diags.AddAttributeError(
+			path.Root("slug"),
+			"Invalid Costory slug",
+			"The slug must start with an ASCII letter or digit and contain only ASCII letters, digits, dots, underscores, and dashes, so it can be sent unencoded in the X-Costory-Slug header. Check the slug shown in the Costory app.",
+		)
+	}
+
+	return diags
+}
@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/costory-io/costory-terraform/internal/costoryapi"
+)
+
+func TestValidateSlugConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		slug      types.String
+		wantError bool
+	}{
+		"simple":          {slug: types.StringValue("acme")},
+		"punctuation":     {slug: types.StringValue("acme-corp_eu.1")},
+		"null":            {slug: types.StringNull()},
+		"unknown":         {slug: types.StringUnknown()},
+		"blank":           {slug: types.StringValue(" ")},
+		"surrounding":     {slug: types.StringValue(" acme ")},
+		"space":           {slug: types.StringValue("acme corp"), wantError: true},
+		"unicode":         {slug: types.StringValue("acmé"), wantError: true},
+		"newline":         {slug: types.StringValue("acme\nX-Injected: 1"), wantError: true},
+		"leading dash":    {slug: types.StringValue("-acme"), wantError: true},
+		"percent-encoded": {slug: types.StringValue("acme%20corp"), wantError: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			diags := validateSlugConfig(costoryProviderModel{Slug: tc.slug})
+			if got := diags.HasError(); got != tc.wantError {
+				t.Fatalf("unexpected error result: got %t, want %t: %v", got, tc.wantError, diags)
+			}
+		})
+	}
+}
+
+func TestValidSlugIsSentVerbatim(t *testing.T) {
+	t.Parallel()
+
+	const slug = "acme-corp_eu.1"
+	if diags := validateSlugConfig(costoryProviderModel{Slug: types.StringValue(slug)}); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	received := make(chan []string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- r.Header.Values("X-Costory-Slug")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"service_account":"sa-test","sub_ids":[]}`))
+	}))
+	defer server.Close()
+
+	client := costoryapi.NewClient(server.URL, "test-token", server.Client(), costoryapi.WithSlug(slug))
+	if _, err := client.GetServiceAccount(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := <-received; len(got) != 1 || got[0] != slug {
+		t.Fatalf("unexpected slug header: got %q, want [%q]", got, slug)
+	}
+}
@@ -0,0 +1,2 @@
+// Package statediff logs the attributes a resource operation changed in Terraform state.
+package statediff
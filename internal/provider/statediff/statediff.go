@@ -0,0 +1,68 @@
+package statediff
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const sensitiveValue = "(sensitive)"
+
+// Log writes a debug entry listing the top-level attributes whose value differs between
+// prior and current state, with both values, to show where the server normalizes input.
+// Sensitive attribute values are masked. Nothing is logged when either state is null or no
+// attribute changed.
+func Log(ctx context.Context, operation string, prior, current tfsdk.State) {
+	if prior.Raw.IsNull() || current.Raw.IsNull() {
+		return
+	}
+
+	changes := make(map[string]any)
+	for name, attribute := range current.Schema.GetAttributes() {
+		attributePath := tftypes.NewAttributePath().WithAttributeName(name)
+		priorValue, ok := attributeValue(prior.Raw, attributePath)
+		if !ok {
+			continue
+		}
+		currentValue, ok := attributeValue(current.Raw, attributePath)
+		if !ok || priorValue.Equal(currentValue) {
+			continue
+		}
+
+		change := map[string]string{"prior": sensitiveValue, "current": sensitiveValue}
+		if !attribute.IsSensitive() {
+			change["prior"] = priorValue.String()
+			change["current"] = currentValue.String()
+		}
+		changes[name] = change
+	}
+
+	if len(changes) == 0 {
+		return
+	}
+
+	names := make([]string, 0, len(changes))
+	for name := range changes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	tflog.Debug(ctx, "Costory resource state changed", map[string]any{
+		"operation":          operation,
+		"changed_attributes": names,
+		"changes":            changes,
+	})
+}
+
+func attributeValue(raw tftypes.Value, attributePath *tftypes.AttributePath) (tftypes.Value, bool) {
+	value, _, err := tftypes.WalkAttributePath(raw, attributePath)
+	if err != nil {
+		return tftypes.Value{}, false
+	}
+
+	typed, ok := value.(tftypes.Value)
+	return typed, ok
+}
@@ -0,0 +1,103 @@
+package statediff
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+type testModel struct {
+	Name   types.String `tfsdk:"name"`
+	Status types.String `tfsdk:"status"`
+	Token  types.String `tfsdk:"token"`
+}
+
+func testState(t *testing.T, model testModel) tfsdk.State {
+	t.Helper()
+
+	state := tfsdk.State{Schema: schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"name":   schema.StringAttribute{Required: true},
+			"status": schema.StringAttribute{Computed: true},
+			"token":  schema.StringAttribute{Computed: true, Sensitive: true},
+		},
+	}}
+	if diags := state.Set(context.Background(), &model); diags.HasError() {
+		t.Fatalf("unable to build state: %v", diags)
+	}
+
+	return state
+}
+
+func TestLog(t *testing.T) {
+	t.Parallel()
+
+	prior := testState(t, testModel{Name: types.StringValue("ds"), Status: types.StringValue("PENDING"), Token: types.StringValue("old-secret")})
+
+	tests := map[string]struct {
+		current     testModel
+		wantChanges map[string]any
+	}{
+		"unchanged": {
+			current: testModel{Name: types.StringValue("ds"), Status: types.StringValue("PENDING"), Token: types.StringValue("old-secret")},
+		},
+		"status and sensitive token": {
+			current: testModel{Name: types.StringValue("ds"), Status: types.StringValue("ACTIVE"), Token: types.StringValue("new-secret")},
+			wantChanges: map[string]any{
+				"status": map[string]any{"prior": `tftypes.String<"PENDING">`, "current": `tftypes.String<"ACTIVE">`},
+				"token":  map[string]any{"prior": sensitiveValue, "current": sensitiveValue},
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var output bytes.Buffer
+			ctx := tflogtest.RootLogger(context.Background(), &output)
+
+			Log(ctx, "read", prior, testState(t, tc.current))
+			raw := output.String()
+
+			entries, err := tflogtest.MultilineJSONDecode(&output)
+			if err != nil {
+				t.Fatalf("unable to decode log output: %v", err)
+			}
+			if tc.wantChanges == nil {
+				if len(entries) != 0 {
+					t.Fatalf("expected no log entries, got %v", entries)
+				}
+				return
+			}
+
+			if len(entries) != 1 {
+				t.Fatalf("unexpected log entries: got %d, want 1: %v", len(entries), entries)
+			}
+			entry := entries[0]
+			if entry["@level"] != "debug" || entry["operation"] != "read" {
+				t.Fatalf("unexpected log entry: %v", entry)
+			}
+			if strings.Contains(raw, "secret") {
+				t.Fatalf("sensitive value leaked into logs: %s", raw)
+			}
+
+			changes, ok := entry["changes"].(map[string]any)
+			if !ok || len(changes) != len(tc.wantChanges) {
+				t.Fatalf("unexpected changes: %v", entry["changes"])
+			}
+			for attribute, want := range tc.wantChanges {
+				got, ok := changes[attribute].(map[string]any)
+				if !ok || got["prior"] != want.(map[string]any)["prior"] || got["current"] != want.(map[string]any)["current"] {
+					t.Fatalf("unexpected %s change: got %v, want %v", attribute, changes[attribute], want)
+				}
+			}
+		})
+	}
+}
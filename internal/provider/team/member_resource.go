@@ -14,6 +14,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/costory-io/costory-terraform/internal/costoryapi"
+	"github.com/costory-io/costory-terraform/internal/provider/retrywarning"
+	"github.com/costory-io/costory-terraform/internal/provider/statediff"
+	"github.com/costory-io/costory-terraform/internal/provider/versiondiag"
 )
 
 var (
@@ -27,11 +30,12 @@ type teamMemberResource struct {
 }
 
 type teamMemberResourceModel struct {
-	ID     types.String `tfsdk:"id"`
-	TeamID types.String `tfsdk:"team_id"`
-	UserID types.String `tfsdk:"user_id"`
-	Email  types.String `tfsdk:"email"`
-	Role   types.String `tfsdk:"role"`
+	ID       types.String `tfsdk:"id"`
+	ImportID types.String `tfsdk:"import_id"`
+	TeamID   types.String `tfsdk:"team_id"`
+	UserID   types.String `tfsdk:"user_id"`
+	Email    types.String `tfsdk:"email"`
+	Role     types.String `tfsdk:"role"`
 }
 
 // NewMemberResource returns the team member resource.
@@ -51,6 +55,10 @@ func (r *teamMemberResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				Computed:            true,
 				MarkdownDescription: "Team member resource ID.",
 			},
+			"import_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Value to pass to `terraform import` for this membership, in the form `<team_id>:<user_id>`.",
+			},
 			"team_id": schema.StringAttribute{
 				Required:            true,
 				MarkdownDescription: "Team ID to manage membership for.",
@@ -93,7 +101,7 @@ func (r *teamMemberResource) Configure(_ context.Context, req resource.Configure
 	client, ok := req.ProviderData.(*costoryapi.Client)
 	if !ok {
 		resp.Diagnostics.AddError(
-			"Unexpected resource configure type",
+			costoryapi.VersionedSummary("Unexpected resource configure type", req.ProviderData),
 			fmt.Sprintf("Expected *costoryapi.Client, got: %T. This is always a provider implementation bug.", req.ProviderData),
 		)
 		return
@@ -111,6 +119,11 @@ func (r *teamMemberResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 
+	ctx, cancel := r.client.OperationContext(ctx)
+	defer cancel()
+	defer retrywarning.Add(ctx, &resp.Diagnostics)
+	defer versiondiag.Add(r.client, &resp.Diagnostics)
+
 	var plan teamMemberResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -147,6 +160,7 @@ func (r *teamMemberResource) Create(ctx context.Context, req resource.CreateRequ
 	}
 
 	plan.ID = types.StringValue(formatTeamMemberID(teamID, memberKey))
+	plan.ImportID = plan.ID
 	if plan.Role.IsNull() || plan.Role.IsUnknown() {
 		plan.Role = types.StringValue("MEMBER")
 	}
@@ -161,13 +175,15 @@ func (r *teamMemberResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
+	state.ImportID = state.ID
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	statediff.Log(ctx, "read", req.State, resp.State)
 }
 
 func (r *teamMemberResource) Update(_ context.Context, _ resource.UpdateRequest, resp *resource.UpdateResponse) {
 	resp.Diagnostics.AddError(
 		"Update not supported",
-		"All attributes are immutable for costory_team_member. Terraform should replace the resource instead.",
+		"All attributes of a team member are immutable. Terraform should replace the resource instead.",
 	)
 }
 
@@ -180,6 +196,11 @@ func (r *teamMemberResource) Delete(ctx context.Context, req resource.DeleteRequ
 		return
 	}
 
+	ctx, cancel := r.client.OperationContext(ctx)
+	defer cancel()
+	defer retrywarning.Add(ctx, &resp.Diagnostics)
+	defer versiondiag.Add(r.client, &resp.Diagnostics)
+
 	var state teamMemberResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -187,15 +208,14 @@ func (r *teamMemberResource) Delete(ctx context.Context, req resource.DeleteRequ
 	}
 
 	if state.UserID.IsNull() || state.UserID.IsUnknown() {
-		resp.Diagnostics.AddWarning(
-			"Unable to remove team member without user_id",
-			"The Costory API requires a user_id to remove a team member. The resource will be removed from state, but the invitation may remain until manually removed.",
-		)
+		summary, detail := "Unable to remove team member without user_id", "The Costory API requires a user_id to remove a team member. The resource will be removed from state, but the invitation may remain until manually removed."
+		resp.Diagnostics.AddWarning(summary, detail)
+		r.client.RecordWarning(summary, detail)
 		return
 	}
 
 	err := r.client.RemoveTeamMember(ctx, state.TeamID.ValueString(), state.UserID.ValueString())
-	if err != nil && !errors.Is(err, costoryapi.ErrNotFound) {
+	if err != nil && (!errors.Is(err, costoryapi.ErrNotFound) || r.client.FailDeleteOnMissing()) {
 		resp.Diagnostics.AddError(
 			"Unable to remove team member",
 			err.Error(),
@@ -13,6 +13,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/costory-io/costory-terraform/internal/costoryapi"
+	"github.com/costory-io/costory-terraform/internal/provider/retrywarning"
+	"github.com/costory-io/costory-terraform/internal/provider/statediff"
+	"github.com/costory-io/costory-terraform/internal/provider/versiondiag"
 )
 
 var (
@@ -27,6 +30,7 @@ type teamResource struct {
 
 type teamResourceModel struct {
 	ID          types.String `tfsdk:"id"`
+	ImportID    types.String `tfsdk:"import_id"`
 	Name        types.String `tfsdk:"name"`
 	Description types.String `tfsdk:"description"`
 	Visibility  types.String `tfsdk:"visibility"`
@@ -51,6 +55,10 @@ func (r *teamResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				Computed:            true,
 				MarkdownDescription: "Team ID returned by Costory.",
 			},
+			"import_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Value to pass to `terraform import` for this team. Always the team ID.",
+			},
 			"name": schema.StringAttribute{
 				Required:            true,
 				MarkdownDescription: "Team display name.",
@@ -73,11 +81,11 @@ func (r *teamResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 			},
 			"created_at": schema.StringAttribute{
 				Computed:            true,
-				MarkdownDescription: "Team creation timestamp.",
+				MarkdownDescription: "Team creation timestamp (RFC3339).",
 			},
 			"updated_at": schema.StringAttribute{
 				Computed:            true,
-				MarkdownDescription: "Team last update timestamp.",
+				MarkdownDescription: "Team last update timestamp (RFC3339).",
 			},
 		},
 	}
@@ -91,7 +99,7 @@ func (r *teamResource) Configure(_ context.Context, req resource.ConfigureReques
 	client, ok := req.ProviderData.(*costoryapi.Client)
 	if !ok {
 		resp.Diagnostics.AddError(
-			"Unexpected resource configure type",
+			costoryapi.VersionedSummary("Unexpected resource configure type", req.ProviderData),
 			fmt.Sprintf("Expected *costoryapi.Client, got: %T. This is always a provider implementation bug.", req.ProviderData),
 		)
 		return
@@ -109,13 +117,19 @@ func (r *teamResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
+	ctx, cancel := r.client.OperationContext(ctx)
+	defer cancel()
+	defer retrywarning.Add(ctx, &resp.Diagnostics)
+	defer versiondiag.Add(r.client, &resp.Diagnostics)
+
 	var plan teamResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	created, err := r.client.CreateTeam(ctx, plan.toCreateRequest())
+	createCtx := costoryapi.WithIdempotencyKey(ctx, costoryapi.NewIdempotencyKey())
+	created, err := r.client.CreateTeam(createCtx, plan.toCreateRequest())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to create team",
@@ -138,6 +152,11 @@ func (r *teamResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
+	ctx, cancel := r.client.OperationContext(ctx)
+	defer cancel()
+	defer retrywarning.Add(ctx, &resp.Diagnostics)
+	defer versiondiag.Add(r.client, &resp.Diagnostics)
+
 	var state teamResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -161,6 +180,7 @@ func (r *teamResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	state.mergeAPIResponse(current)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	statediff.Log(ctx, "read", req.State, resp.State)
 }
 
 func (r *teamResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -172,6 +192,11 @@ func (r *teamResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
+	ctx, cancel := r.client.OperationContext(ctx)
+	defer cancel()
+	defer retrywarning.Add(ctx, &resp.Diagnostics)
+	defer versiondiag.Add(r.client, &resp.Diagnostics)
+
 	var plan teamResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -195,6 +220,7 @@ func (r *teamResource) Update(ctx context.Context, req resource.UpdateRequest, r
 
 	plan.mergeAPIResponse(updated)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	statediff.Log(ctx, "update", req.State, resp.State)
 }
 
 func (r *teamResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -206,6 +232,11 @@ func (r *teamResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
+	ctx, cancel := r.client.OperationContext(ctx)
+	defer cancel()
+	defer retrywarning.Add(ctx, &resp.Diagnostics)
+	defer versiondiag.Add(r.client, &resp.Diagnostics)
+
 	var state teamResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -213,7 +244,7 @@ func (r *teamResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 	}
 
 	err := r.client.DeleteTeam(ctx, state.ID.ValueString())
-	if err != nil && !errors.Is(err, costoryapi.ErrNotFound) {
+	if err != nil && (!errors.Is(err, costoryapi.ErrNotFound) || r.client.FailDeleteOnMissing()) {
 		resp.Diagnostics.AddError(
 			"Unable to delete team",
 			err.Error(),
@@ -283,4 +314,5 @@ func (m *teamResourceModel) mergeAPIResponse(apiResponse *costoryapi.Team) {
 	m.Visibility = types.StringValue(apiResponse.Visibility)
 	m.CreatedAt = types.StringValue(apiResponse.CreatedAt)
 	m.UpdatedAt = types.StringValue(apiResponse.UpdatedAt)
+	m.ImportID = m.ID
 }
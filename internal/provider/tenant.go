@@ -0,0 +1,39 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+
+	"github.com/costory-io/costory-terraform/internal/costoryapi"
+)
+
+// tenantDiagnostics reports a token that belongs to a different tenant than the configured
+// slug, which otherwise surfaces as confusing 403 or 404 errors on the first API call. The
+// check only runs when a slug is configured, and deployments that do not report a tenant
+// for the service account, or failures to reach the endpoint, are tolerated.
+func tenantDiagnostics(ctx context.Context, client *costoryapi.Client, slug string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if client == nil || slug == "" {
+		return diags
+	}
+
+	account, err := client.GetServiceAccount(ctx)
+	if err != nil || account == nil || account.Tenant == "" {
+		return diags
+	}
+
+	if !strings.EqualFold(account.Tenant, slug) {
+		diags.AddAttributeError(
+			path.Root("slug"),
+			"Costory token and slug belong to different tenants",
+			fmt.Sprintf("The configured Costory token belongs to tenant %q, but the provider slug is %q. Use a token issued for tenant %q or set slug to %q.", account.Tenant, slug, slug, account.Tenant),
+		)
+	}
+
+	return diags
+}
@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/costory-io/costory-terraform/internal/costoryapi"
+)
+
+func TestTenantDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		slug      string
+		status    int
+		body      string
+		wantError bool
+	}{
+		"matching tenant":    {slug: "acme", status: http.StatusOK, body: `{"service_account":"sa","tenant":"acme"}`},
+		"case-only mismatch": {slug: "Acme", status: http.StatusOK, body: `{"service_account":"sa","tenant":"acme"}`},
+		"different tenant":   {slug: "acme", status: http.StatusOK, body: `{"service_account":"sa","tenantSlug":"globex"}`, wantError: true},
+		"tenant absent":      {slug: "acme", status: http.StatusOK, body: `{"service_account":"sa"}`},
+		"no slug":            {slug: "", status: http.StatusOK, body: `{"service_account":"sa","tenant":"globex"}`},
+		"endpoint failure":   {slug: "acme", status: http.StatusForbidden, body: `{}`},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tc.status)
+				_, _ = w.Write([]byte(tc.body))
+			}))
+			defer server.Close()
+
+			client := costoryapi.NewClient(server.URL, "test-token", server.Client(), costoryapi.WithSlug(tc.slug))
+
+			diags := tenantDiagnostics(context.Background(), client, tc.slug)
+			if diags.HasError() != tc.wantError {
+				t.Fatalf("unexpected error result: got %t, want %t: %v", diags.HasError(), tc.wantError, diags)
+			}
+			if !tc.wantError {
+				return
+			}
+
+			got := diags.Errors()[0]
+			if got.Summary() != "Costory token and slug belong to different tenants" {
+				t.Fatalf("unexpected summary: got %q", got.Summary())
+			}
+			if !strings.Contains(got.Detail(), `tenant "globex"`) || !strings.Contains(got.Detail(), `slug is "acme"`) {
+				t.Fatalf("expected detail to name both tenants, got %q", got.Detail())
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	// Embed the time zone database so timezone resolves the same way on hosts without one.
+	_ "time/tzdata"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// parseTimezone parses timezone as an IANA time zone name such as "Europe/Paris". A null or
+// unknown value yields nil, which keeps dates in UTC.
+func parseTimezone(value types.String) (*time.Location, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if value.IsNull() || value.IsUnknown() {
+		return nil, diags
+	}
+
+	name := strings.TrimSpace(value.ValueString())
+	loc, err := time.LoadLocation(name)
+	// LoadLocation also accepts "" and "Local", which are not IANA names and would make the
+	// result depend on the machine running Terraform.
+	if err != nil || name == "" || name == "Local" {
+		diags.AddAttributeError(
+			path.Root("timezone"),
+			"Invalid Costory time zone",
+			fmt.Sprintf("timezone must be an IANA time zone name such as \"America/Los_Angeles\" or \"UTC\", got %q.", value.ValueString()),
+		)
+		return nil, diags
+	}
+
+	return loc, diags
+}
@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/costory-io/costory-terraform/internal/costoryapi"
+)
+
+func TestParseTimezone(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		value     types.String
+		want      string
+		wantError bool
+	}{
+		"unset":        {value: types.StringNull(), want: "UTC"},
+		"unknown":      {value: types.StringUnknown(), want: "UTC"},
+		"utc":          {value: types.StringValue("UTC"), want: "UTC"},
+		"los angeles":  {value: types.StringValue("America/Los_Angeles"), want: "America/Los_Angeles"},
+		"surrounding":  {value: types.StringValue(" Europe/Paris "), want: "Europe/Paris"},
+		"unknown zone": {value: types.StringValue("Mars/Olympus_Mons"), wantError: true},
+		"offset":       {value: types.StringValue("+02:00"), wantError: true},
+		"local":        {value: types.StringValue("Local"), wantError: true},
+		"empty":        {value: types.StringValue(""), wantError: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			loc, diags := parseTimezone(tc.value)
+			if diags.HasError() != tc.wantError {
+				t.Fatalf("unexpected error result: got %t, want %t: %v", diags.HasError(), tc.wantError, diags)
+			}
+			if tc.wantError {
+				return
+			}
+
+			client := costoryapi.NewClient("https://example.invalid", "test-token", nil, costoryapi.WithTimezone(loc))
+			if got := client.Now().Location().String(); got != tc.want {
+				t.Fatalf("unexpected client time zone: got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
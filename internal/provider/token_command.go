@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// validateTokenConfig checks that exactly one of token and token_command is set, and that
+// token_command names an executable. Unknown values are checked again at configure time, and
+// a missing token is tolerated until then when credentials_file may provide it.
+func validateTokenConfig(ctx context.Context, config costoryProviderModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if config.Token.IsUnknown() || config.TokenCommand.IsUnknown() {
+		return diags
+	}
+
+	switch {
+	case !config.Token.IsNull() && !config.TokenCommand.IsNull():
+		diags.AddAttributeError(
+			path.Root("token_command"),
+			"Conflicting Costory credentials",
+			"Only one of token and token_command can be set. Remove token to obtain it from the command.",
+		)
+		return diags
+	case config.Token.IsNull() && config.TokenCommand.IsNull() && config.CredentialsFile.IsNull():
+		diags.AddAttributeError(
+			path.Root("token"),
+			"Missing Costory token",
+			"The provider cannot create the Costory client without credentials. Set token, token_command to obtain it from an external command, or credentials_file to read it from a file.",
+		)
+		return diags
+	case config.TokenCommand.IsNull():
+		return diags
+	}
+
+	var command []types.String
+	diags.Append(config.TokenCommand.ElementsAs(ctx, &command, false)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	if len(command) == 0 || (!command[0].IsUnknown() && strings.TrimSpace(command[0].ValueString()) == "") {
+		diags.AddAttributeError(
+			path.Root("token_command"),
+			"Invalid Costory token command",
+			"token_command must start with the executable to run, followed by its arguments.",
+		)
+	}
+
+	return diags
+}
+
+// tokenCommand returns the configured token command, nil when token_command is not set.
+func tokenCommand(ctx context.Context, config costoryProviderModel) ([]string, diag.Diagnostics) {
+	if config.TokenCommand.IsNull() {
+		return nil, nil
+	}
+
+	var command []string
+	diags := config.TokenCommand.ElementsAs(ctx, &command, false)
+	return command, diags
+}
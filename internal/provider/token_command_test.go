@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestValidateTokenConfig(t *testing.T) {
+	t.Parallel()
+
+	command := func(values ...attr.Value) types.List {
+		return types.ListValueMust(types.StringType, values)
+	}
+
+	tests := map[string]struct {
+		token           types.String
+		command         types.List
+		credentialsFile types.String
+		wantError       bool
+	}{
+		"token": {
+			token:   types.StringValue("test-token"),
+			command: types.ListNull(types.StringType),
+		},
+		"command": {
+			token:   types.StringNull(),
+			command: command(types.StringValue("costory-sso"), types.StringValue("token")),
+		},
+		"unknown executable": {
+			token:   types.StringNull(),
+			command: command(types.StringUnknown()),
+		},
+		"unknown command": {
+			token:   types.StringNull(),
+			command: types.ListUnknown(types.StringType),
+		},
+		"both": {
+			token:     types.StringValue("test-token"),
+			command:   command(types.StringValue("costory-sso")),
+			wantError: true,
+		},
+		"neither": {
+			token:     types.StringNull(),
+			command:   types.ListNull(types.StringType),
+			wantError: true,
+		},
+		"credentials file": {
+			token:           types.StringNull(),
+			command:         types.ListNull(types.StringType),
+			credentialsFile: types.StringValue("credentials.json"),
+		},
+		"empty command": {
+			token:     types.StringNull(),
+			command:   command(),
+			wantError: true,
+		},
+		"blank executable": {
+			token:     types.StringNull(),
+			command:   command(types.StringValue(" "), types.StringValue("token")),
+			wantError: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			diags := validateTokenConfig(context.Background(), costoryProviderModel{
+				Token:           tc.token,
+				TokenCommand:    tc.command,
+				CredentialsFile: tc.credentialsFile,
+			})
+
+			if got := diags.HasError(); got != tc.wantError {
+				t.Fatalf("unexpected error result: got %t, want %t: %v", got, tc.wantError, diags)
+			}
+		})
+	}
+}
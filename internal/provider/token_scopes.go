@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+
+	"github.com/costory-io/costory-terraform/internal/costoryapi"
+)
+
+// tokenScopeDiagnostics reports tokens that cannot manage resources. The provider cannot see
+// which resources the configuration declares, so a read-only token only warns (it is enough
+// for data sources and plans) while a token with neither read nor write scope is an error.
+// Scopes the provider does not recognize may be newer grants that still allow access, so a
+// token carrying only those warns instead of failing.
+func tokenScopeDiagnostics(scopes *costoryapi.TokenScopes) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if scopes == nil || !scopes.Known || scopes.Has(costoryapi.TokenScopeWrite) {
+		return diags
+	}
+
+	if scopes.Has(costoryapi.TokenScopeRead) {
+		diags.AddWarning(
+			"Costory token lacks write scope",
+			"The configured Costory token is read-only. Data sources will work, but creating, updating or deleting Costory resources will fail with a permission error. Use a token with write scope to manage resources.",
+		)
+		return diags
+	}
+
+	if len(scopes.Scopes) > 0 {
+		diags.AddWarning(
+			"Unrecognized Costory token scopes",
+			fmt.Sprintf("The configured Costory token has scopes %s, but neither read nor write scope. The provider does not recognize these scopes, so API calls may be rejected. Use a token with write scope to manage resources.", strings.Join(scopes.Scopes, ", ")),
+		)
+		return diags
+	}
+
+	diags.AddError(
+		"Insufficient Costory token scope",
+		"The configured Costory token has neither read nor write scope, so every API call will be rejected. Create a token with write scope in Costory.",
+	)
+
+	return diags
+}
+
+// preflightDiagnostics checks the token's scopes and tenant, so a read-only token or one
+// issued for another tenant is reported at configure time rather than as an opaque 403 on
+// the first API call. Deployments without the introspection endpoint, or failures to reach
+// it, are tolerated silently.
+func preflightDiagnostics(ctx context.Context, client *costoryapi.Client, slug string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if scopes, err := client.GetTokenScopes(ctx); err == nil {
+		diags.Append(tokenScopeDiagnostics(scopes)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	diags.Append(tenantDiagnostics(ctx, client, slug)...)
+	return diags
+}
@@ -0,0 +1,91 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/costory-io/costory-terraform/internal/costoryapi"
+)
+
+func TestTokenScopeDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		scopes      *costoryapi.TokenScopes
+		wantWarning bool
+		wantError   bool
+	}{
+		"full scope":      {scopes: &costoryapi.TokenScopes{Known: true, Scopes: []string{"read", "write"}}},
+		"write only":      {scopes: &costoryapi.TokenScopes{Known: true, Scopes: []string{"write"}}},
+		"read only":       {scopes: &costoryapi.TokenScopes{Known: true, Scopes: []string{"read"}}, wantWarning: true},
+		"no scopes":       {scopes: &costoryapi.TokenScopes{Known: true, Scopes: []string{}}, wantError: true},
+		"unknown scopes":  {scopes: &costoryapi.TokenScopes{Known: true, Scopes: []string{"billing:admin"}}, wantWarning: true},
+		"write and other": {scopes: &costoryapi.TokenScopes{Known: true, Scopes: []string{"write", "billing:admin"}}},
+		"endpoint absent": {scopes: &costoryapi.TokenScopes{Scopes: []string{}}},
+		"not fetched":     {scopes: nil},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			diags := tokenScopeDiagnostics(tc.scopes)
+			if diags.HasError() != tc.wantError {
+				t.Fatalf("unexpected error result: got %t, want %t: %v", diags.HasError(), tc.wantError, diags)
+			}
+			if gotWarning := diags.WarningsCount() > 0; gotWarning != tc.wantWarning {
+				t.Fatalf("unexpected warning result: got %t, want %t: %v", gotWarning, tc.wantWarning, diags)
+			}
+		})
+	}
+}
+
+func TestPreflightDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		scopes          string
+		tenant          string
+		wantWarnings    int
+		wantErrors      int
+		wantTenantCalls int32
+	}{
+		"write token of the tenant": {scopes: `{"scopes":["write"]}`, tenant: "acme", wantTenantCalls: 1},
+		"read-only token":           {scopes: `{"scopes":["read"]}`, tenant: "acme", wantWarnings: 1, wantTenantCalls: 1},
+		"other tenant":              {scopes: `{"scopes":["write"]}`, tenant: "globex", wantErrors: 1, wantTenantCalls: 1},
+		"scopeless token stops":     {scopes: `{"scopes":[]}`, tenant: "globex", wantErrors: 1},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var tenantCalls atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/terraform/token/scopes" {
+					_, _ = w.Write([]byte(tc.scopes))
+					return
+				}
+				tenantCalls.Add(1)
+				_, _ = w.Write([]byte(`{"service_account":"sa","tenant":"` + tc.tenant + `"}`))
+			}))
+			defer server.Close()
+
+			client := costoryapi.NewClient(server.URL, "test-token", server.Client(), costoryapi.WithSlug("acme"))
+
+			diags := preflightDiagnostics(context.Background(), client, "acme")
+			if got := len(diags.Warnings()); got != tc.wantWarnings {
+				t.Fatalf("unexpected warnings: got %d, want %d: %v", got, tc.wantWarnings, diags)
+			}
+			if got := len(diags.Errors()); got != tc.wantErrors {
+				t.Fatalf("unexpected errors: got %d, want %d: %v", got, tc.wantErrors, diags)
+			}
+			if got := tenantCalls.Load(); got != tc.wantTenantCalls {
+				t.Fatalf("unexpected tenant lookups: got %d, want %d", got, tc.wantTenantCalls)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	httpClientTimeout = 45 * time.Second
+
+	defaultMaxIdleConns    = 100
+	defaultMaxConnsPerHost = 16
+
+	// defaultIdleConnTimeout matches http.DefaultTransport.
+	defaultIdleConnTimeout = 90 * time.Second
+)
+
+// validateConnectionPoolConfig rejects non-positive connection pool sizes. Unknown values
+// are left to Configure.
+func validateConnectionPoolConfig(config costoryProviderModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for name, value := range map[string]types.Int64{
+		"max_idle_conns":     config.MaxIdleConns,
+		"max_conns_per_host": config.MaxConnsPerHost,
+	} {
+		if value.IsNull() || value.IsUnknown() || value.ValueInt64() > 0 {
+			continue
+		}
+
+		diags.AddAttributeError(
+			path.Root(name),
+			"Invalid connection pool size",
+			fmt.Sprintf("%s must be a positive integer, got %d.", name, value.ValueInt64()),
+		)
+	}
+
+	return diags
+}
+
+// parseIdleConnTimeout parses idle_conn_timeout as a Go duration. A null or unknown value
+// yields defaultIdleConnTimeout.
+func parseIdleConnTimeout(value types.String) (time.Duration, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if value.IsNull() || value.IsUnknown() {
+		return defaultIdleConnTimeout, diags
+	}
+
+	timeout, err := time.ParseDuration(strings.TrimSpace(value.ValueString()))
+	if err != nil || timeout <= 0 {
+		diags.AddAttributeError(
+			path.Root("idle_conn_timeout"),
+			"Invalid Costory idle connection timeout",
+			fmt.Sprintf("idle_conn_timeout must be a positive duration such as \"30s\" or \"2m\", got %q.", value.ValueString()),
+		)
+		return 0, diags
+	}
+
+	return timeout, diags
+}
+
+// newHTTPClient builds the HTTP client used by the Costory API client. The transport is a
+// clone of http.DefaultTransport with the pool sized from the provider configuration, so
+// proxy settings and dial timeouts keep their defaults. Idle connections per host are
+// capped at max_conns_per_host so concurrent requests reuse connections rather than
+// reopening them once the default of 2 idle connections is exceeded. Idle connections are
+// closed after idleConnTimeout, which should stay below the idle timeout of any load
+// balancer in front of the API so the provider never reuses a connection it already reset.
+func newHTTPClient(maxIdleConns, maxConnsPerHost int, idleConnTimeout time.Duration, disableKeepAlives bool) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConns = maxIdleConns
+	transport.MaxConnsPerHost = maxConnsPerHost
+	transport.MaxIdleConnsPerHost = min(maxIdleConns, maxConnsPerHost)
+	transport.IdleConnTimeout = idleConnTimeout
+	transport.DisableKeepAlives = disableKeepAlives
+
+	return &http.Client{
+		Timeout:   httpClientTimeout,
+		Transport: transport,
+	}
+}
+
+// int64OrDefault returns the configured value, or fallback when the attribute is not set.
+func int64OrDefault(value types.Int64, fallback int) int {
+	if value.IsNull() || value.IsUnknown() {
+		return fallback
+	}
+
+	return int(value.ValueInt64())
+}
@@ -0,0 +1,147 @@
+package provider
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestNewHTTPClientTransport(t *testing.T) {
+	t.Parallel()
+
+	client := newHTTPClient(
+		int64OrDefault(types.Int64Value(32), defaultMaxIdleConns),
+		int64OrDefault(types.Int64Value(8), defaultMaxConnsPerHost),
+		defaultIdleConnTimeout,
+		false,
+	)
+
+	transport, ok := client.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("unexpected transport type: %T", client.Transport)
+	}
+	if transport == http.DefaultTransport {
+		t.Fatal("expected a dedicated transport, got http.DefaultTransport")
+	}
+
+	if transport.MaxIdleConns != 32 {
+		t.Fatalf("unexpected MaxIdleConns: got %d, want 32", transport.MaxIdleConns)
+	}
+	if transport.MaxConnsPerHost != 8 {
+		t.Fatalf("unexpected MaxConnsPerHost: got %d, want 8", transport.MaxConnsPerHost)
+	}
+	if transport.MaxIdleConnsPerHost != 8 {
+		t.Fatalf("unexpected MaxIdleConnsPerHost: got %d, want 8", transport.MaxIdleConnsPerHost)
+	}
+	if client.Timeout != httpClientTimeout {
+		t.Fatalf("unexpected timeout: got %s, want %s", client.Timeout, httpClientTimeout)
+	}
+}
+
+func TestNewHTTPClientDefaults(t *testing.T) {
+	t.Parallel()
+
+	client := newHTTPClient(
+		int64OrDefault(types.Int64Null(), defaultMaxIdleConns),
+		int64OrDefault(types.Int64Null(), defaultMaxConnsPerHost),
+		defaultIdleConnTimeout,
+		false,
+	)
+
+	transport := client.Transport.(*http.Transport)
+	if transport.MaxIdleConns != defaultMaxIdleConns || transport.MaxConnsPerHost != defaultMaxConnsPerHost {
+		t.Fatalf("unexpected default pool sizes: idle %d, per host %d", transport.MaxIdleConns, transport.MaxConnsPerHost)
+	}
+	if transport.IdleConnTimeout != defaultIdleConnTimeout || transport.DisableKeepAlives {
+		t.Fatalf("unexpected default keep-alive settings: idle timeout %s, keep-alives disabled %t", transport.IdleConnTimeout, transport.DisableKeepAlives)
+	}
+}
+
+func TestValidateConnectionPoolConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		maxIdleConns    types.Int64
+		maxConnsPerHost types.Int64
+		wantErrors      int
+	}{
+		"unset":    {maxIdleConns: types.Int64Null(), maxConnsPerHost: types.Int64Null()},
+		"unknown":  {maxIdleConns: types.Int64Unknown(), maxConnsPerHost: types.Int64Unknown()},
+		"positive": {maxIdleConns: types.Int64Value(10), maxConnsPerHost: types.Int64Value(1)},
+		"zero":     {maxIdleConns: types.Int64Value(0), maxConnsPerHost: types.Int64Value(4), wantErrors: 1},
+		"negative": {maxIdleConns: types.Int64Value(-1), maxConnsPerHost: types.Int64Value(-5), wantErrors: 2},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			diags := validateConnectionPoolConfig(costoryProviderModel{
+				MaxIdleConns:    tc.maxIdleConns,
+				MaxConnsPerHost: tc.maxConnsPerHost,
+			})
+
+			if got := diags.ErrorsCount(); got != tc.wantErrors {
+				t.Fatalf("unexpected error count: got %d, want %d: %v", got, tc.wantErrors, diags)
+			}
+		})
+	}
+}
+
+func TestNewHTTPClientKeepAlive(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		idleConnTimeout   types.String
+		disableKeepAlives types.Bool
+		wantTimeout       time.Duration
+	}{
+		"defaults":           {idleConnTimeout: types.StringNull(), disableKeepAlives: types.BoolNull(), wantTimeout: defaultIdleConnTimeout},
+		"short idle timeout": {idleConnTimeout: types.StringValue("30s"), disableKeepAlives: types.BoolValue(false), wantTimeout: 30 * time.Second},
+		"keep-alives off":    {idleConnTimeout: types.StringNull(), disableKeepAlives: types.BoolValue(true), wantTimeout: defaultIdleConnTimeout},
+		"surrounding spaces": {idleConnTimeout: types.StringValue(" 2m "), disableKeepAlives: types.BoolNull(), wantTimeout: 2 * time.Minute},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			idleConnTimeout, diags := parseIdleConnTimeout(tc.idleConnTimeout)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			client := newHTTPClient(defaultMaxIdleConns, defaultMaxConnsPerHost, idleConnTimeout, tc.disableKeepAlives.ValueBool())
+
+			transport := client.Transport.(*http.Transport)
+			if transport.IdleConnTimeout != tc.wantTimeout {
+				t.Fatalf("unexpected IdleConnTimeout: got %s, want %s", transport.IdleConnTimeout, tc.wantTimeout)
+			}
+			if transport.DisableKeepAlives != tc.disableKeepAlives.ValueBool() {
+				t.Fatalf("unexpected DisableKeepAlives: got %t, want %t", transport.DisableKeepAlives, tc.disableKeepAlives.ValueBool())
+			}
+		})
+	}
+}
+
+func TestParseIdleConnTimeoutRejectsInvalidDurations(t *testing.T) {
+	t.Parallel()
+
+	for name, value := range map[string]string{
+		"not a duration": "soon",
+		"no unit":        "30",
+		"zero":           "0s",
+		"negative":       "-1m",
+		"empty":          "",
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if _, diags := parseIdleConnTimeout(types.StringValue(value)); !diags.HasError() {
+				t.Fatalf("expected %q to be rejected", value)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+func TestProviderTypeName(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		opts []Option
+		want string
+	}{
+		"default": {want: "costory"},
+		"custom":  {opts: []Option{WithTypeName("acme")}, want: "acme"},
+		"blank":   {opts: []Option{WithTypeName(" ")}, want: "costory"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			p := New("test", tc.opts...)()
+
+			var meta provider.MetadataResponse
+			p.Metadata(ctx, provider.MetadataRequest{}, &meta)
+			if meta.TypeName != tc.want {
+				t.Fatalf("unexpected provider type name: got %q, want %q", meta.TypeName, tc.want)
+			}
+			if meta.Version != "test" {
+				t.Fatalf("unexpected provider version: got %q", meta.Version)
+			}
+
+			prefix := meta.TypeName + "_"
+			for _, newResource := range p.Resources(ctx) {
+				var resp resource.MetadataResponse
+				newResource().Metadata(ctx, resource.MetadataRequest{ProviderTypeName: meta.TypeName}, &resp)
+				if !strings.HasPrefix(resp.TypeName, prefix) {
+					t.Errorf("resource type name %q does not start with %q", resp.TypeName, prefix)
+				}
+			}
+			for _, newDataSource := range p.DataSources(ctx) {
+				var resp datasource.MetadataResponse
+				newDataSource().Metadata(ctx, datasource.MetadataRequest{ProviderTypeName: meta.TypeName}, &resp)
+				if !strings.HasPrefix(resp.TypeName, prefix) {
+					t.Errorf("data source type name %q does not start with %q", resp.TypeName, prefix)
+				}
+			}
+		})
+	}
+}
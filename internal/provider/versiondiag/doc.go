@@ -0,0 +1,2 @@
+// Package versiondiag names the provider version in the errors of Costory API operations.
+package versiondiag
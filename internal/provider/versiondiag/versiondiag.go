@@ -0,0 +1,32 @@
+package versiondiag
+
+import (
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+
+	"github.com/costory-io/costory-terraform/internal/costoryapi"
+)
+
+// Add appends the provider version reported by client to the summary of every error in
+// diags, as in "Unable to read team (costory provider 1.2.0)", so bug reports about failed
+// API calls name the version in use. Call it deferred, once the client is known to be
+// configured. Errors that already name the version are left unchanged.
+func Add(client any, diags *diag.Diagnostics) {
+	suffix := costoryapi.VersionedSummary("", client)
+	if suffix == "" {
+		return
+	}
+
+	for i, d := range *diags {
+		if d.Severity() != diag.SeverityError || strings.HasSuffix(d.Summary(), suffix) {
+			continue
+		}
+
+		if withPath, ok := d.(diag.DiagnosticWithPath); ok {
+			(*diags)[i] = diag.NewAttributeErrorDiagnostic(withPath.Path(), d.Summary()+suffix, d.Detail())
+			continue
+		}
+		(*diags)[i] = diag.NewErrorDiagnostic(d.Summary()+suffix, d.Detail())
+	}
+}
@@ -0,0 +1,39 @@
+package versiondiag
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+
+	"github.com/costory-io/costory-terraform/internal/costoryapi"
+)
+
+func TestAdd(t *testing.T) {
+	t.Parallel()
+
+	client := costoryapi.NewClient("https://example.invalid", "test-token", nil, costoryapi.WithProviderVersion("dev"))
+
+	var diags diag.Diagnostics
+	diags.AddError("Unable to read team", "boom")
+	diags.AddAttributeError(path.Root("name"), "Invalid name", "boom")
+	diags.AddWarning("Costory maintenance", "soon")
+	diags.AddError("Unexpected configure type (costory provider dev)", "boom")
+
+	Add(client, &diags)
+
+	want := []string{
+		"Unable to read team (costory provider dev)",
+		"Invalid name (costory provider dev)",
+		"Costory maintenance",
+		"Unexpected configure type (costory provider dev)",
+	}
+	for i, d := range diags {
+		if got := d.Summary(); got != want[i] {
+			t.Errorf("diagnostic %d: got summary %q, want %q", i, got, want[i])
+		}
+	}
+	if withPath, ok := diags[1].(diag.DiagnosticWithPath); !ok || !withPath.Path().Equal(path.Root("name")) {
+		t.Errorf("expected the attribute path to be kept, got %#v", diags[1])
+	}
+}